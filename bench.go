@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xuri/excelize/v2"
+
+	"excelite/exporter"
+)
+
+// benchFlags는 bench 커맨드의 플래그 값입니다.
+type benchFlags struct {
+	rows      string
+	tables    int
+	columns   int
+	count     int
+	languages string
+	output    string
+	seed      int64
+}
+
+func newBenchCommand() *cobra.Command {
+	var flags benchFlags
+
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Measure parse and export throughput on synthesized workbooks",
+		Long: "Synthesizes workbooks of the given sizes and measures parse and per-exporter export throughput.\n" +
+			"Results are written in the Go benchmark format, so two runs can be compared with benchstat.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBench(cmd.OutOrStdout(), &flags)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&flags.rows, "rows", "1000,10000", "Comma-separated row counts per table to benchmark")
+	f.IntVar(&flags.tables, "tables", 3, "Number of tables in the synthesized workbook")
+	f.IntVar(&flags.columns, "columns", 8, "Number of columns per table (including the index column)")
+	f.IntVar(&flags.count, "count", 5, "Number of times to run each benchmark (use >= 5 for benchstat)")
+	f.StringVar(&flags.languages, "lang", "all", "Comma-separated list of exporters to benchmark")
+	f.StringVarP(&flags.output, "output", "o", "", "Write results to this file (defaults to stdout)")
+	f.Int64Var(&flags.seed, "seed", 1, "Random seed for the synthesized data")
+	return cmd
+}
+
+func runBench(out io.Writer, flags *benchFlags) error {
+	if flags.tables <= 0 || flags.columns <= 0 || flags.count <= 0 {
+		return fmt.Errorf("--tables, --columns and --count must be positive")
+	}
+	var sizes []int
+	for _, s := range strings.Split(flags.rows, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid row count %q", s)
+		}
+		sizes = append(sizes, n)
+	}
+
+	registry := newCLIRegistry("bench")
+	langs := registry.Languages()
+	if flags.languages != "all" {
+		langs = strings.Split(flags.languages, ",")
+	}
+
+	if flags.output != "" {
+		f, err := os.Create(flags.output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	tmpDir, err := os.MkdirTemp("", "excelite-bench-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	schema := filepath.Join(tmpDir, "bench.xlsx")
+	if err := writeBenchSchema(schema, flags.tables, flags.columns); err != nil {
+		return err
+	}
+
+	// benchstat이 인식하는 헤더
+	fmt.Fprintf(out, "goos: %s\ngoarch: %s\npkg: excelite\ncpu: %d cores\n", runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+
+	for _, size := range sizes {
+		dataDir := filepath.Join(tmpDir, fmt.Sprintf("rows%d", size))
+		files, err := exporter.FakeWorkbooks([]string{schema}, dataDir, exporter.FakeOptions{Rows: size, Seed: flags.seed})
+		if err != nil {
+			return fmt.Errorf("failed to synthesize %d rows: %v", size, err)
+		}
+		totalRows := size * flags.tables
+
+		var tables []exporter.Table
+		for i := 0; i < flags.count; i++ {
+			result := measure(func() error {
+				tables, err = exporter.ParseExcelFile(files[0])
+				return err
+			})
+			if result.err != nil {
+				return fmt.Errorf("parse failed: %v", result.err)
+			}
+			result.write(out, fmt.Sprintf("Parse/rows=%d", size), totalRows)
+		}
+
+		for _, lang := range langs {
+			for i := 0; i < flags.count; i++ {
+				opts := exporter.Options{
+					OutputDir:    filepath.Join(dataDir, "out", lang, strconv.Itoa(i)),
+					PackageName:  "bench",
+					DBDriver:     "sqlite",
+					ExtraOptions: map[string]interface{}{},
+				}
+				result := measure(func() error {
+					return registry.Export(lang, tables, opts)
+				})
+				if result.err != nil {
+					return fmt.Errorf("export %s failed: %v", lang, result.err)
+				}
+				result.write(out, fmt.Sprintf("Export/%s/rows=%d", lang, size), totalRows)
+			}
+		}
+	}
+	return nil
+}
+
+// benchResult는 한 번의 측정 결과입니다.
+type benchResult struct {
+	elapsed time.Duration
+	bytes   uint64
+	allocs  uint64
+	err     error
+}
+
+// measure는 fn 한 번의 실행 시간과 할당량을 잽니다.
+func measure(fn func() error) benchResult {
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	err := fn()
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	return benchResult{
+		elapsed: elapsed,
+		bytes:   after.TotalAlloc - before.TotalAlloc,
+		allocs:  after.Mallocs - before.Mallocs,
+		err:     err,
+	}
+}
+
+// write는 결과를 Go 벤치마크 형식의 한 줄로 씁니다. 한 번의 실행이 하나의 op입니다.
+func (r benchResult) write(out io.Writer, name string, rows int) {
+	rowsPerSec := float64(rows) / r.elapsed.Seconds()
+	fmt.Fprintf(out, "Benchmark%s-%d\t%d\t%d ns/op\t%.0f rows/s\t%d B/op\t%d allocs/op\n",
+		name, runtime.GOMAXPROCS(0), 1, r.elapsed.Nanoseconds(), rowsPerSec, r.bytes, r.allocs)
+}
+
+// benchColumnTypes는 합성 테이블의 인덱스 외 컬럼에 순서대로 사용할 타입입니다.
+var benchColumnTypes = []string{"string", "int32", "float", "bool", "int64", "datetime", "array<int32>"}
+
+// writeBenchSchema는 헤더만 있는 합성 워크북을 만듭니다. 두 번째 테이블부터는 앞 테이블을 ref<>로 참조합니다.
+func writeBenchSchema(path string, tables, columns int) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	for t := 0; t < tables; t++ {
+		sheet := fmt.Sprintf("Bench%d", t+1)
+		if t == 0 {
+			f.SetSheetName("Sheet1", sheet)
+		} else if _, err := f.NewSheet(sheet); err != nil {
+			return err
+		}
+
+		names := []interface{}{"Code"}
+		tags := []interface{}{"index"}
+		types := []interface{}{"int32"}
+		for c := 1; c < columns; c++ {
+			typ := benchColumnTypes[(c-1)%len(benchColumnTypes)]
+			if c == 1 && t > 0 {
+				typ = fmt.Sprintf("ref<Bench%d>", t)
+			}
+			names = append(names, fmt.Sprintf("Col%d", c))
+			tags = append(tags, "")
+			types = append(types, typ)
+		}
+
+		for r, row := range [][]interface{}{names, tags, types} {
+			cell, _ := excelize.CoordinatesToCellName(1, r+1)
+			if err := f.SetSheetRow(sheet, cell, &row); err != nil {
+				return err
+			}
+		}
+	}
+	return f.SaveAs(path)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+func newCheckCommand(input *inputFlags) *cobra.Command {
+	var baseline string
+	var update bool
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Fail on breaking schema changes relative to a baseline snapshot",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := input.resolve()
+			if err != nil {
+				return err
+			}
+
+			current := exporter.TakeSnapshot(parseWorkbooks(files, nil))
+
+			// 릴리스 시점에 스냅샷을 갱신
+			if update {
+				if err := exporter.WriteSnapshot(baseline, current); err != nil {
+					return fmt.Errorf("failed to write baseline: %v", err)
+				}
+				log.Printf("Wrote schema snapshot of %d tables to %s", len(current.Tables), baseline)
+				return nil
+			}
+
+			snapshot, err := exporter.LoadSnapshot(baseline)
+			if err != nil {
+				return fmt.Errorf("failed to load baseline: %v", err)
+			}
+
+			changes := exporter.CompareSnapshots(snapshot, current)
+			for _, change := range changes {
+				fmt.Fprintf(cmd.OutOrStdout(), "breaking: %s\n", change)
+			}
+			if len(changes) > 0 {
+				return fmt.Errorf("found %d breaking schema change(s) relative to %s", len(changes), baseline)
+			}
+
+			log.Printf("No breaking schema changes relative to %s", baseline)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&baseline, "baseline", "schema-snapshot.json", "Schema snapshot of the last release")
+	cmd.Flags().BoolVar(&update, "update", false, "Overwrite the baseline with the current schema instead of checking")
+	cmd.MarkFlagFilename("baseline", "json")
+	return cmd
+}
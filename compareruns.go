@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+func newCompareRunsCommand() *cobra.Command {
+	var format string
+	var exitCode bool
+
+	cmd := &cobra.Command{
+		Use:   "compare-runs <base-output> <target-output>",
+		Short: "Show what changed between two generated output directories (tables, row counts, schemas and artifacts)",
+		Long: `Compares two output directories of generate, e.g. the builds before and after a live issue.
+Artifacts are compared by manifest.json (computed from the files if missing).
+Tables, row counts and schemas are compared when both builds were generated with --tables-manifest.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := exporter.CompareRuns(args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			switch format {
+			case "text":
+				writeRunComparison(cmd.OutOrStdout(), c)
+			case "json":
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(c); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unknown compare-runs format %q (expected text or json)", format)
+			}
+
+			if exitCode && c.Changed() {
+				return fmt.Errorf("%s and %s differ", args[0], args[1])
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+	cmd.Flags().BoolVar(&exitCode, "exit-code", false, "Exit with an error when the builds differ (for scripts bisecting builds)")
+	return cmd
+}
+
+func writeRunComparison(w io.Writer, c exporter.RunComparison) {
+	if !c.Changed() {
+		fmt.Fprintf(w, "%s and %s are identical (root %s)\n", c.Base, c.Target, c.BaseRoot)
+		return
+	}
+	fmt.Fprintf(w, "root: %s -> %s\n", c.BaseRoot, c.TargetRoot)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if !c.TablesCompared {
+		fmt.Fprintf(tw, "\ntables not compared: generate both builds with --tables-manifest\n")
+	} else if len(c.Tables) > 0 {
+		fmt.Fprintf(tw, "\nTABLE\tCHANGE\tROWS\tDATA\tSCHEMA\n")
+		for _, t := range c.Tables {
+			data := ""
+			if t.Data {
+				data = "changed"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d -> %d\t%s\t%s\n", t.Name, t.Change, t.BaseRows, t.TargetRows, data, strings.Join(t.Schema, ", "))
+		}
+	}
+	if len(c.Artifacts) > 0 {
+		fmt.Fprintf(tw, "\nARTIFACT\tCHANGE\tSIZE\t\t\n")
+		for _, a := range c.Artifacts {
+			fmt.Fprintf(tw, "%s\t%s\t%s -> %s\t\t\n", a.Path, a.Change, exporter.FormatByteSize(a.BaseSize), exporter.FormatByteSize(a.TargetSize))
+		}
+	}
+	tw.Flush()
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+func newDiffCommand() *cobra.Command {
+	var patchFile string
+	var htmlFile string
+	var baseRev string
+
+	cmd := &cobra.Command{
+		Use:   "diff <base> <target> | diff --base-rev <rev> <target>",
+		Short: "Show row changes between two workbooks or workbook directories",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if baseRev != "" {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target := args[len(args)-1]
+			targetFiles, err := expandPaths([]string{target})
+			if err != nil {
+				return err
+			}
+
+			base := args[0]
+			var baseFiles []string
+			if baseRev != "" {
+				base = baseRev
+				tmpDir, err := os.MkdirTemp("", "excelite-diff-")
+				if err != nil {
+					return err
+				}
+				defer os.RemoveAll(tmpDir)
+
+				if baseFiles, err = revisionWorkbooks(baseRev, target, tmpDir); err != nil {
+					return err
+				}
+			} else if baseFiles, err = expandPaths([]string{base}); err != nil {
+				return err
+			}
+
+			baseTables := parseWorkbooks(baseFiles, nil)
+			targetTables := parseWorkbooks(targetFiles, nil)
+
+			ops := exporter.DiffTables(baseTables, targetTables)
+			for _, op := range ops {
+				fmt.Fprintf(cmd.OutOrStdout(), "%-6s %s[%s]\n", op.Op, op.Table, op.Index)
+			}
+
+			if patchFile != "" {
+				if err := exporter.WritePatchFile(patchFile, ops); err != nil {
+					return err
+				}
+			}
+
+			// 리뷰용 HTML 아티팩트
+			if htmlFile != "" {
+				f, err := os.Create(htmlFile)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+
+				title := fmt.Sprintf("Data diff: %s → %s", base, target)
+				if err := exporter.WriteHTMLDiff(f, title, exporter.BuildTableDiffs(baseTables, targetTables)); err != nil {
+					return err
+				}
+				log.Printf("HTML diff written to %s", htmlFile)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&patchFile, "patch", "", "Write the changes as a JSON patch file")
+	cmd.Flags().StringVar(&htmlFile, "html", "", "Write an HTML report of changed rows with old and new values")
+	cmd.Flags().StringVar(&baseRev, "base-rev", "", "Compare the target against the same path at this git revision (e.g. origin/main)")
+	return cmd
+}
+
+// revisionWorkbooks는 git 리비전 rev에서 path 아래의 워크북들을 tmpDir에 꺼냅니다.
+func revisionWorkbooks(rev, path, tmpDir string) ([]string, error) {
+	repo, err := openGitRepository()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := repo.workbookPathsAt(rev, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, p := range paths {
+		file, err := repo.extract(rev, p, tmpDir)
+		if err != nil {
+			return nil, err
+		}
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,38 @@
+// exporter/access.go
+package exporter
+
+import "fmt"
+
+// IsReadOnly는 컬럼이 readonly 태그를 가지고 있는지 반환합니다.
+// 읽기 전용 컬럼은 행을 만들 때만 쓰이며, 생성되는 리포지토리/ORM 코드의 수정(update)에서 제외됩니다.
+func IsReadOnly(col Column) bool {
+	return HasTag(col.Tags, TagReadOnly)
+}
+
+// IsWriteOnly는 컬럼이 writeonly 태그를 가지고 있는지 반환합니다.
+// 쓰기 전용 컬럼은 DB에는 저장되지만 생성 코드의 직렬화(JSON 태그, 백오피스 화면, 출력 스키마)에서 제외됩니다.
+func IsWriteOnly(col Column) bool {
+	return HasTag(col.Tags, TagWriteOnly)
+}
+
+// validateAccess는 readonly/writeonly 태그가 함께 쓰일 수 있는 컬럼에 붙었는지 확인합니다.
+// 키 컬럼은 행을 찾는 데 쓰이므로 직렬화에서 뺄 수 없습니다.
+func validateAccess(table Table) []error {
+	var errs []error
+	for i, col := range table.Columns {
+		if !IsWriteOnly(col) {
+			continue
+		}
+		reason := ""
+		switch {
+		case IsReadOnly(col):
+			reason = "readonly and writeonly cannot be used together"
+		case i == IndexColumn(table) || (table.IsMatrix && i < 2):
+			reason = "key columns cannot be writeonly"
+		}
+		if reason != "" {
+			errs = append(errs, fmt.Errorf("table %s column %s: %s", table.Name, col.Name, reason))
+		}
+	}
+	return errs
+}
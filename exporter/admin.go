@@ -0,0 +1,200 @@
+// exporter/admin.go
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+)
+
+// AdminExporter는 SQLite exporter가 만든 DB를 읽는 읽기 전용 웹 백오피스(Go 서버 + HTML 템플릿)를 생성합니다.
+// 테이블마다 목록/검색/상세 페이지가 있고, 외래 키와 ref 컬럼은 참조하는 행으로, 상세 페이지는 이 행을 참조하는 행들로 이동할 수 있어
+// QA와 라이브 운영에서 데이터를 확인하는 데 사용합니다. writeonly 컬럼은 표시하지 않습니다.
+type AdminExporter struct {
+	BaseExporter
+}
+
+func NewAdminExporter() Exporter {
+	return &AdminExporter{
+		BaseExporter: NewBaseExporter("admin"),
+	}
+}
+
+// adminTable은 생성된 서버가 다루는 테이블 하나입니다.
+type adminTable struct {
+	Name     string
+	DB       string // 테이블이 들어있는 DB 파일 이름
+	Index    string
+	Columns  []string
+	Search   []string // 검색어를 LIKE로 비교할 텍스트 컬럼
+	Refs     []adminLink
+	Children []adminLink
+}
+
+// adminLink는 두 테이블 사이의 이동 경로입니다.
+// Refs에서는 Column 값이 Table의 Key 컬럼을 가리키고, Children에서는 Table의 Column 값이 이 테이블의 Key 컬럼을 가리킵니다.
+type adminLink struct {
+	Column string
+	Table  string
+	Key    string
+}
+
+func (e *AdminExporter) Describe() ExporterInfo {
+	return ExporterInfo{
+		Description: "read-only web backoffice (Go server and HTML templates) browsing the SQLite DB",
+		Outputs:     []string{"main.go", "templates/*.html"},
+	}
+}
+
+func (e *AdminExporter) Export(tables []Table, opts Options) error {
+	if errs := rejectInterned(tables, e.Language()); len(errs) > 0 {
+		return errs[0]
+	}
+	if err := os.MkdirAll(filepath.Join(opts.OutputDir, "templates"), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	data := struct {
+		DBDir  string
+		Tables []adminTable
+	}{DBDir: "../sqlite", Tables: buildAdminTables(tables, opts.PackageName, opts.DBName)}
+
+	tmplText, err := loadTemplate(opts.TemplateDir, "admin/main.go.tmpl")
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("admin").Parse(tmplText)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated code: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(opts.OutputDir, "main.go"), src, 0644); err != nil {
+		return err
+	}
+
+	// HTML 템플릿은 생성된 서버가 embed로 포함하므로 그대로 씀
+	for _, name := range []string{"layout.html", "index.html", "list.html", "detail.html"} {
+		content, err := loadTemplate(opts.TemplateDir, "admin/templates/"+name)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(opts.OutputDir, "templates", name), []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildAdminTables는 테이블마다 컬럼, 검색 대상, 관계로 이동할 링크를 정리합니다.
+// DB 파일 이름은 SQLite exporter와 같은 규칙(<package>.db 또는 DSN의 파일, 그룹은 <group>.db)을 따릅니다.
+func buildAdminTables(tables []Table, packageName, dbName string) []adminTable {
+	byName := make(map[string]Table, len(tables))
+	for _, table := range tables {
+		byName[table.Name] = table
+	}
+	keyOf := func(table Table, name string) string {
+		if name != "" && columnIndex(table, name) != -1 {
+			return name
+		}
+		return table.Columns[IndexColumn(table)].Name
+	}
+
+	result := make([]adminTable, 0, len(tables))
+	index := make(map[string]int, len(tables))
+	for _, table := range tables {
+		if len(table.Columns) == 0 {
+			continue
+		}
+		at := adminTable{
+			Name:  table.Name,
+			DB:    packageName + ".db",
+			Index: table.Columns[IndexColumn(table)].Name,
+		}
+		if table.Group != "" {
+			at.DB = table.Group + ".db"
+		} else if dbName != "" {
+			at.DB = filepath.Base(sqliteDSNPath(".", dbName))
+		}
+		for _, col := range table.Columns {
+			// writeonly 컬럼은 화면에 보이지 않고 검색할 수도 없음
+			if IsWriteOnly(col) {
+				continue
+			}
+			at.Columns = append(at.Columns, col.Name)
+			if !col.Type.IsArray && col.Type.Type == StringType.Type {
+				at.Search = append(at.Search, col.Name)
+			}
+		}
+		index[table.Name] = len(result)
+		result = append(result, at)
+	}
+
+	// ref<Table> 컬럼
+	for _, table := range tables {
+		i, ok := index[table.Name]
+		if !ok {
+			continue
+		}
+		for _, col := range table.Columns {
+			target, ok := byName[col.Type.RefTable]
+			if !ok || col.Type.IsArray || IsWriteOnly(col) {
+				continue
+			}
+			link := adminLink{Column: col.Name, Table: target.Name, Key: keyOf(target, "")}
+			result[i].Refs = append(result[i].Refs, link)
+			result[index[target.Name]].Children = append(result[index[target.Name]].Children, adminLink{Column: col.Name, Table: table.Name, Key: link.Key})
+		}
+	}
+
+	// #Relation 관계: 외래 키는 belongsTo면 시작 테이블, hasOne/hasMany면 대상 테이블에 있음
+	seen := make(map[adminLink]bool)
+	for _, table := range tables {
+		for _, rel := range table.Relations {
+			owner, owned := rel.TargetTable, rel.SourceTable
+			if rel.RelationType != "belongsTo" {
+				owner, owned = rel.SourceTable, rel.TargetTable
+			}
+			oi, ok1 := index[owner]
+			mi, ok2 := index[owned]
+			fk := columnIndex(byName[owned], rel.ForeignKey)
+			if !ok1 || !ok2 || fk == -1 || IsWriteOnly(byName[owned].Columns[fk]) {
+				continue
+			}
+			ref := adminLink{Column: rel.ForeignKey, Table: owner, Key: keyOf(byName[owner], rel.ReferenceKey)}
+			child := adminLink{Column: rel.ForeignKey, Table: owned, Key: ref.Key}
+			if seen[child] || adminHasLink(result[mi].Refs, ref) {
+				continue
+			}
+			seen[child] = true
+			result[mi].Refs = append(result[mi].Refs, ref)
+			result[oi].Children = append(result[oi].Children, child)
+		}
+	}
+
+	for i := range result {
+		sort.SliceStable(result[i].Children, func(a, b int) bool {
+			return result[i].Children[a].Table < result[i].Children[b].Table
+		})
+	}
+	return result
+}
+
+func adminHasLink(links []adminLink, link adminLink) bool {
+	for _, l := range links {
+		if l == link {
+			return true
+		}
+	}
+	return false
+}
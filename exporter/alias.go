@@ -0,0 +1,42 @@
+// exporter/alias.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// buildAliasViewQueries는 테이블의 이전 이름들로 조회할 수 있는 뷰를 생성합니다.
+func buildAliasViewQueries(table Table, d SQLDialect) string {
+	var b strings.Builder
+	for _, alias := range table.Aliases {
+		b.WriteString(fmt.Sprintf("CREATE VIEW IF NOT EXISTS %s AS SELECT * FROM %s;\n",
+			d.QuoteIdentifier(alias), d.QuoteIdentifier(table.Name)))
+	}
+	return b.String()
+}
+
+// validateAliases는 별칭이 다른 테이블 이름이나 다른 별칭과 겹치지 않는지 확인합니다.
+func validateAliases(tables []Table) []error {
+	owners := make(map[string]string)
+	for _, table := range tables {
+		owners[strings.ToLower(table.Name)] = table.Name
+	}
+
+	var errs []error
+	for _, table := range tables {
+		for _, alias := range table.Aliases {
+			key := strings.ToLower(alias)
+			if owner, ok := owners[key]; ok {
+				if owner == table.Name {
+					errs = append(errs, fmt.Errorf("table %s: alias %s is the table's own name", table.Name, alias))
+				} else {
+					errs = append(errs, fmt.Errorf("table %s: alias %s conflicts with %s", table.Name, alias, owner))
+				}
+				continue
+			}
+			owners[key] = table.Name
+		}
+	}
+	return errs
+}
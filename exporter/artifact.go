@@ -0,0 +1,261 @@
+// exporter/artifact.go
+package exporter
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultEncryptKeyEnv는 암호화 키를 읽어올 기본 환경 변수 이름입니다.
+const DefaultEncryptKeyEnv = "EXCELITE_ENCRYPT_KEY"
+
+// EncryptedExt는 암호화된 산출물에 붙는 확장자입니다.
+const EncryptedExt = ".enc"
+
+// 압축 산출물 확장자
+const (
+	GzipExt = ".gz"
+	ZstdExt = ".zst"
+)
+
+// dataArtifactExts는 후처리(암호화 등) 대상이 되는 데이터 산출물 확장자입니다.
+// 생성된 코드와 스키마는 대상이 아닙니다.
+var dataArtifactExts = map[string]bool{
+	".db":   true,
+	".json": true,
+}
+
+// finalizeArtifacts는 exporter가 출력을 마친 뒤 옵션에 따라 산출물을 후처리합니다.
+func finalizeArtifacts(opts Options) error {
+	if algo := stringOption(opts, OptCompress, ""); algo != "" {
+		if err := CompressArtifacts(opts.OutputDir, algo, intOption(opts, OptCompressLevel, 0)); err != nil {
+			return fmt.Errorf("failed to compress artifacts: %v", err)
+		}
+		if err := generateDecompressLoaders(opts); err != nil {
+			return fmt.Errorf("failed to generate decompression loaders: %v", err)
+		}
+	}
+
+	if boolOption(opts, OptEncrypt, false) {
+		key, err := loadEncryptionKey(stringOption(opts, OptEncryptKeyEnv, DefaultEncryptKeyEnv))
+		if err != nil {
+			return err
+		}
+		if err := EncryptArtifacts(opts.OutputDir, key); err != nil {
+			return fmt.Errorf("failed to encrypt artifacts: %v", err)
+		}
+		if err := generateDecryptLoaders(opts); err != nil {
+			return fmt.Errorf("failed to generate decryption loaders: %v", err)
+		}
+	}
+	return nil
+}
+
+// loadEncryptionKey는 환경 변수에서 32바이트 AES 키를 읽습니다. hex 또는 base64 인코딩을 지원합니다.
+func loadEncryptionKey(envName string) ([]byte, error) {
+	encoded := strings.TrimSpace(os.Getenv(envName))
+	if encoded == "" {
+		return nil, fmt.Errorf("encryption key environment variable %s is not set", envName)
+	}
+
+	if key, err := hex.DecodeString(encoded); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	return nil, fmt.Errorf("encryption key in %s must be 32 bytes encoded as hex or base64", envName)
+}
+
+// listDataArtifacts는 디렉토리 내의 데이터 산출물 경로를 반환합니다.
+func listDataArtifacts(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && isDataArtifact(path) {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return paths, err
+}
+
+// isDataArtifact는 압축 확장자를 제외한 확장자로 데이터 산출물 여부를 판단합니다.
+func isDataArtifact(path string) bool {
+	path = strings.ToLower(path)
+	path = strings.TrimSuffix(strings.TrimSuffix(path, GzipExt), ZstdExt)
+	return dataArtifactExts[filepath.Ext(path)]
+}
+
+// EncryptArtifacts는 디렉토리 내의 데이터 산출물을 AES-GCM으로 암호화합니다.
+// 암호문은 nonce 뒤에 이어붙여 <파일>.enc로 저장되고 평문 파일은 삭제됩니다.
+func EncryptArtifacts(dir string, key []byte) error {
+	paths, err := listDataArtifacts(dir)
+	if err != nil {
+		return err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		plain, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return err
+		}
+
+		sealed := gcm.Seal(nonce, nonce, plain, nil)
+		if err := os.WriteFile(path+EncryptedExt, sealed, 0644); err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DecryptArtifact는 EncryptArtifacts로 암호화된 데이터를 복호화합니다.
+func DecryptArtifact(data, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted artifact is too short")
+	}
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// generateDecryptLoaders는 암호화된 산출물을 읽기 위한 Go/TypeScript 로더 코드를 생성합니다.
+func generateDecryptLoaders(opts Options) error {
+	const goLoader = `// Code generated by excelite. DO NOT EDIT.
+package %s
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// KeyEnv is the environment variable holding the artifact key: 32 bytes encoded as hex or base64.
+const KeyEnv = %q
+
+// ReadEncryptedArtifact reads and decrypts an artifact written with the .enc extension.
+func ReadEncryptedArtifact(path string, key []byte) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted artifact %%s is too short", path)
+	}
+	return gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+}
+
+// KeyFromEnv loads the artifact key from KeyEnv, accepting the same hex or base64 forms as excelite.
+func KeyFromEnv() ([]byte, error) {
+	encoded := strings.TrimSpace(os.Getenv(KeyEnv))
+	if encoded == "" {
+		return nil, fmt.Errorf("encryption key environment variable %%s is not set", KeyEnv)
+	}
+	if key, err := hex.DecodeString(encoded); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	return nil, fmt.Errorf("encryption key in %%s must be 32 bytes encoded as hex or base64", KeyEnv)
+}
+`
+
+	const tsLoader = `// Code generated by excelite. DO NOT EDIT.
+import { createDecipheriv } from "crypto";
+import { readFileSync } from "fs";
+
+export const KEY_ENV = %q;
+
+// readEncryptedArtifact reads and decrypts an artifact written with the .enc extension.
+export function readEncryptedArtifact(path: string, key: Buffer): Buffer {
+    const data = readFileSync(path);
+    const nonce = data.subarray(0, 12);
+    const tag = data.subarray(data.length - 16);
+    const sealed = data.subarray(12, data.length - 16);
+    const decipher = createDecipheriv("aes-256-gcm", key, nonce);
+    decipher.setAuthTag(tag);
+    return Buffer.concat([decipher.update(sealed), decipher.final()]);
+}
+
+// keyFromEnv loads the artifact key from KEY_ENV, accepting the same hex or base64 forms as excelite.
+export function keyFromEnv(): Buffer {
+    const encoded = (process.env[KEY_ENV] ?? "").trim();
+    if (encoded === "") {
+        throw new Error(` + "`encryption key environment variable ${KEY_ENV} is not set`" + `);
+    }
+    // Buffer.from skips invalid characters, so check the text before decoding
+    if (/^[0-9a-fA-F]{64}$/.test(encoded)) {
+        return Buffer.from(encoded, "hex");
+    }
+    if (/^[A-Za-z0-9+/]{43}=$/.test(encoded)) {
+        const key = Buffer.from(encoded, "base64");
+        if (key.length === 32) {
+            return key;
+        }
+    }
+    throw new Error(` + "`encryption key in ${KEY_ENV} must be 32 bytes encoded as hex or base64`" + `);
+}
+`
+
+	keyEnv := stringOption(opts, OptEncryptKeyEnv, DefaultEncryptKeyEnv)
+	loaderDir := filepath.Join(opts.OutputDir, "loader")
+	if err := os.MkdirAll(loaderDir, 0755); err != nil {
+		return err
+	}
+
+	goCode := fmt.Sprintf(goLoader, opts.PackageName, keyEnv)
+	if err := os.WriteFile(filepath.Join(loaderDir, "decrypt.go"), []byte(goCode), 0644); err != nil {
+		return err
+	}
+
+	tsCode := fmt.Sprintf(tsLoader, keyEnv)
+	return os.WriteFile(filepath.Join(loaderDir, "decrypt.ts"), []byte(tsCode), 0644)
+}
@@ -0,0 +1,171 @@
+// exporter/audit.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ORM 모델의 키 방식
+const (
+	ModelKeyID    = "id"    // 자동 증가 id 컬럼을 주입해 기본 키로 사용
+	ModelKeySheet = "sheet" // 시트의 인덱스 컬럼(매트릭스는 행 키와 열 키)을 기본 키로 사용
+)
+
+// 감사(audit) 타임스탬프 컬럼의 타입
+const (
+	TimestampsTime      = "time"      // time.Time
+	TimestampsUnix      = "unix"      // 초 단위 int64
+	TimestampsUnixMilli = "unixmilli" // 밀리초 단위 int64
+	TimestampsNone      = "none"      // created_at/updated_at을 주입하지 않음
+)
+
+// AuditPolicy는 ORM exporter가 시트 컬럼 외에 모델에 주입하는 키(id)와 감사 컬럼(created_at, updated_at, deleted_at)의 설정입니다.
+// 기본값(id, time, soft delete)은 gorm.Model과 같습니다. 정적 데이터 테이블은 행이 워크북에서만 바뀌므로
+// 보통 시트의 키를 기본 키로 쓰고 soft delete를 끕니다.
+type AuditPolicy struct {
+	Key        string
+	Timestamps string
+	SoftDelete bool
+}
+
+// DefaultAuditPolicy는 gorm.Model과 같은 기본 설정입니다.
+var DefaultAuditPolicy = AuditPolicy{Key: ModelKeyID, Timestamps: TimestampsTime, SoftDelete: true}
+
+// auditPolicy는 exporter 옵션(modelKey, timestamps, softDelete)에서 설정을 읽습니다.
+func auditPolicy(e BaseExporter, opts Options) (AuditPolicy, error) {
+	policy := AuditPolicy{
+		Key:        strings.ToLower(e.GetStringOption(opts, OptModelKey, DefaultAuditPolicy.Key)),
+		Timestamps: strings.ToLower(e.GetStringOption(opts, OptTimestamps, DefaultAuditPolicy.Timestamps)),
+		SoftDelete: e.GetBoolOption(opts, OptSoftDelete, DefaultAuditPolicy.SoftDelete),
+	}
+	switch policy.Key {
+	case ModelKeyID, ModelKeySheet:
+	default:
+		return policy, fmt.Errorf("unknown %s %q (expected %s or %s)", OptModelKey, policy.Key, ModelKeyID, ModelKeySheet)
+	}
+	switch policy.Timestamps {
+	case TimestampsTime, TimestampsUnix, TimestampsUnixMilli, TimestampsNone:
+	default:
+		return policy, fmt.Errorf("unknown %s %q (expected %s, %s, %s or %s)", OptTimestamps, policy.Timestamps,
+			TimestampsTime, TimestampsUnix, TimestampsUnixMilli, TimestampsNone)
+	}
+	return policy, nil
+}
+
+// auditField는 모델에 주입되는 필드 하나입니다.
+type auditField struct {
+	Name   string // Go 필드 이름
+	Column string // DB 컬럼 이름
+	Decl   string // 필드 선언 (gorm.Model을 임베딩하면 첫 필드만 선언을 가짐)
+	Hint   string // 주입을 끄는 옵션
+}
+
+// gormFields는 GORM 모델에 주입할 필드들을 반환합니다. 기본 설정이면 gorm.Model을 임베딩합니다.
+// soft delete를 정수 타임스탬프와 함께 쓰면 gorm.io/plugin/soft_delete의 타입을 사용합니다.
+func (p AuditPolicy) gormFields() []auditField {
+	fields := []auditField{
+		{Name: "ID", Column: "id", Decl: "ID uint `gorm:\"primaryKey\"`", Hint: OptModelKey + "=" + ModelKeySheet},
+		{Name: "CreatedAt", Column: "created_at", Hint: OptTimestamps + "=" + TimestampsNone},
+		{Name: "UpdatedAt", Column: "updated_at", Hint: OptTimestamps + "=" + TimestampsNone},
+		{Name: "DeletedAt", Column: "deleted_at", Hint: OptSoftDelete + "=false"},
+	}
+	if p == DefaultAuditPolicy {
+		fields[0].Decl = "gorm.Model"
+		return fields
+	}
+
+	var result []auditField
+	if p.Key == ModelKeyID {
+		result = append(result, fields[0])
+	}
+	switch p.Timestamps {
+	case TimestampsTime:
+		fields[1].Decl = "CreatedAt time.Time"
+		fields[2].Decl = "UpdatedAt time.Time"
+	case TimestampsUnix:
+		fields[1].Decl = "CreatedAt int64 `gorm:\"autoCreateTime\"`"
+		fields[2].Decl = "UpdatedAt int64 `gorm:\"autoUpdateTime\"`"
+	case TimestampsUnixMilli:
+		fields[1].Decl = "CreatedAt int64 `gorm:\"autoCreateTime:milli\"`"
+		fields[2].Decl = "UpdatedAt int64 `gorm:\"autoUpdateTime:milli\"`"
+	}
+	if p.Timestamps != TimestampsNone {
+		result = append(result, fields[1], fields[2])
+	}
+	if p.SoftDelete {
+		switch p.Timestamps {
+		case TimestampsUnix:
+			fields[3].Decl = "DeletedAt soft_delete.DeletedAt `gorm:\"index\"`"
+		case TimestampsUnixMilli:
+			fields[3].Decl = "DeletedAt soft_delete.DeletedAt `gorm:\"softDelete:milli;index\"`"
+		default:
+			fields[3].Decl = "DeletedAt gorm.DeletedAt `gorm:\"index\"`"
+		}
+		result = append(result, fields[3])
+	}
+	return result
+}
+
+// typeormFields는 TypeORM 엔티티에 주입할 프로퍼티들을 반환합니다. Decl은 데코레이터를 포함한 프로퍼티 선언입니다.
+// TypeORM의 soft delete는 datetime 컬럼만 지원하므로 deleted_at은 timestamps 설정과 관계없이 datetime입니다.
+// 정수 타임스탬프는 엔티티의 BeforeInsert/BeforeUpdate 리스너가 채웁니다. (typeormNow)
+func (p AuditPolicy) typeormFields() []auditField {
+	var result []auditField
+	if p.Key == ModelKeyID {
+		result = append(result, auditField{Name: "id", Column: "id", Decl: "@PrimaryGeneratedColumn()\n  id!: number;", Hint: OptModelKey + "=" + ModelKeySheet})
+	}
+	created := auditField{Name: "createdAt", Column: "created_at", Hint: OptTimestamps + "=" + TimestampsNone}
+	updated := auditField{Name: "updatedAt", Column: "updated_at", Hint: OptTimestamps + "=" + TimestampsNone}
+	switch p.Timestamps {
+	case TimestampsTime:
+		created.Decl = "@CreateDateColumn({ name: \"created_at\" })\n  createdAt!: Date;"
+		updated.Decl = "@UpdateDateColumn({ name: \"updated_at\" })\n  updatedAt!: Date;"
+	case TimestampsUnix, TimestampsUnixMilli:
+		created.Decl = "@Column({ name: \"created_at\", type: \"bigint\" })\n  createdAt!: number;"
+		updated.Decl = "@Column({ name: \"updated_at\", type: \"bigint\" })\n  updatedAt!: number;"
+	}
+	if p.Timestamps != TimestampsNone {
+		result = append(result, created, updated)
+	}
+	if p.SoftDelete {
+		result = append(result, auditField{Name: "deletedAt", Column: "deleted_at", Decl: "@DeleteDateColumn({ name: \"deleted_at\", nullable: true })\n  deletedAt!: Date | null;", Hint: OptSoftDelete + "=false"})
+	}
+	return result
+}
+
+// typeormNow는 정수 타임스탬프를 채우는 TypeScript 식입니다. time, none이면 빈 문자열입니다.
+func (p AuditPolicy) typeormNow() string {
+	switch p.Timestamps {
+	case TimestampsUnix:
+		return "Math.floor(Date.now() / 1000)"
+	case TimestampsUnixMilli:
+		return "Date.now()"
+	}
+	return ""
+}
+
+// usesSoftDeletePlugin은 생성 코드가 gorm.io/plugin/soft_delete를 가져와야 하는지 반환합니다.
+func (p AuditPolicy) usesSoftDeletePlugin() bool {
+	return p.SoftDelete && (p.Timestamps == TimestampsUnix || p.Timestamps == TimestampsUnixMilli)
+}
+
+// validateTable은 테이블에 설정을 적용할 수 있는지 확인합니다.
+// 주입되는 필드와 이름이 같은 시트 컬럼이 있거나, sheet 키를 쓰는데 인덱스가 행마다 유일하지 않은 테이블(변형, 적용 기간)이면 에러입니다.
+func (p AuditPolicy) validateTable(table Table, fields []auditField) error {
+	for _, col := range table.Columns {
+		for _, f := range fields {
+			if strings.EqualFold(col.Name, f.Name) || strings.EqualFold(col.Name, f.Column) {
+				return fmt.Errorf("table %s: column %s conflicts with the injected %s field (set %s or rename the column)",
+					table.Name, col.Name, f.Name, f.Hint)
+			}
+		}
+	}
+	if p.Key == ModelKeySheet && !table.IsMatrix {
+		if from, _ := EffectiveDateColumns(table); from != -1 || VariantColumn(table) != -1 {
+			return fmt.Errorf("table %s: rows share the index across variants or validity periods, so it cannot be the primary key (use %s=%s)",
+				table.Name, OptModelKey, ModelKeyID)
+		}
+	}
+	return nil
+}
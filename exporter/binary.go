@@ -0,0 +1,343 @@
+// exporter/binary.go
+package exporter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"text/template"
+	"time"
+)
+
+// BinaryExporter는 테이블마다 컬럼 단위의 바이너리 파일(<Table>.bin)과 이를 읽는 Go, TypeScript 디코더를 생성합니다.
+// compress 태그의 인코딩(delta, rle)과 intern 태그는 이 포맷에서 컬럼 단위로 적용됩니다.
+//
+// 포맷 (정수는 unsigned varint, 문자열은 길이 + UTF-8 바이트):
+//
+//	"XLCB" 버전 테이블이름 행수 컬럼수
+//	컬럼마다: 이름 kind(1바이트) encoding(1바이트) 값이 있는 행의 비트맵(행수/8 올림 바이트) 본문길이 본문
+//
+// 본문은 값이 있는 행의 값만 순서대로 담습니다. 정수는 zigzag varint, 실수는 little-endian float64, bool은 1바이트,
+// 문자열, datetime(UTC RFC 3339), bytes, JSON(배열, 좌표, 커브)은 문자열입니다.
+// delta는 앞 값과의 차이를 zigzag varint로, rle는 (반복 수, 값) 쌍으로 저장합니다.
+// intern은 컬럼의 서로 다른 문자열을 처음 나온 순서대로 한 번씩 담은 문자열 테이블(개수, 문자열들) 뒤에
+// 값마다 문자열 테이블의 위치를 unsigned varint로 저장합니다.
+type BinaryExporter struct {
+	BaseExporter
+}
+
+func NewBinaryExporter() Exporter {
+	return &BinaryExporter{BaseExporter: NewBaseExporter("binary")}
+}
+
+// 바이너리 포맷의 머리와 버전
+const (
+	binaryMagic   = "XLCB"
+	binaryVersion = 1
+)
+
+// 바이너리 포맷의 컬럼 값 종류
+const (
+	binaryKindInt      byte = 1
+	binaryKindFloat    byte = 2
+	binaryKindBool     byte = 3
+	binaryKindString   byte = 4
+	binaryKindBytes    byte = 5
+	binaryKindJSON     byte = 6
+	binaryKindDateTime byte = 7
+)
+
+// 바이너리 포맷의 컬럼 인코딩 (compress 태그)
+const (
+	binaryEncodingPlain  byte = 0
+	binaryEncodingDelta  byte = 1
+	binaryEncodingRLE    byte = 2
+	binaryEncodingIntern byte = 3 // intern 태그
+)
+
+var binaryKindNames = map[byte]string{
+	binaryKindInt: "int", binaryKindFloat: "float", binaryKindBool: "bool", binaryKindString: "string",
+	binaryKindBytes: "bytes", binaryKindJSON: "json", binaryKindDateTime: "datetime",
+}
+
+func (e *BinaryExporter) Describe() ExporterInfo {
+	return ExporterInfo{
+		Description: "Columnar binary tables applying compress:delta/rle and intern, with Go and TypeScript decoders",
+		Outputs:     []string{"<Table>.bin", "<group>/<Table>.bin", "decoder.go", "decoder.ts"},
+		Types: typeMappings(func(col Column) (string, error) {
+			return binaryKindNames[binaryKind(col)], nil
+		}),
+	}
+}
+
+func (e *BinaryExporter) Export(tables []Table, opts Options) error {
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	for _, table := range tables {
+		if errs := append(validateColumnEncoding(table), validateIntern(table)...); len(errs) > 0 {
+			return errs[0]
+		}
+		// 그룹이 있는 테이블은 그룹 이름의 하위 디렉토리에 씀 (JSON exporter와 같음)
+		dir := opts.OutputDir
+		if table.Group != "" {
+			dir = filepath.Join(opts.OutputDir, table.Group)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create output directory: %v", err)
+			}
+		}
+		data, err := EncodeBinaryTable(table)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, table.Name+".bin"), data, 0644); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range []struct{ tmpl, name string }{
+		{"binary/decoder.go.tmpl", "decoder.go"},
+		{"binary/decoder.ts.tmpl", "decoder.ts"},
+	} {
+		if err := writeBinaryDecoder(opts, file.tmpl, file.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeBinaryDecoder는 디코더 템플릿을 출력 디렉토리에 씁니다.
+func writeBinaryDecoder(opts Options, tmplName, fileName string) error {
+	tmplText, err := loadTemplate(opts.TemplateDir, tmplName)
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("binary").Parse(tmplText)
+	if err != nil {
+		return err
+	}
+	packageName := opts.PackageName
+	if packageName == "" {
+		packageName = "models"
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ PackageName string }{packageName}); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(opts.OutputDir, fileName), buf.Bytes(), 0644)
+}
+
+// binaryKind는 컬럼 값의 바이너리 종류입니다.
+func binaryKind(col Column) byte {
+	switch {
+	case col.Type.IsArray || col.Type.IsGeo() || col.Type.IsCurve():
+		return binaryKindJSON
+	case col.Type.IsFormula():
+		return binaryKindString
+	case col.Type.Type == DateTimeType.Type:
+		return binaryKindDateTime
+	case col.Type.Type == BytesType.Type:
+		return binaryKindBytes
+	}
+	switch col.Type.Type.Kind() {
+	case reflect.Int32, reflect.Int64:
+		return binaryKindInt
+	case reflect.Float64:
+		return binaryKindFloat
+	case reflect.Bool:
+		return binaryKindBool
+	}
+	return binaryKindString
+}
+
+// binaryEncoding은 compress 태그의 인코딩 또는 intern입니다. 태그가 없으면 plain입니다.
+func binaryEncoding(col Column) byte {
+	if isInterned(col) {
+		return binaryEncodingIntern
+	}
+	value, _ := GetTagValue(col.Tags, TagCompress)
+	switch normalizeColumnEncoding(value) {
+	case EncodingDelta:
+		return binaryEncodingDelta
+	case EncodingRLE:
+		return binaryEncodingRLE
+	}
+	return binaryEncodingPlain
+}
+
+// EncodeBinaryTable은 테이블을 바이너리 포맷으로 인코딩합니다.
+func EncodeBinaryTable(table Table) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(binaryMagic)
+	putUvarint(&buf, binaryVersion)
+	putString(&buf, table.Name)
+	putUvarint(&buf, uint64(len(table.Rows)))
+	putUvarint(&buf, uint64(len(table.Columns)))
+
+	for i, col := range table.Columns {
+		kind, encoding := binaryKind(col), binaryEncoding(col)
+		putString(&buf, col.Name)
+		buf.WriteByte(kind)
+		buf.WriteByte(encoding)
+
+		present := make([]byte, (len(table.Rows)+7)/8)
+		var values []interface{}
+		for r, row := range table.Rows {
+			var value interface{}
+			if i < len(row) {
+				value = row[i]
+			}
+			if value == nil {
+				continue
+			}
+			present[r/8] |= 1 << (r % 8)
+			values = append(values, value)
+		}
+		buf.Write(present)
+
+		body, err := encodeBinaryColumn(kind, encoding, values)
+		if err != nil {
+			return nil, fmt.Errorf("table %s column %s: %v", table.Name, col.Name, err)
+		}
+		putUvarint(&buf, uint64(len(body)))
+		buf.Write(body)
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeBinaryColumn은 값이 있는 행의 값들을 컬럼 본문으로 인코딩합니다.
+func encodeBinaryColumn(kind, encoding byte, values []interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	switch encoding {
+	case binaryEncodingDelta:
+		var prev int64
+		for _, value := range values {
+			n, ok := toInt64(value)
+			if !ok {
+				return nil, fmt.Errorf("delta encoding needs integer values, got %v (%T)", value, value)
+			}
+			putVarint(&buf, n-prev)
+			prev = n
+		}
+	case binaryEncodingRLE:
+		for start := 0; start < len(values); {
+			end := start + 1
+			for end < len(values) && reflect.DeepEqual(values[end], values[start]) {
+				end++
+			}
+			putUvarint(&buf, uint64(end-start))
+			if err := putBinaryValue(&buf, kind, values[start]); err != nil {
+				return nil, err
+			}
+			start = end
+		}
+	case binaryEncodingIntern:
+		var strs []string
+		index := make(map[string]uint64)
+		var indexes []uint64
+		for _, value := range values {
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("intern needs string values, got %v (%T)", value, value)
+			}
+			i, ok := index[s]
+			if !ok {
+				i = uint64(len(strs))
+				index[s] = i
+				strs = append(strs, s)
+			}
+			indexes = append(indexes, i)
+		}
+		putUvarint(&buf, uint64(len(strs)))
+		for _, s := range strs {
+			putString(&buf, s)
+		}
+		for _, i := range indexes {
+			putUvarint(&buf, i)
+		}
+	default:
+		for _, value := range values {
+			if err := putBinaryValue(&buf, kind, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// putBinaryValue는 값 하나를 종류에 맞게 씁니다.
+func putBinaryValue(buf *bytes.Buffer, kind byte, value interface{}) error {
+	switch kind {
+	case binaryKindInt:
+		n, ok := toInt64(value)
+		if !ok {
+			return fmt.Errorf("not an integer value %v (%T)", value, value)
+		}
+		putVarint(buf, n)
+	case binaryKindFloat:
+		f, ok := statsNumber(value)
+		if !ok {
+			return fmt.Errorf("not a number value %v (%T)", value, value)
+		}
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(f))
+		buf.Write(b[:])
+	case binaryKindBool:
+		b, err := encodeBool(value)
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(byte(b))
+	case binaryKindDateTime:
+		t, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("not a datetime value %v (%T)", value, value)
+		}
+		putString(buf, t.UTC().Format(time.RFC3339Nano))
+	case binaryKindBytes:
+		b, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("not a bytes value %v (%T)", value, value)
+		}
+		putString(buf, string(b))
+	case binaryKindJSON:
+		// 배열은 파서가 JSON 문자열로 저장함
+		if s, ok := value.(string); ok {
+			putString(buf, s)
+			break
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		putString(buf, string(data))
+	default:
+		s, ok := value.(string)
+		if !ok {
+			s = fmt.Sprint(value)
+		}
+		putString(buf, s)
+	}
+	return nil
+}
+
+func putUvarint(buf *bytes.Buffer, n uint64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutUvarint(b[:], n)])
+}
+
+func putVarint(buf *bytes.Buffer, n int64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutVarint(b[:], n)])
+}
+
+func putString(buf *bytes.Buffer, s string) {
+	putUvarint(buf, uint64(len(s)))
+	buf.WriteString(s)
+}
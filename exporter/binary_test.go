@@ -0,0 +1,186 @@
+package exporter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func binaryTestColumn(name, typ string, tags ...string) Column {
+	col := Column{Name: name, Type: ParseColumnType(typ)}
+	for _, tag := range tags {
+		col.Tags = append(col.Tags, ParseTagWithValue(tag))
+	}
+	return col
+}
+
+func binaryTestTable() Table {
+	at := time.Date(2024, 5, 1, 9, 0, 0, 123000000, time.UTC)
+	return Table{
+		Name: "Item",
+		Columns: []Column{
+			binaryTestColumn("ID", "int", "compress:delta"),
+			binaryTestColumn("Type", "string", "compress:rle"),
+			binaryTestColumn("Grade", "int", "compress:rle"),
+			binaryTestColumn("Big", "int64", "compress:delta"),
+			binaryTestColumn("Score", "float"),
+			binaryTestColumn("On", "bool"),
+			binaryTestColumn("At", "datetime"),
+			binaryTestColumn("Tags", "array<string>"),
+			binaryTestColumn("Data", "blob"),
+			binaryTestColumn("Name", "string"),
+			binaryTestColumn("Effect", "string", "intern"),
+		},
+		Rows: [][]interface{}{
+			{int32(1000), "weapon", int32(1), int64(math.MaxInt64), 1.5, true, at, `["a","b"]`, []byte{0, 1, 2}, "검", "불꽃"},
+			{int32(1001), "weapon", int32(1), int64(math.MinInt64), -0.25, false, nil, `[]`, nil, "", "poison"},
+			{int32(1003), "weapon", nil, int64(0), 1e300, nil, at.Add(time.Hour), nil, []byte{}, "quote \" and\nnewline", nil},
+			{int32(900), "armor", int32(1), int64(-5), nil, true, at, `["c"]`, []byte("xyz"), nil, "불꽃"},
+			{int32(901), nil, int32(2)}, // ragged row: the rest are empty cells
+		},
+	}
+}
+
+// TestBinaryRoundTrip은 binary exporter의 산출물을 생성된 Go 디코더로 읽어 원래 값과 비교합니다.
+func TestBinaryRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the generated decoder with go run")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	dir := t.TempDir()
+	table := binaryTestTable()
+	if err := NewBinaryExporter().Export([]Table{table}, Options{OutputDir: dir, PackageName: "main"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"decoder.go", "decoder.ts", "Item.bin"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("missing output %s: %v", name, err)
+		}
+	}
+
+	main := `package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+func main() {
+	data, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		panic(err)
+	}
+	table, err := DecodeBinaryTable(data)
+	if err != nil {
+		panic(err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	for i := 0; i < table.Rows; i++ {
+		if err := enc.Encode(table.Row(i)); err != nil {
+			panic(err)
+		}
+	}
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(main), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module decodetest\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(goTool, "run", ".", "Item.bin")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("generated decoder failed: %v\n%s", err, stderr.String())
+	}
+
+	var got [][]interface{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		dec := json.NewDecoder(strings.NewReader(scanner.Text()))
+		dec.UseNumber()
+		var row map[string]interface{}
+		if err := dec.Decode(&row); err != nil {
+			t.Fatal(err)
+		}
+		values := make([]interface{}, len(table.Columns))
+		for i, col := range table.Columns {
+			values[i] = row[col.Name]
+		}
+		got = append(got, values)
+	}
+	if len(got) != len(table.Rows) {
+		t.Fatalf("decoded %d rows, want %d", len(got), len(table.Rows))
+	}
+	for r, row := range table.Rows {
+		for c, col := range table.Columns {
+			var want interface{}
+			if c < len(row) {
+				want = row[c]
+			}
+			w := canonicalCell(col, want, DateTimeRFC3339, true)
+			g := canonicalCell(col, got[r][c], DateTimeRFC3339, false)
+			if w != g {
+				t.Errorf("row %d column %s: want %s, got %s", r+1, col.Name, w, g)
+			}
+		}
+	}
+}
+
+// TestBinaryEncodingSize는 delta와 rle가 맞는 데이터에서 plain보다 작게 인코딩하는지 확인합니다.
+func TestBinaryEncodingSize(t *testing.T) {
+	ids := make([]interface{}, 1000)
+	types := make([]interface{}, 1000)
+	for i := range ids {
+		ids[i] = int64(1_000_000 + i)
+		types[i] = []string{"weapon", "armor"}[i/500]
+	}
+	for _, tc := range []struct {
+		name     string
+		kind     byte
+		encoding byte
+		values   []interface{}
+	}{
+		{"delta", binaryKindInt, binaryEncodingDelta, ids},
+		{"rle", binaryKindString, binaryEncodingRLE, types},
+		{"intern", binaryKindString, binaryEncodingIntern, types},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			plain, err := encodeBinaryColumn(tc.kind, binaryEncodingPlain, tc.values)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encoded, err := encodeBinaryColumn(tc.kind, tc.encoding, tc.values)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(encoded) >= len(plain) {
+				t.Errorf("%s encoding is %d bytes, plain is %d bytes", tc.name, len(encoded), len(plain))
+			}
+		})
+	}
+}
+
+func TestBinaryRejectsInvalidEncoding(t *testing.T) {
+	table := Table{Name: "Item", Columns: []Column{binaryTestColumn("Name", "string", "compress:delta")}, Rows: [][]interface{}{{"a"}}}
+	err := NewBinaryExporter().Export([]Table{table}, Options{OutputDir: t.TempDir()})
+	if err == nil || !strings.Contains(err.Error(), "compress:delta requires an int") {
+		t.Fatalf("want a compress:delta error, got %v", err)
+	}
+}
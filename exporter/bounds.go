@@ -0,0 +1,173 @@
+// exporter/bounds.go
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Bounds는 min/max 태그로 지정한 컬럼 값의 허용 범위입니다.
+// 숫자 컬럼은 값, 문자열 컬럼은 글자 수, 배열 컬럼은 각 원소에 적용됩니다.
+type Bounds struct {
+	Min, Max       float64
+	HasMin, HasMax bool
+}
+
+// ColumnBounds는 컬럼의 min/max 태그를 파싱합니다.
+func ColumnBounds(col Column) (Bounds, error) {
+	var b Bounds
+	if value, ok := GetTagValue(col.Tags, TagMin); ok {
+		min, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return b, fmt.Errorf("invalid min value %q", value)
+		}
+		b.Min, b.HasMin = min, true
+	}
+	if value, ok := GetTagValue(col.Tags, TagMax); ok {
+		max, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return b, fmt.Errorf("invalid max value %q", value)
+		}
+		b.Max, b.HasMax = max, true
+	}
+	if b.HasMin && b.HasMax && b.Min > b.Max {
+		return b, fmt.Errorf("min %v is greater than max %v", b.Min, b.Max)
+	}
+	if (b.HasMin || b.HasMax) && !boundable(elementType(col.Type)) {
+		return b, fmt.Errorf("min/max are not supported for %s columns", ColumnTypeName(col.Type))
+	}
+	return b, nil
+}
+
+// IsSet은 범위가 지정되어 있는지 확인합니다.
+func (b Bounds) IsSet() bool {
+	return b.HasMin || b.HasMax
+}
+
+func elementType(ct ColumnType) ColumnType {
+	if ct.IsArray && ct.BaseType != nil {
+		return *ct.BaseType
+	}
+	return ct
+}
+
+func boundable(ct ColumnType) bool {
+	if ct.IsCurve() {
+		return true // 키프레임의 값
+	}
+	switch ct.Type.Kind() {
+	case reflect.Int32, reflect.Int64, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
+
+// checkBounds는 파싱된 셀 값이 컬럼의 범위 안에 있는지 확인합니다.
+func checkBounds(col Column, b Bounds, value interface{}) error {
+	if value == nil || !b.IsSet() {
+		return nil
+	}
+
+	if col.Type.IsArray {
+		s, ok := value.(string)
+		if !ok {
+			return nil
+		}
+		var items []interface{}
+		if err := json.Unmarshal([]byte(s), &items); err != nil {
+			return err
+		}
+		for i, item := range items {
+			if err := b.check(item); err != nil {
+				return fmt.Errorf("element %d: %v", i, err)
+			}
+		}
+		return nil
+	}
+
+	// 커브는 키프레임의 값에 범위를 적용
+	if curve, ok := value.(Curve); ok {
+		for i, key := range curve.Keys {
+			if err := b.check(key[1]); err != nil {
+				return fmt.Errorf("keyframe %d: %v", i, err)
+			}
+		}
+		return nil
+	}
+
+	return b.check(value)
+}
+
+func (b Bounds) check(value interface{}) error {
+	var n float64
+	var what string
+	switch v := value.(type) {
+	case int32:
+		n, what = float64(v), "value"
+	case int64:
+		n, what = float64(v), "value"
+	case float64:
+		n, what = v, "value"
+	case string:
+		n, what = float64(utf8.RuneCountInString(v)), "length"
+	default:
+		return nil
+	}
+
+	if b.HasMin && n < b.Min {
+		return fmt.Errorf("%s %v is below min %v", what, n, b.Min)
+	}
+	if b.HasMax && n > b.Max {
+		return fmt.Errorf("%s %v is above max %v", what, n, b.Max)
+	}
+	return nil
+}
+
+// buildCheckConstraint는 범위를 SQL CHECK 제약으로 변환합니다.
+// 배열과 커브 컬럼은 JSON 텍스트로 저장되므로 CHECK 대신 로드 시점 검증만 적용됩니다.
+func buildCheckConstraint(col Column, b Bounds, d SQLDialect) string {
+	if !b.IsSet() || col.Type.IsArray || col.Type.IsCurve() {
+		return ""
+	}
+
+	expr := d.QuoteIdentifier(col.Name)
+	if col.Type.Type.Kind() == reflect.String {
+		expr = "length(" + expr + ")"
+	}
+
+	var conds []string
+	if b.HasMin {
+		conds = append(conds, fmt.Sprintf("%s >= %s", expr, formatBound(b.Min)))
+	}
+	if b.HasMax {
+		conds = append(conds, fmt.Sprintf("%s <= %s", expr, formatBound(b.Max)))
+	}
+	return "CHECK (" + strings.Join(conds, " AND ") + ")"
+}
+
+// validatorTag는 go-playground/validator 형식의 검증 규칙을 반환합니다.
+func validatorTag(col Column, b Bounds) string {
+	if !b.IsSet() || col.Type.IsCurve() {
+		return ""
+	}
+
+	var rules []string
+	if col.Type.IsArray {
+		rules = append(rules, "dive")
+	}
+	if b.HasMin {
+		rules = append(rules, "min="+formatBound(b.Min))
+	}
+	if b.HasMax {
+		rules = append(rules, "max="+formatBound(b.Max))
+	}
+	return strings.Join(rules, ",")
+}
+
+func formatBound(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,95 @@
+// exporter/budget.go
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Budget은 테이블의 최대 행 수와 최대 직렬화 크기입니다. (#Config의 maxRows, maxSize 설정)
+// 시트가 실수로 커져서 클라이언트 다운로드 크기가 늘어나는 것을 생성 단계에서 막기 위함입니다.
+type Budget struct {
+	MaxRows string // 최대 행 수
+	MaxSize string // 최대 직렬화 크기 (예: 512KB, 2MB, 바이트 단위 숫자)
+}
+
+// parseByteSize는 "512KB", "2MB", "1024" 형식의 크기를 바이트 수로 변환합니다. 단위는 1024 배수입니다.
+func parseByteSize(s string) (int64, error) {
+	text := strings.ToUpper(strings.TrimSpace(s))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(text, unit.suffix) {
+			text, multiplier = strings.TrimSpace(strings.TrimSuffix(text, unit.suffix)), unit.size
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(text, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q (expected e.g. 512KB, 2MB or a byte count)", s)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// FormatByteSize는 바이트 수를 읽기 쉬운 단위로 표시합니다.
+func FormatByteSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
+
+// SerializedSize는 테이블의 행들을 JSON exporter와 같은 형식(행 객체의 배열, 들여쓰기 없음)으로 직렬화한 크기입니다.
+// exporter마다 산출물 형식이 다르므로 예산은 이 크기를 기준으로 합니다.
+func SerializedSize(table Table) (int64, error) {
+	var size int64 = 2 // []
+	for i, row := range table.Rows {
+		data, err := json.Marshal(patchValues(table, row))
+		if err != nil {
+			return 0, fmt.Errorf("table %s row %s: %v", table.Name, RowKey(table, row), err)
+		}
+		size += int64(len(data))
+		if i > 0 {
+			size++ // ,
+		}
+	}
+	return size, nil
+}
+
+// validateBudget은 테이블이 행 수와 직렬화 크기 예산을 넘지 않는지 확인합니다.
+// 프로필의 행 필터와 transform이 적용된 뒤, 실제로 생성될 내용을 기준으로 검사합니다.
+func validateBudget(table Table) []error {
+	var errs []error
+	if table.Budget.MaxRows != "" {
+		max, err := strconv.Atoi(strings.TrimSpace(table.Budget.MaxRows))
+		switch {
+		case err != nil || max < 0:
+			errs = append(errs, fmt.Errorf("table %s: invalid maxRows %q", table.Name, table.Budget.MaxRows))
+		case len(table.Rows) > max:
+			errs = append(errs, fmt.Errorf("table %s has %d rows, exceeding its budget of %d rows (maxRows in #Config)", table.Name, len(table.Rows), max))
+		}
+	}
+
+	if table.Budget.MaxSize != "" {
+		max, err := parseByteSize(table.Budget.MaxSize)
+		if err != nil {
+			return append(errs, fmt.Errorf("table %s: maxSize: %v", table.Name, err))
+		}
+		size, err := SerializedSize(table)
+		if err != nil {
+			return append(errs, err)
+		}
+		if size > max {
+			errs = append(errs, fmt.Errorf("table %s serializes to %s, exceeding its budget of %s (maxSize in #Config)",
+				table.Name, FormatByteSize(size), FormatByteSize(max)))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,152 @@
+// exporter/coerce.go
+package exporter
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// 셀 값 변환 규칙
+//
+// 모든 타입:
+//   - 앞뒤 공백을 제거하고, 빈 셀은 NULL입니다.
+//   - 올바르지 않은 UTF-8이나 MaxCellLength(Excel 셀 최대 길이)를 넘는 값은 에러입니다.
+//
+// 문자열:
+//   - 탭, 줄바꿈(\n, \r)을 제외한 제어 문자는 제거합니다.
+//   - 맨 앞의 작은따옴표(')는 의도된 값일 수 있으므로 그대로 유지합니다.
+//
+// 숫자/불리언/날짜:
+//   - Excel의 텍스트 접두어인 맨 앞의 작은따옴표(')는 제거합니다.
+//   - 제어 문자가 포함된 값은 에러입니다.
+//   - 정수: 1.23E+5, 3.0 처럼 정수로 정확히 표현되는 실수 표기를 허용합니다.
+//     소수부가 있거나 범위를 벗어나면 에러입니다.
+//   - 실수: NaN, Inf는 에러입니다.
+//   - 불리언: true/false, 1/0, yes/no, y/n, on/off (대소문자 무시)
+//   - 날짜: 지원되는 날짜 형식 외에 Excel 날짜 일련번호(예: 45292, 45292.5)를 허용합니다.
+//
+// 변환할 수 없는 값은 NULL로 대체하지 않고 에러로 보고합니다.
+
+// MaxCellLength는 한 셀이 가질 수 있는 최대 문자 수입니다 (Excel 제한).
+const MaxCellLength = 32767
+
+// normalizeCell은 타입과 무관한 공통 규칙을 적용합니다.
+func normalizeCell(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if !utf8.ValidString(value) {
+		return "", fmt.Errorf("invalid UTF-8 in %s", describeCell(value))
+	}
+	if n := utf8.RuneCountInString(value); n > MaxCellLength {
+		return "", fmt.Errorf("cell has %d characters, exceeding the limit of %d", n, MaxCellLength)
+	}
+	return value, nil
+}
+
+// cleanString은 문자열 셀에서 탭과 줄바꿈을 제외한 제어 문자를 제거합니다.
+func cleanString(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// scalarText는 숫자/불리언/날짜 셀에서 텍스트 접두어를 제거하고 제어 문자를 검사합니다.
+func scalarText(value string) (string, error) {
+	value = strings.TrimSpace(strings.TrimPrefix(value, "'"))
+	for _, r := range value {
+		if unicode.IsControl(r) {
+			return "", fmt.Errorf("control character %U in %s", r, describeCell(value))
+		}
+	}
+	return value, nil
+}
+
+// coerceInt는 셀 값을 bitSize 비트 정수로 변환합니다.
+func coerceInt(value string, bitSize int) (int64, error) {
+	value, err := scalarText(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if i, err := strconv.ParseInt(value, 10, bitSize); err == nil {
+		return i, nil
+	} else if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+		return 0, fmt.Errorf("%s is out of range for int%d", describeCell(value), bitSize)
+	}
+
+	// 1.23E+5, 3.0 처럼 실수로 표기된 정수
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%s is not an integer", describeCell(value))
+	}
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("%s has a fractional part and cannot be stored as an integer", describeCell(value))
+	}
+	limit := math.Ldexp(1, bitSize-1)
+	if f < -limit || f >= limit {
+		return 0, fmt.Errorf("%s is out of range for int%d", describeCell(value), bitSize)
+	}
+	return int64(f), nil
+}
+
+// coerceFloat는 셀 값을 유한한 실수로 변환합니다.
+func coerceFloat(value string) (float64, error) {
+	value, err := scalarText(value)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%s is not a finite number", describeCell(value))
+	}
+	return f, nil
+}
+
+// coerceBool은 셀 값을 불리언으로 변환합니다.
+func coerceBool(value string) (bool, error) {
+	value, err := scalarText(value)
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(value) {
+	case "true", "t", "1", "yes", "y", "on":
+		return true, nil
+	case "false", "f", "0", "no", "n", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("%s is not a boolean", describeCell(value))
+}
+
+// excelSerialTime은 Excel 날짜 일련번호를 UTC 시각으로 변환합니다.
+func excelSerialTime(value string) (time.Time, bool) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f <= 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+		return time.Time{}, false
+	}
+	t, err := excelize.ExcelDateToTime(f, false)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.UTC(), true
+}
+
+// describeCell은 에러 메시지에 사용할 셀 값을 적당한 길이로 잘라 인용합니다.
+func describeCell(value string) string {
+	const max = 40
+	if utf8.RuneCountInString(value) > max {
+		runes := []rune(value)
+		return fmt.Sprintf("value %q...", string(runes[:max]))
+	}
+	return fmt.Sprintf("value %q", value)
+}
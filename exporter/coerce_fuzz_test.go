@@ -0,0 +1,113 @@
+package exporter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// coerceSeeds는 셀 값 변환 fuzz 테스트의 시드입니다.
+// 지수 표기, 텍스트 접두어('), 제어 문자, 범위를 벗어나는 값, 셀 최대 길이를 넘는 값을 포함합니다.
+var coerceSeeds = []string{
+	"", " ", "0", "-0", "1", "-1", "+1", "007", "3.0", "3.5", "-3.5",
+	"1e3", "1E+5", "1.23E+5", "1.5e-3", "-2.5E-10", "1e400", "-1e400", "1e-400", "9e18", "1e19",
+	"9223372036854775807", "9223372036854775808", "-9223372036854775808", "-9223372036854775809",
+	"2147483647", "2147483648", "-2147483648", "-2147483649",
+	"NaN", "nan", "Inf", "-Inf", "+Infinity", "0x1p-2", "0x10", "1_000", ".5", "5.", "e5", "--1",
+	"'42", "'1.5", "'true", "''1", "'", "' 7 ", " 42 ",
+	"\x00", "1\x00", "4\t2", "4\n2", "\x7f1", "1\u200b", "\ufeff1",
+	"true", "FALSE", "Yes", "n", "on", "OFF", "t", "2",
+	"2024-05-01", "2024-05-01 09:00:00", "2024-05-01T09:00:00Z", "2024-05-01 09:00:00.123Z",
+	"2024-13-40", "45292", "45292.5", "-1", "0.0001", "2958466", "1e308",
+	"\xff\xfe", "이름", strings.Repeat("9", 400), strings.Repeat("1", MaxCellLength+1),
+}
+
+func FuzzCoerceInt(f *testing.F) {
+	for _, seed := range coerceSeeds {
+		f.Add(seed, true)
+		f.Add(seed, false)
+	}
+	f.Fuzz(func(t *testing.T, value string, wide bool) {
+		bitSize := 32
+		if wide {
+			bitSize = 64
+		}
+		n, err := coerceInt(value, bitSize)
+		if err != nil {
+			if n != 0 {
+				t.Fatalf("coerceInt(%q, %d) returned %d with error %v", value, bitSize, n, err)
+			}
+			return
+		}
+		if bitSize == 32 && (n < math.MinInt32 || n > math.MaxInt32) {
+			t.Fatalf("coerceInt(%q, %d) = %d is out of range", value, bitSize, n)
+		}
+		// 변환된 값은 다시 변환해도 같아야 함
+		if again, err := coerceInt(strconv.FormatInt(n, 10), bitSize); err != nil || again != n {
+			t.Fatalf("coerceInt(%q, %d) = %d does not round-trip: %d, %v", value, bitSize, n, again, err)
+		}
+	})
+}
+
+func FuzzCoerceFloat(f *testing.F) {
+	for _, seed := range coerceSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		v, err := coerceFloat(value)
+		if err != nil {
+			if v != 0 {
+				t.Fatalf("coerceFloat(%q) returned %v with error %v", value, v, err)
+			}
+			return
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			t.Fatalf("coerceFloat(%q) = %v is not finite", value, v)
+		}
+		if again, err := coerceFloat(strconv.FormatFloat(v, 'g', -1, 64)); err != nil || again != v {
+			t.Fatalf("coerceFloat(%q) = %v does not round-trip: %v, %v", value, v, again, err)
+		}
+	})
+}
+
+func FuzzCoerceBool(f *testing.F) {
+	for _, seed := range coerceSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		b, err := coerceBool(value)
+		if err != nil {
+			if b {
+				t.Fatalf("coerceBool(%q) returned true with error %v", value, err)
+			}
+			return
+		}
+		if again, err := coerceBool(strconv.FormatBool(b)); err != nil || again != b {
+			t.Fatalf("coerceBool(%q) = %v does not round-trip: %v, %v", value, b, again, err)
+		}
+	})
+}
+
+func FuzzTimeParser(f *testing.F) {
+	for _, seed := range coerceSeeds {
+		f.Add(seed)
+	}
+	parser := NewTimeParser("At", DateTimeType)
+	f.Fuzz(func(t *testing.T, value string) {
+		v, err := parser.Parse(value)
+		if err != nil {
+			if !v.IsZero() {
+				t.Fatalf("Parse(%q) returned %v with error %v", value, v.Interface(), err)
+			}
+			if !strings.HasPrefix(err.Error(), "column At: ") {
+				t.Fatalf("Parse(%q) error does not name the column: %v", value, err)
+			}
+			return
+		}
+		if _, ok := v.Interface().(time.Time); !ok {
+			t.Fatalf("Parse(%q) = %v (%T) is not a time", value, v.Interface(), v.Interface())
+		}
+	})
+}
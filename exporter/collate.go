@@ -0,0 +1,135 @@
+// exporter/collate.go
+package exporter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SQLite가 기본 제공하는 collation
+var sqliteCollations = []string{"binary", "nocase", "rtrim"}
+
+var collationNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+
+// ColumnCollation은 컬럼의 collate 태그 값을 반환합니다.
+func ColumnCollation(col Column) (string, bool) {
+	name, ok := GetTagValue(col.Tags, TagCollate)
+	return strings.TrimSpace(name), ok
+}
+
+// sqliteCollation은 SQLite 컬럼 정의에 쓸 collation을 반환합니다.
+// SQLite에 없는 collation(서버 DB용 이름)이면 빈 문자열을 반환하고 기본 비교 규칙(BINARY)을 사용합니다.
+func sqliteCollation(col Column) string {
+	name, ok := ColumnCollation(col)
+	if !ok || !containsString(sqliteCollations, strings.ToLower(name)) {
+		return ""
+	}
+	return strings.ToUpper(name)
+}
+
+// collationKey는 SQLite collation에서 같다고 비교되는 값들이 같은 키를 갖도록 값을 바꿉니다.
+// NOCASE는 ASCII 문자만 대소문자를 무시하고, RTRIM은 끝의 공백을 무시합니다.
+func collationKey(collation, s string) string {
+	switch collation {
+	case "NOCASE":
+		return strings.Map(func(r rune) rune {
+			if r >= 'A' && r <= 'Z' {
+				return r + ('a' - 'A')
+			}
+			return r
+		}, s)
+	case "RTRIM":
+		return strings.TrimRight(s, " ")
+	}
+	return s
+}
+
+// 읽을 때 적용하는 텍스트 정규화 (셀 앞뒤 공백은 정규화와 관계없이 항상 제거됩니다)
+const (
+	NormalizeLower = "lower" // 소문자로
+	NormalizeUpper = "upper" // 대문자로
+	NormalizeSpace = "space" // 연속된 공백 문자를 공백 하나로
+)
+
+// columnNormalization은 normalize 태그의 정규화 목록을 반환합니다.
+// 대소문자 변환은 하나만 지정할 수 있으며, string 컬럼(배열은 원소)에만 사용할 수 있습니다.
+func columnNormalization(col Column) ([]string, error) {
+	value, ok := GetTagValue(col.Tags, TagNormalize)
+	if !ok {
+		return nil, nil
+	}
+	if elementType(col.Type).Type != StringType.Type {
+		return nil, fmt.Errorf("normalize is only supported for string columns")
+	}
+
+	ops := strings.FieldsFunc(strings.ToLower(value), func(c rune) bool { return c == '|' || c == ' ' })
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("normalize needs a value (%s, %s or %s, combined with |)", NormalizeLower, NormalizeUpper, NormalizeSpace)
+	}
+	for _, op := range ops {
+		if op != NormalizeLower && op != NormalizeUpper && op != NormalizeSpace {
+			return nil, fmt.Errorf("unknown normalize %q (expected %s, %s or %s)", op, NormalizeLower, NormalizeUpper, NormalizeSpace)
+		}
+	}
+	if containsString(ops, NormalizeLower) && containsString(ops, NormalizeUpper) {
+		return nil, fmt.Errorf("normalize cannot be both %s and %s", NormalizeLower, NormalizeUpper)
+	}
+	return ops, nil
+}
+
+// normalizeText는 셀 텍스트에 정규화를 순서대로 적용합니다.
+func normalizeText(ops []string, s string) string {
+	for _, op := range ops {
+		switch op {
+		case NormalizeLower:
+			s = strings.ToLower(s)
+		case NormalizeUpper:
+			s = strings.ToUpper(s)
+		case NormalizeSpace:
+			s = strings.Join(strings.Fields(s), " ")
+		}
+	}
+	return s
+}
+
+// validateCollation은 collate 태그를 확인하고, SQLite collation을 쓰는 키/유니크 컬럼에서
+// collation으로 비교하면 같은 값(예: "IronSword"와 "ironsword")을 찾습니다. 이런 값은 DB에 넣을 때 UNIQUE 위반이 되거나 키 조회에서 여러 행이 찾아집니다.
+func validateCollation(table Table, i int) []error {
+	col := table.Columns[i]
+	name, ok := ColumnCollation(col)
+	if !ok {
+		return nil
+	}
+	switch {
+	case name == "" || !collationNamePattern.MatchString(name):
+		return []error{fmt.Errorf("table %s column %s: invalid collation %q", table.Name, col.Name, name)}
+	case col.Type.IsArray || col.Type.Type != StringType.Type:
+		return []error{fmt.Errorf("table %s column %s: collate is only supported for string columns", table.Name, col.Name)}
+	}
+
+	collation := sqliteCollation(col)
+	key := i == IndexColumn(table) && !table.IsSettings && !table.IsMatrix
+	if collation == "" || collation == "BINARY" || !(key || col.IsUnique || HasTag(col.Tags, TagUnique)) {
+		return nil
+	}
+	// 완전히 같은 값(변형, 적용 기간 행의 인덱스 등)은 collation과 관계없으므로 보고하지 않음
+	var errs []error
+	seen := make(map[string]string)
+	for _, row := range table.Rows {
+		if i >= len(row) {
+			continue
+		}
+		s, ok := row[i].(string)
+		if !ok {
+			continue
+		}
+		folded := collationKey(collation, s)
+		if prev, ok := seen[folded]; !ok {
+			seen[folded] = s
+		} else if prev != s {
+			errs = append(errs, fmt.Errorf("table %s column %s: values %q and %q are equal under COLLATE %s", table.Name, col.Name, prev, s, collation))
+		}
+	}
+	return errs
+}
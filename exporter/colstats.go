@@ -0,0 +1,187 @@
+// exporter/colstats.go
+package exporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnStatsFile은 generate가 컬럼별 통계와 검증 결과를 기록하는 파일 이름입니다. (출력 디렉토리)
+const ColumnStatsFile = ".excelite-stats.json"
+
+// ColumnStats는 컬럼 하나의 값 통계와 컬럼 검증 결과입니다.
+// Hash는 컬럼 정의, 값, 검증에 쓰이는 #Enum/#Locale 내용의 해시이며, 다음 실행에서 같고 Valid이면 컬럼 검증을 건너뜁니다.
+type ColumnStats struct {
+	Table    string `json:"table"`
+	Column   string `json:"column"`
+	Type     string `json:"type"`
+	Rows     int    `json:"rows"`
+	Nulls    int    `json:"nulls"`
+	Distinct int    `json:"distinct"`      // 빈 값이 아닌 서로 다른 값의 수
+	Min      string `json:"min,omitempty"` // 숫자, datetime 컬럼의 최솟값
+	Max      string `json:"max,omitempty"` // 숫자, datetime 컬럼의 최댓값
+	Hash     string `json:"hash"`
+	Valid    bool   `json:"valid"`            // 컬럼 검증(columnValidators)을 통과함
+	Cached   bool   `json:"cached,omitempty"` // 이전 실행과 같아 컬럼 검증을 건너뜀
+}
+
+// ColumnStatsCache는 이전 실행의 컬럼 통계입니다. 키는 "테이블.컬럼"입니다.
+type ColumnStatsCache map[string]ColumnStats
+
+func columnStatsKey(table, column string) string {
+	return table + "." + column
+}
+
+// LoadColumnStats는 통계 파일을 읽습니다. 파일이 없으면 빈 캐시를 반환합니다.
+func LoadColumnStats(path string) (ColumnStatsCache, error) {
+	cache := make(ColumnStatsCache)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return cache, fmt.Errorf("failed to read column stats: %v", err)
+	}
+	var stats []ColumnStats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return cache, fmt.Errorf("failed to parse column stats %s: %v", path, err)
+	}
+	for _, s := range stats {
+		cache[columnStatsKey(s.Table, s.Column)] = s
+	}
+	return cache, nil
+}
+
+// WriteColumnStats는 통계를 파일에 씁니다.
+func WriteColumnStats(path string, stats []ColumnStats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Merge는 stats에 없는 테이블의 이전 통계를 stats 뒤에 더합니다.
+// 일부 테이블만 검증한 실행(--tables, --staged)에서 나머지 테이블의 기록을 유지하기 위함입니다.
+func (c ColumnStatsCache) Merge(stats []ColumnStats) []ColumnStats {
+	tables := make(map[string]bool)
+	for _, s := range stats {
+		tables[s.Table] = true
+	}
+	merged := stats
+	for _, key := range sortedMapKeys(c) {
+		if s := c[key]; !tables[s.Table] {
+			s.Cached = false
+			merged = append(merged, s)
+		}
+	}
+	return merged
+}
+
+// CachedColumns는 컬럼 검증을 건너뛴 컬럼 수입니다.
+func CachedColumns(stats []ColumnStats) int {
+	n := 0
+	for _, s := range stats {
+		if s.Cached {
+			n++
+		}
+	}
+	return n
+}
+
+// computeColumnStats는 테이블의 i번째 컬럼의 통계와 해시를 계산합니다.
+// 해시에는 컬럼 검증의 결과를 바꿀 수 있는 것(타입, 태그, 키 여부, enum 코드, 로컬라이제이션 키)을 모두 넣습니다.
+func computeColumnStats(table Table, i int) ColumnStats {
+	col := table.Columns[i]
+	stats := ColumnStats{Table: table.Name, Column: col.Name, Type: ColumnTypeName(col.Type), Rows: len(table.Rows)}
+
+	h := sha256.New()
+	key := i == IndexColumn(table) && !table.IsSettings && !table.IsMatrix
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%v\x00%t\x00%t\x00", table.Name, col.Name, stats.Type, col.Tags, col.IsUnique, key)
+	if enum, ok := GetTagValue(col.Tags, TagEnum); ok {
+		codes, _ := table.Display.enum(strings.TrimSpace(enum))
+		data, _ := json.Marshal(codes)
+		fmt.Fprintf(h, "enum\x00%s\x00", data)
+	}
+	if HasTag(col.Tags, TagLocalized) && table.Display != nil {
+		data, _ := json.Marshal(table.Display.Texts)
+		fmt.Fprintf(h, "locale\x00%s\x00%v\x00", data, table.Display.Locales)
+	}
+
+	distinct := make(map[string]bool)
+	var min, max interface{}
+	for _, row := range table.Rows {
+		var value interface{}
+		if i < len(row) {
+			value = row[i]
+		}
+		text := fmt.Sprintf("%T\x00%v", value, value)
+		fmt.Fprintf(h, "%s\x00", text)
+		if value == nil || value == "" {
+			stats.Nulls++
+			continue
+		}
+		distinct[text] = true
+		if col.Type.IsArray {
+			continue
+		}
+		if min == nil || statsLess(value, min) {
+			min = value
+		}
+		if max == nil || statsLess(max, value) {
+			max = value
+		}
+	}
+	stats.Distinct = len(distinct)
+	stats.Min, stats.Max = statsText(min), statsText(max)
+	stats.Hash = hex.EncodeToString(h.Sum(nil))
+	return stats
+}
+
+// statsLess는 숫자와 datetime 값의 크기를 비교합니다. 비교할 수 없는 값은 false입니다.
+func statsLess(a, b interface{}) bool {
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		return ok && ta.Before(tb)
+	}
+	fa, okA := statsNumber(a)
+	fb, okB := statsNumber(b)
+	return okA && okB && fa < fb
+}
+
+func statsNumber(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// statsText는 최솟값, 최댓값을 표시합니다. 숫자와 datetime이 아니면 빈 문자열입니다.
+func statsText(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return ""
+}
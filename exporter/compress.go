@@ -0,0 +1,181 @@
+// exporter/compress.go
+package exporter
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// CompressArtifacts는 디렉토리 내의 데이터 산출물을 gzip 또는 zstd로 압축합니다.
+// 압축된 파일은 <파일>.gz / <파일>.zst로 저장되고 원본은 삭제됩니다.
+// level이 0이면 알고리즘의 기본 레벨을 사용합니다.
+func CompressArtifacts(dir, algo string, level int) error {
+	ext, err := compressionExt(algo)
+	if err != nil {
+		return err
+	}
+
+	paths, err := listDataArtifacts(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		if strings.HasSuffix(path, GzipExt) || strings.HasSuffix(path, ZstdExt) {
+			continue
+		}
+		if err := compressFile(path, path+ext, algo, level); err != nil {
+			return fmt.Errorf("%s: %v", filepath.Base(path), err)
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func compressionExt(algo string) (string, error) {
+	switch strings.ToLower(algo) {
+	case "gzip", "gz":
+		return GzipExt, nil
+	case "zstd", "zst":
+		return ZstdExt, nil
+	default:
+		return "", fmt.Errorf("unsupported compression algorithm: %s", algo)
+	}
+}
+
+func compressFile(src, dst, algo string, level int) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	var w io.WriteCloser
+	switch strings.ToLower(algo) {
+	case "gzip", "gz":
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		w, err = gzip.NewWriterLevel(out, level)
+	default:
+		zlevel := zstd.SpeedDefault
+		if level != 0 {
+			zlevel = zstd.EncoderLevelFromZstd(level)
+		}
+		w, err = zstd.NewWriter(out, zstd.WithEncoderLevel(zlevel))
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(w, in); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// generateDecompressLoaders는 압축된 산출물을 스트리밍으로 읽기 위한 Go/TypeScript 로더 코드를 생성합니다.
+func generateDecompressLoaders(opts Options) error {
+	const goLoader = `// Code generated by excelite. DO NOT EDIT.
+package %s
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// OpenCompressedArtifact opens a .gz or .zst artifact and returns a streaming decompressor.
+func OpenCompressedArtifact(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case strings.HasSuffix(path, ".gz"):
+		r, err := gzip.NewReader(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &artifactReader{Reader: r, closers: []io.Closer{r, f}}, nil
+	case strings.HasSuffix(path, ".zst"):
+		r, err := zstd.NewReader(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &artifactReader{Reader: r, closers: []io.Closer{r.IOReadCloser(), f}}, nil
+	default:
+		return f, nil
+	}
+}
+
+type artifactReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *artifactReader) Close() error {
+	var firstErr error
+	for _, c := range r.closers {
+		if err := c.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+`
+
+	const tsLoader = `// Code generated by excelite. DO NOT EDIT.
+import { createReadStream } from "fs";
+import { Readable } from "stream";
+import * as zlib from "zlib";
+
+// openCompressedArtifact opens a .gz or .zst artifact as a decompressed stream.
+// zstd streams require Node.js 22.15 or later.
+export function openCompressedArtifact(path: string): Readable {
+    const file = createReadStream(path);
+    if (path.endsWith(".gz")) {
+        return file.pipe(zlib.createGunzip());
+    }
+    if (path.endsWith(".zst")) {
+        return file.pipe((zlib as any).createZstdDecompress());
+    }
+    return file;
+}
+`
+
+	loaderDir := filepath.Join(opts.OutputDir, "loader")
+	if err := os.MkdirAll(loaderDir, 0755); err != nil {
+		return err
+	}
+
+	goCode := fmt.Sprintf(goLoader, opts.PackageName)
+	if err := os.WriteFile(filepath.Join(loaderDir, "decompress.go"), []byte(goCode), 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(loaderDir, "decompress.ts"), []byte(tsLoader), 0644)
+}
@@ -0,0 +1,223 @@
+// exporter/config.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ConfigEntry는 #Config 시트의 한 행을 나타냅니다.
+// Table이 "*" 이거나 비어있으면 워크북 전체에 적용됩니다.
+type ConfigEntry struct {
+	Table string
+	Key   string
+	Value string
+}
+
+// 설정 키 상수
+// 키는 대소문자를 구분하지 않으므로 소문자로 정의합니다.
+const (
+	ConfigKeyGroup            = "group"            // 테이블을 별도 출력 단위(DB 파일 등)로 묶습니다
+	ConfigKeyDataVersion      = "dataversion"      // 데이터 버전 (예: 1.4)
+	ConfigKeyDeprecatedUntil  = "deprecateduntil"  // 이 버전을 넘으면 폐기 예정 컬럼이 남아있을 때 생성 실패
+	ConfigKeyAlias            = "alias"            // 이름이 바뀐 테이블의 이전 이름 (한 릴리스 동안 유지)
+	ConfigKeyLayout           = "layout"           // 시트 배치 (standard, transposed)
+	ConfigKeyFilter           = "filter"           // 생성 결과에 남길 행의 조건 (filter:<프로필>은 해당 프로필에서만)
+	ConfigKeyOptional         = "optional"         // 테이블이 쓰는 optional 컬럼 그룹 (쉼표로 구분, #optional.<이름> 시트로 선언)
+	ConfigKeyTransform        = "transform"        // 테이블 변환 (rename, derive, split 등, 여러 행이면 순서대로 적용)
+	ConfigKeyMaxRows          = "maxrows"          // 최대 행 수 (넘으면 생성 실패)
+	ConfigKeyMaxSize          = "maxsize"          // 최대 직렬화 크기 (예: 512KB, 넘으면 생성 실패)
+	ConfigKeyShard            = "shard"            // 데이터 출력을 샤드 파일로 나눔 (hash:<샤드 수> 또는 range:<샤드당 행 수>)
+	ConfigKeyStructTags       = "structtags"       // 생성된 Go 모델 필드에 붙일 구조체 태그 (예: json:camel,omitempty yaml:snake)
+	ConfigKeyDateTimeStorage  = "datetimestorage"  // datetime 컬럼을 DB에 저장하는 형식 (rfc3339, epoch)
+	ConfigKeyDuplicateHeaders = "duplicateheaders" // 배열이 아닌 컬럼 이름이 반복될 때의 처리 (error, suffix)
+)
+
+// parseConfig는 #Config 시트에서 테이블별 설정을 파싱합니다.
+// 시트는 Table, Key, Value 헤더를 가져야 합니다.
+func parseConfig(f *excelize.File) ([]ConfigEntry, error) {
+	configSheet := "#Config"
+	if !contains(f.GetSheetList(), configSheet) {
+		return nil, nil
+	}
+
+	rows, err := f.GetRows(configSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config sheet: %v", err)
+	}
+
+	if len(rows) < 2 {
+		return nil, nil
+	}
+
+	colIndexes := map[string]int{
+		"Table": -1,
+		"Key":   -1,
+		"Value": -1,
+	}
+
+	for i, cell := range rows[0] {
+		colName := strings.TrimSpace(cell)
+		if _, ok := colIndexes[colName]; ok {
+			colIndexes[colName] = i
+		}
+	}
+
+	for col, idx := range colIndexes {
+		if idx == -1 {
+			return nil, fmt.Errorf("required column %s not found in config sheet", col)
+		}
+	}
+
+	var entries []ConfigEntry
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		entry := ConfigEntry{
+			Table: cellAt(row, colIndexes["Table"]),
+			Key:   strings.ToLower(cellAt(row, colIndexes["Key"])),
+			Value: cellAt(row, colIndexes["Value"]),
+		}
+		if entry.Key == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// configValue는 시트에 적용되는 설정 값을 반환합니다. 테이블별 항목이 전체 항목보다 우선합니다.
+// 시트 파싱 전에 필요한 설정(layout 등)에 사용합니다.
+func configValue(entries []ConfigEntry, sheetName, key string) string {
+	var global, specific string
+	for _, entry := range entries {
+		if entry.Key != key {
+			continue
+		}
+		switch entry.Table {
+		case "", "*":
+			global = entry.Value
+		case sheetName, formatTableName(sheetName):
+			specific = entry.Value
+		}
+	}
+	if specific != "" {
+		return specific
+	}
+	return global
+}
+
+// cellAt은 행의 idx번째 셀을 공백을 제거하여 반환합니다. 범위를 벗어나면 빈 문자열을 반환합니다.
+func cellAt(row []string, idx int) string {
+	if idx < 0 || idx >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[idx])
+}
+
+// applyConfig는 설정 항목들을 테이블에 반영합니다.
+// Table이 "*" 이거나 비어있는 항목을 먼저 모든 테이블에 적용한 뒤 테이블별 항목을 적용합니다.
+// 워크북에 존재하지만 파싱되지 않은(선택되지 않은) 시트에 대한 항목은 무시합니다.
+func applyConfig(tables []Table, entries []ConfigEntry, sheetNames []string) ([]Table, error) {
+	tableMap := make(map[string]int)
+	for i, table := range tables {
+		tableMap[table.Name] = i
+		tableMap[table.SheetName] = i
+	}
+
+	knownSheets := make(map[string]bool)
+	for _, name := range sheetNames {
+		knownSheets[name] = true
+		knownSheets[sheetTableName(name)] = true
+	}
+
+	for _, entry := range entries {
+		if entry.Table == "" || entry.Table == "*" {
+			for i := range tables {
+				applyConfigEntry(&tables[i], entry)
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.Table == "" || entry.Table == "*" {
+			continue
+		}
+
+		idx, ok := tableMap[entry.Table]
+		if !ok && knownSheets[entry.Table] {
+			continue
+		}
+		if !ok {
+			return nil, fmt.Errorf("config entry %s refers to unknown table %s", entry.Key, entry.Table)
+		}
+
+		applyConfigEntry(&tables[idx], entry)
+	}
+
+	return tables, nil
+}
+
+func applyConfigEntry(table *Table, entry ConfigEntry) {
+	if profile, ok := strings.CutPrefix(entry.Key, ConfigKeyFilter+":"); ok {
+		table.RowFilters = append(table.RowFilters, RowFilter{Profile: strings.TrimSpace(profile), Source: entry.Value})
+		return
+	}
+
+	switch entry.Key {
+	case ConfigKeyGroup:
+		table.Group = entry.Value
+	case ConfigKeyDataVersion:
+		table.DataVersion = entry.Value
+	case ConfigKeyDeprecatedUntil:
+		table.DeprecatedUntil = entry.Value
+	case ConfigKeyAlias:
+		if alias := formatTableName(entry.Value); alias != "" {
+			table.Aliases = append(table.Aliases, alias)
+		}
+	case ConfigKeyFilter:
+		table.RowFilters = append(table.RowFilters, RowFilter{Source: entry.Value})
+	case ConfigKeyTransform:
+		table.Transforms = append(table.Transforms, entry.Value)
+	case ConfigKeyMaxRows:
+		table.Budget.MaxRows = entry.Value
+	case ConfigKeyMaxSize:
+		table.Budget.MaxSize = entry.Value
+	case ConfigKeyShard:
+		table.Shard = entry.Value
+	case ConfigKeyStructTags:
+		table.StructTags = entry.Value
+	case ConfigKeyDateTimeStorage:
+		table.DateTimeStorage = entry.Value
+	case ConfigKeyLayout, ConfigKeyOptional, ConfigKeyDuplicateHeaders:
+		// 시트 파싱 시 반영됨 (sheetLayout, applyOptionalGroups, sheetHeaderRules)
+	}
+}
+
+// GroupTables는 테이블을 Group별로 묶어 반환합니다. 그룹이 없는 테이블은 빈 문자열 키에 담깁니다.
+// 관계가 그룹 경계를 넘는 경우 에러를 반환합니다.
+func GroupTables(tables []Table) (map[string][]Table, error) {
+	groupOf := make(map[string]string)
+	for _, table := range tables {
+		groupOf[table.Name] = table.Group
+	}
+
+	groups := make(map[string][]Table)
+	for _, table := range tables {
+		for _, rel := range table.Relations {
+			target, ok := groupOf[rel.TargetTable]
+			if !ok {
+				continue
+			}
+			if target != table.Group {
+				return nil, fmt.Errorf("relation %s -> %s crosses group boundary (%q -> %q)",
+					rel.SourceTable, rel.TargetTable, table.Group, target)
+			}
+		}
+		groups[table.Group] = append(groups[table.Group], table)
+	}
+
+	return groups, nil
+}
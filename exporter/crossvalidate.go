@@ -0,0 +1,177 @@
+// exporter/crossvalidate.go
+package exporter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 다른 테이블의 컬럼을 참조하는 validate 규칙입니다. 인자는 Table.Column 형태입니다.
+//
+//	exists(Skill.Index)     값(배열이면 각 원소)이 Skill.Index 값 중 하나여야 함
+//	lte(Character.Level)    값이 Character.Level의 최댓값 이하여야 함 (lt는 미만)
+//	gte(Character.Level)    값이 Character.Level의 최솟값 이상이어야 함 (gt는 초과)
+//
+// 파싱이 끝난 뒤 Validate에서 모든 테이블을 대상으로 검사합니다.
+var crossTableRuleNames = []string{"exists", "lt", "lte", "gt", "gte"}
+
+// columnRef는 규칙 인자로 주어진 Table.Column 참조입니다.
+type columnRef struct {
+	Table  string
+	Column string
+}
+
+func (r columnRef) String() string {
+	return r.Table + "." + r.Column
+}
+
+func parseColumnRef(arg string) (columnRef, error) {
+	parts := strings.Split(strings.TrimSpace(arg), ".")
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return columnRef{}, fmt.Errorf("expected Table.Column, got %q", arg)
+	}
+	return columnRef{Table: strings.TrimSpace(parts[0]), Column: strings.TrimSpace(parts[1])}, nil
+}
+
+// isCrossTableRule은 규칙이 다른 테이블을 참조하는지 확인합니다.
+func (r validateRule) isCrossTableRule() bool {
+	return containsString(crossTableRuleNames, r.Name)
+}
+
+// validateCrossTable은 테이블의 c번째 컬럼에서 다른 테이블을 참조하는 validate 규칙을 모든 행에 대해 검사합니다.
+// byName은 모든 테이블을 이름으로 찾는 맵입니다.
+func validateCrossTable(byName map[string]Table, table Table, c int) []error {
+	col := table.Columns[c]
+	rules, err := ValidateRules(col)
+	if err != nil {
+		return nil // 규칙 문법 에러는 Validate에서 따로 보고함
+	}
+
+	var errs []error
+	for _, rule := range rules {
+		if !rule.isCrossTableRule() {
+			continue
+		}
+		ref, _ := parseColumnRef(rule.Arg)
+		target, ok := byName[ref.Table]
+		targetIdx := -1
+		if ok {
+			targetIdx = columnIndex(target, ref.Column)
+		}
+		if targetIdx == -1 {
+			errs = append(errs, fmt.Errorf("table %s column %s: validate rule %s refers to unknown column %s", table.Name, col.Name, rule.Name, ref))
+			continue
+		}
+		errs = append(errs, checkCrossTableRule(table, c, rule, target, targetIdx)...)
+	}
+	return errs
+}
+
+// crossTableRefs는 컬럼의 validate 규칙이 참조하는 다른 테이블의 컬럼들입니다.
+func crossTableRefs(col Column) []columnRef {
+	rules, err := ValidateRules(col)
+	if err != nil {
+		return nil
+	}
+	var refs []columnRef
+	for _, rule := range rules {
+		if !rule.isCrossTableRule() {
+			continue
+		}
+		if ref, err := parseColumnRef(rule.Arg); err == nil {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// checkCrossTableRule은 규칙 하나를 테이블의 모든 행에 대해 검사합니다.
+func checkCrossTableRule(table Table, c int, rule validateRule, target Table, t int) []error {
+	col := table.Columns[c]
+	ref := columnRef{Table: target.Name, Column: target.Columns[t].Name}
+	rowErr := func(rowIdx int, row []interface{}, format string, args ...interface{}) error {
+		return fmt.Errorf("table %s: row %d (%s) column %s: %s", table.Name, rowIdx+1, RowKey(table, row), col.Name, fmt.Sprintf(format, args...))
+	}
+
+	if rule.Name == "exists" {
+		values := make(map[string]bool, len(target.Rows))
+		for _, row := range target.Rows {
+			if t < len(row) && row[t] != nil {
+				for _, v := range cellValues(target.Columns[t], row[t]) {
+					values[v] = true
+				}
+			}
+		}
+		var errs []error
+		for rowIdx, row := range table.Rows {
+			if c >= len(row) || row[c] == nil {
+				continue
+			}
+			for _, v := range cellValues(col, row[c]) {
+				if !values[v] {
+					errs = append(errs, rowErr(rowIdx, row, "%s does not exist in %s", v, ref))
+				}
+			}
+		}
+		return errs
+	}
+
+	if !boundable(elementType(col.Type)) || !boundable(elementType(target.Columns[t].Type)) || elementType(col.Type).Type == StringType.Type {
+		return []error{fmt.Errorf("table %s column %s: validate rule %s requires numeric columns", table.Name, col.Name, rule.Name)}
+	}
+
+	// lt/lte는 대상 컬럼의 최댓값, gt/gte는 최솟값과 비교
+	var limit float64
+	found := false
+	for _, row := range target.Rows {
+		if t >= len(row) || row[t] == nil {
+			continue
+		}
+		for _, v := range numericCellValues(target.Columns[t], row[t]) {
+			upper := rule.Name == "lt" || rule.Name == "lte"
+			if !found || (upper && v > limit) || (!upper && v < limit) {
+				limit, found = v, true
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	var errs []error
+	for rowIdx, row := range table.Rows {
+		if c >= len(row) || row[c] == nil {
+			continue
+		}
+		for _, v := range numericCellValues(col, row[c]) {
+			var ok bool
+			var what string
+			switch rule.Name {
+			case "lt":
+				ok, what = v < limit, "less than the maximum"
+			case "lte":
+				ok, what = v <= limit, "at most the maximum"
+			case "gt":
+				ok, what = v > limit, "greater than the minimum"
+			case "gte":
+				ok, what = v >= limit, "at least the minimum"
+			}
+			if !ok {
+				errs = append(errs, rowErr(rowIdx, row, "%s must be %s of %s (%s)", formatBound(v), what, ref, formatBound(limit)))
+			}
+		}
+	}
+	return errs
+}
+
+// numericCellValues는 셀 값(배열 컬럼이면 원소들)을 숫자 목록으로 반환합니다.
+func numericCellValues(col Column, value interface{}) []float64 {
+	var values []float64
+	for _, s := range cellValues(col, value) {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			values = append(values, f)
+		}
+	}
+	return values
+}
@@ -0,0 +1,282 @@
+// exporter/curve.go
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// curve 타입의 보간 방식
+const (
+	CurveLinear = "linear" // 키프레임 사이를 직선으로 보간
+	CurveBezier = "bezier" // 키프레임의 기울기로 만든 3차 베지어 곡선으로 보간
+)
+
+// Curve는 curve 타입 컬럼의 값입니다. 키프레임은 시간 순서로 정렬된 [시간, 값]이며, bezier 키프레임은 [시간, 값, 기울기]입니다.
+// SQLite에는 {"mode":"linear","keys":[[0,1],[10,5]]} 형태의 JSON 텍스트로 저장됩니다.
+type Curve struct {
+	Mode string      `json:"mode"`
+	Keys [][]float64 `json:"keys"`
+}
+
+// CurveType은 애니메이션 커브(스탯 성장 곡선 등) 컬럼 타입입니다. 셀에는 "시간:값;시간:값;..." 형태로 입력합니다.
+// curve는 선형 보간, curve<bezier>는 베지어 보간이며 bezier 키프레임은 "시간:값:기울기"로 기울기를 지정할 수 있습니다.
+var CurveType = ColumnType{
+	Type:    reflect.TypeOf(Curve{}),
+	SQLType: "TEXT",
+	Interp:  CurveLinear,
+}
+
+// IsCurve는 컬럼 타입이 curve 타입인지 확인합니다.
+func (ct ColumnType) IsCurve() bool {
+	return !ct.IsArray && ct.Type == CurveType.Type
+}
+
+// parseCurveType은 "curve", "curve<linear>", "curve<bezier>" 타입 표기를 파싱합니다.
+func parseCurveType(typeStr string) (ColumnType, bool) {
+	if typeStr == "curve" {
+		return CurveType, true
+	}
+	if !strings.HasPrefix(typeStr, "curve<") || !strings.HasSuffix(typeStr, ">") {
+		return ColumnType{}, false
+	}
+	mode := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(typeStr, "curve<"), ">"))
+	if mode != CurveLinear && mode != CurveBezier {
+		return ColumnType{}, false
+	}
+	ct := CurveType
+	ct.Interp = mode
+	return ct, true
+}
+
+// parseCurve는 "t:v;t:v;..." 형태의 셀 값을 키프레임으로 변환합니다. 키프레임의 시간은 순서대로 증가해야 합니다.
+// bezier 커브에서 기울기를 지정하지 않은 키프레임은 양옆 키프레임으로 기울기를 정합니다.
+func parseCurve(value, mode string) (Curve, error) {
+	curve := Curve{Mode: mode}
+	var explicit []bool
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == '\n' }) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		fields := strings.Split(part, ":")
+		if len(fields) < 2 || len(fields) > 3 || (len(fields) == 3 && mode != CurveBezier) {
+			if mode == CurveBezier {
+				return Curve{}, fmt.Errorf("keyframe %q is not time:value or time:value:slope", part)
+			}
+			return Curve{}, fmt.Errorf("keyframe %q is not time:value", part)
+		}
+		key := make([]float64, len(fields))
+		for i, field := range fields {
+			f, err := coerceFloat(strings.TrimSpace(field))
+			if err != nil {
+				return Curve{}, fmt.Errorf("keyframe %q: %v", part, err)
+			}
+			key[i] = f
+		}
+		if n := len(curve.Keys); n > 0 && key[0] <= curve.Keys[n-1][0] {
+			return Curve{}, fmt.Errorf("keyframe times must increase (%g after %g)", key[0], curve.Keys[n-1][0])
+		}
+		curve.Keys = append(curve.Keys, key)
+		explicit = append(explicit, len(key) == 3)
+	}
+	if len(curve.Keys) == 0 {
+		return Curve{}, fmt.Errorf("%s has no keyframes (expected \"time:value;time:value\")", describeCell(value))
+	}
+
+	if mode == CurveBezier {
+		keys := curve.Keys
+		for i := range keys {
+			if explicit[i] {
+				continue
+			}
+			prev, next := keys[max(i-1, 0)], keys[min(i+1, len(keys)-1)]
+			slope := 0.0
+			if next[0] > prev[0] {
+				slope = (next[1] - prev[1]) / (next[0] - prev[0])
+			}
+			keys[i] = append(keys[i], slope)
+		}
+	}
+	return curve, nil
+}
+
+// validateCurves는 curve 타입을 배열 원소로 쓰지 않았는지 확인합니다. 키프레임 구분자(;)가 배열 구분자와 겹칩니다.
+func validateCurves(table Table) []error {
+	var errs []error
+	for _, col := range table.Columns {
+		if col.Type.IsArray && col.Type.BaseType != nil && col.Type.BaseType.IsCurve() {
+			errs = append(errs, fmt.Errorf("table %s column %s: curve cannot be an array element", table.Name, col.Name))
+		}
+	}
+	return errs
+}
+
+// hasCurveColumn은 테이블들에 curve 컬럼이 있는지 반환합니다.
+func hasCurveColumn(tables []Table) bool {
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			if col.Type.IsCurve() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// goCurveSource는 생성된 Go 코드의 Curve 타입과 보간 함수입니다. 쿼리 레이어의 그룹 파일들이 같은 패키지를 쓰므로 별도 파일에 한 번만 씁니다.
+const goCurveSource = `// Code generated by excelite. DO NOT EDIT.
+package %s
+
+// Curve is an animation curve stored as JSON. Keys are [time, value] keyframes sorted by time;
+// bezier keyframes also carry the slope: [time, value, slope].
+type Curve struct {
+	Mode string      ` + "`json:\"mode\"`" + `
+	Keys [][]float64 ` + "`json:\"keys\"`" + `
+}
+
+// Evaluate returns the value of the curve at time t. Times outside the keyframes are clamped
+// to the first or last value; an empty curve is 0.
+func (c Curve) Evaluate(t float64) float64 {
+	n := len(c.Keys)
+	if n == 0 {
+		return 0
+	}
+	if t <= c.Keys[0][0] {
+		return c.Keys[0][1]
+	}
+	if t >= c.Keys[n-1][0] {
+		return c.Keys[n-1][1]
+	}
+
+	lo, hi := 0, n-1
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		if c.Keys[mid][0] <= t {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	a, b := c.Keys[lo], c.Keys[hi]
+	dt := b[0] - a[0]
+	u := (t - a[0]) / dt
+	if c.Mode != "bezier" || len(a) < 3 || len(b) < 3 {
+		return a[1] + (b[1]-a[1])*u
+	}
+
+	// Cubic Bezier whose inner control points follow the keyframe slopes.
+	p1 := a[1] + a[2]*dt/3
+	p2 := b[1] - b[2]*dt/3
+	s := 1 - u
+	return s*s*s*a[1] + 3*s*s*u*p1 + 3*s*u*u*p2 + u*u*u*b[1]
+}
+`
+
+// csCurveSource는 생성된 C# 코드의 Curve 클래스와 보간 함수입니다.
+const csCurveSource = `// Code generated by excelite. DO NOT EDIT.
+#nullable enable
+using System;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+
+namespace %s
+{
+    /// <summary>
+    /// An animation curve stored as JSON. Keys are [time, value] keyframes sorted by time;
+    /// bezier keyframes also carry the slope: [time, value, slope].
+    /// </summary>
+    public sealed class Curve
+    {
+        [JsonPropertyName("mode")]
+        public string Mode { get; set; } = "linear";
+
+        [JsonPropertyName("keys")]
+        public double[][] Keys { get; set; } = Array.Empty<double[]>();
+
+        /// <summary>Parses a curve column value. Empty text is null.</summary>
+        public static Curve? Parse(string json) =>
+            string.IsNullOrEmpty(json) ? null : JsonSerializer.Deserialize<Curve>(json);
+
+        /// <summary>
+        /// Returns the value of the curve at time t. Times outside the keyframes are clamped
+        /// to the first or last value; an empty curve is 0.
+        /// </summary>
+        public double Evaluate(double t)
+        {
+            int n = Keys.Length;
+            if (n == 0) return 0;
+            if (t <= Keys[0][0]) return Keys[0][1];
+            if (t >= Keys[n - 1][0]) return Keys[n - 1][1];
+
+            int lo = 0, hi = n - 1;
+            while (hi - lo > 1)
+            {
+                int mid = (lo + hi) / 2;
+                if (Keys[mid][0] <= t) lo = mid; else hi = mid;
+            }
+            double[] a = Keys[lo], b = Keys[hi];
+            double dt = b[0] - a[0];
+            double u = (t - a[0]) / dt;
+            if (Mode != "bezier" || a.Length < 3 || b.Length < 3)
+            {
+                return a[1] + (b[1] - a[1]) * u;
+            }
+
+            // Cubic Bezier whose inner control points follow the keyframe slopes.
+            double p1 = a[1] + a[2] * dt / 3;
+            double p2 = b[1] - b[2] * dt / 3;
+            double s = 1 - u;
+            return s * s * s * a[1] + 3 * s * s * u * p1 + 3 * s * u * u * p2 + u * u * u * b[1];
+        }
+    }
+}
+`
+
+// tsCurveSource는 zod/typebox 스키마 파일에 추가하는 커브 보간 함수입니다.
+const tsCurveSource = `
+// evaluateCurve returns the value of a curve column at time t. Keys are [time, value] keyframes
+// sorted by time (bezier keyframes also carry the slope). Times outside the keyframes are clamped.
+export function evaluateCurve(curve: { mode: string; keys: number[][] }, t: number): number {
+  const keys = curve.keys;
+  const n = keys.length;
+  if (n === 0) return 0;
+  if (t <= keys[0][0]) return keys[0][1];
+  if (t >= keys[n - 1][0]) return keys[n - 1][1];
+
+  let lo = 0;
+  let hi = n - 1;
+  while (hi - lo > 1) {
+    const mid = (lo + hi) >> 1;
+    if (keys[mid][0] <= t) lo = mid;
+    else hi = mid;
+  }
+  const a = keys[lo];
+  const b = keys[hi];
+  const dt = b[0] - a[0];
+  const u = (t - a[0]) / dt;
+  if (curve.mode !== "bezier" || a.length < 3 || b.length < 3) {
+    return a[1] + (b[1] - a[1]) * u;
+  }
+
+  // Cubic Bezier whose inner control points follow the keyframe slopes.
+  const p1 = a[1] + (a[2] * dt) / 3;
+  const p2 = b[1] - (b[2] * dt) / 3;
+  const s = 1 - u;
+  return s * s * s * a[1] + 3 * s * s * u * p1 + 3 * s * u * u * p2 + u * u * u * b[1];
+}
+`
+
+// writeCurveSource는 dir에 Curve 타입 파일을 씁니다. lang은 go 또는 csharp이며, name은 Go 패키지나 C# 네임스페이스입니다.
+func writeCurveSource(dir, lang, name string) error {
+	switch lang {
+	case "go":
+		return os.WriteFile(filepath.Join(dir, "curve.go"), []byte(fmt.Sprintf(goCurveSource, name)), 0644)
+	case "csharp":
+		return os.WriteFile(filepath.Join(dir, "Curve.cs"), []byte(fmt.Sprintf(csCurveSource, name)), 0644)
+	}
+	return nil
+}
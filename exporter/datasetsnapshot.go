@@ -0,0 +1,379 @@
+// exporter/datasetsnapshot.go
+package exporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultSnapshotDir는 데이터셋 스냅샷을 보관하는 기본 디렉토리입니다.
+const DefaultSnapshotDir = ".excelite/snapshots"
+
+const (
+	snapshotIndexFile = "index.json"
+	snapshotExt       = ".snap"
+	snapshotVersion   = 1
+	snapshotLatest    = "latest"
+)
+
+func init() {
+	// 행 값으로 나오는 기본 타입 외의 값
+	gob.Register(time.Time{})
+	gob.Register(GeoPoint{})
+	gob.Register(Curve{})
+}
+
+// DatasetSnapshot은 보관된 데이터셋 스냅샷 하나의 정보입니다.
+// 스냅샷은 생성할 때 파싱한 테이블들(프로필과 테이블 변환을 적용하기 전)을 담으므로,
+// 현재 워크북이 깨졌을 때 그 시점의 데이터로 다시 생성할 수 있습니다.
+type DatasetSnapshot struct {
+	ID        string    `json:"id"`
+	Time      time.Time `json:"time"`
+	Hash      string    `json:"hash"` // 테이블들의 내용 해시 (같으면 새 스냅샷을 만들지 않음)
+	Tags      []string  `json:"tags,omitempty"`
+	Workbooks []string  `json:"workbooks"`
+	Tables    int       `json:"tables"`
+	Rows      int       `json:"rows"`
+	Size      int64     `json:"size"` // 압축된 스냅샷 파일의 크기
+}
+
+// snapshotFile은 스냅샷 파일의 내용입니다. (gzip으로 압축한 gob)
+// reflect.Type을 담은 ColumnType은 직렬화할 수 없으므로 컬럼 타입은 시트의 타입 표기로 저장합니다.
+type snapshotFile struct {
+	Version int
+	Tables  []snapshotTable
+}
+
+type snapshotTable struct {
+	Table   Table // Columns와 Source는 비움
+	Columns []snapshotColumn
+}
+
+// 태그는 Tag 상수 값이 바뀌어도 읽을 수 있도록 시트의 태그 표기로 저장합니다.
+type snapshotColumn struct {
+	Name     string
+	Type     string
+	Tags     []string
+	IsUnique bool
+	Optional string
+}
+
+// SnapshotStore는 디렉토리에 보관된 데이터셋 스냅샷들입니다.
+type SnapshotStore struct {
+	Dir string
+}
+
+// List는 스냅샷들을 오래된 것부터 반환합니다.
+func (s SnapshotStore) List() ([]DatasetSnapshot, error) {
+	data, err := os.ReadFile(filepath.Join(s.Dir, snapshotIndexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []DatasetSnapshot
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return nil, fmt.Errorf("invalid snapshot index %s: %v", filepath.Join(s.Dir, snapshotIndexFile), err)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].Time.Before(snapshots[j].Time) })
+	return snapshots, nil
+}
+
+func (s SnapshotStore) writeIndex(snapshots []DatasetSnapshot) error {
+	if snapshots == nil {
+		snapshots = []DatasetSnapshot{}
+	}
+	data, err := json.MarshalIndent(snapshots, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.Dir, snapshotIndexFile), append(data, '\n'), 0644)
+}
+
+// PendingSnapshot은 디스크에 보관하기 전의 스냅샷입니다.
+// 파싱 직후의 테이블을 인코딩해 두었다가 생성이 성공한 뒤에 Save로 보관합니다.
+// (이후 단계가 테이블을 바꾸어도 파싱한 그대로의 데이터가 보관됨)
+type PendingSnapshot struct {
+	hash   string
+	data   []byte
+	tables int
+	rows   int
+}
+
+// PrepareSnapshot은 테이블들을 스냅샷 형식으로 인코딩합니다.
+func PrepareSnapshot(tables []Table) (*PendingSnapshot, error) {
+	hash, err := datasetHash(tables)
+	if err != nil {
+		return nil, err
+	}
+	data, err := encodeSnapshot(tables)
+	if err != nil {
+		return nil, err
+	}
+	return &PendingSnapshot{hash: hash, data: data, tables: len(tables), rows: CountRows(tables)}, nil
+}
+
+// Save는 스냅샷을 보관합니다.
+// 마지막 스냅샷과 내용이 같으면 새로 만들지 않고 그 스냅샷에 태그만 추가하며, created는 false입니다.
+func (s SnapshotStore) Save(p *PendingSnapshot, workbooks, tags []string) (snapshot DatasetSnapshot, created bool, err error) {
+	snapshots, err := s.List()
+	if err != nil {
+		return snapshot, false, err
+	}
+	for _, tag := range tags {
+		if err := checkSnapshotTag(tag); err != nil {
+			return snapshot, false, err
+		}
+	}
+
+	if n := len(snapshots); n > 0 && snapshots[n-1].Hash == p.hash {
+		if len(tags) == 0 {
+			return snapshots[n-1], false, nil
+		}
+		snapshots = retagSnapshots(snapshots, n-1, tags)
+		return snapshots[n-1], false, s.writeIndex(snapshots)
+	}
+
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return snapshot, false, err
+	}
+	now := time.Now().UTC()
+	snapshot = DatasetSnapshot{
+		ID:        now.Format("20060102-150405") + "-" + p.hash[:8],
+		Time:      now,
+		Hash:      p.hash,
+		Workbooks: workbooks,
+		Tables:    p.tables,
+		Rows:      p.rows,
+		Size:      int64(len(p.data)),
+	}
+	if err := os.WriteFile(filepath.Join(s.Dir, snapshot.ID+snapshotExt), p.data, 0644); err != nil {
+		return snapshot, false, err
+	}
+	snapshots = append(snapshots, snapshot)
+	if len(tags) > 0 {
+		snapshots = retagSnapshots(snapshots, len(snapshots)-1, tags)
+		snapshot = snapshots[len(snapshots)-1]
+	}
+	return snapshot, true, s.writeIndex(snapshots)
+}
+
+// Tag는 스냅샷에 태그를 붙입니다. 같은 태그가 다른 스냅샷에 있으면 옮겨집니다.
+func (s SnapshotStore) Tag(ref string, tags []string) (DatasetSnapshot, error) {
+	snapshots, err := s.List()
+	if err != nil {
+		return DatasetSnapshot{}, err
+	}
+	i, err := resolveSnapshot(snapshots, ref)
+	if err != nil {
+		return DatasetSnapshot{}, err
+	}
+	for _, tag := range tags {
+		if err := checkSnapshotTag(tag); err != nil {
+			return DatasetSnapshot{}, err
+		}
+	}
+	snapshots = retagSnapshots(snapshots, i, tags)
+	return snapshots[i], s.writeIndex(snapshots)
+}
+
+// Resolve는 ID, 고유한 ID 앞부분, 태그 또는 latest로 스냅샷을 찾습니다.
+func (s SnapshotStore) Resolve(ref string) (DatasetSnapshot, error) {
+	snapshots, err := s.List()
+	if err != nil {
+		return DatasetSnapshot{}, err
+	}
+	i, err := resolveSnapshot(snapshots, ref)
+	if err != nil {
+		return DatasetSnapshot{}, err
+	}
+	return snapshots[i], nil
+}
+
+// Load는 스냅샷의 테이블들을 읽습니다. 소스맵 정보(셀 위치)는 보관되지 않습니다.
+func (s SnapshotStore) Load(ref string) ([]Table, DatasetSnapshot, error) {
+	snapshot, err := s.Resolve(ref)
+	if err != nil {
+		return nil, snapshot, err
+	}
+	tables, err := readSnapshotFile(filepath.Join(s.Dir, snapshot.ID+snapshotExt))
+	if err != nil {
+		return nil, snapshot, fmt.Errorf("snapshot %s: %v", snapshot.ID, err)
+	}
+	return tables, snapshot, nil
+}
+
+// Prune은 최근 keep개와 태그가 붙은 스냅샷을 남기고 나머지를 삭제합니다.
+func (s SnapshotStore) Prune(keep int) ([]DatasetSnapshot, error) {
+	snapshots, err := s.List()
+	if err != nil || keep <= 0 || len(snapshots) <= keep {
+		return nil, err
+	}
+
+	var kept, removed []DatasetSnapshot
+	for i, snapshot := range snapshots {
+		if i >= len(snapshots)-keep || len(snapshot.Tags) > 0 {
+			kept = append(kept, snapshot)
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.Dir, snapshot.ID+snapshotExt)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, err
+		}
+		removed = append(removed, snapshot)
+	}
+	return removed, s.writeIndex(kept)
+}
+
+func resolveSnapshot(snapshots []DatasetSnapshot, ref string) (int, error) {
+	if len(snapshots) == 0 {
+		return -1, fmt.Errorf("no snapshots found")
+	}
+	if ref == snapshotLatest {
+		return len(snapshots) - 1, nil
+	}
+	for i, snapshot := range snapshots {
+		if snapshot.ID == ref || containsString(snapshot.Tags, ref) {
+			return i, nil
+		}
+	}
+	found := -1
+	for i, snapshot := range snapshots {
+		if strings.HasPrefix(snapshot.ID, ref) {
+			if found != -1 {
+				return -1, fmt.Errorf("snapshot %q is ambiguous (%s, %s, ...)", ref, snapshots[found].ID, snapshot.ID)
+			}
+			found = i
+		}
+	}
+	if found == -1 {
+		return -1, fmt.Errorf("snapshot %q not found", ref)
+	}
+	return found, nil
+}
+
+// retagSnapshots는 i번째 스냅샷에 태그를 붙이고 다른 스냅샷에서는 같은 태그를 뗍니다.
+func retagSnapshots(snapshots []DatasetSnapshot, i int, tags []string) []DatasetSnapshot {
+	for j := range snapshots {
+		if j == i {
+			continue
+		}
+		var rest []string
+		for _, tag := range snapshots[j].Tags {
+			if !containsString(tags, tag) {
+				rest = append(rest, tag)
+			}
+		}
+		snapshots[j].Tags = rest
+	}
+	for _, tag := range tags {
+		if !containsString(snapshots[i].Tags, tag) {
+			snapshots[i].Tags = append(snapshots[i].Tags, tag)
+		}
+	}
+	return snapshots
+}
+
+func checkSnapshotTag(tag string) error {
+	if tag == "" || tag == snapshotLatest || strings.ContainsAny(tag, " \t,/\\") {
+		return fmt.Errorf("invalid snapshot tag %q", tag)
+	}
+	return nil
+}
+
+// datasetHash는 테이블 이름과 내용 해시로 데이터셋의 해시를 계산합니다.
+func datasetHash(tables []Table) (string, error) {
+	h := sha256.New()
+	for _, table := range tables {
+		hash, err := LockHash(table, "")
+		if err != nil {
+			return "", fmt.Errorf("table %s: %v", table.Name, err)
+		}
+		fmt.Fprintf(h, "%s\x00%s\n", table.Name, hash)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func encodeSnapshot(tables []Table) ([]byte, error) {
+	file := snapshotFile{Version: snapshotVersion, Tables: make([]snapshotTable, 0, len(tables))}
+	for _, table := range tables {
+		st := snapshotTable{Table: table}
+		st.Table.Columns, st.Table.Source = nil, nil
+		for _, col := range table.Columns {
+			var tags []string
+			for _, tag := range col.Tags {
+				name := tagInfoMap[tag.Tag].Name
+				if tag.Value != "" {
+					name += ":" + tag.Value
+				}
+				tags = append(tags, name)
+			}
+			st.Columns = append(st.Columns, snapshotColumn{
+				Name:     col.Name,
+				Type:     ColumnTypeName(col.Type),
+				Tags:     tags,
+				IsUnique: col.IsUnique,
+				Optional: col.Optional,
+			})
+		}
+		file.Tables = append(file.Tables, st)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := gob.NewEncoder(zw).Encode(file); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func readSnapshotFile(path string) ([]Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var file snapshotFile
+	if err := gob.NewDecoder(zr).Decode(&file); err != nil {
+		return nil, err
+	}
+	if file.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", file.Version)
+	}
+
+	tables := make([]Table, 0, len(file.Tables))
+	for _, st := range file.Tables {
+		table := st.Table
+		for _, col := range st.Columns {
+			table.Columns = append(table.Columns, Column{
+				Name:     col.Name,
+				Type:     ParseColumnType(col.Type),
+				Tags:     ParseColumnTags(col.Tags),
+				IsUnique: col.IsUnique,
+				Optional: col.Optional,
+			})
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
@@ -0,0 +1,91 @@
+// exporter/delimiter.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultArrayDelimiter는 배열 셀의 원소를 나누는 기본 구분자입니다.
+// 배열 컬럼에 delim 태그를 붙이면 바꿀 수 있습니다. (예: delim:; 또는 delim:'|', delim:tab)
+//
+// 원소 안에 구분자를 넣으려면 \를 앞에 붙입니다. (예: "deal 1\,000 damage, heal 5")
+// \\는 \ 하나이며, 그 외의 \는 그대로 유지됩니다.
+const DefaultArrayDelimiter = ","
+
+// 따옴표 없이 쓰기 어려운 구분자의 이름
+var namedDelimiters = map[string]string{
+	"tab":     "\t",
+	"newline": "\n",
+	"space":   " ",
+	"comma":   ",",
+}
+
+// ArrayDelimiter는 배열 컬럼의 원소 구분자를 반환합니다.
+func ArrayDelimiter(col Column) string {
+	delim, err := columnDelimiter(col)
+	if err != nil || delim == "" {
+		return DefaultArrayDelimiter
+	}
+	return delim
+}
+
+// columnDelimiter는 delim 태그를 파싱합니다. 태그가 없으면 빈 문자열입니다.
+func columnDelimiter(col Column) (string, error) {
+	value, ok := GetTagValue(col.Tags, TagDelim)
+	if !ok {
+		return "", nil
+	}
+	if !col.Type.IsArray {
+		return "", fmt.Errorf("delim tag requires an array column")
+	}
+
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	} else if named, ok := namedDelimiters[strings.ToLower(value)]; ok {
+		value = named
+	}
+
+	if value == "" {
+		return "", fmt.Errorf("delim tag needs a delimiter (e.g. delim:;)")
+	}
+	if strings.Contains(value, `\`) {
+		return "", fmt.Errorf("delimiter %q cannot contain a backslash (it escapes delimiters)", value)
+	}
+	return value, nil
+}
+
+// splitArrayCell은 셀 텍스트를 구분자로 나눕니다. \로 이스케이프된 구분자는 나누지 않고 원소에 포함합니다.
+func splitArrayCell(s, delim string) []string {
+	var items []string
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '\\' && strings.HasPrefix(s[i+1:], delim):
+			b.WriteString(delim)
+			i += 1 + len(delim)
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == '\\':
+			b.WriteByte('\\')
+			i += 2
+		case strings.HasPrefix(s[i:], delim):
+			items = append(items, b.String())
+			b.Reset()
+			i += len(delim)
+		default:
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+	return append(items, b.String())
+}
+
+// joinArrayCell은 원소들을 splitArrayCell로 다시 나눌 수 있도록 이스케이프하여 잇습니다.
+func joinArrayCell(items []string, delim string) string {
+	escaped := make([]string, len(items))
+	for i, item := range items {
+		item = strings.ReplaceAll(item, `\`, `\\`)
+		escaped[i] = strings.ReplaceAll(item, delim, `\`+delim)
+	}
+	return strings.Join(escaped, delim)
+}
@@ -0,0 +1,87 @@
+// exporter/deprecation.go
+package exporter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DeprecationMessage는 컬럼이 deprecated 태그를 가지고 있으면 안내 메시지와 true를 반환합니다.
+// 태그에 메시지가 없으면 기본 메시지를 사용합니다.
+func DeprecationMessage(col Column) (string, bool) {
+	message, ok := GetTagValue(col.Tags, TagDeprecated)
+	if !ok {
+		return "", false
+	}
+	if message = strings.TrimSpace(message); message == "" {
+		message = "this column will be removed in a future data version"
+	}
+	return message, true
+}
+
+// DeprecationWarnings는 폐기 예정 컬럼마다 경고 메시지를 반환합니다.
+func DeprecationWarnings(tables []Table) []string {
+	var warnings []string
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			if message, ok := DeprecationMessage(col); ok {
+				warnings = append(warnings, fmt.Sprintf("%s.%s is deprecated: %s", table.Name, col.Name, message))
+			}
+		}
+	}
+	return warnings
+}
+
+// validateDeprecations는 데이터 버전이 deprecatedUntil을 넘었는데 폐기 예정 컬럼이 남아있으면 에러를 반환합니다.
+func validateDeprecations(table Table) error {
+	if table.DataVersion == "" || table.DeprecatedUntil == "" {
+		return nil
+	}
+
+	cmp, err := compareVersions(table.DataVersion, table.DeprecatedUntil)
+	if err != nil {
+		return fmt.Errorf("table %s: %v", table.Name, err)
+	}
+	if cmp <= 0 {
+		return nil
+	}
+
+	var expired []string
+	for _, col := range table.Columns {
+		if HasTag(col.Tags, TagDeprecated) {
+			expired = append(expired, col.Name)
+		}
+	}
+	if len(expired) > 0 {
+		return fmt.Errorf("table %s: deprecated columns %s must be removed after data version %s (current %s)",
+			table.Name, strings.Join(expired, ", "), table.DeprecatedUntil, table.DataVersion)
+	}
+	return nil
+}
+
+// compareVersions는 점으로 구분된 숫자 버전(1.4, 2.0.1 등)을 비교합니다.
+// a < b 이면 음수, 같으면 0, a > b 이면 양수를 반환합니다. 누락된 자리는 0으로 간주합니다.
+func compareVersions(a, b string) (int, error) {
+	as := strings.Split(strings.TrimPrefix(strings.TrimSpace(a), "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(strings.TrimSpace(b), "v"), ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		var err error
+		if i < len(as) {
+			if x, err = strconv.Atoi(as[i]); err != nil {
+				return 0, fmt.Errorf("invalid version %q", a)
+			}
+		}
+		if i < len(bs) {
+			if y, err = strconv.Atoi(bs[i]); err != nil {
+				return 0, fmt.Errorf("invalid version %q", b)
+			}
+		}
+		if x != y {
+			return x - y, nil
+		}
+	}
+	return 0, nil
+}
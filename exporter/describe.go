@@ -0,0 +1,184 @@
+// exporter/describe.go
+package exporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Describer는 exporter가 자신의 기능을 설명할 때 구현합니다.
+// excelite exporters 커맨드가 이 정보로 사용할 수 있는 exporter, 옵션, 타입 매핑을 보여줍니다.
+type Describer interface {
+	Describe() ExporterInfo
+}
+
+// ExporterInfo는 exporter 하나의 기능 설명입니다.
+type ExporterInfo struct {
+	Language    string        `json:"language"`
+	Description string        `json:"description"`
+	Outputs     []string      `json:"outputs,omitempty"` // 생성하는 파일 (출력 디렉토리 기준)
+	Options     []OptionInfo  `json:"options,omitempty"` // 이 exporter만 사용하는 ExtraOptions
+	Types       []TypeMapping `json:"types,omitempty"`   // 시트 타입별 생성 결과의 타입
+}
+
+// OptionInfo는 ExtraOptions 키 하나의 설명입니다.
+type OptionInfo struct {
+	Key         string `json:"key"`
+	Type        string `json:"type"` // bool, int, string
+	Default     string `json:"default"`
+	Description string `json:"description"`
+}
+
+// TypeMapping은 시트의 타입 하나가 생성 결과에서 어떤 타입이 되는지 나타냅니다.
+type TypeMapping struct {
+	Type   string `json:"type"`
+	Target string `json:"target"`
+}
+
+// describedTypes는 타입 매핑을 보여줄 대표 시트 타입입니다.
+var describedTypes = []string{
+	"int", "int64", "float", "bool", "string", "datetime", "blob", "geo", "curve", "formula",
+	"ref<Item>", "array<int>", "array<string>",
+}
+
+// CommonOptions는 모든 exporter의 산출물에 적용되는 ExtraOptions입니다. (finalizeArtifacts, verifyExport에서 처리)
+func CommonOptions() []OptionInfo {
+	return []OptionInfo{
+		{Key: OptEncrypt, Type: "bool", Default: "false", Description: "encrypt .db and .json artifacts with AES-GCM"},
+		{Key: OptEncryptKeyEnv, Type: "string", Default: DefaultEncryptKeyEnv, Description: "environment variable holding the encryption key"},
+		{Key: OptCompress, Type: "string", Default: "", Description: "compress .db and .json artifacts (gzip, zstd)"},
+		{Key: OptCompressLevel, Type: "int", Default: "0", Description: "compression level (0 uses the algorithm default)"},
+		{Key: OptVerify, Type: "bool", Default: "false", Description: "re-read .db and .json outputs and compare every cell with the parsed sheets"},
+	}
+}
+
+// Describe는 등록된 exporter의 기능 설명을 반환합니다.
+// Describer를 구현하지 않은 exporter는 언어 이름만 채워서 반환합니다.
+func (r *Registry) Describe(lang string) (ExporterInfo, error) {
+	exp, err := r.Get(lang)
+	if err != nil {
+		return ExporterInfo{}, err
+	}
+
+	info := ExporterInfo{Language: lang}
+	if d, ok := exp.(Describer); ok {
+		info = d.Describe()
+		info.Language = lang
+	}
+	return info, nil
+}
+
+// typeMappings는 대표 시트 타입마다 target으로 생성 결과의 타입을 구합니다.
+// target이 에러를 반환하는 타입은 지원하지 않는 것으로 표시합니다.
+func typeMappings(target func(col Column) (string, error)) []TypeMapping {
+	mappings := make([]TypeMapping, 0, len(describedTypes))
+	for _, typeStr := range describedTypes {
+		col := Column{Name: "value", Type: ParseColumnType(typeStr)}
+		result, err := target(col)
+		if err != nil {
+			result = fmt.Sprintf("unsupported (%v)", err)
+		}
+		mappings = append(mappings, TypeMapping{Type: ColumnTypeName(col.Type), Target: result})
+	}
+	return mappings
+}
+
+// ValidateOptions는 ExtraOptions를 exporter의 옵션 스키마(Describe의 Options와 CommonOptions)로 검사합니다.
+// 알 수 없는 키(오타 등)와 타입이 맞지 않는 값은 무시되지 않고 에러가 됩니다.
+// Describer를 구현하지 않은 exporter는 스키마가 없으므로 검사하지 않습니다.
+func (r *Registry) ValidateOptions(lang string, opts Options) error {
+	exp, err := r.Get(lang)
+	if err != nil {
+		return err
+	}
+	d, ok := exp.(Describer)
+	if !ok {
+		return nil
+	}
+
+	schema := make(map[string]OptionInfo)
+	for _, opt := range append(d.Describe().Options, CommonOptions()...) {
+		schema[opt.Key] = opt
+	}
+
+	keys := make([]string, 0, len(opts.ExtraOptions))
+	for key := range opts.ExtraOptions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []string
+	for _, key := range keys {
+		opt, ok := schema[key]
+		if !ok {
+			msg := fmt.Sprintf("unknown option %q", key)
+			if suggestion := closestOption(key, schema); suggestion != "" {
+				msg += fmt.Sprintf(" (did you mean %q?)", suggestion)
+			}
+			errs = append(errs, msg)
+			continue
+		}
+		if !optionTypeMatches(opt.Type, opts.ExtraOptions[key]) {
+			errs = append(errs, fmt.Sprintf("option %q must be %s, got %T", key, opt.Type, opts.ExtraOptions[key]))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid options for %s exporter: %s", lang, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// optionTypeMatches는 값이 옵션 스키마의 타입과 맞는지 확인합니다. int는 JSON에서 읽은 정수 float64도 허용합니다.
+func optionTypeMatches(typ string, value interface{}) bool {
+	switch typ {
+	case "bool":
+		_, ok := value.(bool)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "int":
+		switch v := value.(type) {
+		case int, int64:
+			return true
+		case float64:
+			return v == float64(int64(v))
+		}
+		return false
+	}
+	return true
+}
+
+// closestOption은 key와 편집 거리가 가장 가까운 옵션 키를 반환합니다. 충분히 가깝지 않으면 빈 문자열입니다.
+func closestOption(key string, schema map[string]OptionInfo) string {
+	best, bestDist := "", len(key)/2+1
+	for candidate := range schema {
+		dist := editDistance(strings.ToLower(key), strings.ToLower(candidate))
+		if dist < bestDist || (dist == bestDist && best != "" && candidate < best) {
+			best, bestDist = candidate, dist
+		}
+	}
+	return best
+}
+
+// editDistance는 두 문자열의 레벤슈타인 거리입니다.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
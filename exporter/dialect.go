@@ -0,0 +1,113 @@
+// exporter/dialect.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SQLDialect는 SQL exporter가 데이터베이스마다 다르게 생성해야 하는 문법입니다.
+// 새 데이터베이스를 지원할 때는 SQLiteExporter를 복사하지 않고 이 인터페이스를 구현합니다.
+// 테이블과 컬럼 이름은 따옴표 없이 넘기며, dialect가 필요하면 감쌉니다.
+type SQLDialect interface {
+	// Name은 dialect 이름입니다. (sqlite, postgres 등)
+	Name() string
+	// QuoteIdentifier는 테이블, 컬럼, 뷰 이름을 SQL에 쓸 수 있게 만듭니다.
+	QuoteIdentifier(name string) string
+	// ColumnType은 컬럼의 SQL 타입 이름입니다.
+	ColumnType(col Column, opts SQLTypeOptions) string
+	// AutoIncrementKey는 값을 자동으로 부여하는 정수 기본 키 컬럼의 정의입니다.
+	AutoIncrementKey(name string) string
+	// Placeholder는 n번째(1부터) 바인드 파라미터입니다.
+	Placeholder(n int) string
+	// Insert는 columns에 한 행을 넣는 INSERT 문입니다.
+	Insert(table string, columns []string) string
+	// Upsert는 conflict 컬럼 값이 같은 행이 이미 있으면 update 컬럼만 갱신하는 INSERT 문입니다.
+	// update가 비어있으면 기존 행을 그대로 둡니다.
+	Upsert(table string, columns, conflict, update []string) string
+}
+
+// SQLTypeOptions는 컬럼의 SQL 타입을 정할 때 쓰는 테이블 설정입니다.
+type SQLTypeOptions struct {
+	Strict          bool   // 데이터베이스가 컬럼 타입을 강제하는 테이블 (SQLite STRICT)
+	DateTimeStorage string // datetime 컬럼의 저장 형식 (DateTimeRFC3339, DateTimeEpoch)
+}
+
+// SQLiteDialect는 SQLite exporter가 사용하는 dialect입니다. 식별자는 필요할 때만 감쌉니다.
+var SQLiteDialect SQLDialect = sqliteDialect{}
+
+// NewSQLiteDialect는 SQLite dialect를 만듭니다. quoteAll이면 모든 식별자를 큰따옴표로 감쌉니다.
+func NewSQLiteDialect(quoteAll bool) SQLDialect {
+	return sqliteDialect{quoteAll: quoteAll}
+}
+
+type sqliteDialect struct {
+	quoteAll bool
+}
+
+func (sqliteDialect) Name() string { return "sqlite" }
+
+func (d sqliteDialect) QuoteIdentifier(name string) string {
+	if d.quoteAll {
+		return QuoteIdentifierAlways(name)
+	}
+	return QuoteIdentifier(name)
+}
+
+// ColumnType은 STRICT 테이블에서 DATETIME 대신 TEXT를 사용하고, epoch로 저장하는 datetime과 intern 컬럼(문자열 테이블의 id)은 INTEGER입니다.
+func (sqliteDialect) ColumnType(col Column, opts SQLTypeOptions) string {
+	sqliteType := GetSQLiteType(col.Type)
+	if sqliteType == SQLiteDateTime && opts.DateTimeStorage == DateTimeEpoch {
+		sqliteType = SQLiteInteger
+	}
+	// STRICT 테이블은 INTEGER, REAL, TEXT, BLOB, ANY 타입만 허용
+	if opts.Strict && sqliteType == SQLiteDateTime {
+		sqliteType = SQLiteText
+	}
+	if isInterned(col) {
+		sqliteType = SQLiteInteger
+	}
+	return sqliteType.String()
+}
+
+func (d sqliteDialect) AutoIncrementKey(name string) string {
+	return d.QuoteIdentifier(name) + " INTEGER PRIMARY KEY AUTOINCREMENT"
+}
+
+func (sqliteDialect) Placeholder(n int) string { return "?" }
+
+func (d sqliteDialect) Insert(table string, columns []string) string {
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", d.QuoteIdentifier(table), strings.Join(quoteIdentifiers(d, columns), ", "), placeholders(d, len(columns)))
+}
+
+// Upsert는 ON CONFLICT 절을 사용합니다. (SQLite 3.24+)
+func (d sqliteDialect) Upsert(table string, columns, conflict, update []string) string {
+	query := d.Insert(table, columns) + fmt.Sprintf(" ON CONFLICT(%s)", strings.Join(quoteIdentifiers(d, conflict), ", "))
+	if len(update) == 0 {
+		return query + " DO NOTHING"
+	}
+	var set []string
+	for _, name := range update {
+		quoted := d.QuoteIdentifier(name)
+		set = append(set, fmt.Sprintf("%s = excluded.%s", quoted, quoted))
+	}
+	return query + " DO UPDATE SET " + strings.Join(set, ", ")
+}
+
+// quoteIdentifiers는 이름들을 dialect에 맞게 감쌉니다.
+func quoteIdentifiers(d SQLDialect, names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = d.QuoteIdentifier(name)
+	}
+	return quoted
+}
+
+// placeholders는 n개의 바인드 파라미터 목록입니다.
+func placeholders(d SQLDialect, n int) string {
+	params := make([]string, n)
+	for i := range params {
+		params[i] = d.Placeholder(i + 1)
+	}
+	return strings.Join(params, ", ")
+}
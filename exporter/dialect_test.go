@@ -0,0 +1,206 @@
+package exporter
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Item", "Item"},
+		{"item_id", "item_id"},
+		{"_private", "_private"},
+		{"Order", `"Order"`},
+		{"order", `"order"`},
+		{"Group", `"Group"`},
+		{"SELECT", `"SELECT"`},
+		{"", `""`},
+		{"1st", `"1st"`},
+		{"Full Name", `"Full Name"`},
+		{`Say "hi"`, `"Say ""hi"""`},
+		{`"`, `""""`},
+		{"이름", `"이름"`},
+		{"아이템 목록", `"아이템 목록"`},
+		{"price-usd", `"price-usd"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := QuoteIdentifier(tc.name); got != tc.want {
+				t.Errorf("QuoteIdentifier(%q) = %s, want %s", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewSQLiteDialect(t *testing.T) {
+	tests := []struct {
+		name     string
+		quoteAll bool
+		want     string
+	}{
+		{"Item", false, "Item"},
+		{"Item", true, `"Item"`},
+		{"Order", false, `"Order"`},
+		{"Order", true, `"Order"`},
+		{`Say "hi"`, false, `"Say ""hi"""`},
+		{`Say "hi"`, true, `"Say ""hi"""`},
+		{"이름", false, `"이름"`},
+		{"이름", true, `"이름"`},
+	}
+	for _, tc := range tests {
+		d := NewSQLiteDialect(tc.quoteAll)
+		if got := d.QuoteIdentifier(tc.name); got != tc.want {
+			t.Errorf("quoteAll=%v: QuoteIdentifier(%q) = %s, want %s", tc.quoteAll, tc.name, got, tc.want)
+		}
+	}
+	if got := NewSQLiteDialect(true).Insert("Item", []string{"ID", "Group"}); got != `INSERT INTO "Item" ("ID", "Group") VALUES (?, ?)` {
+		t.Errorf("quoteAll Insert = %s", got)
+	}
+	if got := SQLiteDialect.Insert("Item", []string{"ID", "Group"}); got != `INSERT INTO Item (ID, "Group") VALUES (?, ?)` {
+		t.Errorf("Insert = %s", got)
+	}
+}
+
+func TestSingleIndexName(t *testing.T) {
+	tests := []struct {
+		table, column string
+		want          string
+		quoted        string
+	}{
+		{"Item", "Type", "idx_Item_Type", "idx_Item_Type"},
+		{"Order", "Group", "idx_Order_Group", "idx_Order_Group"},
+		{"Item", "Full Name", "idx_Item_Full Name", `"idx_Item_Full Name"`},
+		{"Item", `Say "hi"`, `idx_Item_Say "hi"`, `"idx_Item_Say ""hi"""`},
+		{"아이템", "이름", "idx_아이템_이름", `"idx_아이템_이름"`},
+	}
+	for _, tc := range tests {
+		got := SingleIndexName(tc.table, tc.column)
+		if got != tc.want {
+			t.Errorf("SingleIndexName(%q, %q) = %s, want %s", tc.table, tc.column, got, tc.want)
+		}
+		if quoted := QuoteIdentifier(got); quoted != tc.quoted {
+			t.Errorf("QuoteIdentifier(%q) = %s, want %s", got, quoted, tc.quoted)
+		}
+	}
+}
+
+// quoteTestTable은 예약어, 공백, 큰따옴표, 한글 이름을 가진 테이블입니다.
+func quoteTestTable() Table {
+	return Table{
+		Name: "Order",
+		Columns: []Column{
+			binaryTestColumn("Group", "string", "index"),
+			binaryTestColumn("Full Name", "string"),
+			binaryTestColumn("이름", "string", "index:idx_order_name"),
+			binaryTestColumn(`Say "hi"`, "int", "index:idx_order_name"),
+		},
+		Rows:    [][]interface{}{{"a", "Alice", "앨리스", int32(1)}},
+		Aliases: []string{"Select"},
+	}
+}
+
+func TestSQLiteCreateQueries(t *testing.T) {
+	tests := []struct {
+		quoteAll bool
+		table    string
+		indexes  []string
+	}{
+		{
+			quoteAll: false,
+			table: "CREATE TABLE IF NOT EXISTS \"Order\" (\n" +
+				"  id INTEGER PRIMARY KEY AUTOINCREMENT,\n" +
+				"  \"Group\" TEXT,\n" +
+				"  \"Full Name\" TEXT,\n" +
+				"  \"이름\" TEXT,\n" +
+				"  \"Say \"\"hi\"\"\" INTEGER);\n" +
+				"\n" +
+				"CREATE VIEW IF NOT EXISTS \"Select\" AS SELECT * FROM \"Order\";\n",
+			indexes: []string{
+				`CREATE INDEX IF NOT EXISTS idx_Order_Group ON "Order"("Group");`,
+				`CREATE INDEX IF NOT EXISTS idx_order_name ON "Order"("이름", "Say ""hi""");`,
+			},
+		},
+		{
+			quoteAll: true,
+			table: "CREATE TABLE IF NOT EXISTS \"Order\" (\n" +
+				"  \"id\" INTEGER PRIMARY KEY AUTOINCREMENT,\n" +
+				"  \"Group\" TEXT,\n" +
+				"  \"Full Name\" TEXT,\n" +
+				"  \"이름\" TEXT,\n" +
+				"  \"Say \"\"hi\"\"\" INTEGER);\n" +
+				"\n" +
+				"CREATE VIEW IF NOT EXISTS \"Select\" AS SELECT * FROM \"Order\";\n",
+			indexes: []string{
+				`CREATE INDEX IF NOT EXISTS "idx_Order_Group" ON "Order"("Group");`,
+				`CREATE INDEX IF NOT EXISTS "idx_order_name" ON "Order"("이름", "Say ""hi""");`,
+			},
+		},
+	}
+
+	table := quoteTestTable()
+	for _, tc := range tests {
+		e := &SQLiteExporter{BaseExporter: NewBaseExporter("sqlite"), dialect: NewSQLiteDialect(tc.quoteAll)}
+		create := e.buildCreateTableQuery(table, sqliteTableOptions{})
+		if create != tc.table {
+			t.Errorf("quoteAll=%v: CREATE TABLE\n got: %s\nwant: %s", tc.quoteAll, create, tc.table)
+		}
+		indexes := buildIndexQueries(table, e.dialect)
+		if strings.Join(indexes, "\n") != strings.Join(tc.indexes, "\n") {
+			t.Errorf("quoteAll=%v: CREATE INDEX\n got: %q\nwant: %q", tc.quoteAll, indexes, tc.indexes)
+		}
+
+		// 생성된 SQL을 SQLite가 실제로 받아들이는지 확인
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, query := range append([]string{create}, indexes...) {
+			if _, err := db.Exec(query); err != nil {
+				t.Errorf("quoteAll=%v: %v\n%s", tc.quoteAll, err, query)
+			}
+		}
+		columns := make([]string, len(table.Columns))
+		for i, col := range table.Columns {
+			columns[i] = col.Name
+		}
+		if _, err := db.Exec(e.dialect.Insert(table.Name, columns), table.Rows[0]...); err != nil {
+			t.Errorf("quoteAll=%v: insert: %v", tc.quoteAll, err)
+		}
+		rows, err := readSQLiteRows(db, table, true, e.dialect)
+		if err != nil {
+			t.Errorf("quoteAll=%v: read: %v", tc.quoteAll, err)
+		} else if len(rows) != 1 || rows[0][2] != "앨리스" {
+			t.Errorf("quoteAll=%v: read %v", tc.quoteAll, rows)
+		}
+		db.Close()
+	}
+}
+
+// TestSQLiteQuoteAllQueryLayer는 quoteAll이 CREATE TABLE 밖의 SQL(쿼리 레이어, 뷰)에도 적용되는지 확인합니다.
+func TestSQLiteQuoteAllQueryLayer(t *testing.T) {
+	plain := Table{Name: "Item", Columns: []Column{binaryTestColumn("ID", "int"), binaryTestColumn("Name", "string")}}
+	for quoteAll, want := range map[bool]string{
+		false: `SELECT COALESCE(ID, 0) AS ID, COALESCE(Name, '') AS Name FROM Item ORDER BY ID`,
+		true:  `SELECT COALESCE("ID", 0) AS "ID", COALESCE("Name", '') AS "Name" FROM "Item" ORDER BY "ID"`,
+	} {
+		if got := buildQueryTables([]Table{plain}, false, NewSQLiteDialect(quoteAll))[0].AllSQL; got != want {
+			t.Errorf("quoteAll=%v: AllSQL\n got: %s\nwant: %s", quoteAll, got, want)
+		}
+	}
+
+	plain.Aliases = []string{"Items"}
+	for quoteAll, want := range map[bool]string{
+		false: "CREATE VIEW IF NOT EXISTS Items AS SELECT * FROM Item;\n",
+		true:  "CREATE VIEW IF NOT EXISTS \"Items\" AS SELECT * FROM \"Item\";\n",
+	} {
+		if got := buildAliasViewQueries(plain, NewSQLiteDialect(quoteAll)); got != want {
+			t.Errorf("quoteAll=%v: alias view\n got: %s\nwant: %s", quoteAll, got, want)
+		}
+	}
+}
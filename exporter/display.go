@@ -0,0 +1,468 @@
+// exporter/display.go
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// EnumSheet는 enum 코드와 표시 이름을 나열하는 시트입니다. (Enum, Code, Label 헤더)
+// Label이 #Locale 시트의 키이면 선택한 언어의 텍스트로 표시합니다.
+const EnumSheet = "#Enum"
+
+// LocaleSheet는 로컬라이제이션 키별 언어별 텍스트 시트입니다.
+// Key 헤더 뒤의 컬럼 이름이 언어 코드입니다. (예: Key, ko, en, ja)
+const LocaleSheet = "#Locale"
+
+// DisplayDictionary는 워크북의 #Enum, #Locale 시트 내용입니다.
+// 같은 워크북의 테이블들이 하나를 공유하며, display exporter와 검증에서 코드와 키를 사람이 읽는 텍스트로 바꿀 때 사용합니다.
+type DisplayDictionary struct {
+	Enums   map[string]map[string]string // enum 이름 → 코드 → 표시 이름 (또는 로컬라이제이션 키)
+	Locales []string                     // #Locale 시트의 언어 코드 (시트의 컬럼 순서)
+	Texts   map[string]map[string]string // 로컬라이제이션 키 → 언어 코드 → 텍스트
+}
+
+// Text는 로컬라이제이션 키의 locale 텍스트를 반환합니다. 해당 언어의 텍스트가 비어있으면 첫 번째 언어의 텍스트를 사용합니다.
+func (d *DisplayDictionary) Text(key, locale string) (string, bool) {
+	if d == nil {
+		return "", false
+	}
+	texts, ok := d.Texts[key]
+	if !ok {
+		return "", false
+	}
+	if text := texts[locale]; text != "" {
+		return text, true
+	}
+	if len(d.Locales) > 0 && texts[d.Locales[0]] != "" {
+		return texts[d.Locales[0]], true
+	}
+	return "", true
+}
+
+// EnumLabel은 enum 코드의 표시 이름을 반환합니다. 표시 이름이 로컬라이제이션 키이면 locale 텍스트로 바꿉니다.
+func (d *DisplayDictionary) EnumLabel(enum, code, locale string) (string, bool) {
+	if d == nil {
+		return "", false
+	}
+	label, ok := d.Enums[enum][code]
+	if !ok {
+		return "", false
+	}
+	if text, ok := d.Text(label, locale); ok && text != "" {
+		return text, true
+	}
+	return label, true
+}
+
+func (d *DisplayDictionary) enum(name string) (map[string]string, bool) {
+	if d == nil {
+		return nil, false
+	}
+	codes, ok := d.Enums[name]
+	return codes, ok
+}
+
+// parseDisplayDictionary는 #Enum, #Locale 시트를 파싱합니다. 두 시트가 모두 없으면 nil입니다.
+func parseDisplayDictionary(f *excelize.File) (*DisplayDictionary, error) {
+	sheets := f.GetSheetList()
+	if !contains(sheets, EnumSheet) && !contains(sheets, LocaleSheet) {
+		return nil, nil
+	}
+
+	dict := &DisplayDictionary{
+		Enums: make(map[string]map[string]string),
+		Texts: make(map[string]map[string]string),
+	}
+	if err := parseEnumSheet(f, dict); err != nil {
+		return nil, err
+	}
+	if err := parseLocaleSheet(f, dict); err != nil {
+		return nil, err
+	}
+	return dict, nil
+}
+
+func parseEnumSheet(f *excelize.File, dict *DisplayDictionary) error {
+	if !contains(f.GetSheetList(), EnumSheet) {
+		return nil
+	}
+	rows, err := f.GetRows(EnumSheet)
+	if err != nil {
+		return fmt.Errorf("failed to read enum sheet: %v", err)
+	}
+	if len(rows) < 1 {
+		return nil
+	}
+
+	colIndexes := map[string]int{
+		"Enum":  -1,
+		"Code":  -1,
+		"Label": -1,
+	}
+	for i, cell := range rows[0] {
+		colName := strings.TrimSpace(cell)
+		if _, ok := colIndexes[colName]; ok {
+			colIndexes[colName] = i
+		}
+	}
+	for _, col := range []string{"Enum", "Code", "Label"} {
+		if colIndexes[col] == -1 {
+			return fmt.Errorf("required column %s not found in enum sheet", col)
+		}
+	}
+
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		enum := cellAt(row, colIndexes["Enum"])
+		code := cellAt(row, colIndexes["Code"])
+		if enum == "" || code == "" {
+			continue
+		}
+		if dict.Enums[enum] == nil {
+			dict.Enums[enum] = make(map[string]string)
+		}
+		if _, ok := dict.Enums[enum][code]; ok {
+			return fmt.Errorf("enum %s code %s is defined twice (row %d of %s)", enum, code, i+1, EnumSheet)
+		}
+		dict.Enums[enum][code] = cellAt(row, colIndexes["Label"])
+	}
+	return nil
+}
+
+func parseLocaleSheet(f *excelize.File, dict *DisplayDictionary) error {
+	if !contains(f.GetSheetList(), LocaleSheet) {
+		return nil
+	}
+	rows, err := f.GetRows(LocaleSheet)
+	if err != nil {
+		return fmt.Errorf("failed to read locale sheet: %v", err)
+	}
+	if len(rows) < 1 {
+		return nil
+	}
+
+	keyIdx := -1
+	localeIdx := make(map[string]int)
+	for i, cell := range rows[0] {
+		colName := strings.TrimSpace(cell)
+		switch {
+		case colName == "Key":
+			keyIdx = i
+		case colName != "":
+			if _, err := language.Parse(colName); err != nil {
+				return fmt.Errorf("locale sheet column %s is not a language code: %v", colName, err)
+			}
+			localeIdx[colName] = i
+			dict.Locales = append(dict.Locales, colName)
+		}
+	}
+	if keyIdx == -1 {
+		return fmt.Errorf("required column Key not found in locale sheet")
+	}
+
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		key := cellAt(row, keyIdx)
+		if key == "" {
+			continue
+		}
+		if _, ok := dict.Texts[key]; ok {
+			return fmt.Errorf("localization key %s is defined twice (row %d of %s)", key, i+1, LocaleSheet)
+		}
+		texts := make(map[string]string, len(localeIdx))
+		for locale, idx := range localeIdx {
+			texts[locale] = cellAt(row, idx)
+		}
+		dict.Texts[key] = texts
+	}
+	return nil
+}
+
+// displayCells는 셀 값을 원소 단위로 나눕니다. 배열 컬럼의 값은 JSON 문자열로 저장되어 있습니다.
+func displayCells(col Column, value interface{}) []interface{} {
+	if value == nil {
+		return nil
+	}
+	if s, ok := value.(string); ok && col.Type.IsArray {
+		var elems []interface{}
+		if err := json.Unmarshal([]byte(s), &elems); err == nil {
+			return elems
+		}
+	}
+	return []interface{}{value}
+}
+
+// displayCode는 enum 코드로 비교할 셀 원소의 문자열입니다.
+func displayCode(value interface{}) string {
+	if f, ok := value.(float64); ok && f == float64(int64(f)) {
+		return fmt.Sprintf("%d", int64(f))
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// validateDisplay는 enum 컬럼의 값이 #Enum에 정의된 코드인지, localized 컬럼의 값이 #Locale에 있는 키인지 확인합니다.
+func validateDisplay(table Table, i int) []error {
+	col := table.Columns[i]
+	enum, isEnum := GetTagValue(col.Tags, TagEnum)
+	localized := HasTag(col.Tags, TagLocalized)
+	if !isEnum && !localized {
+		return nil
+	}
+	var codes map[string]string
+	if isEnum {
+		var ok bool
+		enum = strings.TrimSpace(enum)
+		if codes, ok = table.Display.enum(enum); !ok {
+			return []error{fmt.Errorf("table %s column %s: enum %q is not defined in %s", table.Name, col.Name, enum, EnumSheet)}
+		}
+	}
+
+	var errs []error
+	for _, row := range table.Rows {
+		if i >= len(row) {
+			continue
+		}
+		for _, elem := range displayCells(col, row[i]) {
+			code := displayCode(elem)
+			if code == "" {
+				continue
+			}
+			if isEnum {
+				if _, ok := codes[code]; !ok {
+					errs = append(errs, fmt.Errorf("table %s row %s column %s: %q is not a code of enum %s", table.Name, RowKey(table, row), col.Name, code, enum))
+				}
+			} else if _, ok := table.Display.Text(code, ""); !ok {
+				errs = append(errs, fmt.Errorf("table %s row %s column %s: localization key %q is not defined in %s", table.Name, RowKey(table, row), col.Name, code, LocaleSheet))
+			}
+		}
+	}
+	return errs
+}
+
+// DisplayTable은 사람이 읽는 형태로 바꾼 테이블입니다. 기획/운영/QA 등 개발자가 아닌 사람이 데이터를 확인하는 용도입니다.
+type DisplayTable struct {
+	Name    string
+	Headers []string
+	Rows    [][]string
+}
+
+// BuildDisplayTable은 enum 코드를 표시 이름으로, 로컬라이제이션 키를 locale 텍스트로 바꾸고
+// 숫자와 날짜를 locale 형식으로 표시한 테이블을 만듭니다.
+// 헤더는 #Locale에 <Table>.<Column> 키가 있으면 그 텍스트, 없으면 컬럼 이름입니다.
+func BuildDisplayTable(table Table, locale string) DisplayTable {
+	dict := table.Display
+	printer := message.NewPrinter(language.Make(locale))
+
+	display := DisplayTable{Name: table.Name}
+	for _, col := range table.Columns {
+		header := col.Name
+		if text, ok := dict.Text(table.Name+"."+col.Name, locale); ok && text != "" {
+			header = text
+		}
+		display.Headers = append(display.Headers, header)
+	}
+
+	for _, row := range table.Rows {
+		cells := make([]string, len(table.Columns))
+		for i, col := range table.Columns {
+			if i >= len(row) {
+				continue
+			}
+			enum, isEnum := GetTagValue(col.Tags, TagEnum)
+			localized := HasTag(col.Tags, TagLocalized)
+
+			var parts []string
+			for _, elem := range displayCells(col, row[i]) {
+				switch {
+				case isEnum:
+					code := displayCode(elem)
+					if label, ok := dict.EnumLabel(strings.TrimSpace(enum), code, locale); ok && label != "" {
+						parts = append(parts, label)
+					} else {
+						parts = append(parts, code)
+					}
+				case localized:
+					key := displayCode(elem)
+					if text, ok := dict.Text(key, locale); ok && text != "" {
+						parts = append(parts, text)
+					} else {
+						parts = append(parts, key)
+					}
+				default:
+					parts = append(parts, formatDisplayValue(printer, elem))
+				}
+			}
+			cells[i] = strings.Join(parts, ", ")
+		}
+		display.Rows = append(display.Rows, cells)
+	}
+	return display
+}
+
+// formatDisplayValue는 숫자를 locale의 자릿수 구분 기호로, 시각을 날짜 형식으로 표시합니다.
+func formatDisplayValue(printer *message.Printer, value interface{}) string {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return printer.Sprintf("%d", v)
+	case float32, float64:
+		return printer.Sprintf("%v", v)
+	case bool:
+		if v {
+			return "✓"
+		}
+		return ""
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05")
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// DisplayExporter는 enum 코드와 로컬라이제이션 키를 사람이 읽는 텍스트로 바꾼 표시용 테이블을 CSV/HTML로 생성합니다.
+// 엔지니어가 아닌 사람에게 데이터를 보여주는 도구(리뷰, 운영, 번역 확인)에 사용합니다.
+type DisplayExporter struct {
+	BaseExporter
+}
+
+func NewDisplayExporter() Exporter {
+	return &DisplayExporter{
+		BaseExporter: NewBaseExporter("display"),
+	}
+}
+
+func (e *DisplayExporter) Describe() ExporterInfo {
+	return ExporterInfo{
+		Description: "human-readable tables with enum codes and localization keys replaced by text (#Enum, #Locale sheets)",
+		Outputs:     []string{"<Table>.csv", "<Table>.html", "index.html"},
+		Options: []OptionInfo{
+			{Key: OptDisplayLocale, Type: "string", Default: "first #Locale language", Description: "language code of the texts and number formats"},
+			{Key: OptDisplayFormats, Type: "string", Default: "csv,html", Description: "comma-separated output formats (csv, html)"},
+		},
+		Types: typeMappings(func(col Column) (string, error) {
+			switch {
+			case HasTag(col.Tags, TagEnum):
+				return "enum label", nil
+			case HasTag(col.Tags, TagLocalized):
+				return "localized text", nil
+			}
+			return "formatted text", nil
+		}),
+	}
+}
+
+func (e *DisplayExporter) Export(tables []Table, opts Options) error {
+	formats := make(map[string]bool)
+	for _, format := range strings.Split(e.GetStringOption(opts, OptDisplayFormats, "csv,html"), ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format != "csv" && format != "html" {
+			return fmt.Errorf("unknown display format %q (expected csv or html)", format)
+		}
+		formats[format] = true
+	}
+
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	locale := e.GetStringOption(opts, OptDisplayLocale, "")
+	indexLocale := locale
+	var displays []DisplayTable
+	for _, table := range tables {
+		tableLocale, err := displayLocale(table.Display, locale)
+		if err != nil {
+			return fmt.Errorf("table %s: %v", table.Name, err)
+		}
+		if indexLocale == "" {
+			indexLocale = tableLocale
+		}
+		display := BuildDisplayTable(table, tableLocale)
+		displays = append(displays, display)
+
+		if formats["csv"] {
+			if err := writeDisplayCSV(filepath.Join(opts.OutputDir, table.Name+".csv"), display); err != nil {
+				return err
+			}
+		}
+		if formats["html"] {
+			if err := writeDisplayHTML(filepath.Join(opts.OutputDir, table.Name+".html"), opts.TemplateDir, tableLocale, []DisplayTable{display}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if formats["html"] {
+		sort.Slice(displays, func(i, j int) bool { return displays[i].Name < displays[j].Name })
+		return writeDisplayHTML(filepath.Join(opts.OutputDir, "index.html"), opts.TemplateDir, indexLocale, displays)
+	}
+	return nil
+}
+
+// displayLocale은 사용할 언어 코드를 정합니다. 지정하지 않으면 #Locale 시트의 첫 번째 언어입니다.
+func displayLocale(dict *DisplayDictionary, locale string) (string, error) {
+	if locale == "" {
+		if dict != nil && len(dict.Locales) > 0 {
+			return dict.Locales[0], nil
+		}
+		return "en", nil
+	}
+	if _, err := language.Parse(locale); err != nil {
+		return "", fmt.Errorf("invalid locale %q: %v", locale, err)
+	}
+	if dict != nil && len(dict.Locales) > 0 && !contains(dict.Locales, locale) {
+		return "", fmt.Errorf("locale %s is not a column of %s (available: %s)", locale, LocaleSheet, strings.Join(dict.Locales, ", "))
+	}
+	return locale, nil
+}
+
+// writeDisplayCSV는 표시용 테이블을 CSV로 씁니다. Excel이 UTF-8로 읽도록 BOM을 붙입니다.
+func writeDisplayCSV(path string, display DisplayTable) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString("\ufeff"); err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if err := w.Write(display.Headers); err != nil {
+		return err
+	}
+	if err := w.WriteAll(display.Rows); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return nil
+}
+
+func writeDisplayHTML(path, templateDir, locale string, displays []DisplayTable) error {
+	tmplText, err := loadTemplate(templateDir, "display/tables.html.tmpl")
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("display").Parse(tmplText)
+	if err != nil {
+		return fmt.Errorf("failed to parse display template: %v", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return tmpl.Execute(f, struct {
+		Locale string
+		Tables []DisplayTable
+	}{locale, displays})
+}
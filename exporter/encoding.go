@@ -0,0 +1,44 @@
+// exporter/encoding.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 컬럼 인코딩 (compress 태그)
+// binary exporter가 컬럼 단위로 적용하며(BinaryExporter), tables.json의 컬럼 정의로도 나가 다른 바이너리 인코더가 쓸 수 있습니다.
+// SQLite와 JSON 산출물은 인코딩과 관계없이 원래 값을 저장합니다.
+const (
+	EncodingDelta = "delta" // 앞 행 값과의 차이를 저장 (키 순서로 증가하는 정수 ID 등)
+	EncodingRLE   = "rle"   // 같은 값이 이어지는 구간을 (값, 길이)로 저장 (반복되는 타입, 등급 등)
+)
+
+func normalizeColumnEncoding(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// validateColumnEncoding은 compress 태그의 인코딩이 컬럼 타입에 맞는지 확인합니다.
+// delta는 정수 컬럼에만, rle는 배열과 바이너리가 아닌 컬럼에만 쓸 수 있습니다.
+func validateColumnEncoding(table Table) []error {
+	var errs []error
+	for _, col := range table.Columns {
+		value, ok := GetTagValue(col.Tags, TagCompress)
+		if !ok {
+			continue
+		}
+		switch normalizeColumnEncoding(value) {
+		case EncodingDelta:
+			if !isIntColumn(col) {
+				errs = append(errs, fmt.Errorf("table %s column %s: compress:delta requires an int or int64 column", table.Name, col.Name))
+			}
+		case EncodingRLE:
+			if col.Type.IsArray || col.Type.IsGeo() || col.Type.IsCurve() || col.Type.Type == BytesType.Type {
+				errs = append(errs, fmt.Errorf("table %s column %s: compress:rle is not supported for %s columns", table.Name, col.Name, ColumnTypeName(col.Type)))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("table %s column %s: unknown compress encoding %q (expected %s or %s)", table.Name, col.Name, value, EncodingDelta, EncodingRLE))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,468 @@
+// exporter/ent.go
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// EntExporter는 entgo 스키마 정의(ent/schema)를 생성합니다.
+// 생성된 스키마로 `go generate ./...`를 실행하면 ent 클라이언트 코드가 만들어집니다.
+type EntExporter struct {
+	BaseExporter
+}
+
+func NewEntExporter() Exporter {
+	return &EntExporter{
+		BaseExporter: NewBaseExporter("ent"),
+	}
+}
+
+// entField는 스키마의 필드 하나입니다.
+type entField struct {
+	Name       string // ent 필드 이름 (snake_case)
+	Column     string // 원본 컬럼 이름 (StorageKey)
+	Definition string // field.Int32("...")... 형태의 정의
+}
+
+// entEdge는 스키마의 엣지 하나입니다.
+type entEdge struct {
+	Name       string
+	Definition string
+}
+
+// entSchema는 테이블 하나의 스키마 파일입니다.
+type entSchema struct {
+	Name      string
+	TableName string
+	Fields    []entField
+	Edges     []entEdge
+	Indexes   []string
+	HasEdges  bool
+}
+
+func (e *EntExporter) Describe() ExporterInfo {
+	return ExporterInfo{
+		Description: "entgo schema definitions; run go generate ./... in the output to build the ent client",
+		Outputs:     []string{"schema/<table>.go", "generate.go"},
+		Types: typeMappings(func(col Column) (string, error) {
+			def, err := entFieldDefinition(col, col.Name, false)
+			if err != nil {
+				return "", err
+			}
+			def, _, _ = strings.Cut(def, ".\n")
+			return strings.Replace(def, fmt.Sprintf("%q", col.Name), "name", 1), nil
+		}),
+	}
+}
+
+func (e *EntExporter) Export(tables []Table, opts Options) error {
+	if errs := rejectInterned(tables, e.Language()); len(errs) > 0 {
+		return errs[0]
+	}
+	schemaDir := filepath.Join(opts.OutputDir, "schema")
+	if err := os.MkdirAll(schemaDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	schemas, err := buildEntSchemas(tables)
+	if err != nil {
+		return err
+	}
+
+	tmplText, err := loadTemplate(opts.TemplateDir, "ent/schema.go.tmpl")
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("ent").Parse(tmplText)
+	if err != nil {
+		return err
+	}
+	for _, schema := range schemas {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, schema); err != nil {
+			return fmt.Errorf("failed to generate ent schema of %s: %v", schema.Name, err)
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("failed to format ent schema of %s: %v", schema.Name, err)
+		}
+		if err := os.WriteFile(filepath.Join(schemaDir, toSnakeCase(schema.Name)+".go"), src, 0644); err != nil {
+			return err
+		}
+	}
+
+	// curve 컬럼의 Curve 타입과 formula 컬럼의 Formula 타입은 스키마 패키지에 함께 생성
+	if hasCurveColumn(tables) {
+		if err := writeCurveSource(schemaDir, "go", "schema"); err != nil {
+			return err
+		}
+	}
+	if hasFormulaColumn(tables) {
+		if err := writeFormulaSource(schemaDir, "schema"); err != nil {
+			return err
+		}
+	}
+
+	// ent 코드 생성 진입점
+	generate := "// Code generated by excelite. DO NOT EDIT.\npackage ent\n\n//go:generate go run -mod=mod entgo.io/ent/cmd/ent generate ./schema\n"
+	return os.WriteFile(filepath.Join(opts.OutputDir, "generate.go"), []byte(generate), 0644)
+}
+
+// entIDColumn은 ent의 id 필드로 사용할 컬럼의 위치를 반환합니다.
+// 인덱스가 행을 유일하게 식별하지 않는 테이블(매트릭스, 설정, 변형, 적용 기간)은 -1이며 ent 기본 id를 사용합니다.
+func entIDColumn(table Table) int {
+	from, _ := EffectiveDateColumns(table)
+	if table.IsMatrix || table.IsSettings || VariantColumn(table) != -1 || from != -1 {
+		return -1
+	}
+	return IndexColumn(table)
+}
+
+// buildEntSchemas는 테이블마다 필드, 인덱스, #Relation 엣지를 정의한 스키마를 만듭니다.
+func buildEntSchemas(tables []Table) ([]entSchema, error) {
+	schemas := make([]entSchema, 0, len(tables))
+	byName := make(map[string]int)
+	for _, table := range tables {
+		schema, err := buildEntSchema(table)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %v", table.Name, err)
+		}
+		byName[table.Name] = len(schemas)
+		schemas = append(schemas, schema)
+	}
+
+	// 관계마다 소유 쪽에 edge.To, 소유된 쪽에 edge.From(...).Ref(...)를 추가
+	// belongsTo는 대상이 소유자, hasOne/hasMany는 시작 테이블이 소유자
+	tableByName := make(map[string]Table)
+	for _, table := range tables {
+		tableByName[table.Name] = table
+	}
+	seen := make(map[string]bool)
+	for _, table := range tables {
+		for _, rel := range table.Relations {
+			owner, owned := rel.TargetTable, rel.SourceTable
+			if rel.RelationType != "belongsTo" {
+				owner, owned = rel.SourceTable, rel.TargetTable
+			}
+			key := owner + "\x00" + owned + "\x00" + rel.ForeignKey
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			oi, ok1 := byName[owner]
+			mi, ok2 := byName[owned]
+			if !ok1 || !ok2 {
+				continue
+			}
+			unique := rel.RelationType == "hasOne"
+
+			toName := lowerFirst(owned)
+			if !unique {
+				toName += "s"
+			}
+			toName = schemas[oi].edgeName(toName, rel.ForeignKey)
+			fromName := schemas[mi].edgeName(lowerFirst(owner), rel.ForeignKey)
+
+			to := fmt.Sprintf("edge.To(%q, %s.Type)", toName, owned)
+			if unique {
+				to += ".Unique()"
+			}
+			from := fmt.Sprintf("edge.From(%q, %s.Type).Ref(%q).Unique()", fromName, owner, toName)
+
+			// 외래 키 컬럼의 타입이 대상의 id와 같으면 엣지가 그 컬럼을 사용
+			ownedTable := tableByName[owned]
+			if fk := columnIndex(ownedTable, rel.ForeignKey); fk != -1 {
+				ownerTable := tableByName[owner]
+				if id := entIDColumn(ownerTable); id != -1 && ownerTable.Columns[id].Type.Type == ownedTable.Columns[fk].Type.Type && fk != entIDColumn(ownedTable) {
+					from += fmt.Sprintf(".Field(%q)", toSnakeCase(rel.ForeignKey))
+					if HasTag(ownedTable.Columns[fk].Tags, TagNotNull) {
+						from += ".Required()"
+					}
+				}
+			}
+
+			schemas[oi].Edges = append(schemas[oi].Edges, entEdge{Name: toName, Definition: to})
+			schemas[mi].Edges = append(schemas[mi].Edges, entEdge{Name: fromName, Definition: from})
+		}
+	}
+
+	for i := range schemas {
+		schemas[i].HasEdges = len(schemas[i].Edges) > 0
+	}
+	return schemas, nil
+}
+
+// edgeName은 필드나 다른 엣지와 겹치지 않는 엣지 이름을 반환합니다.
+func (s *entSchema) edgeName(name, foreignKey string) string {
+	taken := func(n string) bool {
+		for _, f := range s.Fields {
+			if f.Name == toSnakeCase(n) || f.Name == n {
+				return true
+			}
+		}
+		for _, e := range s.Edges {
+			if e.Name == n {
+				return true
+			}
+		}
+		return false
+	}
+	for _, candidate := range []string{name, name + "Ref", name + "By" + strings.ReplaceAll(formatTableName(foreignKey), "_", "")} {
+		if !taken(candidate) {
+			return candidate
+		}
+	}
+	return name + "Edge"
+}
+
+func buildEntSchema(table Table) (entSchema, error) {
+	schema := entSchema{Name: table.Name, TableName: table.Name}
+	idCol := entIDColumn(table)
+	parentCol := ParentColumn(table)
+
+	tagRules := tableStructTags(table)
+	for i, col := range table.Columns {
+		name := toSnakeCase(col.Name)
+		if i == idCol {
+			name = "id"
+		}
+
+		def, err := entFieldDefinition(col, name, i == idCol)
+		if err != nil {
+			return schema, fmt.Errorf("column %s: %v", col.Name, err)
+		}
+		if tag := entStructTag(tagRules, col, name); tag != "" {
+			def += fmt.Sprintf(".\n\t\t\tStructTag(%q)", tag)
+		}
+		schema.Fields = append(schema.Fields, entField{Name: name, Column: col.Name, Definition: def})
+
+		if i != idCol && isSingleIndexed(col) && !HasTag(col.Tags, TagUnique) && !col.IsUnique {
+			schema.Indexes = append(schema.Indexes, fmt.Sprintf("index.Fields(%q)", name))
+		}
+	}
+	for _, idx := range CompositeIndexes(table) {
+		fields := make([]string, len(idx.Columns))
+		for i, c := range idx.Columns {
+			fields[i] = fmt.Sprintf("%q", schema.Fields[c].Name)
+		}
+		schema.Indexes = append(schema.Indexes, fmt.Sprintf("index.Fields(%s).\n\t\t\tStorageKey(%q)", strings.Join(fields, ", "), idx.Name))
+	}
+
+	switch {
+	case table.IsMatrix:
+		schema.Indexes = append(schema.Indexes, fmt.Sprintf("index.Fields(%q, %q).Unique()",
+			toSnakeCase(MatrixRowKey), toSnakeCase(MatrixColKey)))
+	case VariantColumn(table) != -1:
+		schema.Indexes = append(schema.Indexes, fmt.Sprintf("index.Fields(%q, %q).Unique()",
+			schema.Fields[IndexColumn(table)].Name, schema.Fields[VariantColumn(table)].Name))
+	case idCol == -1 && len(table.Columns) > 0 && !table.IsSettings:
+		schema.Indexes = append(schema.Indexes, fmt.Sprintf("index.Fields(%q)", schema.Fields[IndexColumn(table)].Name))
+	}
+
+	// 부모 컬럼은 같은 테이블을 가리키는 트리 엣지
+	if parentCol != -1 && idCol != -1 && table.Columns[parentCol].Type.Type == table.Columns[idCol].Type.Type {
+		from := "parent"
+		if schema.Fields[parentCol].Name == from {
+			from = "parentRow"
+		}
+		schema.Edges = append(schema.Edges, entEdge{Name: "children", Definition: fmt.Sprintf(
+			"edge.To(\"children\", %s.Type).From(%q).Unique().Field(%q)", table.Name, from, schema.Fields[parentCol].Name)})
+	}
+	return schema, nil
+}
+
+// entFieldDefinition은 컬럼 타입과 태그로 ent 필드 정의를 만듭니다.
+func entFieldDefinition(col Column, name string, isID bool) (string, error) {
+	var b strings.Builder
+	ct := col.Type
+	numeric := false
+
+	switch {
+	case ct.IsArray:
+		fmt.Fprintf(&b, "field.JSON(%q, []%s{})", name, getGoTypeFromColumnType(*ct.BaseType))
+	case ct.IsGeo():
+		fmt.Fprintf(&b, "field.JSON(%q, [2]float64{})", name)
+	case ct.IsCurve():
+		fmt.Fprintf(&b, "field.JSON(%q, Curve{})", name)
+	case ct.Type == DateTimeType.Type:
+		fmt.Fprintf(&b, "field.Time(%q)", name)
+	case ct.Type == BytesType.Type:
+		fmt.Fprintf(&b, "field.Bytes(%q)", name)
+	default:
+		switch ct.Type.Kind() {
+		case reflect.Int32:
+			fmt.Fprintf(&b, "field.Int32(%q)", name)
+			numeric = true
+		case reflect.Int64:
+			fmt.Fprintf(&b, "field.Int64(%q)", name)
+			numeric = true
+		case reflect.Float64:
+			fmt.Fprintf(&b, "field.Float(%q)", name)
+			numeric = true
+		case reflect.Bool:
+			fmt.Fprintf(&b, "field.Bool(%q)", name)
+		default:
+			fmt.Fprintf(&b, "field.String(%q)", name)
+			if ct.IsFormula() {
+				b.WriteString(".\n\t\t\tGoType(Formula(\"\"))")
+			}
+		}
+	}
+
+	fmt.Fprintf(&b, ".\n\t\t\tStorageKey(%q)", col.Name)
+
+	bounds, err := ColumnBounds(col)
+	if err != nil {
+		return "", err
+	}
+	isString := !ct.IsArray && ct.Type.Kind() == reflect.String && !ct.IsGeo()
+	if bounds.HasMin {
+		switch {
+		case isString:
+			fmt.Fprintf(&b, ".\n\t\t\tMinLen(%d)", int(bounds.Min))
+		case numeric && ct.Type.Kind() == reflect.Float64:
+			fmt.Fprintf(&b, ".\n\t\t\tMin(%v)", bounds.Min)
+		case numeric:
+			fmt.Fprintf(&b, ".\n\t\t\tMin(%d)", int64(bounds.Min))
+		}
+	}
+	if bounds.HasMax {
+		switch {
+		case isString:
+			fmt.Fprintf(&b, ".\n\t\t\tMaxLen(%d)", int(bounds.Max))
+		case numeric && ct.Type.Kind() == reflect.Float64:
+			fmt.Fprintf(&b, ".\n\t\t\tMax(%v)", bounds.Max)
+		case numeric:
+			fmt.Fprintf(&b, ".\n\t\t\tMax(%d)", int64(bounds.Max))
+		}
+	}
+	if size, ok := GetTagValue(col.Tags, TagSize); ok && isString && !bounds.HasMax {
+		if n, err := strconv.Atoi(strings.TrimSpace(size)); err == nil {
+			fmt.Fprintf(&b, ".\n\t\t\tMaxLen(%d)", n)
+		}
+	}
+
+	if name, ok := ColumnCollation(col); ok {
+		fmt.Fprintf(&b, ".\n\t\t\tAnnotations(entsql.Annotation{Collation: %q})", name)
+	}
+
+	if isID {
+		// 데이터는 워크북에서 오므로 id를 바꾸지 않음
+		b.WriteString(".\n\t\t\tImmutable()")
+		return b.String(), nil
+	}
+
+	if value, ok := GetTagValue(col.Tags, TagDefault); ok {
+		if def := entDefaultValue(ct, value); def != "" {
+			fmt.Fprintf(&b, ".\n\t\t\tDefault(%s)", def)
+		}
+	}
+	if col.IsUnique || HasTag(col.Tags, TagUnique) {
+		b.WriteString(".\n\t\t\tUnique()")
+	}
+	if !HasTag(col.Tags, TagNotNull) {
+		b.WriteString(".\n\t\t\tOptional()")
+	}
+	if IsReadOnly(col) {
+		b.WriteString(".\n\t\t\tImmutable()")
+	}
+	if IsWriteOnly(col) {
+		// ent은 Sensitive 필드를 JSON과 String()에서 제외함
+		b.WriteString(".\n\t\t\tSensitive()")
+	}
+	if msg, ok := DeprecationMessage(col); ok {
+		fmt.Fprintf(&b, ".\n\t\t\tDeprecated(%q)", msg)
+	}
+	return b.String(), nil
+}
+
+// entStructTag는 structTags 설정으로 만든 ent 필드의 구조체 태그입니다. 설정이 없으면 빈 문자열(ent 기본 태그)입니다.
+// StructTag는 ent의 기본 json 태그를 대체하므로 json 규칙이 없으면 기본 태그(json:"<필드>,omitempty")를 함께 넣습니다.
+func entStructTag(rules []StructTagRule, col Column, name string) string {
+	if len(rules) == 0 {
+		return ""
+	}
+	tags := goStructTags(rules, col, false)
+	hasJSON := false
+	for _, rule := range rules {
+		hasJSON = hasJSON || rule.Key == "json"
+	}
+	if !hasJSON {
+		json := name + ",omitempty"
+		if IsWriteOnly(col) {
+			json = "-"
+		}
+		tags = append([]string{fmt.Sprintf("json:%q", json)}, tags...)
+	}
+	return strings.Join(tags, " ")
+}
+
+// entDefaultValue는 default 태그 값을 Go 리터럴로 바꿉니다. 표현할 수 없으면 빈 문자열입니다.
+func entDefaultValue(ct ColumnType, value string) string {
+	value = strings.Trim(strings.TrimSpace(value), `'"`)
+	if ct.IsArray || ct.IsGeo() || ct.IsCurve() {
+		return ""
+	}
+	switch ct.Type.Kind() {
+	case reflect.Int32, reflect.Int64:
+		if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return value
+		}
+	case reflect.Float64:
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value
+		}
+	case reflect.Bool:
+		if v, err := strconv.ParseBool(value); err == nil {
+			return strconv.FormatBool(v)
+		}
+	case reflect.String:
+		return strconv.Quote(value)
+	}
+	return ""
+}
+
+// columnIndex는 이름이 같은 컬럼의 위치를 반환합니다. 없으면 -1입니다.
+func columnIndex(table Table, name string) int {
+	for i, col := range table.Columns {
+		if strings.EqualFold(col.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// toSnakeCase는 컬럼 이름을 ent 필드 이름(snake_case)으로 바꿉니다.
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && runes[i-1] != '_' && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -41,14 +41,39 @@ func (b BaseExporter) EnsureOutputDir(dir string) error {
 
 // GetBoolOption은 ExtraOptions에서 bool 값을 가져옵니다.
 func (b BaseExporter) GetBoolOption(opts Options, key string, defaultValue bool) bool {
+	return boolOption(opts, key, defaultValue)
+}
+
+// GetIntOption은 ExtraOptions에서 int 값을 가져옵니다.
+func (b BaseExporter) GetIntOption(opts Options, key string, defaultValue int) int {
+	return intOption(opts, key, defaultValue)
+}
+
+// GetStringOption은 ExtraOptions에서 string 값을 가져옵니다.
+func (b BaseExporter) GetStringOption(opts Options, key string, defaultValue string) string {
+	return stringOption(opts, key, defaultValue)
+}
+
+func boolOption(opts Options, key string, defaultValue bool) bool {
 	if val, ok := opts.ExtraOptions[key].(bool); ok {
 		return val
 	}
 	return defaultValue
 }
 
-// GetStringOption은 ExtraOptions에서 string 값을 가져옵니다.
-func (b BaseExporter) GetStringOption(opts Options, key string, defaultValue string) string {
+func intOption(opts Options, key string, defaultValue int) int {
+	switch val := opts.ExtraOptions[key].(type) {
+	case int:
+		return val
+	case int64:
+		return int(val)
+	case float64:
+		return int(val)
+	}
+	return defaultValue
+}
+
+func stringOption(opts Options, key string, defaultValue string) string {
 	if val, ok := opts.ExtraOptions[key].(string); ok {
 		return val
 	}
@@ -0,0 +1,95 @@
+// exporter/exportertest/fixture.go
+package exportertest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"excelite/exporter"
+)
+
+// FixtureFileName은 픽스처 워크북의 파일 이름입니다.
+const FixtureFileName = "fixture.xlsx"
+
+// fixtureSheets는 픽스처 워크북의 시트 내용입니다.
+// 데이터 시트는 컬럼명, 태그, 타입, 데이터 순서의 일반 레이아웃을 따르며
+// 모든 컬럼 타입, 반복된 배열 헤더, 빈 셀, 관계, 설정 시트를 포함합니다.
+var fixtureSheets = []struct {
+	name string
+	rows [][]interface{}
+}{
+	{
+		name: "Item",
+		rows: [][]interface{}{
+			{"index", "name", "price", "weight", "stackable", "tags", "tags", "releasedAt", "memo"},
+			{"unique", "", "", "", "", "", "", "", "design"},
+			{"int", "string", "int64", "float", "bool", "array<string>", "array<string>", "datetime", "string"},
+			{1, "Sword", 1500, 3.5, false, "weapon", "melee", "2024-01-01 00:00:00", "starter weapon"},
+			{2, "Potion", 50, 0.25, true, "consumable", "", "2024-02-15 12:30:00", ""},
+			{3, "Shield", 1200, 5, false, "", "", "", "no tags"},
+		},
+	},
+	{
+		name: "Shop Item",
+		rows: [][]interface{}{
+			{"index", "itemIndex", "stock", "discounts"},
+			{"unique", "", "", ""},
+			{"int", "int", "int", "array<int>"},
+			{100, 1, 10, "10,20"},
+			{101, 2, 99, ""},
+		},
+	},
+	{
+		name: "#Relation",
+		rows: [][]interface{}{
+			{"SourceTable", "TargetTable", "RelationType", "ForeignKey", "ReferenceKey"},
+			{"ShopItem", "Item", "belongsTo", "ItemIndex", "Index"},
+		},
+	},
+}
+
+// WriteFixture는 exporter 테스트에 사용하는 표준 픽스처 워크북을 path에 생성합니다.
+func WriteFixture(path string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	for i, sheet := range fixtureSheets {
+		if i == 0 {
+			if err := f.SetSheetName(f.GetSheetName(0), sheet.name); err != nil {
+				return err
+			}
+		} else if _, err := f.NewSheet(sheet.name); err != nil {
+			return err
+		}
+
+		for r, row := range sheet.rows {
+			cell, err := excelize.CoordinatesToCellName(1, r+1)
+			if err != nil {
+				return err
+			}
+			if err := f.SetSheetRow(sheet.name, cell, &row); err != nil {
+				return err
+			}
+		}
+	}
+
+	return f.SaveAs(path)
+}
+
+// LoadFixture는 픽스처 워크북을 임시 디렉토리에 생성하고 파싱한 테이블들을 반환합니다.
+func LoadFixture(t testing.TB) []exporter.Table {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), FixtureFileName)
+	if err := WriteFixture(path); err != nil {
+		t.Fatalf("failed to write fixture workbook: %v", err)
+	}
+
+	tables, err := exporter.ParseExcelFile(path)
+	if err != nil {
+		t.Fatalf("failed to parse fixture workbook: %v", err)
+	}
+	return tables
+}
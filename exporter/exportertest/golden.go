@@ -0,0 +1,287 @@
+// exporter/exportertest/golden.go
+
+// Package exportertest는 exporter를 픽스처 워크북으로 실행하고
+// 출력물을 golden 파일과 비교하는 테스트 도구를 제공합니다.
+//
+//	func TestSQLiteExporter(t *testing.T) {
+//		exportertest.Run(t, exportertest.Case{
+//			Name:     "sqlite",
+//			Exporter: exporter.NewSQLiteExporter(),
+//		})
+//	}
+//
+// golden 파일은 testdata/golden/<Name>/ 아래에 저장되며
+// `go test ./... -update` 로 현재 출력물로 갱신합니다.
+package exportertest
+
+import (
+	"bytes"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"excelite/exporter"
+)
+
+var update = flag.Bool("update", false, "update golden files with the current exporter output")
+
+// GoldenDir은 golden 파일이 저장되는 디렉토리입니다. 테스트 패키지 디렉토리 기준입니다.
+const GoldenDir = "testdata/golden"
+
+// Normalizer는 출력 파일을 비교 가능한 결정적인 텍스트로 변환합니다.
+type Normalizer func(path string) ([]byte, error)
+
+// DefaultNormalizers는 확장자별 기본 변환 함수입니다.
+// SQLite 데이터베이스는 바이트 단위로 결정적이지 않으므로 스키마와 데이터를 텍스트로 덤프합니다.
+var DefaultNormalizers = map[string]Normalizer{
+	".db": DumpSQLite,
+}
+
+// Case는 하나의 golden 테스트 케이스입니다.
+type Case struct {
+	Name     string            // golden 디렉토리 이름
+	Exporter exporter.Exporter // 테스트할 exporter
+	Options  exporter.Options  // OutputDir은 임시 디렉토리로 덮어씁니다
+
+	// Tables가 비어있으면 픽스처 워크북의 테이블을 사용합니다.
+	Tables []exporter.Table
+
+	// Normalizers는 DefaultNormalizers에 추가/덮어쓸 확장자별 변환 함수입니다.
+	Normalizers map[string]Normalizer
+}
+
+// Run은 exporter를 실행하고 출력 디렉토리 전체를 golden 파일과 비교합니다.
+// -update 플래그가 주어지면 golden 파일을 현재 출력물로 교체합니다.
+func Run(t *testing.T, c Case) {
+	t.Helper()
+
+	tables := c.Tables
+	if len(tables) == 0 {
+		tables = LoadFixture(t)
+	}
+
+	opts := c.Options
+	opts.OutputDir = t.TempDir()
+	if opts.PackageName == "" {
+		opts.PackageName = "models"
+	}
+
+	if err := c.Exporter.Export(tables, opts); err != nil {
+		t.Fatalf("%s: export failed: %v", c.Name, err)
+	}
+
+	normalizers := make(map[string]Normalizer, len(DefaultNormalizers)+len(c.Normalizers))
+	for ext, fn := range DefaultNormalizers {
+		normalizers[ext] = fn
+	}
+	for ext, fn := range c.Normalizers {
+		normalizers[ext] = fn
+	}
+
+	got, err := collectOutput(opts.OutputDir, normalizers)
+	if err != nil {
+		t.Fatalf("%s: failed to read output: %v", c.Name, err)
+	}
+
+	goldenDir := filepath.Join(GoldenDir, c.Name)
+	if *update {
+		if err := writeGolden(goldenDir, got); err != nil {
+			t.Fatalf("%s: failed to update golden files: %v", c.Name, err)
+		}
+		return
+	}
+
+	want, err := collectOutput(goldenDir, nil)
+	if err != nil {
+		t.Fatalf("%s: failed to read golden files (run with -update to create them): %v", c.Name, err)
+	}
+	compareOutput(t, c.Name, got, want)
+}
+
+// collectOutput은 디렉토리의 모든 파일을 상대 경로 -> 내용 맵으로 읽습니다.
+// 변환 함수가 있는 파일은 "<path>.golden" 이름으로 변환된 내용을 담습니다.
+func collectOutput(dir string, normalizers map[string]Normalizer) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if fn, ok := normalizers[filepath.Ext(path)]; ok {
+			data, err := fn(path)
+			if err != nil {
+				return fmt.Errorf("%s: %v", rel, err)
+			}
+			files[rel+".golden"] = data
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[rel] = data
+		return nil
+	})
+
+	return files, err
+}
+
+func writeGolden(dir string, files map[string][]byte) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	for rel, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compareOutput(t *testing.T, name string, got, want map[string][]byte) {
+	t.Helper()
+
+	for _, rel := range sortedNames(want) {
+		data, ok := got[rel]
+		if !ok {
+			t.Errorf("%s: missing output file %s", name, rel)
+			continue
+		}
+		if !bytes.Equal(data, want[rel]) {
+			t.Errorf("%s: %s differs from golden file\n%s", name, rel, firstDifference(data, want[rel]))
+		}
+	}
+
+	for _, rel := range sortedNames(got) {
+		if _, ok := want[rel]; !ok {
+			t.Errorf("%s: unexpected output file %s", name, rel)
+		}
+	}
+}
+
+// firstDifference는 처음으로 다른 줄을 보여줍니다.
+func firstDifference(got, want []byte) string {
+	gotLines := strings.Split(string(got), "\n")
+	wantLines := strings.Split(string(want), "\n")
+
+	for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			return fmt.Sprintf("line %d:\n  got:  %q\n  want: %q", i+1, g, w)
+		}
+	}
+	return "files differ"
+}
+
+func sortedNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DumpSQLite는 SQLite 데이터베이스의 스키마와 모든 테이블의 행을 결정적인 텍스트로 덤프합니다.
+func DumpSQLite(path string) ([]byte, error) {
+	db, err := sql.Open("sqlite3", path+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var buf bytes.Buffer
+
+	rows, err := db.Query("SELECT type, name, COALESCE(sql, '') FROM sqlite_master WHERE name NOT LIKE 'sqlite_%' ORDER BY type, name")
+	if err != nil {
+		return nil, err
+	}
+	var tables []string
+	for rows.Next() {
+		var typ, name, stmt string
+		if err := rows.Scan(&typ, &name, &stmt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "-- %s %s\n%s\n", typ, name, stmt)
+		if typ == "table" {
+			tables = append(tables, name)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, table := range tables {
+		fmt.Fprintf(&buf, "\n-- data %s\n", table)
+		if err := dumpTableRows(db, table, &buf); err != nil {
+			return nil, fmt.Errorf("table %s: %v", table, err)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func dumpTableRows(db *sql.DB, table string, buf *bytes.Buffer) error {
+	rows, err := db.Query("SELECT * FROM " + exporter.QuoteIdentifier(table) + " ORDER BY 1")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(buf, strings.Join(cols, "\t"))
+
+	values := make([]interface{}, len(cols))
+	ptrs := make([]interface{}, len(cols))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return err
+		}
+		cells := make([]string, len(values))
+		for i, v := range values {
+			switch v := v.(type) {
+			case nil:
+				cells[i] = "NULL"
+			case []byte:
+				cells[i] = fmt.Sprintf("%q", v)
+			default:
+				cells[i] = fmt.Sprintf("%v", v)
+			}
+		}
+		fmt.Fprintln(buf, strings.Join(cells, "\t"))
+	}
+	return rows.Err()
+}
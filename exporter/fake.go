@@ -0,0 +1,513 @@
+// exporter/fake.go
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// FakeOptions는 가짜 데이터 생성 옵션입니다.
+type FakeOptions struct {
+	Rows int   // 테이블마다 생성할 행 수
+	Seed int64 // 같은 시드는 같은 데이터를 생성
+}
+
+// fakeSheet는 가짜 데이터를 채울 시트 하나입니다.
+type fakeSheet struct {
+	file    *excelize.File
+	sheet   string
+	dataRow int     // 데이터가 시작하는 엑셀 행 번호 (1부터)
+	oldRows int     // 기존 데이터 행 수 (덮어쓰기 전에 지움)
+	table   Table   // 헤더로부터 파싱한 테이블 정의
+	sources [][]int // 컬럼별 시트 열 인덱스
+	values  [][][]interface{}
+	refCols map[int]string // 컬럼 인덱스 → 참조하는 테이블
+	parent  int            // 같은 테이블을 가리키는 부모 컬럼 (-1이면 없음)
+	keyCol  int            // 인덱스 컬럼
+}
+
+// FakeWorkbooks는 워크북들의 헤더(컬럼명, 태그, 타입)만 보고 테이블마다 opts.Rows개의 가짜 행을 생성하여
+// outputDir에 같은 이름의 워크북으로 저장합니다. 기존 데이터 행은 생성된 행으로 대체됩니다.
+//
+// 생성된 값은 컬럼 타입, min/max 범위, unique/index 태그(인덱스 컬럼 포함)를 지키며,
+// ref<Table> 컬럼과 #Relation의 belongsTo 외래 키, 트리의 부모 컬럼은 생성된 행의 키만 가리킵니다.
+// 매트릭스 시트와 표준 배치가 아닌 시트는 건너뜁니다.
+func FakeWorkbooks(files []string, outputDir string, opts FakeOptions) ([]string, error) {
+	if opts.Rows <= 0 {
+		return nil, fmt.Errorf("number of rows must be positive")
+	}
+
+	var sheets []*fakeSheet
+	var workbooks []*excelize.File
+	defer func() {
+		for _, f := range workbooks {
+			f.Close()
+		}
+	}()
+
+	for _, file := range files {
+		f, err := openWorkbook(file)
+		if err != nil {
+			return nil, err
+		}
+		workbooks = append(workbooks, f)
+
+		fileSheets, err := fakeSheets(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		sheets = append(sheets, fileSheets...)
+	}
+
+	gen := &fakeGenerator{rng: rand.New(rand.NewSource(opts.Seed))}
+
+	// 1단계: 참조가 아닌 값 생성 (키가 먼저 정해져야 참조를 채울 수 있음)
+	keys := make(map[string][]interface{})
+	columnValues := make(map[string]map[string][]interface{})
+	for _, s := range sheets {
+		s.values = make([][][]interface{}, opts.Rows)
+		for r := range s.values {
+			s.values[r] = make([][]interface{}, len(s.table.Columns))
+		}
+
+		for c, col := range s.table.Columns {
+			if _, ok := s.refCols[c]; ok || c == s.parent {
+				continue
+			}
+			unique := c == s.keyCol || col.IsUnique || HasTag(col.Tags, TagPrimaryKey)
+			for r := 0; r < opts.Rows; r++ {
+				s.values[r][c] = gen.column(s.table, col, len(s.sources[c]), r, unique)
+			}
+		}
+		gen.fixEffectiveDates(s)
+
+		values := make(map[string][]interface{}, len(s.table.Columns))
+		for c, col := range s.table.Columns {
+			for r := 0; r < opts.Rows; r++ {
+				if v := s.values[r][c]; len(v) == 1 {
+					values[col.Name] = append(values[col.Name], v[0])
+				}
+			}
+		}
+		keys[s.table.Name] = values[s.table.Columns[s.keyCol].Name]
+		columnValues[s.table.Name] = values
+	}
+
+	// 2단계: 참조 컬럼은 대상 테이블의 생성된 키 중에서 선택
+	for _, s := range sheets {
+		// 같은 시드로 같은 결과를 얻도록 맵이 아닌 컬럼 순서로 생성
+		for c, col := range s.table.Columns {
+			target, ok := s.refCols[c]
+			if !ok {
+				continue
+			}
+			candidates := keys[target]
+			for _, rel := range s.table.Relations {
+				if rel.RelationType == "belongsTo" && rel.ForeignKey == col.Name && rel.ReferenceKey != "" {
+					if v, ok := columnValues[target][rel.ReferenceKey]; ok {
+						candidates = v
+					}
+				}
+			}
+			if len(candidates) == 0 {
+				log.Printf("Warning: %s.%s refers to %s which has no generated rows; leaving it empty", s.table.Name, col.Name, target)
+				continue
+			}
+			for r := 0; r < opts.Rows; r++ {
+				s.values[r][c] = gen.pick(candidates, fakeElements(col, len(s.sources[c]), gen.rng))
+			}
+		}
+
+		// 부모는 앞선 행만 가리키므로 순환이 생기지 않음
+		if s.parent != -1 {
+			for r := 1; r < opts.Rows; r++ {
+				if gen.rng.Intn(4) != 0 {
+					s.values[r][s.parent] = s.values[gen.rng.Intn(r)][s.keyCol]
+				}
+			}
+		}
+	}
+
+	for _, s := range sheets {
+		if err := s.write(); err != nil {
+			return nil, fmt.Errorf("sheet %s: %v", s.sheet, err)
+		}
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, err
+	}
+	var outputs []string
+	for i, f := range workbooks {
+		out := filepath.Join(outputDir, filepath.Base(files[i]))
+		if same, _ := samePath(out, files[i]); same {
+			return nil, fmt.Errorf("output %s would overwrite the input workbook", out)
+		}
+		if err := f.SaveAs(out); err != nil {
+			return nil, fmt.Errorf("failed to save %s: %v", out, err)
+		}
+		outputs = append(outputs, out)
+	}
+	return outputs, nil
+}
+
+// fakeSheets는 워크북에서 가짜 데이터를 채울 수 있는 시트들의 헤더를 읽습니다.
+func fakeSheets(f *excelize.File) ([]*fakeSheet, error) {
+	entries, err := parseConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	relations, err := parseRelations(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse relations: %v", err)
+	}
+
+	var sheets []*fakeSheet
+	var tables []Table
+	for _, sheetName := range f.GetSheetList() {
+		if strings.HasPrefix(sheetName, "#") {
+			continue
+		}
+
+		rows, err := f.GetRows(sheetName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sheet %s: %v", sheetName, err)
+		}
+		if _, ok := matrixValueType(rows); ok {
+			log.Printf("Skipping matrix sheet %s", sheetName)
+			continue
+		}
+
+		layout, rows, pos, err := sheetLayout(sheetName, rows, entries)
+		if err != nil {
+			return nil, fmt.Errorf("sheet %s: %v", sheetName, err)
+		}
+		if layout != LayoutStandard {
+			log.Printf("Skipping %s sheet %s", layout, sheetName)
+			continue
+		}
+		if len(rows) < 3 {
+			continue
+		}
+		// 행 길이를 헤더의 열 수에 맞춤 (헤더 범위 밖의 값은 기존 데이터 행과 함께 지워지므로 경고)
+		rows, warnings := normalizeRows(sheetName, rows, pos)
+		for _, warning := range warnings {
+			log.Printf("Warning: %s", warning)
+		}
+
+		rules, err := sheetHeaderRules(entries, sheetName, pos)
+		if err != nil {
+			return nil, fmt.Errorf("sheet %s: %v", sheetName, err)
+		}
+		table, sources, err := parseHeader(sheetName, rows[:3], rules)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sheet %s: %v", sheetName, err)
+		}
+		if len(table.Columns) == 0 {
+			continue
+		}
+
+		sheets = append(sheets, &fakeSheet{
+			file:    f,
+			sheet:   sheetName,
+			dataRow: pos.skipped + 4,
+			oldRows: len(rows) - 3,
+			table:   table,
+			sources: sources,
+		})
+		tables = append(tables, table)
+	}
+
+	tables = assignRelationsToTables(tables, relations)
+	for i, s := range sheets {
+		s.table = tables[i]
+		s.keyCol = IndexColumn(s.table)
+		s.parent = ParentColumn(s.table)
+		if s.parent == s.keyCol {
+			s.parent = -1
+		}
+
+		fkTargets := make(map[string]string)
+		for _, rel := range s.table.Relations {
+			if rel.RelationType == "belongsTo" {
+				fkTargets[rel.ForeignKey] = rel.TargetTable
+			}
+		}
+		s.refCols = make(map[int]string)
+		for c, col := range s.table.Columns {
+			if c == s.keyCol || c == s.parent {
+				continue
+			}
+			if target := elementType(col.Type).RefTable; target != "" {
+				s.refCols[c] = target
+			} else if target, ok := fkTargets[col.Name]; ok {
+				s.refCols[c] = target
+			}
+		}
+	}
+	return sheets, nil
+}
+
+// write는 생성된 행을 시트의 데이터 영역에 씁니다.
+func (s *fakeSheet) write() error {
+	for i := 0; i < s.oldRows; i++ {
+		if err := s.file.RemoveRow(s.sheet, s.dataRow); err != nil {
+			return err
+		}
+	}
+
+	width := 0
+	for _, cols := range s.sources {
+		for _, idx := range cols {
+			if idx+1 > width {
+				width = idx + 1
+			}
+		}
+	}
+
+	for r, row := range s.values {
+		cells := make([]interface{}, width)
+		for c, elems := range row {
+			cols := s.sources[c]
+			if len(cols) > 1 {
+				// 반복된 배열 헤더는 열마다 원소 하나
+				for i, v := range elems {
+					if i < len(cols) {
+						cells[cols[i]] = v
+					}
+				}
+				continue
+			}
+			switch len(elems) {
+			case 0:
+			case 1:
+				cells[cols[0]] = elems[0]
+			default:
+				parts := make([]string, len(elems))
+				for i, v := range elems {
+					parts[i] = fmt.Sprintf("%v", v)
+				}
+				cells[cols[0]] = joinArrayCell(parts, ArrayDelimiter(s.table.Columns[c]))
+			}
+		}
+
+		cell, err := excelize.CoordinatesToCellName(1, s.dataRow+r)
+		if err != nil {
+			return err
+		}
+		if err := s.file.SetSheetRow(s.sheet, cell, &cells); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakeGenerator는 컬럼 정의에 맞는 그럴듯한 값을 생성합니다.
+type fakeGenerator struct {
+	rng *rand.Rand
+}
+
+var fakeWords = []string{
+	"iron", "silver", "golden", "ancient", "shadow", "crystal", "storm", "frost", "flame", "royal",
+	"sword", "shield", "bow", "staff", "helm", "ring", "amulet", "potion", "scroll", "gem",
+}
+
+var fakeBaseTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// column은 r번째 행의 셀 원소들을 생성합니다. 배열이 아니면 원소는 하나입니다.
+func (g *fakeGenerator) column(table Table, col Column, sourceCount, r int, unique bool) []interface{} {
+	bounds, _ := ColumnBounds(col)
+	n := fakeElements(col, sourceCount, g.rng)
+
+	elems := make([]interface{}, n)
+	for i := range elems {
+		if unique && !col.Type.IsArray {
+			elems[i] = g.unique(table, col, bounds, r)
+		} else {
+			elems[i] = g.value(table, col, bounds, r)
+		}
+	}
+	return elems
+}
+
+// fakeElements는 셀에 들어갈 원소 수입니다. 배열은 1~3개(반복 헤더는 열 수까지)입니다.
+func fakeElements(col Column, sourceCount int, rng *rand.Rand) int {
+	if !col.Type.IsArray {
+		return 1
+	}
+	max := 3
+	if sourceCount > 1 {
+		max = sourceCount
+	}
+	if col.Type.MaxLen > 0 && col.Type.MaxLen < max {
+		max = col.Type.MaxLen
+	}
+	return 1 + rng.Intn(max)
+}
+
+// unique는 행 번호로 겹치지 않는 값을 만듭니다.
+func (g *fakeGenerator) unique(table Table, col Column, b Bounds, r int) interface{} {
+	switch elementType(col.Type).Type.Kind() {
+	case reflect.Int32, reflect.Int64:
+		start := 1.0
+		if b.HasMin {
+			start = math.Ceil(b.Min)
+		}
+		return int64(start) + int64(r)
+	case reflect.Float64:
+		start := 1.0
+		if b.HasMin {
+			start = b.Min
+		}
+		return start + float64(r)
+	}
+	// 이메일/URL 형태는 행 번호를 포함하므로 그대로 유일함
+	if name := strings.ToLower(col.Name); strings.Contains(name, "email") || strings.Contains(name, "url") {
+		return fitLength(g.text(table, col, r), b)
+	}
+	return fitLength(fmt.Sprintf("%s_%d", strings.ToLower(table.Name), r+1), b)
+}
+
+func (g *fakeGenerator) value(table Table, col Column, b Bounds, r int) interface{} {
+	ct := elementType(col.Type)
+	if ct.IsGeo() {
+		return fmt.Sprintf("%.4f,%.4f", g.rng.Float64()*360-180, g.rng.Float64()*180-90)
+	}
+	// 커브는 범위 안에서 증가하는 키프레임 세 개
+	if ct.IsCurve() {
+		min, max := fakeRange(b, 0, 100)
+		keys := make([]string, 3)
+		for i := range keys {
+			v := min + (max-min)*(float64(i)+g.rng.Float64())/3
+			keys[i] = fmt.Sprintf("%d:%g", i*10, math.Max(min, math.Min(max, math.Round(v*100)/100)))
+		}
+		return strings.Join(keys, ";")
+	}
+	// 수식은 선언한 변수에 비례하는 식 (변수를 선언하지 않았으면 level)
+	if ct.IsFormula() {
+		vars := ct.FormulaVars()
+		if len(vars) == 0 {
+			vars = []string{"level"}
+		}
+		return fmt.Sprintf("%d*(1+0.1*%s)", 1+g.rng.Intn(100), strings.Join(vars, "+"))
+	}
+
+	switch ct.Type.Kind() {
+	case reflect.Int32, reflect.Int64:
+		min, max := fakeRange(b, 1, 100)
+		min, max = math.Ceil(min), math.Floor(max)
+		return int64(min) + g.rng.Int63n(int64(max-min)+1)
+	case reflect.Float64:
+		min, max := fakeRange(b, 0, 100)
+		v := math.Round((min+g.rng.Float64()*(max-min))*100) / 100
+		return math.Max(min, math.Min(max, v))
+	case reflect.Bool:
+		return g.rng.Intn(2) == 1
+	case reflect.String:
+		return fitLength(g.text(table, col, r), b)
+	}
+
+	if ct.Type == reflect.TypeOf(time.Time{}) {
+		t := fakeBaseTime.Add(time.Duration(g.rng.Intn(365*24)) * time.Hour)
+		return t.Format("2006-01-02 15:04:05")
+	}
+	return nil
+}
+
+// fakeRange는 min/max 태그로 좁힌 숫자 범위를 반환합니다. 한쪽만 지정되면 기본 범위의 폭을 유지합니다.
+func fakeRange(b Bounds, min, max float64) (float64, float64) {
+	switch {
+	case b.HasMin && b.HasMax:
+		return b.Min, b.Max
+	case b.HasMin:
+		return b.Min, b.Min + (max - min)
+	case b.HasMax:
+		return b.Max - (max - min), b.Max
+	}
+	return min, max
+}
+
+// text는 컬럼 이름을 보고 그럴듯한 문자열을 만듭니다.
+func (g *fakeGenerator) text(table Table, col Column, r int) string {
+	name := strings.ToLower(col.Name)
+	word := func() string { return fakeWords[g.rng.Intn(len(fakeWords))] }
+
+	switch {
+	case strings.Contains(name, "email"):
+		return fmt.Sprintf("user%d@example.com", r+1)
+	case strings.Contains(name, "url") || strings.Contains(name, "link"):
+		return fmt.Sprintf("https://example.com/%s/%d", strings.ToLower(table.Name), r+1)
+	case strings.Contains(name, "desc") || strings.Contains(name, "text") || strings.Contains(name, "comment"):
+		words := make([]string, 4+g.rng.Intn(5))
+		for i := range words {
+			words[i] = word()
+		}
+		return strings.ToUpper(words[0][:1]) + strings.Join(words, " ")[1:] + "."
+	case strings.Contains(name, "name") || strings.Contains(name, "title"):
+		first, second := word(), word()
+		return strings.ToUpper(first[:1]) + first[1:] + " " + strings.ToUpper(second[:1]) + second[1:]
+	}
+	return word()
+}
+
+// fitLength는 문자열을 min/max 글자 수에 맞춥니다.
+func fitLength(s string, b Bounds) string {
+	runes := []rune(s)
+	if b.HasMax && float64(len(runes)) > b.Max {
+		runes = runes[:int(b.Max)]
+	}
+	for b.HasMin && float64(len(runes)) < b.Min {
+		runes = append(runes, 'x')
+	}
+	return string(runes)
+}
+
+// pick은 후보 중에서 n개를 고릅니다.
+func (g *fakeGenerator) pick(candidates []interface{}, n int) []interface{} {
+	elems := make([]interface{}, n)
+	for i := range elems {
+		elems[i] = candidates[g.rng.Intn(len(candidates))]
+	}
+	return elems
+}
+
+// fixEffectiveDates는 valid_to가 valid_from보다 늦도록 맞춥니다.
+func (g *fakeGenerator) fixEffectiveDates(s *fakeSheet) {
+	from, to := EffectiveDateColumns(s.table)
+	if from == -1 || to == -1 {
+		return
+	}
+	for _, row := range s.values {
+		if len(row[from]) != 1 {
+			continue
+		}
+		start, err := time.Parse("2006-01-02 15:04:05", fmt.Sprintf("%v", row[from][0]))
+		if err != nil {
+			continue
+		}
+		end := start.Add(time.Duration(1+g.rng.Intn(30*24)) * time.Hour)
+		row[to] = []interface{}{end.Format("2006-01-02 15:04:05")}
+	}
+}
+
+// samePath는 두 경로가 같은 파일을 가리키는지 확인합니다.
+func samePath(a, b string) (bool, error) {
+	absA, err := filepath.Abs(a)
+	if err != nil {
+		return false, err
+	}
+	absB, err := filepath.Abs(b)
+	if err != nil {
+		return false, err
+	}
+	return absA == absB, nil
+}
@@ -0,0 +1,173 @@
+// exporter/fileasset.go
+package exporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// AssetsDir는 asset 컬럼이 참조하는 파일을 복사하는 출력 디렉토리 아래의 폴더입니다.
+const AssetsDir = "assets"
+
+// FileRefsFile은 마지막 생성에서 셀이 참조한 파일 목록입니다. 참조한 파일이 바뀌면 워크북이 그대로여도 다시 파싱하기 위해 사용합니다.
+const FileRefsFile = ".excelite-files.json"
+
+// fileColumnBase는 file/asset 태그가 붙은 컬럼의 셀 경로가 가리키는 기준 디렉토리를 반환합니다.
+// 태그 값이 없으면 워크북 디렉토리, 있으면 워크북 디렉토리 아래의 그 디렉토리입니다.
+func fileColumnBase(col Column, workbookDir string) (string, Tag, bool) {
+	for _, tag := range []Tag{TagFile, TagAsset} {
+		if value, ok := GetTagValue(col.Tags, tag); ok {
+			return filepath.Join(workbookDir, filepath.FromSlash(strings.TrimSpace(value))), tag, true
+		}
+	}
+	return "", TagNone, false
+}
+
+// resolveFileColumns는 file 컬럼의 셀 경로를 파일 내용([]byte)으로 바꾸고, asset 컬럼의 셀 경로를 해시 이름으로 바꿔 Assets에 추가합니다.
+// 파싱 직후(행 필터나 변환 전)에 호출해야 에러에 셀 위치를 표시할 수 있습니다.
+// 컬럼 타입이 맞지 않는 태그는 건너뛰고 validateFileColumns에서 보고합니다.
+func resolveFileColumns(table *Table, workbookDir string) error {
+	refs := make(map[string]bool)
+	for c, col := range table.Columns {
+		base, tag, ok := fileColumnBase(col, workbookDir)
+		if !ok || col.Type.IsArray {
+			continue
+		}
+		if (tag == TagFile && col.Type.Type != BytesType.Type) || (tag == TagAsset && col.Type.Type != StringType.Type) {
+			continue
+		}
+
+		for i, row := range table.Rows {
+			if c >= len(row) {
+				continue
+			}
+			ref, ok := row[c].(string)
+			if !ok || ref == "" {
+				continue
+			}
+			if filepath.IsAbs(ref) || filepath.VolumeName(ref) != "" {
+				return fmt.Errorf("sheet %s %s column %s: file path %q must be relative to the workbook", table.SheetName, table.Source.RowLocation(i), col.Name, ref)
+			}
+			path := filepath.Join(base, filepath.FromSlash(ref))
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("sheet %s %s column %s: %v", table.SheetName, table.Source.RowLocation(i), col.Name, err)
+			}
+			refs[path] = true
+
+			if tag == TagFile {
+				row[c] = data
+				continue
+			}
+			name := assetName(ref, data)
+			if table.Assets == nil {
+				table.Assets = make(map[string][]byte)
+			}
+			table.Assets[name] = data
+			row[c] = name
+		}
+	}
+	table.FileRefs = sortedMapKeys(refs)
+	return nil
+}
+
+// assetName은 파일 내용의 해시와 원래 확장자로 만든 이름입니다. 내용이 같은 파일은 한 번만 복사되고,
+// 내용이 바뀌면 이름도 바뀌므로 클라이언트가 이름으로 캐시할 수 있습니다.
+func assetName(ref string, data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]) + strings.ToLower(filepath.Ext(ref))
+}
+
+// validateFileColumns는 file 태그가 blob 컬럼에, asset 태그가 string 컬럼에 붙었는지 확인합니다.
+func validateFileColumns(table Table) []error {
+	var errs []error
+	for _, col := range table.Columns {
+		hasFile, hasAsset := HasTag(col.Tags, TagFile), HasTag(col.Tags, TagAsset)
+		switch {
+		case hasFile && hasAsset:
+			errs = append(errs, fmt.Errorf("table %s column %s: file and asset cannot be used together", table.Name, col.Name))
+		case hasFile && (col.Type.IsArray || col.Type.Type != BytesType.Type):
+			errs = append(errs, fmt.Errorf("table %s column %s: file is only supported for blob columns", table.Name, col.Name))
+		case hasAsset && (col.Type.IsArray || col.Type.Type != StringType.Type):
+			errs = append(errs, fmt.Errorf("table %s column %s: asset is only supported for string columns", table.Name, col.Name))
+		}
+	}
+	return errs
+}
+
+// WriteAssets는 asset 컬럼이 참조하는 파일들을 dir/assets에 해시 이름으로 복사하고 복사한 파일 수를 반환합니다.
+// 이름이 내용의 해시이므로 이미 있는 파일은 다시 쓰지 않습니다.
+func WriteAssets(dir string, tables []Table) (int, error) {
+	count := 0
+	for _, table := range tables {
+		for _, name := range sortedMapKeys(table.Assets) {
+			path := filepath.Join(dir, AssetsDir, name)
+			if _, err := os.Stat(path); err == nil {
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return count, err
+			}
+			if err := os.WriteFile(path, table.Assets[name], 0644); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// WriteFileRefs는 테이블들의 셀이 참조한 파일 목록을 dir에 씁니다.
+func WriteFileRefs(dir string, tables []Table) error {
+	refs := make(map[string]bool)
+	for _, table := range tables {
+		for _, ref := range table.FileRefs {
+			refs[ref] = true
+		}
+	}
+	if len(refs) == 0 {
+		err := os.Remove(filepath.Join(dir, FileRefsFile))
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	data, err := json.MarshalIndent(sortedMapKeys(refs), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, FileRefsFile), append(data, '\n'), 0644)
+}
+
+// HashFileRefs는 마지막 생성에서 참조한 파일들의 해시를 반환합니다. 참조한 파일이 없으면 빈 문자열입니다.
+// 없어진 파일은 해시 대신 표시만 넣으므로 다시 파싱할 때 에러가 보고됩니다.
+func HashFileRefs(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, FileRefsFile))
+	if err != nil {
+		return ""
+	}
+	var refs []string
+	if err := json.Unmarshal(data, &refs); err != nil {
+		return ""
+	}
+	sort.Strings(refs)
+	var parts []string
+	for _, ref := range refs {
+		sum, err := hashFile(ref)
+		if err != nil {
+			sum = "missing"
+		}
+		parts = append(parts, ref+"\x00"+sum)
+	}
+	h := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,487 @@
+// exporter/formula.go
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// FormulaType은 수식 컬럼 타입입니다. 셀에는 base*(1+0.1*level) 같은 수식을 입력하며, 값은 입력한 그대로 저장됩니다.
+// 수식은 변환식(transform)과 같은 문법의 숫자 식이며, value 대신 이름 있는 변수를 쓰고 문자열과 문자열 함수는 쓸 수 없습니다.
+// formula<base,level>처럼 변수를 선언하면 선언하지 않은 변수를 쓴 셀은 에러입니다.
+// 생성된 Go/TypeScript 코드의 평가 함수에 변수 값을 넘겨 계산합니다.
+var FormulaType = ColumnType{
+	Type:    reflect.TypeOf(""),
+	SQLType: "TEXT",
+	Formula: true,
+}
+
+// IsFormula는 컬럼 타입이 formula 타입인지 확인합니다.
+func (ct ColumnType) IsFormula() bool {
+	return !ct.IsArray && ct.Formula
+}
+
+// FormulaVars는 formula<a,b>에서 선언한 변수 이름들입니다. 선언하지 않았으면 nil입니다.
+func (ct ColumnType) FormulaVars() []string {
+	if ct.FormulaVarList == "" {
+		return nil
+	}
+	return strings.Split(ct.FormulaVarList, ",")
+}
+
+// parseFormulaType은 "formula", "formula<base,level>" 타입 표기를 파싱합니다. 변수 이름은 소문자로 바꿉니다.
+func parseFormulaType(typeStr string) (ColumnType, bool) {
+	if typeStr == "formula" {
+		return FormulaType, true
+	}
+	if !strings.HasPrefix(typeStr, "formula<") || !strings.HasSuffix(typeStr, ">") {
+		return ColumnType{}, false
+	}
+	var vars []string
+	for _, name := range strings.Split(strings.TrimSuffix(strings.TrimPrefix(typeStr, "formula<"), ">"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !indexNamePattern.MatchString(name) || formulaArity[name] != nil {
+			return ColumnType{}, false
+		}
+		vars = append(vars, name)
+	}
+	ct := FormulaType
+	ct.FormulaVarList = strings.Join(vars, ",")
+	return ct, true
+}
+
+// formulaArity는 수식에서 쓸 수 있는 함수와 인자 수의 범위입니다. (최대 -1은 제한 없음)
+var formulaArity = map[string][]int{
+	"round": {1, 2},
+	"floor": {1, 1},
+	"ceil":  {1, 1},
+	"abs":   {1, 1},
+	"min":   {1, -1},
+	"max":   {1, -1},
+	"pow":   {2, 2},
+	"clamp": {3, 3},
+}
+
+// variableNode는 수식의 변수입니다. eval의 value는 변수 이름(소문자) → 숫자 맵입니다.
+type variableNode struct{ name string }
+
+func (n variableNode) eval(value interface{}) (interface{}, error) {
+	vars, _ := value.(map[string]float64)
+	v, ok := vars[n.name]
+	if !ok {
+		return nil, fmt.Errorf("variable %s is not set", n.name)
+	}
+	return v, nil
+}
+
+// compileFormula는 수식을 파싱하고 숫자 식인지 확인합니다. vars가 nil이 아니면 그 변수만 쓸 수 있습니다.
+func compileFormula(source string, vars []string) (exprNode, error) {
+	p := &exprParser{input: source, ident: func(name string) (exprNode, error) {
+		if !indexNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid variable name %q (letters, digits and _)", name)
+		}
+		if vars != nil && !containsString(vars, name) {
+			return nil, fmt.Errorf("unknown variable %q (declared: %s)", name, strings.Join(vars, ", "))
+		}
+		return variableNode{name: name}, nil
+	}}
+	p.next()
+
+	root, err := p.parseExpr()
+	if err == nil && p.tok.kind != tokEOF {
+		err = fmt.Errorf("unexpected %q", p.tok.text)
+	}
+	if err == nil {
+		err = checkFormulaNode(root)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid formula %q: %v", source, err)
+	}
+	return root, nil
+}
+
+// checkFormulaNode는 식에 문자열 리터럴이나 수식에서 쓸 수 없는 함수가 없는지, 함수의 인자 수가 맞는지 확인합니다.
+func checkFormulaNode(n exprNode) error {
+	switch n := n.(type) {
+	case literalNode:
+		if _, ok := n.value.(float64); !ok {
+			return fmt.Errorf("text %q is not allowed in a formula", exprString(n.value))
+		}
+	case unaryNode:
+		return checkFormulaNode(n.operand)
+	case binaryNode:
+		if err := checkFormulaNode(n.left); err != nil {
+			return err
+		}
+		return checkFormulaNode(n.right)
+	case callNode:
+		arity, ok := formulaArity[n.name]
+		if !ok {
+			return fmt.Errorf("function %s is not available in formulas", n.name)
+		}
+		if len(n.args) < arity[0] || (arity[1] != -1 && len(n.args) > arity[1]) {
+			return fmt.Errorf("wrong number of arguments to %s", n.name)
+		}
+		for _, arg := range n.args {
+			if err := checkFormulaNode(arg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasFormulaColumn은 테이블들에 formula 컬럼이 있는지 반환합니다.
+func hasFormulaColumn(tables []Table) bool {
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			if col.Type.IsFormula() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// goFormulaSource는 생성된 Go 코드의 Formula 타입과 평가 함수입니다. curve.go와 같은 이유로 별도 파일에 씁니다.
+const goFormulaSource = `// Code generated by excelite. DO NOT EDIT.
+package %s
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Formula is a formula column: an arithmetic expression over named variables, e.g. base*(1+0.1*level).
+// It supports + - * / %%, unary -, parentheses and the functions round(x[, digits]), floor, ceil,
+// abs, min, max, pow(x, y) and clamp(x, lo, hi). Variable names are case-insensitive.
+type Formula string
+
+// Eval evaluates the formula with the given variables.
+func (f Formula) Eval(vars map[string]float64) (float64, error) {
+	p := &formulaParser{src: string(f), vars: vars}
+	p.next()
+	v, err := p.expr()
+	if err == nil && p.tok != "" {
+		err = fmt.Errorf("unexpected %%q", p.tok)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("formula %%q: %%v", string(f), err)
+	}
+	return v, nil
+}
+
+type formulaParser struct {
+	src  string
+	pos  int
+	tok  string
+	vars map[string]float64
+}
+
+func (p *formulaParser) next() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t' || p.src[p.pos] == '\n' || p.src[p.pos] == '\r') {
+		p.pos++
+	}
+	start := p.pos
+	switch {
+	case p.pos >= len(p.src):
+	case isFormulaDigit(p.src[p.pos]):
+		for p.pos < len(p.src) && isFormulaDigit(p.src[p.pos]) {
+			p.pos++
+		}
+	case isFormulaLetter(p.src[p.pos]):
+		for p.pos < len(p.src) && (isFormulaLetter(p.src[p.pos]) || isFormulaDigit(p.src[p.pos])) {
+			p.pos++
+		}
+	default:
+		p.pos++
+	}
+	p.tok = p.src[start:p.pos]
+}
+
+func isFormulaDigit(c byte) bool  { return c >= '0' && c <= '9' || c == '.' }
+func isFormulaLetter(c byte) bool { return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+
+func (p *formulaParser) expr() (float64, error) {
+	left, err := p.term()
+	for err == nil && (p.tok == "+" || p.tok == "-") {
+		op := p.tok
+		p.next()
+		var right float64
+		if right, err = p.term(); op == "+" {
+			left += right
+		} else {
+			left -= right
+		}
+	}
+	return left, err
+}
+
+func (p *formulaParser) term() (float64, error) {
+	left, err := p.unary()
+	for err == nil && (p.tok == "*" || p.tok == "/" || p.tok == "%%") {
+		op := p.tok
+		p.next()
+		var right float64
+		if right, err = p.unary(); err != nil {
+			break
+		}
+		switch {
+		case op == "*":
+			left *= right
+		case right == 0:
+			err = fmt.Errorf("division by zero")
+		case op == "/":
+			left /= right
+		default:
+			left = math.Mod(left, right)
+		}
+	}
+	return left, err
+}
+
+func (p *formulaParser) unary() (float64, error) {
+	if p.tok == "-" {
+		p.next()
+		v, err := p.unary()
+		return -v, err
+	}
+	return p.primary()
+}
+
+func (p *formulaParser) primary() (float64, error) {
+	tok := p.tok
+	switch {
+	case tok == "":
+		return 0, fmt.Errorf("unexpected end of formula")
+	case tok == "(":
+		p.next()
+		v, err := p.expr()
+		if err == nil && p.tok != ")" {
+			err = fmt.Errorf("missing )")
+		}
+		p.next()
+		return v, err
+	case isFormulaDigit(tok[0]):
+		p.next()
+		return strconv.ParseFloat(tok, 64)
+	case !isFormulaLetter(tok[0]):
+		return 0, fmt.Errorf("unexpected %%q", tok)
+	}
+
+	p.next()
+	name := strings.ToLower(tok)
+	if p.tok != "(" {
+		for k, v := range p.vars {
+			if strings.EqualFold(k, name) {
+				return v, nil
+			}
+		}
+		return 0, fmt.Errorf("variable %%s is not set", name)
+	}
+
+	p.next()
+	var args []float64
+	for p.tok != ")" {
+		v, err := p.expr()
+		if err != nil {
+			return 0, err
+		}
+		args = append(args, v)
+		if p.tok == "," {
+			p.next()
+		} else if p.tok != ")" {
+			return 0, fmt.Errorf("expected , or ) in call to %%s", name)
+		}
+	}
+	p.next()
+	return callFormulaFunc(name, args)
+}
+
+func callFormulaFunc(name string, args []float64) (float64, error) {
+	arity := func(min, max int) error {
+		if len(args) < min || (max >= 0 && len(args) > max) {
+			return fmt.Errorf("wrong number of arguments to %%s", name)
+		}
+		return nil
+	}
+	switch name {
+	case "round":
+		if err := arity(1, 2); err != nil {
+			return 0, err
+		}
+		scale := 1.0
+		if len(args) == 2 {
+			scale = math.Pow(10, args[1])
+		}
+		return math.Round(args[0]*scale) / scale, nil
+	case "floor":
+		if err := arity(1, 1); err != nil {
+			return 0, err
+		}
+		return math.Floor(args[0]), nil
+	case "ceil":
+		if err := arity(1, 1); err != nil {
+			return 0, err
+		}
+		return math.Ceil(args[0]), nil
+	case "abs":
+		if err := arity(1, 1); err != nil {
+			return 0, err
+		}
+		return math.Abs(args[0]), nil
+	case "min", "max":
+		if err := arity(1, -1); err != nil {
+			return 0, err
+		}
+		v := args[0]
+		for _, a := range args[1:] {
+			if name == "min" {
+				v = math.Min(v, a)
+			} else {
+				v = math.Max(v, a)
+			}
+		}
+		return v, nil
+	case "pow":
+		if err := arity(2, 2); err != nil {
+			return 0, err
+		}
+		return math.Pow(args[0], args[1]), nil
+	case "clamp":
+		if err := arity(3, 3); err != nil {
+			return 0, err
+		}
+		return math.Max(args[1], math.Min(args[2], args[0])), nil
+	}
+	return 0, fmt.Errorf("unknown function %%s", name)
+}
+`
+
+// tsFormulaSource는 zod/typebox 스키마 파일에 추가하는 수식 평가 함수입니다.
+const tsFormulaSource = `
+// evaluateFormula evaluates a formula column (e.g. "base*(1+0.1*level)") with the given variables.
+// It supports + - * / %, unary -, parentheses and the functions round(x[, digits]), floor, ceil,
+// abs, min, max, pow(x, y) and clamp(x, lo, hi). Variable names are case-insensitive.
+export function evaluateFormula(source: string, vars: Record<string, number>): number {
+  const tokens = source.match(/[0-9.]+|[A-Za-z_][A-Za-z0-9_]*|\S/g) ?? [];
+  let pos = 0;
+  const peek = (): string => tokens[pos] ?? "";
+  const fail = (message: string): never => {
+    throw new Error("formula " + JSON.stringify(source) + ": " + message);
+  };
+
+  const expr = (): number => {
+    let left = term();
+    while (peek() === "+" || peek() === "-") {
+      const op = tokens[pos++];
+      const right = term();
+      left = op === "+" ? left + right : left - right;
+    }
+    return left;
+  };
+  const term = (): number => {
+    let left = unary();
+    while (peek() === "*" || peek() === "/" || peek() === "%") {
+      const op = tokens[pos++];
+      const right = unary();
+      if (op === "*") {
+        left *= right;
+      } else if (right === 0) {
+        fail("division by zero");
+      } else {
+        left = op === "/" ? left / right : left % right;
+      }
+    }
+    return left;
+  };
+  const unary = (): number => {
+    if (peek() === "-") {
+      pos++;
+      return -unary();
+    }
+    return primary();
+  };
+  const primary = (): number => {
+    const tok = tokens[pos++];
+    if (tok === undefined) return fail("unexpected end of formula");
+    if (tok === "(") {
+      const v = expr();
+      if (tokens[pos++] !== ")") fail("missing )");
+      return v;
+    }
+    if (/^[0-9.]/.test(tok)) {
+      const n = Number(tok);
+      return Number.isNaN(n) ? fail("invalid number " + tok) : n;
+    }
+    if (!/^[A-Za-z_]/.test(tok)) return fail("unexpected " + JSON.stringify(tok));
+
+    const name = tok.toLowerCase();
+    if (peek() !== "(") {
+      for (const key of Object.keys(vars)) {
+        if (key.toLowerCase() === name) return vars[key];
+      }
+      return fail("variable " + name + " is not set");
+    }
+    pos++;
+    const args: number[] = [];
+    while (peek() !== ")") {
+      args.push(expr());
+      if (peek() === ",") pos++;
+      else if (peek() !== ")") fail("expected , or ) in call to " + name);
+    }
+    pos++;
+    return callFormulaFunc(name, args, fail);
+  };
+
+  const result = expr();
+  if (pos < tokens.length) fail("unexpected " + JSON.stringify(tokens[pos]));
+  return result;
+}
+
+function callFormulaFunc(name: string, args: number[], fail: (message: string) => never): number {
+  const arity = (min: number, max: number): void => {
+    if (args.length < min || (max >= 0 && args.length > max)) fail("wrong number of arguments to " + name);
+  };
+  switch (name) {
+    case "round": {
+      arity(1, 2);
+      const scale = args.length === 2 ? Math.pow(10, args[1]) : 1;
+      // Go's math.Round rounds half away from zero.
+      return (Math.sign(args[0]) * Math.round(Math.abs(args[0]) * scale)) / scale;
+    }
+    case "floor":
+      arity(1, 1);
+      return Math.floor(args[0]);
+    case "ceil":
+      arity(1, 1);
+      return Math.ceil(args[0]);
+    case "abs":
+      arity(1, 1);
+      return Math.abs(args[0]);
+    case "min":
+      arity(1, -1);
+      return Math.min(...args);
+    case "max":
+      arity(1, -1);
+      return Math.max(...args);
+    case "pow":
+      arity(2, 2);
+      return Math.pow(args[0], args[1]);
+    case "clamp":
+      arity(3, 3);
+      return Math.max(args[1], Math.min(args[2], args[0]));
+  }
+  return fail("unknown function " + name);
+}
+`
+
+// writeFormulaSource는 dir에 Go 패키지 pkg의 Formula 타입 파일을 씁니다.
+func writeFormulaSource(dir, pkg string) error {
+	return os.WriteFile(filepath.Join(dir, "formula.go"), []byte(fmt.Sprintf(goFormulaSource, pkg)), 0644)
+}
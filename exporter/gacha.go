@@ -0,0 +1,361 @@
+// exporter/gacha.go
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// GachaReport는 가챠 풀의 아이템 확률, 목표 아이템까지의 기대 뽑기 횟수와 비용, 천장(pity) 계산, 재화 소모처 요약입니다.
+// 여러 지역의 스토어 심사에 제출하는 확률 공개 자료를 시트 데이터만으로 만들기 위함입니다.
+//
+// 가챠 풀은 probability 컬럼이 있는 테이블이며, probability의 그룹 컬럼 값이 풀 하나입니다.
+// 같은 테이블의 pity 컬럼은 아이템의 천장(이 횟수 안에 반드시 나옴), cost 컬럼은 풀의 1회 뽑기 비용입니다.
+// probability 컬럼이 없는 테이블의 cost 컬럼은 재화 소모처로 요약합니다.
+type GachaReport struct {
+	Pools []GachaPool   `json:"pools"`
+	Sinks []EconomySink `json:"sinks,omitempty"`
+}
+
+// GachaPool은 가챠 풀 하나입니다.
+type GachaPool struct {
+	Table    string      `json:"table"`
+	Column   string      `json:"column"`         // probability 컬럼
+	Pool     string      `json:"pool,omitempty"` // 그룹 컬럼 값 (그룹이 없으면 비어있음)
+	Currency string      `json:"currency,omitempty"`
+	Cost     float64     `json:"cost,omitempty"` // 1회 뽑기 비용 (cost 컬럼이 없으면 0)
+	Items    []GachaItem `json:"items"`
+}
+
+// GachaItem은 풀의 아이템 하나의 확률과 목표 아이템으로 삼았을 때의 기대값입니다.
+// 확률이 0이고 천장도 없는 아이템은 얻을 수 없으므로 기대값과 뽑기 횟수가 0입니다.
+type GachaItem struct {
+	Key           string  `json:"key"`
+	Name          string  `json:"name,omitempty"` // Name 컬럼 값
+	Rate          float64 `json:"rate"`           // 1회 뽑기에서 나올 확률 (0~1)
+	Pity          int     `json:"pity,omitempty"` // 천장: 이 횟수 안에 나오지 않았으면 이 횟수째에 반드시 나옴
+	ExpectedPulls float64 `json:"expectedPulls"`
+	ExpectedCost  float64 `json:"expectedCost,omitempty"`
+	Pulls50       int     `json:"pulls50"` // 50% 확률로 얻는 데 필요한 뽑기 횟수
+	Pulls90       int     `json:"pulls90"`
+	Pulls99       int     `json:"pulls99"`
+}
+
+// EconomySink는 재화 소모처(cost 컬럼) 하나의 요약입니다.
+type EconomySink struct {
+	Table    string  `json:"table"`
+	Column   string  `json:"column"`
+	Currency string  `json:"currency,omitempty"`
+	Rows     int     `json:"rows"` // 비용이 있는 행 수
+	Total    float64 `json:"total"`
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+}
+
+// columnCurrency는 cost 컬럼의 재화 이름입니다. 태그 값이 없으면 빈 문자열입니다.
+func columnCurrency(col Column) (string, bool) {
+	value, ok := GetTagValue(col.Tags, TagCost)
+	return strings.TrimSpace(value), ok
+}
+
+// isNumericColumn은 배열이 아닌 int/float 컬럼인지 확인합니다.
+func isNumericColumn(col Column) bool {
+	if col.Type.IsArray {
+		return false
+	}
+	switch col.Type.Type.Kind() {
+	case reflect.Int32, reflect.Int64, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// pullsForChance는 확률 rate인 아이템을 chance 이상의 확률로 얻는 데 필요한 뽑기 횟수입니다. 천장을 넘지 않습니다.
+func pullsForChance(rate float64, pity int, chance float64) int {
+	if rate >= 1 {
+		return 1
+	}
+	if rate <= 0 {
+		return pity
+	}
+	n := int(math.Ceil(math.Log(1-chance) / math.Log(1-rate)))
+	if pity > 0 && n > pity {
+		return pity
+	}
+	return max(n, 1)
+}
+
+// expectedPulls는 아이템이 처음 나올 때까지의 기대 뽑기 횟수입니다.
+// 천장 n이 있으면 n번째까지의 기하분포 기대값 (1-(1-p)^n)/p이고, 없으면 1/p입니다.
+func expectedPulls(rate float64, pity int) float64 {
+	switch {
+	case rate <= 0:
+		return float64(pity)
+	case pity > 0:
+		return (1 - math.Pow(1-rate, float64(pity))) / rate
+	}
+	return 1 / rate
+}
+
+// validateGacha는 pity와 cost 태그를 확인합니다. pity는 probability 컬럼이 있는 테이블의 정수 컬럼이어야 하고,
+// 가챠 풀의 cost는 풀 안에서 같아야 합니다.
+func validateGacha(table Table) []error {
+	var errs []error
+	var probabilities []int
+	for c := range table.Columns {
+		if spec, err := columnProbability(table, c); err == nil && spec != nil {
+			probabilities = append(probabilities, c)
+		}
+	}
+
+	for c, col := range table.Columns {
+		if HasTag(col.Tags, TagPity) {
+			switch {
+			case !isNumericColumn(col) || col.Type.Type.Kind() == reflect.Float64:
+				errs = append(errs, fmt.Errorf("table %s column %s: pity is only supported for int columns", table.Name, col.Name))
+			case len(probabilities) == 0:
+				errs = append(errs, fmt.Errorf("table %s column %s: pity needs a probability column in the same table", table.Name, col.Name))
+			default:
+				for _, row := range table.Rows {
+					if c < len(row) && row[c] != nil && probabilityValue(row[c]) < 1 {
+						errs = append(errs, fmt.Errorf("table %s column %s: row %s: pity must be at least 1", table.Name, col.Name, RowKey(table, row)))
+					}
+				}
+			}
+		}
+
+		if _, ok := columnCurrency(col); !ok {
+			continue
+		}
+		if !isNumericColumn(col) {
+			errs = append(errs, fmt.Errorf("table %s column %s: cost is only supported for int and float columns", table.Name, col.Name))
+			continue
+		}
+		for _, p := range probabilities {
+			spec, _ := columnProbability(table, p)
+			groups, err := probabilityGroups(table, p, spec)
+			if err != nil {
+				continue
+			}
+			for _, g := range groups {
+				if _, err := poolCost(table, c, g); err != nil {
+					errs = append(errs, fmt.Errorf("table %s column %s: %s: %v", table.Name, col.Name, g.describe(table, spec), err))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// poolCost는 풀의 1회 뽑기 비용입니다. 비어있지 않은 셀의 값이 모두 같아야 합니다.
+func poolCost(table Table, c int, g *probabilityGroup) (float64, error) {
+	cost, found := 0.0, false
+	for _, i := range g.Rows {
+		row := table.Rows[i]
+		if c >= len(row) || row[c] == nil {
+			continue
+		}
+		v := probabilityValue(row[c])
+		if found && v != cost {
+			return 0, fmt.Errorf("pool has different costs (%g, %g)", cost, v)
+		}
+		cost, found = v, true
+	}
+	return cost, nil
+}
+
+// BuildGachaReport는 테이블들의 가챠 풀과 재화 소모처를 계산합니다. 풀과 소모처는 테이블과 컬럼 순서입니다.
+func BuildGachaReport(tables []Table) (GachaReport, error) {
+	report := GachaReport{Pools: []GachaPool{}}
+	for _, table := range tables {
+		pity, name, costCol := -1, -1, -1
+		for c, col := range table.Columns {
+			switch {
+			case HasTag(col.Tags, TagPity):
+				pity = c
+			case strings.EqualFold(col.Name, "Name"):
+				name = c
+			}
+			if _, ok := columnCurrency(col); ok && costCol == -1 {
+				costCol = c
+			}
+		}
+
+		pools := 0
+		for c, col := range table.Columns {
+			spec, err := columnProbability(table, c)
+			if err != nil {
+				return report, fmt.Errorf("table %s column %s: %v", table.Name, col.Name, err)
+			}
+			if spec == nil {
+				continue
+			}
+			groups, err := probabilityGroups(table, c, spec)
+			if err != nil {
+				return report, fmt.Errorf("table %s column %s: %v", table.Name, col.Name, err)
+			}
+			for _, g := range groups {
+				pool := GachaPool{Table: table.Name, Column: col.Name, Pool: g.Key}
+				if costCol != -1 {
+					if pool.Cost, err = poolCost(table, costCol, g); err != nil {
+						return report, fmt.Errorf("table %s column %s: %s: %v", table.Name, table.Columns[costCol].Name, g.describe(table, spec), err)
+					}
+					pool.Currency, _ = columnCurrency(table.Columns[costCol])
+				}
+				for _, i := range g.Rows {
+					row := table.Rows[i]
+					item := GachaItem{Key: RowKey(table, row)}
+					if c < len(row) {
+						item.Rate = probabilityValue(row[c]) / spec.Total
+					}
+					if name != -1 && name < len(row) && row[name] != nil {
+						item.Name = fmt.Sprint(row[name])
+					}
+					if pity != -1 && pity < len(row) {
+						item.Pity = int(probabilityValue(row[pity]))
+					}
+					if item.Rate > 0 || item.Pity > 0 {
+						item.ExpectedPulls = expectedPulls(item.Rate, item.Pity)
+						item.ExpectedCost = item.ExpectedPulls * pool.Cost
+						item.Pulls50 = pullsForChance(item.Rate, item.Pity, 0.5)
+						item.Pulls90 = pullsForChance(item.Rate, item.Pity, 0.9)
+						item.Pulls99 = pullsForChance(item.Rate, item.Pity, 0.99)
+					}
+					pool.Items = append(pool.Items, item)
+				}
+				report.Pools = append(report.Pools, pool)
+			}
+			pools++
+		}
+		if pools > 0 || costCol == -1 {
+			continue
+		}
+
+		// probability 컬럼이 없는 테이블의 cost 컬럼은 재화 소모처
+		for c, col := range table.Columns {
+			currency, ok := columnCurrency(col)
+			if !ok {
+				continue
+			}
+			sink := EconomySink{Table: table.Name, Column: col.Name, Currency: currency}
+			for _, row := range table.Rows {
+				if c >= len(row) || row[c] == nil {
+					continue
+				}
+				v := probabilityValue(row[c])
+				if sink.Rows == 0 || v < sink.Min {
+					sink.Min = v
+				}
+				if sink.Rows == 0 || v > sink.Max {
+					sink.Max = v
+				}
+				sink.Total += v
+				sink.Rows++
+			}
+			report.Sinks = append(report.Sinks, sink)
+		}
+	}
+	return report, nil
+}
+
+// GachaExporter는 가챠 풀의 확률 공개 자료(gacha.json, gacha.md)를 생성합니다.
+type GachaExporter struct {
+	BaseExporter
+}
+
+func NewGachaExporter() Exporter {
+	return &GachaExporter{
+		BaseExporter: NewBaseExporter("gacha"),
+	}
+}
+
+func (e *GachaExporter) Describe() ExporterInfo {
+	return ExporterInfo{
+		Description: "gacha disclosure report: item rates, expected pulls and cost per item, pity and currency sinks (probability, pity and cost tags)",
+		Outputs:     []string{"gacha.json", "gacha.md"},
+	}
+}
+
+func (e *GachaExporter) Export(tables []Table, opts Options) error {
+	report, err := BuildGachaReport(tables)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(opts.OutputDir, "gacha.json"), append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(opts.OutputDir, "gacha.md"), []byte(gachaMarkdown(report)), 0644)
+}
+
+// gachaMarkdown은 보고서를 사람이 읽는 표로 씁니다. 확률은 백분율로 표시합니다.
+func gachaMarkdown(report GachaReport) string {
+	var b strings.Builder
+	b.WriteString("# Gacha Rates\n")
+	if len(report.Pools) == 0 {
+		b.WriteString("\nNo gacha pools (tables with a probability column).\n")
+	}
+	for _, pool := range report.Pools {
+		title := pool.Table
+		if pool.Pool != "" {
+			title += " / " + pool.Pool
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", title)
+		if pool.Cost > 0 {
+			fmt.Fprintf(&b, "Cost per pull: %s\n\n", formatCost(pool.Cost, pool.Currency))
+		}
+
+		b.WriteString("| Item | Rate | Pity | Expected pulls | Expected cost | Pulls for 50% / 90% / 99% |\n")
+		b.WriteString("|---|---:|---:|---:|---:|---:|\n")
+		for _, item := range pool.Items {
+			label := item.Key
+			if item.Name != "" {
+				label = fmt.Sprintf("%s (%s)", item.Name, item.Key)
+			}
+			pity, expected, cost, pulls := "-", "-", "-", "-"
+			if item.Pity > 0 {
+				pity = fmt.Sprint(item.Pity)
+			}
+			if item.ExpectedPulls > 0 {
+				expected = fmt.Sprintf("%.2f", item.ExpectedPulls)
+				pulls = fmt.Sprintf("%d / %d / %d", item.Pulls50, item.Pulls90, item.Pulls99)
+			}
+			if item.ExpectedCost > 0 {
+				cost = formatCost(item.ExpectedCost, pool.Currency)
+			}
+			fmt.Fprintf(&b, "| %s | %s%% | %s | %s | %s | %s |\n",
+				label, formatBound(math.Round(item.Rate*1e6)/1e4), pity, expected, cost, pulls)
+		}
+	}
+
+	if len(report.Sinks) > 0 {
+		b.WriteString("\n# Currency Sinks\n\n")
+		b.WriteString("| Table | Column | Currency | Rows | Total | Min | Max |\n")
+		b.WriteString("|---|---|---|---:|---:|---:|---:|\n")
+		for _, sink := range report.Sinks {
+			fmt.Fprintf(&b, "| %s | %s | %s | %d | %s | %s | %s |\n", sink.Table, sink.Column, sink.Currency, sink.Rows,
+				formatBound(sink.Total), formatBound(sink.Min), formatBound(sink.Max))
+		}
+	}
+	return b.String()
+}
+
+func formatCost(cost float64, currency string) string {
+	text := formatBound(math.Round(cost*100) / 100)
+	if currency != "" {
+		text += " " + currency
+	}
+	return text
+}
@@ -0,0 +1,85 @@
+// exporter/geo.go
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// GeoPoint는 geo 타입 컬럼의 값입니다. [x, y] (또는 [lat, lon]) 순서입니다.
+// SQLite에는 JSON 배열 텍스트로 저장되고, 범위 검색을 위한 R*Tree 인덱스가 함께 생성됩니다.
+type GeoPoint [2]float64
+
+// GeoType은 2차원 좌표 컬럼 타입입니다. 셀에는 "x,y" 또는 "x;y" 형태로 입력합니다.
+var GeoType = ColumnType{
+	Type:    reflect.TypeOf(GeoPoint{}),
+	SQLType: "TEXT",
+}
+
+// IsGeo는 컬럼 타입이 geo 타입인지 확인합니다.
+func (ct ColumnType) IsGeo() bool {
+	return !ct.IsArray && ct.Type == GeoType.Type
+}
+
+// parseGeoPoint는 "x,y", "x;y", "x y" 형태의 셀 값을 좌표로 변환합니다.
+func parseGeoPoint(value string) (GeoPoint, error) {
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' '
+	})
+	if len(parts) != 2 {
+		return GeoPoint{}, fmt.Errorf("%s is not a coordinate pair (expected \"x,y\")", describeCell(value))
+	}
+
+	var point GeoPoint
+	for i, part := range parts {
+		f, err := coerceFloat(part)
+		if err != nil {
+			return GeoPoint{}, err
+		}
+		point[i] = f
+	}
+	return point, nil
+}
+
+// GeoColumns는 테이블의 geo 타입 컬럼 위치를 반환합니다.
+func GeoColumns(table Table) []int {
+	var cols []int
+	for i, col := range table.Columns {
+		if col.Type.IsGeo() {
+			cols = append(cols, i)
+		}
+	}
+	return cols
+}
+
+// SpatialIndexName은 geo 컬럼의 R*Tree 가상 테이블 이름을 반환합니다.
+// R*Tree의 id는 원본 테이블의 id와 같습니다.
+func SpatialIndexName(table Table, col Column) string {
+	return table.Name + "_" + col.Name + "_rtree"
+}
+
+// buildSpatialIndexQueries는 geo 컬럼마다 R*Tree 가상 테이블 생성 쿼리를 반환합니다.
+func buildSpatialIndexQueries(table Table, d SQLDialect) string {
+	var b strings.Builder
+	for _, idx := range GeoColumns(table) {
+		b.WriteString(fmt.Sprintf("CREATE VIRTUAL TABLE IF NOT EXISTS %s USING rtree(id, minX, maxX, minY, maxY);\n",
+			d.QuoteIdentifier(SpatialIndexName(table, table.Columns[idx]))))
+	}
+	return b.String()
+}
+
+// buildSpatialIndexFillQueries는 데이터 입력 후 R*Tree 인덱스를 채우는 쿼리를 반환합니다.
+func buildSpatialIndexFillQueries(table Table, d SQLDialect) []string {
+	var queries []string
+	for _, idx := range GeoColumns(table) {
+		col := d.QuoteIdentifier(table.Columns[idx].Name)
+		queries = append(queries, fmt.Sprintf(
+			"INSERT INTO %s (id, minX, maxX, minY, maxY)\n"+
+				"SELECT id, json_extract(%s, '$[0]'), json_extract(%s, '$[0]'), json_extract(%s, '$[1]'), json_extract(%s, '$[1]')\n"+
+				"FROM %s WHERE %s IS NOT NULL;",
+			d.QuoteIdentifier(SpatialIndexName(table, table.Columns[idx])), col, col, col, col,
+			d.QuoteIdentifier(table.Name), col))
+	}
+	return queries
+}
@@ -0,0 +1,34 @@
+package exporter_test
+
+import (
+	"testing"
+
+	"excelite/exporter"
+	"excelite/exporter/exportertest"
+)
+
+// golden 파일은 testdata/golden/<Name>/에 있으며 `go test ./exporter -update`로 갱신합니다.
+
+func TestSQLiteGolden(t *testing.T) {
+	exportertest.Run(t, exportertest.Case{
+		Name:     "sqlite",
+		Exporter: exporter.NewSQLiteExporter(),
+	})
+}
+
+func TestSQLiteQuoteAllGolden(t *testing.T) {
+	exportertest.Run(t, exportertest.Case{
+		Name:     "sqlite-quote-all",
+		Exporter: exporter.NewSQLiteExporter(),
+		Options: exporter.Options{
+			ExtraOptions: map[string]interface{}{exporter.OptSQLiteQuoteAll: true},
+		},
+	})
+}
+
+func TestJSONGolden(t *testing.T) {
+	exportertest.Run(t, exportertest.Case{
+		Name:     "json",
+		Exporter: exporter.NewJSONExporter(),
+	})
+}
@@ -1,9 +1,10 @@
-// exporter/golang.go
+// exporter/gorm.go
 package exporter
 
 import (
 	"bytes"
 	"fmt"
+	"go/format"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -12,7 +13,9 @@ import (
 	"time"
 )
 
-// GORMExporter implements code generation for Go
+// GORMExporter는 GORM 모델을 생성합니다.
+// models.go에는 모든 모델 목록과 AutoMigrate(useSQLite이면 SQLite로 여는 Open)가, 테이블마다 <table>.model.go에는 모델 구조체와 조회 헬퍼가 들어갑니다.
+// min/max 범위가 있는 테이블은 저장 전에 범위를 확인하는 BeforeSave 훅(hooks.go)을, generateRepo 옵션이면 키와 인덱스 조회 함수를 가진 저장소(repository.go)를 함께 생성합니다.
 type GORMExporter struct {
 	BaseExporter
 }
@@ -23,7 +26,79 @@ func NewGORMExporter() Exporter {
 	}
 }
 
+func (e *GORMExporter) Describe() ExporterInfo {
+	return ExporterInfo{
+		Description: "GORM models with AutoMigrate, min/max BeforeSave hooks and optional repositories",
+		Outputs:     []string{"models.go", "<table>.model.go", "hooks.go", "repository.go"},
+		Options: []OptionInfo{
+			{Key: OptGoUseGorm, Type: "bool", Default: "true", Description: "generate GORM models (false is not supported; use the sqlx exporter for plain structs)"},
+			{Key: OptGoUseSQLite, Type: "bool", Default: "true", Description: "also write an Open(dsn) helper in models.go using gorm.io/driver/sqlite"},
+			{Key: OptGoGenerateRepo, Type: "bool", Default: "true", Description: "also write repository.go: a <Table>Repository per table with All/Save/Delete and key, index and foreign key lookups"},
+			{Key: OptModelKey, Type: "string", Default: DefaultAuditPolicy.Key, Description: "primary key: id (injected auto-increment id) or sheet (the sheet's index column)"},
+			{Key: OptTimestamps, Type: "string", Default: DefaultAuditPolicy.Timestamps, Description: "injected created_at/updated_at type: time, unix, unixmilli or none"},
+			{Key: OptSoftDelete, Type: "bool", Default: fmt.Sprint(DefaultAuditPolicy.SoftDelete), Description: "inject deleted_at for soft delete"},
+		},
+		Types: typeMappings(func(col Column) (string, error) {
+			return gormGoType(col), nil
+		}),
+	}
+}
+
+// gormReservedNames는 models.go, hooks.go, repository.go와 함께 생성하는 파일이 선언하는 이름입니다. 같은 이름의 테이블은 컴파일되지 않습니다.
+var gormReservedNames = []string{"Models", "AutoMigrate", "Open", "Point", "Curve", "Formula", "ErrOutOfRange", "Repositories", "NewRepositories"}
+
+// gormModel은 모델 파일 하나의 템플릿 데이터입니다.
+type gormModel struct {
+	Name         string
+	File         string   // 출력 파일 이름
+	StdImports   []string // 표준 라이브러리 패키지
+	Imports      []string
+	Columns      []goColumn
+	IndexField   string
+	IndexType    string
+	ValidFrom    string
+	ValidTo      string
+	VariantField string
+	VariantGroup string
+	Aliases      []string
+	GeoFields    []goGeoField
+	IsSettings   bool
+	MatrixType   string
+	ParentField  string
+	Checks       []gormCheck  // BeforeSave 훅의 범위 검사
+	KeyColumns   []string     // 전체 조회의 정렬 컬럼 (인덱스 컬럼, 매트릭스는 행 키와 열 키)
+	Lookups      []gormLookup // 저장소의 조회 함수
+}
+
+// gormCheck는 BeforeSave 훅에서 필드 하나의 min/max를 확인하는 코드입니다.
+// Loop가 있으면 배열의 원소(커브는 키프레임)마다 v로 확인합니다.
+type gormCheck struct {
+	Field  string // 에러 메시지의 필드 이름 (Item.Name)
+	Loop   string // 순회할 식
+	Skip   string // 확인하지 않을 원소의 조건 (값이 없는 커브 키프레임)
+	Value  string // 확인할 float64 식
+	What   string // value 또는 length
+	Min    string
+	Max    string
+	HasMin bool
+	HasMax bool
+}
+
+// gormLookup은 저장소의 조회 함수 하나입니다. 조회 규칙은 SQLite 쿼리 레이어(buildQueryTables)와 같습니다.
+type gormLookup struct {
+	Method string
+	Params []goColumn // 조건 컬럼 (Name은 컬럼 이름, GoType은 파라미터 타입)
+	Unique bool
+}
+
 func (e *GORMExporter) Export(tables []Table, opts Options) error {
+	if !e.GetBoolOption(opts, OptGoUseGorm, true) {
+		return fmt.Errorf("%s=false is not supported; use the sqlx exporter for Go structs without GORM", OptGoUseGorm)
+	}
+	if errs := rejectInterned(tables, e.Language()); len(errs) > 0 {
+		return errs[0]
+	}
+
 	// 1. 출력 디렉토리 생성
 	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %v", err)
@@ -38,175 +113,320 @@ func (e *GORMExporter) Export(tables []Table, opts Options) error {
 }
 
 func (e *GORMExporter) generateModels(tables []Table, opts Options) error {
-	const modelTemplate = `// Code generated by excelite. DO NOT EDIT.
-package {{.PackageName}}
-
-import (
-	"gorm.io/gorm"
-	"time"
-)
-
-{{range .Tables}}
-// {{.Name}} represents the {{.Name}} table
-type {{.Name}} struct {
-	gorm.Model
-	{{range .Columns}}
-	{{.Name}} {{.GoType}} {{.Tags}}
-	{{end}}
-}
-
-{{if .HasArrayFields}}
-// BeforeSave handles array field serialization
-func (m *{{.Name}}) BeforeSave(tx *gorm.DB) error {
-	{{range .ArrayFields}}
-	// Handle {{.Name}} array
-	if m.{{.Name}} != nil {
-		for i, v := range m.{{.Name}} {
-			fieldName := fmt.Sprintf("{{.Name}}_%d", i)
-			tx.Statement.SetColumn(fieldName, v)
-		}
-	}
-	{{end}}
-	return nil
-}
-
-// AfterFind handles array field deserialization
-func (m *{{.Name}}) AfterFind(tx *gorm.DB) error {
-	{{range .ArrayFields}}
-	// Initialize {{.Name}} array
-	m.{{.Name}} = make([]{{.BaseType}}, 0)
-	for i := 0; ; i++ {
-		field := reflect.ValueOf(m).Elem().FieldByName(fmt.Sprintf("{{.Name}}_%d", i))
-		if !field.IsValid() {
-			break
-		}
-		if !field.IsZero() {
-			m.{{.Name}} = append(m.{{.Name}}, field.Interface().({{.BaseType}}))
-		}
-	}
-	{{end}}
-	return nil
-}
-{{end}}
-{{end}}
-`
-
-	type modelData struct {
-		Name           string
-		Columns        []goColumn
-		Relations      []Relation
-		HasArrayFields bool
-		ArrayFields    []goArrayField
+	policy, err := auditPolicy(e.BaseExporter, opts)
+	if err != nil {
+		return err
 	}
+	auditFields := policy.gormFields()
 
 	data := struct {
-		PackageName string
-		Tables      []modelData
+		PackageName  string
+		UseSQLite    bool
+		GenerateRepo bool
+		HasGeo       bool
+		HasCurve     bool
+		HasHooks     bool
+		HasUTF8      bool // 문자열 길이 검사에 unicode/utf8 필요
+		HasEpoch     bool // datetimeStorage=epoch인 테이블의 datetime 컬럼이 있어 epoch serializer 필요
+		ModelFields  []auditField
+		Models       []gormModel
 	}{
-		PackageName: opts.PackageName,
-		Tables:      make([]modelData, len(tables)),
+		PackageName:  opts.PackageName,
+		UseSQLite:    e.GetBoolOption(opts, OptGoUseSQLite, true),
+		GenerateRepo: e.GetBoolOption(opts, OptGoGenerateRepo, true),
+		HasCurve:     hasCurveColumn(tables),
+		ModelFields:  auditFields,
+	}
+
+	queryTables := make(map[string]queryTable, len(tables))
+	for _, qt := range buildQueryTables(tables, false, SQLiteDialect) {
+		queryTables[qt.Name] = qt
 	}
 
-	for i, table := range tables {
-		var arrayFields []goArrayField
+	files := make(map[string]string, len(tables))
+	for _, table := range tables {
+		if err := policy.validateTable(table, auditFields); err != nil {
+			return err
+		}
+		if containsString(gormReservedNames, table.Name) {
+			return fmt.Errorf("table %s conflicts with the generated declaration of the same name", table.Name)
+		}
+		if len(table.Columns) == 0 {
+			continue
+		}
 		columns := make([]goColumn, len(table.Columns))
+		tagRules := tableStructTags(table)
+		if tableDateTimeStorage(table) == DateTimeEpoch && hasDateTimeColumn(table) {
+			data.HasEpoch = true
+		}
 
 		for j, col := range table.Columns {
-			goType := getGoTypeFromColumnType(col.Type)
-
-			if col.Type.IsArray {
-				baseType := getGoTypeFromColumnType(*col.Type.BaseType)
-				arrayFields = append(arrayFields, goArrayField{
-					Name:     col.Name,
-					BaseType: baseType,
-				})
-				goType = fmt.Sprintf("[]%s", baseType)
+			if col.Name == "TableName" || col.Name == "BeforeSave" {
+				return fmt.Errorf("table %s: column %s conflicts with the generated method of the same name", table.Name, col.Name)
 			}
-
 			columns[j] = goColumn{
 				Name:   col.Name,
-				GoType: goType,
-				Tags:   buildGormTags(col),
+				GoType: gormGoType(col),
+				Tags:   buildGormTags(col, policy.Key == ModelKeySheet && isSheetKey(table, j), tagRules, tableDateTimeStorage(table)),
+			}
+			columns[j].Deprecated, _ = DeprecationMessage(col)
+		}
+
+		model := gormModel{
+			Name:       table.Name,
+			File:       toSnakeCase(table.Name) + ".model.go",
+			Columns:    columns,
+			Aliases:    table.Aliases,
+			IsSettings: table.IsSettings,
+		}
+		if prev, ok := files[model.File]; ok {
+			return fmt.Errorf("tables %s and %s are both written to %s", prev, table.Name, model.File)
+		}
+		files[model.File] = table.Name
+		if table.IsMatrix {
+			model.MatrixType = columns[2].GoType
+		}
+
+		// geo 컬럼마다 R*Tree 인덱스를 이용한 범위 검색 헬퍼를 생성
+		for _, idx := range GeoColumns(table) {
+			col := table.Columns[idx]
+			rtree := SpatialIndexName(table, col)
+			model.GeoFields = append(model.GeoFields, goGeoField{
+				Name:  col.Name,
+				Index: rtree,
+				Query: fmt.Sprintf("SELECT t.* FROM %s t JOIN %s r ON r.id = t.id WHERE r.minX >= ? AND r.maxX <= ? AND r.minY >= ? AND r.maxY <= ?",
+					SQLiteDialect.QuoteIdentifier(table.Name), SQLiteDialect.QuoteIdentifier(rtree)),
+			})
+			data.HasGeo = true
+		}
+
+		// 적용 기간 컬럼이 있으면 시점별 조회 헬퍼를 생성
+		if from, to := EffectiveDateColumns(table); from != -1 {
+			if err := validateEffectiveDates(table); err != nil {
+				return err
+			}
+			model.IndexField = columns[IndexColumn(table)].Name
+			model.IndexType = columns[IndexColumn(table)].GoType
+			model.ValidFrom = columns[from].Name
+			if to != -1 {
+				model.ValidTo = columns[to].Name
+			}
+		}
+
+		// 부모 컬럼이 있으면 트리 탐색 헬퍼를 생성
+		if idx := ParentColumn(table); idx != -1 {
+			if err := validateTree(table); err != nil {
+				return err
 			}
+			model.IndexField = columns[IndexColumn(table)].Name
+			model.IndexType = columns[IndexColumn(table)].GoType
+			model.ParentField = columns[idx].Name
 		}
 
-		data.Tables[i] = modelData{
-			Name:           table.Name,
-			Columns:        columns,
-			Relations:      table.Relations,
-			HasArrayFields: len(arrayFields) > 0,
-			ArrayFields:    arrayFields,
+		// 변형 컬럼이 있으면 변형별 조회 헬퍼를 생성
+		if idx := VariantColumn(table); idx != -1 {
+			if err := validateVariants(table); err != nil {
+				return err
+			}
+			model.IndexField = columns[IndexColumn(table)].Name
+			model.IndexType = columns[IndexColumn(table)].GoType
+			model.VariantField = columns[idx].Name
+			model.VariantGroup, _ = GetTagValue(table.Columns[idx].Tags, TagVariant)
+		}
+
+		// min/max가 있는 컬럼은 BeforeSave 훅에서 확인
+		for _, col := range table.Columns {
+			bounds, err := ColumnBounds(col)
+			if err != nil {
+				return fmt.Errorf("table %s: column %s: %v", table.Name, col.Name, err)
+			}
+			if check, ok := gormBoundsCheck(table.Name, col, bounds); ok {
+				model.Checks = append(model.Checks, check)
+				data.HasUTF8 = data.HasUTF8 || check.What == "length"
+			}
+		}
+		data.HasHooks = data.HasHooks || len(model.Checks) > 0
+
+		// 저장소의 정렬 키와 조회 함수 (파라미터는 모델 필드의 타입)
+		qt := queryTables[table.Name]
+		for _, lookup := range qt.Lookups {
+			gl := gormLookup{Method: lookup.Method, Unique: lookup.Unique}
+			for _, p := range lookup.Params {
+				gl.Params = append(gl.Params, goColumn{Name: p.Name, GoType: columns[columnIndex(table, p.Name)].GoType})
+			}
+			if lookup.Method == "Get" {
+				for _, p := range gl.Params {
+					model.KeyColumns = append(model.KeyColumns, p.Name)
+				}
+			}
+			model.Lookups = append(model.Lookups, gl)
+		}
+		if len(model.KeyColumns) == 0 {
+			// 키 컬럼으로 조회할 수 없는 타입이어도 전체 조회는 키 순서로 정렬
+			keys := []int{IndexColumn(table)}
+			if table.IsMatrix {
+				keys = []int{0, 1}
+			}
+			for _, k := range keys {
+				model.KeyColumns = append(model.KeyColumns, table.Columns[k].Name)
+			}
 		}
+
+		model.StdImports, model.Imports = gormModelImports(model, auditFields)
+		data.Models = append(data.Models, model)
 	}
 
-	// 템플릿 실행
-	tmpl, err := template.New("model").Parse(modelTemplate)
-	if err != nil {
+	if err := writeGORMFile(opts, "gorm/models.go.tmpl", "models.go", data); err != nil {
 		return err
 	}
+	for _, model := range data.Models {
+		modelData := struct {
+			PackageName string
+			ModelFields []auditField
+			gormModel
+		}{data.PackageName, auditFields, model}
+		if err := writeGORMFile(opts, "gorm/model.go.tmpl", model.File, modelData); err != nil {
+			return err
+		}
+	}
+	if data.HasHooks {
+		if err := writeGORMFile(opts, "gorm/hooks.go.tmpl", "hooks.go", data); err != nil {
+			return err
+		}
+	}
+	if data.GenerateRepo {
+		if err := writeGORMFile(opts, "gorm/repository.go.tmpl", "repository.go", data); err != nil {
+			return err
+		}
+	}
+	if data.HasCurve {
+		if err := writeCurveSource(opts.OutputDir, "go", opts.PackageName); err != nil {
+			return err
+		}
+	}
+	if hasFormulaColumn(tables) {
+		return writeFormulaSource(opts.OutputDir, opts.PackageName)
+	}
+	return nil
+}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return err
+// gormModelImports는 모델 파일이 사용하는 표준 라이브러리 패키지와 그 밖의 패키지입니다.
+func gormModelImports(model gormModel, auditFields []auditField) (std, imports []string) {
+	uses := func(decl, pkg string) bool { return strings.Contains(decl, pkg+".") }
+	usesTime := model.ValidFrom != ""
+	usesGorm := model.IsSettings || model.MatrixType != "" || len(model.GeoFields) > 0
+	usesSoftDelete := false
+	for _, f := range auditFields {
+		usesTime = usesTime || uses(f.Decl, "time")
+		usesGorm = usesGorm || uses(f.Decl, "gorm")
+		usesSoftDelete = usesSoftDelete || uses(f.Decl, "soft_delete")
+	}
+	for _, col := range model.Columns {
+		usesTime = usesTime || uses(col.GoType, "time")
 	}
 
-	// 파일 저장
-	outputFile := filepath.Join(opts.OutputDir, "models.go")
-	return os.WriteFile(outputFile, buf.Bytes(), 0644)
+	if usesTime {
+		std = append(std, "time")
+	}
+	if usesGorm {
+		imports = append(imports, "gorm.io/gorm")
+	}
+	if usesSoftDelete {
+		imports = append(imports, "gorm.io/plugin/soft_delete")
+	}
+	return std, imports
 }
 
-func (e *GORMExporter) generateDBSchema(tables []Table, opts Options) error {
-	const schemaTemplate = `-- Code generated by excelite. DO NOT EDIT.
-
-{{range .Tables}}
-CREATE TABLE IF NOT EXISTS {{.TableName}} (
-	id INTEGER PRIMARY KEY AUTOINCREMENT,
-	{{range .Columns}}
-	{{.Name}} {{.SQLType}}{{if .IsUnique}} UNIQUE{{end}},
-	{{end}}
-	created_at DATETIME NOT NULL,
-	updated_at DATETIME NOT NULL,
-	deleted_at DATETIME
-);
-
-{{range .Indices}}
-CREATE INDEX IF NOT EXISTS idx_{{$.TableName}}_{{.Name}} ON {{$.TableName}}({{.Columns}});
-{{end}}
-{{end}}
-`
-	tmpl, err := template.New("schema").Parse(schemaTemplate)
-	if err != nil {
-		return err
+// gormBoundsCheck는 컬럼의 min/max를 BeforeSave 훅의 검사로 만듭니다. 범위가 없으면 false입니다.
+// 문자열은 글자 수, 배열은 원소마다, 커브는 키프레임의 값마다 확인합니다. (파싱 시점의 checkBounds와 같은 규칙)
+func gormBoundsCheck(tableName string, col Column, b Bounds) (gormCheck, bool) {
+	if !b.IsSet() {
+		return gormCheck{}, false
 	}
+	check := gormCheck{
+		Field:  tableName + "." + col.Name,
+		What:   "value",
+		Min:    formatBound(b.Min),
+		Max:    formatBound(b.Max),
+		HasMin: b.HasMin,
+		HasMax: b.HasMax,
+	}
+	value, goType := "m."+col.Name, getGoTypeFromColumnType(elementType(col.Type))
+	switch {
+	case col.Type.IsCurve():
+		check.Loop, check.Skip, value, goType = value+".Keys", "len(v) < 2", "v[1]", "float64"
+	case col.Type.IsArray:
+		check.Loop, value = value, "v"
+	}
+	switch {
+	case elementType(col.Type).Type.Kind() == reflect.String:
+		check.What = "length"
+		if goType != "string" {
+			value = "string(" + value + ")"
+		}
+		check.Value = "float64(utf8.RuneCountInString(" + value + "))"
+	case goType == "float64":
+		check.Value = value
+	default:
+		check.Value = "float64(" + value + ")"
+	}
+	return check, true
+}
 
-	data := struct {
-		Tables []Table
-	}{
-		Tables: tables,
+// gormGoType은 컬럼의 모델 필드 타입입니다. 배열은 JSON으로 직렬화하는 슬라이스입니다.
+func gormGoType(col Column) string {
+	if col.Type.IsArray {
+		return "[]" + getGoTypeFromColumnType(*col.Type.BaseType)
 	}
+	return getGoTypeFromColumnType(col.Type)
+}
 
+// writeGORMFile은 템플릿을 실행하고 gofmt한 결과를 출력 디렉토리에 씁니다.
+func writeGORMFile(opts Options, tmplName, fileName string, data interface{}) error {
+	tmplText, err := loadTemplate(opts.TemplateDir, tmplName)
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("gorm").Funcs(template.FuncMap{"lower": lowerFirst}).Parse(tmplText)
+	if err != nil {
+		return err
+	}
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
 		return err
 	}
-
-	outputFile := filepath.Join(opts.OutputDir, "schema.sql")
-	return os.WriteFile(outputFile, buf.Bytes(), 0644)
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated %s: %v", fileName, err)
+	}
+	return os.WriteFile(filepath.Join(opts.OutputDir, fileName), src, 0644)
 }
 
 // Helper structs and functions
 type goColumn struct {
-	Name   string
-	GoType string
-	Tags   string
+	Name       string
+	GoType     string
+	Tags       string
+	Deprecated string // 폐기 예정 안내 메시지
 }
 
-type goArrayField struct {
-	Name     string
-	BaseType string
+type goGeoField struct {
+	Name  string // 컬럼 이름
+	Index string // R*Tree 가상 테이블 이름
+	Query string // 범위 검색 쿼리
 }
 
 func getGoTypeFromColumnType(colType ColumnType) string {
+	if colType.IsGeo() {
+		return "Point"
+	}
+	if colType.IsCurve() {
+		return "Curve"
+	}
+	if colType.IsFormula() {
+		return "Formula"
+	}
+
 	switch colType.Type.Kind() {
 	case reflect.Int:
 		return "int"
@@ -236,102 +456,61 @@ func getGoTypeFromColumnType(colType ColumnType) string {
 	}
 }
 
-// buildGormTags generates GORM tag string from Column definition
-func buildGormTags(col Column) string {
-	var tags []string
+// isSheetKey는 컬럼이 시트의 키(인덱스 컬럼, 매트릭스는 행 키와 열 키)인지 반환합니다.
+func isSheetKey(table Table, i int) bool {
+	if table.IsMatrix {
+		return i < 2
+	}
+	return i == IndexColumn(table)
+}
 
-	// 1. Type tag from ColumnType
-	if col.Type.SQLType != "" {
-		tags = append(tags, fmt.Sprintf("type:%s", col.Type.SQLType))
+// buildGormTags generates GORM tag string from Column definition
+// storage is the table's datetimeStorage; epoch datetimes are INTEGER columns read through the epoch serializer (models.go)
+func buildGormTags(col Column, primaryKey bool, rules []StructTagRule, storage string) string {
+	// Column names match the sheet (and the SQLite exporter) instead of GORM's snake_case naming
+	tags := []string{"column:" + col.Name}
+
+	// 0. Sheet key as the primary key (modelKey=sheet); values come from the sheet, not autoincrement
+	if primaryKey {
+		tags = append(tags, "primaryKey")
+		if isIntColumn(col) {
+			tags = append(tags, "autoIncrement:false")
+		}
 	}
 
-	// 2. Size tag for array types (if needed)
+	// 1. Type tag from ColumnType; arrays are stored as JSON in a text field
 	if col.Type.IsArray {
-		tags = append(tags, "type:text") // Arrays are stored as JSON in text field
+		tags = append(tags, "type:text", "serializer:json")
+	} else if isEpochDateTime(col, storage) {
+		tags = append(tags, "type:INTEGER", "serializer:epoch")
+	} else if col.Type.SQLType != "" {
+		tags = append(tags, fmt.Sprintf("type:%s", col.Type.SQLType))
 	}
 
 	// 3. Process all tags with framework-specific conversion
 	for _, tagValue := range col.Tags {
+		// Columns sharing a named index form one composite index
+		if tagValue.Tag == TagIndex && tagValue.Value != "" {
+			tags = append(tags, "index:"+tagValue.Value)
+			continue
+		}
 		if gormTag := tagValue.GetFrameworkTag(FrameworkGorm); gormTag != "" {
 			tags = append(tags, gormTag)
 		}
 	}
 
 	// 6. Generate final tag string
-	if len(tags) > 0 {
-		return fmt.Sprintf(`gorm:"%s"`, strings.Join(tags, ";"))
-	}
+	structTags := []string{fmt.Sprintf(`gorm:"%s"`, strings.Join(tags, ";"))}
 
-	return ""
-}
+	// 7. Configured tags (#Config structTags); writeonly columns are left out of JSON
+	structTags = append(structTags, goStructTags(rules, col, true)...)
 
-func getSQLType(dbType string) string {
-	switch strings.ToLower(dbType) {
-	case "int", "integer":
-		return "integer"
-	case "int64", "bigint":
-		return "bigint"
-	case "float", "real":
-		return "real"
-	case "bool", "boolean":
-		return "boolean"
-	case "datetime":
-		return "datetime"
-	case "string", "text", "varchar":
-		return "text"
-	default:
-		return "text"
+	// 8. Validator annotation from min/max
+	if bounds, err := ColumnBounds(col); err == nil {
+		if rules := validatorTag(col, bounds); rules != "" {
+			structTags = append(structTags, fmt.Sprintf(`validate:"%s"`, rules))
+		}
 	}
-}
 
-// // Tag 정보를 파싱하고 GORM 태그로 변환하는 함수
-// func parseTagToGORMTag(tags []string) (string, map[string]string) {
-// 	tagKeyValue := make(map[string]string)
-// 	gormTags := make([]string, 0)
-
-// 	// 쉼표로 구분된 태그들을 처리
-// 	for _, tag := range tags {
-
-// 		// key=value 형태의 태그 처리
-// 		if strings.Contains(tag, "=") {
-// 			parts := strings.SplitN(tag, "=", 2)
-// 			key := strings.TrimSpace(parts[0])
-// 			value := strings.TrimSpace(parts[1])
-// 			tagKeyValue[key] = value
-
-// 			// GORM 태그로 변환
-// 			switch key {
-// 			case TagSize:
-// 				gormTags = append(gormTags, fmt.Sprintf("size:%s", value))
-// 			case TagDefault:
-// 				gormTags = append(gormTags, fmt.Sprintf("default:%s", value))
-// 			case TagForeignKey:
-// 				gormTags = append(gormTags, fmt.Sprintf("foreignKey:%s", value))
-// 			}
-// 		} else {
-// 			// 단일 태그 처리
-// 			tagKeyValue[tag] = "true"
-
-// 			// GORM 태그로 변환
-// 			switch tag {
-// 			case TagPrimaryKey:
-// 				gormTags = append(gormTags, "primaryKey")
-// 			case TagUnique:
-// 				gormTags = append(gormTags, "unique")
-// 			case TagIndex:
-// 				gormTags = append(gormTags, "index")
-// 			case TagNotNull:
-// 				gormTags = append(gormTags, "not null")
-// 			case TagAutoInc:
-// 				gormTags = append(gormTags, "autoIncrement")
-// 			}
-// 		}
-// 	}
-
-// 	gormTag := ""
-// 	if len(gormTags) > 0 {
-// 		gormTag = fmt.Sprintf(`gorm:"%s"`, strings.Join(gormTags, ";"))
-// 	}
-
-// 	return gormTag, tagKeyValue
-// }
+	return strings.Join(structTags, " ")
+}
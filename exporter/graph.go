@@ -0,0 +1,241 @@
+// exporter/graph.go
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// RefNode는 참조 그래프의 노드(테이블의 한 행)입니다.
+type RefNode struct {
+	Table string `json:"table"`
+	Key   string `json:"key"`
+}
+
+func (n RefNode) String() string {
+	return n.Table + ":" + n.Key
+}
+
+// 참조 간선의 출처
+const (
+	RefKindType     = "ref"      // ref<Table> 타입 컬럼
+	RefKindRelation = "relation" // #Relation의 외래 키 컬럼
+	RefKindString   = "string"   // 다른 행의 인덱스와 일치하는 문자열 셀
+)
+
+// RefEdge는 한 행의 셀이 다른 행을 참조하는 간선입니다.
+type RefEdge struct {
+	From   RefNode `json:"from"`
+	To     RefNode `json:"to"`
+	Column string  `json:"column"`
+	Kind   string  `json:"kind"`
+}
+
+// RefGraph는 실제 데이터 셀이 만드는 행 간의 참조 그래프입니다.
+type RefGraph struct {
+	Nodes []RefNode `json:"nodes"`
+	Edges []RefEdge `json:"edges"`
+
+	// Dangling은 존재하지 않는 행을 가리키는 ref<>/관계 참조입니다.
+	Dangling []RefEdge `json:"dangling,omitempty"`
+}
+
+// BuildRefGraph는 테이블 데이터를 분석해 행 간 참조 그래프를 만듭니다.
+//
+// 다음 셀을 참조로 취급합니다.
+//   - ref<Table> 타입 컬럼(배열 포함)의 값
+//   - #Relation의 belongsTo 외래 키 컬럼 값
+//   - 문자열 인덱스를 가진 테이블의 인덱스 값과 일치하는 문자열 셀,
+//     또는 "Table:Key" 형태의 문자열 셀 (id 형태의 문자열)
+func BuildRefGraph(tables []Table) RefGraph {
+	var graph RefGraph
+
+	// 테이블별 행 키 목록
+	keys := make(map[string]map[string]bool)
+	for _, table := range tables {
+		rows := make(map[string]bool, len(table.Rows))
+		for _, row := range table.Rows {
+			key := RowKey(table, row)
+			if key == "" {
+				continue
+			}
+			rows[key] = true
+			graph.Nodes = append(graph.Nodes, RefNode{Table: table.Name, Key: key})
+		}
+		keys[table.Name] = rows
+	}
+
+	// 문자열 인덱스 값 → 해당 행들 (id 형태 문자열 매칭용)
+	stringKeys := make(map[string][]RefNode)
+	for _, table := range tables {
+		if table.IsMatrix || len(table.Columns) == 0 {
+			continue
+		}
+		if table.Columns[IndexColumn(table)].Type.Type.Kind() != reflect.String {
+			continue
+		}
+		for key := range keys[table.Name] {
+			stringKeys[key] = append(stringKeys[key], RefNode{Table: table.Name, Key: key})
+		}
+	}
+
+	for _, table := range tables {
+		fkTargets := make(map[string]string)
+		for _, rel := range table.Relations {
+			if rel.RelationType == "belongsTo" {
+				fkTargets[rel.ForeignKey] = rel.TargetTable
+			}
+		}
+		indexIdx := IndexColumn(table)
+
+		for _, row := range table.Rows {
+			from := RefNode{Table: table.Name, Key: RowKey(table, row)}
+			if from.Key == "" {
+				continue
+			}
+
+			for c, col := range table.Columns {
+				if c == indexIdx || c >= len(row) || row[c] == nil {
+					continue
+				}
+
+				elemType := col.Type
+				if col.Type.IsArray && col.Type.BaseType != nil {
+					elemType = *col.Type.BaseType
+				}
+				target := elemType.RefTable
+				kind := RefKindType
+				if target == "" {
+					target, kind = fkTargets[col.Name], RefKindRelation
+				}
+
+				for _, value := range cellValues(col, row[c]) {
+					if target != "" {
+						edge := RefEdge{From: from, To: RefNode{Table: target, Key: value}, Column: col.Name, Kind: kind}
+						if keys[target][value] {
+							graph.Edges = append(graph.Edges, edge)
+						} else {
+							graph.Dangling = append(graph.Dangling, edge)
+						}
+						continue
+					}
+
+					if elemType.Type.Kind() != reflect.String {
+						continue
+					}
+					for _, to := range stringRefTargets(value, keys, stringKeys) {
+						if to != from {
+							graph.Edges = append(graph.Edges, RefEdge{From: from, To: to, Column: col.Name, Kind: RefKindString})
+						}
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(graph.Edges, func(i, j int) bool {
+		return graph.Edges[i].From.String() < graph.Edges[j].From.String()
+	})
+	return graph
+}
+
+// stringRefTargets는 문자열 셀 값이 가리키는 행들을 찾습니다.
+func stringRefTargets(value string, keys map[string]map[string]bool, stringKeys map[string][]RefNode) []RefNode {
+	// "Table:Key" 형태
+	if idx := strings.Index(value, ":"); idx > 0 {
+		table := formatTableName(value[:idx])
+		key := strings.TrimSpace(value[idx+1:])
+		if keys[table][key] {
+			return []RefNode{{Table: table, Key: key}}
+		}
+	}
+	return stringKeys[value]
+}
+
+// cellValues는 셀 값(배열 컬럼이면 원소들)을 문자열 목록으로 반환합니다.
+// 배열 값은 JSON 배열 텍스트로 저장되어 있습니다.
+func cellValues(col Column, value interface{}) []string {
+	if col.Type.IsArray {
+		text, ok := value.(string)
+		if !ok {
+			return nil
+		}
+		dec := json.NewDecoder(strings.NewReader(text))
+		dec.UseNumber()
+		var items []interface{}
+		if err := dec.Decode(&items); err != nil {
+			return nil
+		}
+		values := make([]string, 0, len(items))
+		for _, item := range items {
+			if s := fmt.Sprintf("%v", item); s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	if s := fmt.Sprintf("%v", value); s != "" {
+		return []string{s}
+	}
+	return nil
+}
+
+// Orphans는 아무 행도 참조하지 않는 행을 반환합니다.
+// 한 번이라도 참조 대상이 된 테이블의 행만 검사하며, roots에 포함된 테이블은
+// 진입점(퀘스트 목록 등)으로 보고 제외합니다. roots는 --tables처럼 시트 이름 그대로 적어도 되도록 정규화해서 비교합니다.
+func (g RefGraph) Orphans(roots map[string]bool) []RefNode {
+	rootTables := make(map[string]bool, len(roots))
+	for name, ok := range roots {
+		if ok {
+			rootTables[formatTableName(name)] = true
+		}
+	}
+
+	referenced := make(map[RefNode]bool)
+	targetTables := make(map[string]bool)
+	for _, edge := range g.Edges {
+		if edge.From.Table == edge.To.Table && edge.From.Key == edge.To.Key {
+			continue
+		}
+		referenced[edge.To] = true
+		targetTables[edge.To.Table] = true
+	}
+
+	var orphans []RefNode
+	for _, node := range g.Nodes {
+		if rootTables[node.Table] || !targetTables[node.Table] || referenced[node] {
+			continue
+		}
+		orphans = append(orphans, node)
+	}
+	return orphans
+}
+
+// WriteDOT은 그래프를 Graphviz DOT 형식으로 씁니다. 테이블마다 클러스터로 묶습니다.
+func (g RefGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph refs {\n  rankdir=LR;\n  node [shape=box];\n")
+
+	byTable := make(map[string][]RefNode)
+	for _, node := range g.Nodes {
+		byTable[node.Table] = append(byTable[node.Table], node)
+	}
+	for i, table := range sortedMapKeys(byTable) {
+		b.WriteString(fmt.Sprintf("  subgraph cluster_%d {\n    label=%q;\n", i, table))
+		for _, node := range byTable[table] {
+			b.WriteString(fmt.Sprintf("    %q [label=%q];\n", node.String(), node.Key))
+		}
+		b.WriteString("  }\n")
+	}
+	for _, edge := range g.Edges {
+		b.WriteString(fmt.Sprintf("  %q -> %q [label=%q];\n", edge.From.String(), edge.To.String(), edge.Column))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
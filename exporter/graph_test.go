@@ -0,0 +1,31 @@
+package exporter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrphansRoots(t *testing.T) {
+	// Monsterdrop:1은 아무도 참조하지 않지만, --roots에 시트 이름 그대로(MonsterDrop) 적으면 진입점으로 제외됨
+	graph := RefGraph{
+		Nodes: []RefNode{{"Monsterdrop", "1"}, {"Monsterdrop", "2"}, {"Item", "1"}},
+		Edges: []RefEdge{
+			{From: RefNode{"Monsterdrop", "1"}, To: RefNode{"Monsterdrop", "2"}, Column: "Next"},
+			{From: RefNode{"Monsterdrop", "2"}, To: RefNode{"Item", "1"}, Column: "Item"},
+		},
+	}
+	tests := []struct {
+		roots map[string]bool
+		want  []RefNode
+	}{
+		{nil, []RefNode{{"Monsterdrop", "1"}}},
+		{map[string]bool{"MonsterDrop": true}, nil},
+		{map[string]bool{" monsterdrop": true}, nil},
+		{map[string]bool{"Item": true}, []RefNode{{"Monsterdrop", "1"}}},
+	}
+	for _, tc := range tests {
+		if got := graph.Orphans(tc.roots); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Orphans(%v) = %v, want %v", tc.roots, got, tc.want)
+		}
+	}
+}
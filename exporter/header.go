@@ -0,0 +1,59 @@
+// exporter/header.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 배열이 아닌 컬럼 이름이 헤더에 반복될 때의 처리 (#Config의 duplicateHeaders 설정)
+const (
+	DuplicateHeadersError  = "error"  // 셀 위치와 함께 파싱 실패 (기본값)
+	DuplicateHeadersSuffix = "suffix" // 반복된 컬럼 이름 뒤에 2, 3, ... 을 붙임 (Reward, Reward2, Reward3)
+)
+
+var duplicateHeaderPolicies = []string{DuplicateHeadersError, DuplicateHeadersSuffix}
+
+// headerRules는 시트의 헤더를 파싱할 때 쓰는 설정입니다.
+// zero value는 중복 헤더를 에러로 보고하는 표준 배치 시트입니다.
+type headerRules struct {
+	pos        sheetPosition // 에러 메시지에 표시할 헤더 셀 위치
+	duplicates string        // 중복 헤더 처리 (DuplicateHeadersError, DuplicateHeadersSuffix)
+}
+
+// sheetHeaderRules는 #Config에서 시트의 헤더 설정을 읽습니다.
+func sheetHeaderRules(entries []ConfigEntry, sheetName string, pos sheetPosition) (headerRules, error) {
+	value := configValue(entries, sheetName, ConfigKeyDuplicateHeaders)
+	policy := strings.ToLower(strings.TrimSpace(value))
+	if policy == "" {
+		policy = DuplicateHeadersError
+	}
+	if !containsString(duplicateHeaderPolicies, policy) {
+		return headerRules{}, fmt.Errorf("unknown %s %q (expected %s)", ConfigKeyDuplicateHeaders, value, strings.Join(duplicateHeaderPolicies, ", "))
+	}
+	return headerRules{pos: pos, duplicates: policy}, nil
+}
+
+// headerNames는 헤더에서 이미 사용한 컬럼 이름과 그 셀의 열 인덱스입니다. 대소문자는 구분하지 않습니다.
+type headerNames map[string]int
+
+// claim은 idx 열의 컬럼 이름을 등록합니다. 이미 있는 이름이면 suffix 정책에서는 숫자를 붙인 이름을,
+// 아니면 두 셀의 위치를 담은 에러를 반환합니다.
+func (h headerNames) claim(name string, idx int, rules headerRules) (string, error) {
+	first, ok := h[strings.ToLower(name)]
+	if !ok {
+		h[strings.ToLower(name)] = idx
+		return name, nil
+	}
+	if rules.duplicates != DuplicateHeadersSuffix {
+		return "", fmt.Errorf("column %s: duplicate header in %s and %s (rename one of them, or set %s to %s in #Config)",
+			name, rules.pos.cell(0, first), rules.pos.cell(0, idx), ConfigKeyDuplicateHeaders, DuplicateHeadersSuffix)
+	}
+	for n := 2; ; n++ {
+		suffixed := fmt.Sprintf("%s%d", name, n)
+		if _, ok := h[strings.ToLower(suffixed)]; !ok {
+			h[strings.ToLower(suffixed)] = idx
+			return suffixed, nil
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// exporter/htmldiff.go
+package exporter
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+)
+
+// CellDiff는 행 하나의 컬럼 값 변경입니다. 추가된 행은 Old가, 삭제된 행은 New가 비어있습니다.
+type CellDiff struct {
+	Old     string
+	New     string
+	Changed bool
+}
+
+// RowDiff는 변경된 행 하나입니다. Cells는 TableDiff.Columns와 같은 순서입니다.
+type RowDiff struct {
+	Op    string // PatchInsert, PatchUpdate, PatchDelete
+	Key   string
+	Cells []CellDiff
+}
+
+// TableDiff는 테이블 하나의 행 변경 목록입니다.
+// Columns는 새 테이블의 컬럼 뒤에 삭제된 컬럼을 이어 붙인 것입니다.
+type TableDiff struct {
+	Name           string
+	Columns        []string
+	AddedColumns   []string
+	RemovedColumns []string
+	Rows           []RowDiff
+	Inserted       int
+	Updated        int
+	Deleted        int
+}
+
+// BuildTableDiffs는 두 테이블 목록을 비교하여 변경이 있는 테이블의 행 단위 변경을 반환합니다.
+// DiffTables와 같은 기준(인덱스 컬럼 값)으로 행을 짝지으며, 수정된 행은 이전 값과 새 값을 함께 담습니다.
+func BuildTableDiffs(oldTables, newTables []Table) []TableDiff {
+	oldMap := make(map[string]Table)
+	for _, table := range oldTables {
+		oldMap[table.Name] = table
+	}
+	newMap := make(map[string]Table)
+	for _, table := range newTables {
+		newMap[table.Name] = table
+	}
+
+	names := make(map[string]bool)
+	for name := range oldMap {
+		names[name] = true
+	}
+	for name := range newMap {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var diffs []TableDiff
+	for _, name := range sorted {
+		oldTable, oldExists := oldMap[name]
+		newTable, newExists := newMap[name]
+		diff := diffTable(name, oldTable, newTable, oldExists, newExists)
+		if len(diff.Rows) > 0 || len(diff.AddedColumns) > 0 || len(diff.RemovedColumns) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+func diffTable(name string, oldTable, newTable Table, oldExists, newExists bool) TableDiff {
+	diff := TableDiff{Name: name}
+
+	oldCols := make(map[string]int)
+	for i, col := range oldTable.Columns {
+		oldCols[col.Name] = i
+	}
+	newCols := make(map[string]int)
+	for i, col := range newTable.Columns {
+		newCols[col.Name] = i
+		diff.Columns = append(diff.Columns, col.Name)
+		if _, ok := oldCols[col.Name]; !ok && oldExists {
+			diff.AddedColumns = append(diff.AddedColumns, col.Name)
+		}
+	}
+	for _, col := range oldTable.Columns {
+		if _, ok := newCols[col.Name]; !ok {
+			diff.Columns = append(diff.Columns, col.Name)
+			if newExists {
+				diff.RemovedColumns = append(diff.RemovedColumns, col.Name)
+			}
+		}
+	}
+
+	cell := func(cols map[string]int, row []interface{}, name string) string {
+		i, ok := cols[name]
+		if !ok || i >= len(row) || row[i] == nil {
+			return ""
+		}
+		return fmt.Sprintf("%v", row[i])
+	}
+
+	oldRows := make(map[string][]interface{})
+	for _, row := range oldTable.Rows {
+		oldRows[RowKey(oldTable, row)] = row
+	}
+
+	seen := make(map[string]bool)
+	for _, row := range newTable.Rows {
+		key := RowKey(newTable, row)
+		seen[key] = true
+
+		oldRow, ok := oldRows[key]
+		rowDiff := RowDiff{Op: PatchUpdate, Key: key}
+		if !ok {
+			rowDiff.Op = PatchInsert
+		}
+
+		changed := false
+		for _, col := range diff.Columns {
+			c := CellDiff{New: cell(newCols, row, col)}
+			if ok {
+				c.Old = cell(oldCols, oldRow, col)
+			}
+			c.Changed = c.Old != c.New
+			changed = changed || c.Changed
+			rowDiff.Cells = append(rowDiff.Cells, c)
+		}
+
+		switch {
+		case !ok:
+			diff.Inserted++
+		case changed:
+			diff.Updated++
+		default:
+			continue
+		}
+		diff.Rows = append(diff.Rows, rowDiff)
+	}
+
+	for _, row := range oldTable.Rows {
+		key := RowKey(oldTable, row)
+		if seen[key] {
+			continue
+		}
+		rowDiff := RowDiff{Op: PatchDelete, Key: key}
+		for _, col := range diff.Columns {
+			old := cell(oldCols, row, col)
+			rowDiff.Cells = append(rowDiff.Cells, CellDiff{Old: old, Changed: old != ""})
+		}
+		diff.Rows = append(diff.Rows, rowDiff)
+		diff.Deleted++
+	}
+
+	return diff
+}
+
+// WriteHTMLDiff는 테이블 변경을 하나의 HTML 문서로 씁니다. CI에서 데이터 PR 리뷰용 아티팩트로 사용합니다.
+// 추가된 행은 초록색, 삭제된 행은 빨간색으로 표시하고, 수정된 행은 바뀐 셀만 이전 값과 새 값을 함께 보여줍니다.
+func WriteHTMLDiff(w io.Writer, title string, diffs []TableDiff) error {
+	tmplText, err := loadTemplate("", "htmldiff/diff.html.tmpl")
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("diff").Parse(tmplText)
+	if err != nil {
+		return fmt.Errorf("failed to parse HTML diff template: %v", err)
+	}
+	return tmpl.Execute(w, struct {
+		Title  string
+		Tables []TableDiff
+	}{title, diffs})
+}
@@ -0,0 +1,237 @@
+// exporter/identifier.go
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultIdentifierMapFile은 이름 매핑 파일의 기본 경로입니다. 파일이 없으면 이름을 바꾸지 않습니다.
+const DefaultIdentifierMapFile = "excelite.names.json"
+
+// IdentifierMap은 한글, 일본어 등으로 작성된 시트와 컬럼 이름을 생성 코드의 식별자로 바꾸는 설정입니다.
+// 파일은 {"romanize": true, "tables": {"아이템": "Item"}, "columns": {"이름": "Name", "아이템.공격력": "Attack"}} 형식의 JSON입니다.
+// 컬럼 키는 모든 테이블에 적용되는 컬럼 이름이거나 한 테이블에만 적용되는 "테이블.컬럼"이며, 테이블 키는 시트 이름이나 테이블 이름입니다.
+type IdentifierMap struct {
+	Romanize bool              `json:"romanize,omitempty"` // 매핑에 없는 한글, 가나 이름을 로마자로 바꿈 (Romanize)
+	Tables   map[string]string `json:"tables,omitempty"`
+	Columns  map[string]string `json:"columns,omitempty"`
+}
+
+// LoadIdentifierMap은 이름 매핑 파일을 읽습니다.
+func LoadIdentifierMap(path string) (IdentifierMap, error) {
+	var m IdentifierMap
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return m, fmt.Errorf("failed to read names file: %v", err)
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return m, fmt.Errorf("failed to parse names file %s: %v", path, err)
+	}
+	for _, key := range sortedMapKeys(m.Tables) {
+		if formatTableName(m.Tables[key]) == "" {
+			return m, fmt.Errorf("%s: table %s is mapped to an empty name", path, key)
+		}
+	}
+	for _, key := range sortedMapKeys(m.Columns) {
+		if ParseColumnName(m.Columns[key]) == "" {
+			return m, fmt.Errorf("%s: column %s is mapped to an empty name", path, key)
+		}
+	}
+	return m, nil
+}
+
+// IsZero는 바꿀 이름이 없는 매핑인지 반환합니다.
+func (m IdentifierMap) IsZero() bool {
+	return !m.Romanize && len(m.Tables) == 0 && len(m.Columns) == 0
+}
+
+// tableName은 테이블의 새 이름입니다.
+func (m IdentifierMap) tableName(table Table) string {
+	for _, key := range []string{table.SheetName, table.Name} {
+		if name, ok := m.Tables[key]; ok {
+			return formatTableName(name)
+		}
+	}
+	return m.romanized(table.Name)
+}
+
+// columnName은 테이블 컬럼의 새 이름입니다. "테이블.컬럼" 키가 컬럼 이름 키보다 우선합니다.
+func (m IdentifierMap) columnName(table Table, col Column) string {
+	for _, key := range []string{table.SheetName + "." + col.Name, table.Name + "." + col.Name, col.Name} {
+		if name, ok := m.Columns[key]; ok {
+			return ParseColumnName(name)
+		}
+	}
+	return m.romanized(col.Name)
+}
+
+func (m IdentifierMap) romanized(name string) string {
+	if !m.Romanize || !hasRomanizable(name) {
+		return name
+	}
+	romanized, _ := Romanize(name)
+	return romanized
+}
+
+// Apply는 테이블과 컬럼 이름을 매핑대로 바꿉니다.
+// 다른 테이블의 ref<> 타입, 관계, 뷰의 테이블 이름과 관계의 키 컬럼도 새 이름을 따라갑니다. 직접 작성한 뷰 SQL은 바꾸지 않습니다.
+// 서로 다른 이름이 같은 이름으로 바뀌면 에러입니다.
+func (m IdentifierMap) Apply(tables []Table) ([]Table, error) {
+	if m.IsZero() {
+		return tables, nil
+	}
+
+	result := make([]Table, len(tables))
+	columnRenames := make(map[string]map[string]string) // 원래 테이블 이름 → 원래 컬럼 이름 → 새 이름
+	for i, table := range tables {
+		renames := make(map[string]string)
+		seen := make(map[string]string, len(table.Columns))
+		columns := make([]Column, len(table.Columns))
+		for j, col := range table.Columns {
+			name := m.columnName(table, col)
+			if prev, ok := seen[strings.ToLower(name)]; ok {
+				return nil, fmt.Errorf("table %s: columns %s and %s are both renamed to %s", table.Name, prev, col.Name, name)
+			}
+			seen[strings.ToLower(name)] = col.Name
+			if name != col.Name {
+				renames[col.Name] = name
+				table.Source = table.Source.renameColumn(col.Name, name)
+				col.Name = name
+			}
+			columns[j] = col
+		}
+		table.Columns = columns
+		columnRenames[table.Name] = renames
+		result[i] = table
+	}
+
+	tableRenames := make(map[string]string)
+	seen := make(map[string]string, len(result))
+	for _, table := range result {
+		name := m.tableName(table)
+		if prev, ok := seen[name]; ok {
+			return nil, fmt.Errorf("tables %s and %s are both renamed to %s", prev, table.Name, name)
+		}
+		seen[name] = table.Name
+		if name != table.Name {
+			tableRenames[table.Name] = name
+		}
+	}
+
+	for i := range result {
+		relations := make([]Relation, len(result[i].Relations))
+		for j, rel := range result[i].Relations {
+			if name, ok := columnRenames[rel.SourceTable][rel.ForeignKey]; ok {
+				rel.ForeignKey = name
+			}
+			if name, ok := columnRenames[rel.TargetTable][rel.ReferenceKey]; ok {
+				rel.ReferenceKey = name
+			}
+			relations[j] = rel
+		}
+		result[i].Relations = relations
+
+		if name, ok := tableRenames[result[i].Name]; ok {
+			result[i].Name = name
+		}
+		renameTableReferences(&result[i], tableRenames)
+	}
+	return result, nil
+}
+
+// 생성 코드의 식별자 규칙을 확인하는 언어
+const (
+	IdentifierGo         = "go"
+	IdentifierTypeScript = "typescript"
+	IdentifierCSharp     = "csharp"
+	IdentifierCpp        = "cpp"
+)
+
+// exporterIdentifiers는 exporter가 테이블과 컬럼 이름으로 식별자를 만드는 코드의 언어입니다.
+// JSON처럼 코드를 만들지 않는 exporter와 이름을 감싸서 쓰는 SQL은 확인하지 않습니다.
+var exporterIdentifiers = map[string][]string{
+	"go":      {IdentifierGo},
+	"ent":     {IdentifierGo},
+	"sqlx":    {IdentifierGo},
+	"admin":   {IdentifierGo},
+	"zod":     {IdentifierTypeScript},
+	"typebox": {IdentifierTypeScript},
+	"nodejs":  {IdentifierTypeScript},
+}
+
+// IdentifierLanguages는 exporter 언어들이 생성하는 코드의 언어 목록입니다.
+// sqlite exporter는 queries 옵션(쿼리 레이어 언어)에 따라 정해집니다.
+func IdentifierLanguages(exporters []string, queries string) []string {
+	var langs []string
+	add := func(list ...string) {
+		for _, lang := range list {
+			if !containsString(langs, lang) {
+				langs = append(langs, lang)
+			}
+		}
+	}
+	for _, name := range exporters {
+		name = strings.TrimSpace(name)
+		if name == "sqlite" {
+			queryLangs, _ := parseQueryLanguages(queries)
+			add(queryLangs...)
+			continue
+		}
+		add(exporterIdentifiers[name]...)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// legalIdentifier는 이름이 언어의 식별자로 쓸 수 있는지 확인합니다. 쓸 수 없으면 이유를 반환합니다.
+// Go는 다른 패키지에서 쓸 수 있도록 대문자로 시작해야 하며, C++은 컴파일러마다 지원이 달라 ASCII만 허용합니다.
+func legalIdentifier(lang, name string) (string, bool) {
+	if name == "" {
+		return "empty name", false
+	}
+	for i, r := range name {
+		letter := unicode.IsLetter(r) || r == '_' || (r == '$' && lang == IdentifierTypeScript)
+		switch {
+		case lang == IdentifierCpp && r > unicode.MaxASCII:
+			return fmt.Sprintf("non-ASCII character %q", r), false
+		case i == 0 && lang == IdentifierGo && !unicode.IsUpper(r):
+			return "does not start with an upper-case letter, so it is not exported", false
+		case i == 0 && !letter:
+			return fmt.Sprintf("starts with %q", r), false
+		case !letter && !unicode.IsDigit(r):
+			return fmt.Sprintf("contains %q", r), false
+		}
+	}
+	return "", true
+}
+
+// ValidateIdentifiers는 테이블과 컬럼 이름이 langs의 모든 언어에서 식별자로 쓸 수 있는지 확인합니다.
+func ValidateIdentifiers(tables []Table, langs []string) []error {
+	var errs []error
+	check := func(what, name string) {
+		for _, lang := range langs {
+			if reason, ok := legalIdentifier(lang, name); !ok {
+				hint := ""
+				if _, ok := Romanize(name); !ok {
+					hint = " (map it in " + DefaultIdentifierMapFile + ")"
+				} else if hasRomanizable(name) {
+					hint = " (map it in " + DefaultIdentifierMapFile + " or set \"romanize\": true)"
+				}
+				errs = append(errs, fmt.Errorf("%s is not a legal %s identifier: %s%s", what, lang, reason, hint))
+				return
+			}
+		}
+	}
+	for _, table := range tables {
+		check("table "+table.Name, table.Name)
+		for _, col := range table.Columns {
+			check("column "+table.Name+"."+col.Name, col.Name)
+		}
+	}
+	return errs
+}
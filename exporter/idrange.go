@@ -0,0 +1,151 @@
+// exporter/idrange.go
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultIDRangesFile은 워크북별 인덱스 범위 설정 파일의 기본 경로입니다.
+const DefaultIDRangesFile = "excelite.ids.json"
+
+// IDRange는 워크북(과 테이블)에 배정된 인덱스 범위입니다. (예: DLC 워크북의 행은 10000부터, 전투 팀 워크북은 1000~1999)
+// 워크북의 행 인덱스는 배정된 범위 안에 있어야 하며, 벗어나면 검증 에러입니다.
+type IDRange struct {
+	Workbook string   `json:"workbook"`         // 워크북 파일 이름 (glob 패턴 허용, 예: dlc_*.xlsx)
+	Tables   []string `json:"tables,omitempty"` // 범위를 적용할 테이블 (비어있으면 모든 테이블)
+	Owner    string   `json:"owner,omitempty"`  // 범위를 소유한 팀 (에러 메시지에 표시)
+	Start    int64    `json:"start"`
+	End      int64    `json:"end,omitempty"` // 0이면 상한 없음
+}
+
+// Contains는 인덱스가 범위 안에 있는지 반환합니다.
+func (r IDRange) Contains(id int64) bool {
+	return id >= r.Start && (r.End == 0 || id <= r.End)
+}
+
+func (r IDRange) String() string {
+	if r.End == 0 {
+		return fmt.Sprintf("%d..", r.Start)
+	}
+	return fmt.Sprintf("%d..%d", r.Start, r.End)
+}
+
+// matches는 범위가 워크북의 테이블에 적용되는지 반환합니다.
+func (r IDRange) matches(workbook, table string) bool {
+	if ok, _ := filepath.Match(r.Workbook, filepath.Base(workbook)); !ok && r.Workbook != workbook {
+		return false
+	}
+	if len(r.Tables) == 0 {
+		return true
+	}
+	for _, name := range r.Tables {
+		if formatTableName(name) == table {
+			return true
+		}
+	}
+	return false
+}
+
+// IDRangePolicy는 워크북별 인덱스 범위 목록입니다.
+// 파일은 {"ranges": [{"workbook": "combat.xlsx", "owner": "combat", "start": 1000, "end": 1999}, ...]} 형식의 JSON입니다.
+type IDRangePolicy struct {
+	Ranges []IDRange `json:"ranges"`
+}
+
+// LoadIDRangePolicy는 인덱스 범위 설정 파일을 읽습니다.
+func LoadIDRangePolicy(path string) (IDRangePolicy, error) {
+	var policy IDRangePolicy
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return policy, fmt.Errorf("failed to read ID ranges file: %v", err)
+	}
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return policy, fmt.Errorf("failed to parse ID ranges file %s: %v", path, err)
+	}
+	for i, r := range policy.Ranges {
+		if strings.TrimSpace(r.Workbook) == "" {
+			return policy, fmt.Errorf("%s: range %d has no workbook", path, i+1)
+		}
+		if _, err := filepath.Match(r.Workbook, ""); err != nil {
+			return policy, fmt.Errorf("%s: range %d: bad workbook pattern %q: %v", path, i+1, r.Workbook, err)
+		}
+		if r.End != 0 && r.End < r.Start {
+			return policy, fmt.Errorf("%s: range %d (%s) ends before it starts", path, i+1, r.Workbook)
+		}
+		// 다른 워크북에 배정된 범위가 겹치면 두 워크북이 같은 인덱스를 쓸 수 있음
+		for j, o := range policy.Ranges[:i] {
+			if o.Workbook != r.Workbook && sharesTables(o, r) && o.overlaps(r) {
+				return policy, fmt.Errorf("%s: range %d (%s %s) overlaps range %d (%s %s)", path, i+1, r.Workbook, r, j+1, o.Workbook, o)
+			}
+		}
+	}
+	return policy, nil
+}
+
+func (r IDRange) overlaps(o IDRange) bool {
+	return (r.End == 0 || o.Start <= r.End) && (o.End == 0 || r.Start <= o.End)
+}
+
+// sharesTables는 두 범위가 같은 테이블에 적용될 수 있는지 반환합니다.
+func sharesTables(a, b IDRange) bool {
+	if len(a.Tables) == 0 || len(b.Tables) == 0 {
+		return true
+	}
+	for _, x := range a.Tables {
+		for _, y := range b.Tables {
+			if formatTableName(x) == formatTableName(y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RangeFor는 워크북의 테이블에 적용되는 첫 번째 범위를 반환합니다.
+func (p IDRangePolicy) RangeFor(workbook, table string) (IDRange, bool) {
+	for _, r := range p.Ranges {
+		if r.matches(workbook, table) {
+			return r, true
+		}
+	}
+	return IDRange{}, false
+}
+
+// Validate는 범위가 배정된 워크북의 정수 인덱스가 모두 범위 안에 있는지 확인하고, 벗어난 행을 모두 보고합니다.
+// 설정 시트와 매트릭스 시트는 검사하지 않습니다.
+func (p IDRangePolicy) Validate(tables []Table) []error {
+	var errs []error
+	for _, table := range tables {
+		if table.IsSettings || table.IsMatrix || len(table.Columns) == 0 || !isIntColumn(table.Columns[IndexColumn(table)]) {
+			continue
+		}
+		workbook, _ := tableWorkbook(table)
+		r, ok := p.RangeFor(workbook, table.Name)
+		if !ok {
+			continue
+		}
+		owner := r.Workbook
+		if r.Owner != "" {
+			owner = r.Owner
+		}
+
+		idx := IndexColumn(table)
+		for _, row := range table.Rows {
+			if idx >= len(row) {
+				continue
+			}
+			id, ok := toInt64(row[idx])
+			if !ok || r.Contains(id) {
+				continue
+			}
+			location, _ := table.Source.Location(RowKey(table, row))
+			errs = append(errs, fmt.Errorf("table %s %s (%s): index %d is outside the ID range %s reserved for %s",
+				table.Name, location, filepath.Base(workbook), id, r, owner))
+		}
+	}
+	return errs
+}
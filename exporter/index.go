@@ -0,0 +1,121 @@
+// exporter/index.go
+package exporter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CompositeIndex는 여러 컬럼에 같은 이름의 index:<이름> 태그를 붙여 선언한 인덱스입니다.
+// 컬럼 순서는 시트의 컬럼 순서이므로, 조회 조건에 항상 들어가는 컬럼을 앞에 둡니다.
+type CompositeIndex struct {
+	Name    string
+	Columns []int // 테이블 컬럼 위치
+}
+
+var indexNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// isSingleIndexed는 컬럼이 값 없는 index 태그로 단일 컬럼 인덱스를 가지는지 반환합니다.
+func isSingleIndexed(col Column) bool {
+	for _, tag := range col.Tags {
+		if tag.Tag == TagIndex && strings.TrimSpace(tag.Value) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CompositeIndexes는 테이블의 이름 있는 인덱스들을 처음 나온 순서로 반환합니다.
+// 한 컬럼이 여러 인덱스에 속할 수 있습니다. (예: index:idx_type_rarity,index:idx_type_level)
+func CompositeIndexes(table Table) []CompositeIndex {
+	var result []CompositeIndex
+	byName := make(map[string]int)
+	for i, col := range table.Columns {
+		for _, tag := range col.Tags {
+			name := strings.TrimSpace(tag.Value)
+			if tag.Tag != TagIndex || name == "" {
+				continue
+			}
+			n, ok := byName[name]
+			if !ok {
+				n = len(result)
+				byName[name] = n
+				result = append(result, CompositeIndex{Name: name})
+			}
+			if !containsInt(result[n].Columns, i) {
+				result[n].Columns = append(result[n].Columns, i)
+			}
+		}
+	}
+	return result
+}
+
+// ColumnNames는 인덱스의 컬럼 이름들입니다.
+func (idx CompositeIndex) ColumnNames(table Table) []string {
+	names := make([]string, len(idx.Columns))
+	for i, c := range idx.Columns {
+		names[i] = table.Columns[c].Name
+	}
+	return names
+}
+
+// SingleIndexName은 단일 컬럼 인덱스의 이름(idx_<테이블>_<컬럼>)입니다.
+// 따옴표로 감싸기 전의 이름으로 만들며, SQL에 쓸 때 이름 전체를 감쌉니다.
+func SingleIndexName(table, column string) string {
+	return fmt.Sprintf("idx_%s_%s", table, column)
+}
+
+// buildIndexQueries는 테이블의 CREATE INDEX 문들을 만듭니다.
+// 단일 컬럼 인덱스(index 태그, belongsTo 외래 키)는 SingleIndexName, 이름 있는 인덱스는 태그의 이름을 씁니다.
+func buildIndexQueries(table Table, d SQLDialect) []string {
+	quotedTableName := d.QuoteIdentifier(table.Name)
+	single := func(column string) string {
+		return fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s(%s);",
+			d.QuoteIdentifier(SingleIndexName(table.Name, column)), quotedTableName, d.QuoteIdentifier(column))
+	}
+
+	var queries []string
+	for _, col := range table.Columns {
+		if isSingleIndexed(col) {
+			queries = append(queries, single(col.Name))
+		}
+	}
+	for _, idx := range CompositeIndexes(table) {
+		queries = append(queries, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s(%s);",
+			d.QuoteIdentifier(idx.Name), quotedTableName, strings.Join(quoteIdentifiers(d, idx.ColumnNames(table)), ", ")))
+	}
+	for _, rel := range table.Relations {
+		if rel.RelationType == "belongsTo" {
+			queries = append(queries, single(rel.ForeignKey))
+		}
+	}
+	return queries
+}
+
+// validateIndexes는 이름 있는 인덱스의 이름을 확인합니다.
+// SQLite에서 인덱스 이름은 DB 전체에서 유일하므로 다른 테이블과 같은 이름을 쓸 수 없습니다.
+func validateIndexes(tables []Table) []error {
+	var errs []error
+	owners := make(map[string]string)
+	for _, table := range tables {
+		for _, idx := range CompositeIndexes(table) {
+			if !indexNamePattern.MatchString(idx.Name) {
+				errs = append(errs, fmt.Errorf("table %s: invalid index name %q (letters, digits and _)", table.Name, idx.Name))
+				continue
+			}
+			key := strings.ToLower(idx.Name)
+			if owner, ok := owners[key]; ok && owner != table.Name {
+				errs = append(errs, fmt.Errorf("table %s: index %s is already declared on table %s", table.Name, idx.Name, owner))
+				continue
+			}
+			owners[key] = table.Name
+			for _, c := range idx.Columns {
+				if table.Columns[c].Type.IsArray {
+					errs = append(errs, fmt.Errorf("table %s: index %s: array column %s cannot be indexed", table.Name, idx.Name, table.Columns[c].Name))
+				}
+			}
+		}
+	}
+	return errs
+}
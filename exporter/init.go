@@ -9,12 +9,64 @@ func init() {
 	}, Options{
 		PackageName: "models",
 		ExtraOptions: map[string]interface{}{
-			"useGorm":      true,
-			"useSQLite":    true,
-			"generateRepo": true,
+			OptGoUseGorm:      true,
+			OptGoUseSQLite:    true,
+			OptGoGenerateRepo: true,
+			OptModelKey:       DefaultAuditPolicy.Key,
+			OptTimestamps:     DefaultAuditPolicy.Timestamps,
+			OptSoftDelete:     DefaultAuditPolicy.SoftDelete,
 		},
 	})
 
+	// ent Exporter 등록
+	Register("ent", func() Exporter {
+		return NewEntExporter()
+	}, Options{})
+
+	// sqlx Exporter 등록 (ORM 없는 Go 코드)
+	Register("sqlx", func() Exporter {
+		return NewSQLXExporter()
+	}, Options{
+		PackageName: "models",
+	})
+
+	// JSON 데이터 Exporter 등록
+	Register("json", func() Exporter {
+		return NewJSONExporter()
+	}, Options{})
+
+	// 컬럼 단위 바이너리 Exporter 등록 (compress 태그 적용)
+	Register("binary", func() Exporter {
+		return NewBinaryExporter()
+	}, Options{
+		PackageName: "models",
+	})
+
+	// 표시용 CSV/HTML Exporter 등록
+	Register("display", func() Exporter {
+		return NewDisplayExporter()
+	}, Options{})
+
+	// 가챠 확률 공개 보고서 Exporter 등록
+	Register("gacha", func() Exporter {
+		return NewGachaExporter()
+	}, Options{})
+
+	// 관리자 웹 UI Exporter 등록
+	Register("admin", func() Exporter {
+		return NewAdminExporter()
+	}, Options{
+		PackageName: "models",
+	})
+
+	// TypeScript 검증 스키마 Exporter 등록
+	Register("zod", func() Exporter {
+		return NewZodExporter()
+	}, Options{})
+	Register("typebox", func() Exporter {
+		return NewTypeBoxExporter()
+	}, Options{})
+
 	// // C++ Exporter 등록
 	// Register("cpp", func() Exporter {
 	// 	return NewCppExporter()
@@ -27,18 +79,19 @@ func init() {
 	// 	},
 	// })
 
-	// // Node.js Exporter 등록
-	// Register("nodejs", func() Exporter {
-	// 	return NewNodeJSExporter()
-	// }, Options{
-	// 	PackageName: "models",
-	// 	ExtraOptions: map[string]interface{}{
-	// 		"useTypeORM":         true,
-	// 		"useTypeScript":      true,
-	// 		"generateMigrations": true,
-	// 		"decoratorStyle":     "experimental", // experimental or legacy
-	// 	},
-	// })
+	// Node.js(TypeORM) Exporter 등록
+	Register("nodejs", func() Exporter {
+		return NewNodeJSExporter()
+	}, Options{
+		PackageName: "models",
+		ExtraOptions: map[string]interface{}{
+			OptNodeUseTypeORM: true,
+			OptNodeSeed:       true,
+			OptModelKey:       DefaultAuditPolicy.Key,
+			OptTimestamps:     DefaultAuditPolicy.Timestamps,
+			OptSoftDelete:     DefaultAuditPolicy.SoftDelete,
+		},
+	})
 }
 
 // 언어별 기능을 쉽게 켜고 끌 수 있는 옵션 상수들
@@ -48,6 +101,11 @@ const (
 	OptGoUseSQLite    = "useSQLite"
 	OptGoGenerateRepo = "generateRepo"
 
+	// ORM 모델에 주입하는 키/감사 컬럼 (AuditPolicy)
+	OptModelKey   = "modelKey"   // 기본 키: id (자동 증가 id 주입) 또는 sheet (시트의 인덱스 컬럼)
+	OptTimestamps = "timestamps" // created_at/updated_at 타입: time, unix, unixmilli, none
+	OptSoftDelete = "softDelete" // deleted_at을 주입해 soft delete 사용
+
 	// C++ options
 	OptCppUseSQLite    = "useSQLite"
 	OptCppUsePointers  = "usePointers"
@@ -57,6 +115,46 @@ const (
 	OptNodeUseTypeORM = "useTypeORM"
 	OptNodeTypeScript = "useTypeScript"
 	OptNodeMigrations = "generateMigrations"
+	OptNodeSeed       = "seed" // 시트의 행을 넣는 seed.ts와 seed/<Table>.json 생성
+
+	// SQLite options
+	OptSQLiteIncremental  = "incremental"  // 기존 DB를 유지하고 주어진 테이블만 다시 생성
+	OptSQLiteStrict       = "strict"       // STRICT 테이블로 생성 (SQLite 3.37+)
+	OptSQLiteWithoutRowID = "withoutRowid" // 인덱스 컬럼을 기본 키로 하는 WITHOUT ROWID 테이블로 생성
+	OptSQLiteQuoteAll     = "quoteAll"     // 테이블, 컬럼, 인덱스 이름을 예약어가 아니어도 항상 큰따옴표로 감쌈
+	OptSQLiteQueries      = "queries"      // 쿼리 레이어를 생성할 언어 (쉼표로 구분: go, cpp, csharp)
+	OptSQLiteMaster       = "master"       // 그룹 DB(팩)를 ATTACH해서 팩 간 뷰로 함께 조회하는 마스터 DB 생성
+	OptSQLiteBeforeSchema = "beforeSchema" // 테이블 생성 전에 실행하고 schema.sql에 넣을 SQL 파일
+	OptSQLiteAfterSchema  = "afterSchema"  // 테이블 생성 후, 데이터 적재 전에 실행하고 schema.sql에 넣을 SQL 파일
+	OptSQLiteAfterData    = "afterData"    // 데이터 적재 후에 실행하고 schema.sql 끝에 넣을 SQL 파일
+	OptSQLitePrune        = "prune"        // Go 쿼리 레이어를 이 디렉토리의 Go 코드가 사용하는 테이블과 조회 함수만으로 생성
+
+	// sqlx options
+	OptSQLXReload    = "reload"    // 메모리 Dataset과 자동으로 다시 읽는 Store(dataset.go) 생성
+	OptSQLXColumnar  = "columnar"  // 테이블별 컬럼 슬라이스 타입(columns.go) 생성
+	OptSQLXGeneric   = "generic"   // 제네릭 Table[K, T] 컨테이너와 테이블별 로더(table.go) 생성
+	OptSQLXCache     = "cache"     // 키 조회 결과를 LRU로 캐시하는 Cache(cache.go) 생성, 값은 조회별 기본 행 수
+	OptSQLXHooks     = "hooks"     // 로더의 조회를 알리는 QueryHook(hooks.go) 생성
+	OptSQLXOTel      = "otel"      // OpenTelemetry QueryHook(otel.go) 생성, hooks 포함
+	OptSQLXInterface = "interface" // 모든 행 타입이 구현하는 공통 인터페이스(interfaces.go)의 이름
+	OptSQLXMarkers   = "markers"   // 테이블별 마커 인터페이스(interfaces.go) 생성
+
+	// 정수 타입 축소 (sqlite 쿼리 레이어, sqlx 공통)
+	OptNarrowInts = "narrowInts" // 정수 컬럼을 데이터 범위에 맞는 가장 작은 타입(uint8, int16 등)으로 생성
+
+	// JSON options
+	OptJSONKeyed = "keyed" // 배열 대신 인덱스 컬럼 값을 키로 하는 객체로 출력하고 groupby 컬럼별 보조 맵 생성
+
+	// Display options
+	OptDisplayLocale  = "locale"  // 텍스트와 숫자 형식의 언어 코드 (기본값: #Locale 시트의 첫 번째 언어)
+	OptDisplayFormats = "formats" // 출력 형식 (쉼표로 구분: csv, html)
+
+	// Artifact options (모든 exporter 공통)
+	OptEncrypt       = "encrypt"
+	OptEncryptKeyEnv = "encryptKeyEnv"
+	OptCompress      = "compress"      // "gzip" 또는 "zstd"
+	OptCompressLevel = "compressLevel" // 압축 레벨 (0이면 기본값)
+	OptVerify        = "verify"        // export 후 .db, .json 산출물을 다시 읽어 시트 값과 셀 단위로 비교
 )
 
 // GenerateAll은 모든 지원 언어에 대해 코드를 생성합니다.
@@ -0,0 +1,168 @@
+// exporter/intern.go
+package exporter
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// StringTableName은 intern 태그가 붙은 컬럼의 문자열을 한 번씩만 저장하는 SQLite 테이블입니다.
+// 같은 DB의 모든 테이블이 공유하며, intern 컬럼에는 이 테이블의 id가 저장됩니다.
+const StringTableName = "_strings"
+
+// isInterned는 컬럼 값을 문자열 테이블의 id로 저장하는지 반환합니다.
+func isInterned(col Column) bool {
+	return HasTag(col.Tags, TagIntern)
+}
+
+// hasInternedColumns는 테이블 중 intern 컬럼이 있는지 반환합니다.
+func hasInternedColumns(tables []Table) bool {
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			if isInterned(col) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// buildStringTableQuery는 문자열 테이블의 CREATE TABLE 문입니다.
+func buildStringTableQuery(strict bool, d SQLDialect) string {
+	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n  id INTEGER PRIMARY KEY,\n  value TEXT NOT NULL UNIQUE)", d.QuoteIdentifier(StringTableName))
+	if strict {
+		query += " STRICT"
+	}
+	return query + ";\n"
+}
+
+// internedExpr는 intern 컬럼을 문자열로 되돌리는 SELECT 식입니다. (NULL은 빈 문자열)
+func internedExpr(table Table, col Column, d SQLDialect) string {
+	return fmt.Sprintf("COALESCE((SELECT %s.value FROM %s WHERE %s.id = %s.%s), '')",
+		d.QuoteIdentifier(StringTableName), d.QuoteIdentifier(StringTableName), d.QuoteIdentifier(StringTableName),
+		d.QuoteIdentifier(table.Name), d.QuoteIdentifier(col.Name))
+}
+
+// internedCondition은 intern 컬럼을 문자열 파라미터와 비교하는 WHERE 조건입니다.
+func internedCondition(col Column, param string, d SQLDialect) string {
+	return fmt.Sprintf("%s = (SELECT id FROM %s WHERE value = %s)", d.QuoteIdentifier(col.Name), d.QuoteIdentifier(StringTableName), param)
+}
+
+// validateIntern은 intern 태그가 중복이 많은 일반 문자열 컬럼에만 붙었는지 확인합니다.
+// 키, 외래 키, 기본값, 길이 제약, collation은 저장된 값(id)에 적용되므로 함께 쓸 수 없고,
+// 바이너리 포맷에서 intern은 컬럼 인코딩이므로 compress 태그와 함께 쓸 수 없습니다.
+func validateIntern(table Table) []error {
+	var errs []error
+	for i, col := range table.Columns {
+		if !isInterned(col) {
+			continue
+		}
+		reason := ""
+		switch {
+		case col.Type.IsArray || col.Type.IsGeo() || col.Type.Type != StringType.Type:
+			reason = "only string columns can be interned"
+		case i == IndexColumn(table) || (table.IsMatrix && i < 2):
+			reason = "key columns cannot be interned"
+		case HasTag(col.Tags, TagDefault):
+			reason = "interned columns cannot have a default"
+		case HasTag(col.Tags, TagMin) || HasTag(col.Tags, TagMax):
+			reason = "interned columns cannot have min/max bounds"
+		case HasTag(col.Tags, TagCollate):
+			reason = "interned columns cannot have a collation"
+		case HasTag(col.Tags, TagCompress):
+			reason = "interned columns cannot also be compressed"
+		}
+		for _, rel := range table.Relations {
+			if rel.RelationType == "belongsTo" && rel.ForeignKey == col.Name {
+				reason = "foreign key columns cannot be interned"
+			}
+		}
+		if reason != "" {
+			errs = append(errs, fmt.Errorf("table %s column %s: %s", table.Name, col.Name, reason))
+		}
+	}
+	return errs
+}
+
+// internUnawareExporters는 SQLite DB의 테이블을 그대로 읽지만 intern 컬럼에 저장된 id를 문자열로 되돌리지 않는 exporter입니다.
+// 이 exporter들이 생성한 모델, 스키마, 관리 도구는 intern 컬럼을 문자열로 선언하므로 id를 문자열로 읽거나 검색하게 됩니다.
+var internUnawareExporters = map[string]bool{"go": true, "ent": true, "admin": true, "nodejs": true}
+
+// ValidateInternExporters는 intern 컬럼이 있는 테이블을 intern 컬럼을 읽지 못하는 exporter로 생성하지 않는지 확인합니다.
+func ValidateInternExporters(tables []Table, exporters []string) []error {
+	var errs []error
+	for _, name := range exporters {
+		if name = strings.TrimSpace(name); internUnawareExporters[name] {
+			errs = append(errs, rejectInterned(tables, name)...)
+		}
+	}
+	return errs
+}
+
+// rejectInterned는 intern 컬럼마다 exporter가 읽을 수 없다는 에러를 반환합니다.
+func rejectInterned(tables []Table, exporter string) []error {
+	var errs []error
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			if isInterned(col) {
+				errs = append(errs, fmt.Errorf("table %s column %s: the %s exporter cannot read interned columns (they are stored as %s ids); remove the intern tag or generate without %s",
+					table.Name, col.Name, exporter, StringTableName, exporter))
+			}
+		}
+	}
+	return errs
+}
+
+// stringInterner는 문자열을 문자열 테이블의 id로 바꿉니다. 처음 나온 순서대로 id를 부여하며 기존 DB의 id는 유지합니다.
+type stringInterner struct {
+	insert *sql.Stmt
+	lookup *sql.Stmt
+	ids    map[string]int64
+
+	values   int // intern한 값의 수
+	distinct int // 새로 추가한 문자열의 수
+}
+
+func newStringInterner(tx *sql.Tx, dialect SQLDialect) (*stringInterner, error) {
+	insert, err := tx.Prepare(dialect.Upsert(StringTableName, []string{"value"}, []string{"value"}, nil))
+	if err != nil {
+		return nil, err
+	}
+	lookup, err := tx.Prepare(fmt.Sprintf("SELECT id FROM %s WHERE value = %s", dialect.QuoteIdentifier(StringTableName), dialect.Placeholder(1)))
+	if err != nil {
+		insert.Close()
+		return nil, err
+	}
+	return &stringInterner{insert: insert, lookup: lookup, ids: make(map[string]int64)}, nil
+}
+
+// id는 s의 id를 반환하며, 문자열 테이블에 없으면 추가합니다.
+func (in *stringInterner) id(s string) (int64, error) {
+	in.values++
+	if id, ok := in.ids[s]; ok {
+		return id, nil
+	}
+	result, err := in.insert.Exec(s)
+	if err != nil {
+		return 0, err
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		in.distinct++
+	}
+	var id int64
+	if err := in.lookup.QueryRow(s).Scan(&id); err != nil {
+		return 0, err
+	}
+	in.ids[s] = id
+	return id, nil
+}
+
+func (in *stringInterner) Close() {
+	in.insert.Close()
+	in.lookup.Close()
+	if in.values > 0 {
+		log.Printf("interned %d string values as %d distinct strings", in.values, in.distinct)
+	}
+}
@@ -0,0 +1,28 @@
+package exporter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateInternExporters(t *testing.T) {
+	tables := []Table{{
+		Name:    "Item",
+		Columns: []Column{binaryTestColumn("ID", "int"), binaryTestColumn("Kind", "string", "intern")},
+		Rows:    [][]interface{}{{int32(1), "fire"}},
+	}}
+	if errs := ValidateInternExporters(tables, []string{"sqlite", "sqlx", "binary", "json"}); len(errs) > 0 {
+		t.Errorf("intern-aware exporters rejected: %v", errs)
+	}
+	errs := ValidateInternExporters(tables, []string{"sqlite", " go", "nodejs"})
+	if len(errs) != 2 || !strings.Contains(errs[0].Error(), "the go exporter cannot read interned columns") {
+		t.Errorf("want errors for go and nodejs, got %v", errs)
+	}
+
+	// exporter를 직접 사용해도 생성하지 않음
+	for _, e := range []Exporter{NewGORMExporter(), NewEntExporter(), NewAdminExporter(), NewNodeJSExporter()} {
+		if err := e.Export(tables, Options{OutputDir: t.TempDir()}); err == nil || !strings.Contains(err.Error(), "cannot read interned columns") {
+			t.Errorf("%s: want an intern error, got %v", e.Language(), err)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// exporter/json.go
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// JSONExporter는 테이블마다 행 데이터를 담은 <Table>.json 파일을 생성합니다.
+// 기본은 행 객체의 배열이며, keyed 옵션을 켜면 인덱스 컬럼 값을 키로 하는 객체로 출력하여
+// 클라이언트가 배열을 순회하지 않고 바로 조회할 수 있게 합니다.
+// #Config의 shard 설정이 있는 테이블은 키로 조회하는 샤드 파일들과 샤드 매니페스트로 나누어 쓰고, 샤드를 필요할 때 여는 로더를 함께 생성합니다.
+type JSONExporter struct {
+	BaseExporter
+}
+
+func NewJSONExporter() Exporter {
+	return &JSONExporter{
+		BaseExporter: NewBaseExporter("json"),
+	}
+}
+
+func (e *JSONExporter) Describe() ExporterInfo {
+	return ExporterInfo{
+		Description: "row data as JSON files, as arrays of row objects or keyed by the index column",
+		Outputs:     []string{"<Table>.json", "<group>/<Table>.json", "<Table>.by<Column>.json", "<Table>.shards.json", "<Table>/<Table>.<n>.json", "loader/shards.go", "loader/shards.ts"},
+		Options: []OptionInfo{
+			{Key: OptJSONKeyed, Type: "bool", Default: "false", Description: "write objects keyed by the index column plus by<Column> maps for groupby columns"},
+		},
+		Types: typeMappings(func(col Column) (string, error) {
+			schema := jsonSchemaType(col.Type)
+			if items, ok := schema["items"].(map[string]interface{}); ok {
+				return fmt.Sprintf("%v of %v", schema["type"], items["type"]), nil
+			}
+			return fmt.Sprintf("%v", schema["type"]), nil
+		}),
+	}
+}
+
+func (e *JSONExporter) Export(tables []Table, opts Options) error {
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	keyed := e.GetBoolOption(opts, OptJSONKeyed, false)
+	sharded := false
+	for _, table := range tables {
+		// 그룹이 있는 테이블은 그룹 이름의 하위 디렉토리에 씀
+		dir := opts.OutputDir
+		if table.Group != "" {
+			dir = filepath.Join(opts.OutputDir, table.Group)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create output directory: %v", err)
+			}
+		}
+
+		if table.Shard != "" {
+			spec, err := ParseShardSpec(table.Shard)
+			if err != nil {
+				return fmt.Errorf("table %s: %v", table.Name, err)
+			}
+			if err := writeShardedTable(dir, table, spec); err != nil {
+				return err
+			}
+			sharded = true
+			continue
+		}
+
+		var data interface{}
+		if keyed {
+			rows, err := keyedRows(table)
+			if err != nil {
+				return fmt.Errorf("table %s: %v", table.Name, err)
+			}
+			data = rows
+
+			for _, idx := range groupByColumns(table) {
+				col := table.Columns[idx]
+				path := filepath.Join(dir, fmt.Sprintf("%s.by%s.json", table.Name, col.Name))
+				if err := writeJSONFile(path, groupRowKeys(table, idx)); err != nil {
+					return err
+				}
+			}
+		} else {
+			rows := make([]map[string]interface{}, 0, len(table.Rows))
+			for _, row := range table.Rows {
+				rows = append(rows, patchValues(table, row))
+			}
+			data = rows
+		}
+
+		if err := writeJSONFile(filepath.Join(dir, table.Name+".json"), data); err != nil {
+			return err
+		}
+	}
+
+	if sharded {
+		return generateShardLoaders(opts)
+	}
+	return nil
+}
+
+// Verify는 생성된 <Table>.json (샤딩된 테이블은 매니페스트의 모든 샤드)을 다시 읽어 파싱된 시트 값과 비교합니다.
+func (e *JSONExporter) Verify(tables []Table, opts Options) ([]CellMismatch, error) {
+	keyed := e.GetBoolOption(opts, OptJSONKeyed, false)
+	var mismatches []CellMismatch
+	for _, table := range tables {
+		dir := table.Group // 출력 디렉토리 기준
+
+		var objects []map[string]interface{}
+		output := filepath.Join(dir, table.Name+".json")
+		if table.Shard != "" {
+			output = filepath.Join(dir, table.Name+ShardManifestExt)
+			var manifest ShardManifest
+			if err := readJSONFile(filepath.Join(opts.OutputDir, output), &manifest); err != nil {
+				return nil, err
+			}
+			for _, shard := range manifest.Shards {
+				var rows map[string]map[string]interface{}
+				if err := readJSONFile(filepath.Join(opts.OutputDir, dir, filepath.FromSlash(shard.File)), &rows); err != nil {
+					return nil, err
+				}
+				for _, row := range rows {
+					objects = append(objects, row)
+				}
+			}
+		} else if keyed {
+			var rows map[string]map[string]interface{}
+			if err := readJSONFile(filepath.Join(opts.OutputDir, output), &rows); err != nil {
+				return nil, err
+			}
+			for _, row := range rows {
+				objects = append(objects, row)
+			}
+		} else if err := readJSONFile(filepath.Join(opts.OutputDir, output), &objects); err != nil {
+			return nil, err
+		}
+
+		rows := make([][]interface{}, len(objects))
+		for i, object := range objects {
+			rows[i] = make([]interface{}, len(table.Columns))
+			for j, col := range table.Columns {
+				value, ok := object[col.Name]
+				if !ok {
+					value = missingValue{}
+				}
+				rows[i][j] = value
+			}
+		}
+		mismatches = append(mismatches, verifyRows(output, table, rows, DateTimeRFC3339)...)
+	}
+	return mismatches, nil
+}
+
+// readJSONFile은 JSON 파일을 읽습니다. 숫자는 정밀도를 잃지 않도록 json.Number로 읽습니다.
+func readJSONFile(path string, v interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", filepath.Base(path), err)
+	}
+	defer f.Close()
+	dec := json.NewDecoder(f)
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %v", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// keyedRows는 행들을 RowKey를 키로 하는 맵으로 변환합니다. 키가 비었거나 중복되면 에러를 반환합니다.
+func keyedRows(table Table) (map[string]map[string]interface{}, error) {
+	rows := make(map[string]map[string]interface{}, len(table.Rows))
+	for i, row := range table.Rows {
+		key := RowKey(table, row)
+		if key == "" {
+			return nil, fmt.Errorf("row %d has no index value", i+1)
+		}
+		if _, dup := rows[key]; dup {
+			return nil, fmt.Errorf("duplicate index %q", key)
+		}
+		rows[key] = patchValues(table, row)
+	}
+	return rows, nil
+}
+
+// groupByColumns는 groupby 태그가 붙은 컬럼의 위치를 반환합니다.
+func groupByColumns(table Table) []int {
+	var indices []int
+	for i, col := range table.Columns {
+		if HasTag(col.Tags, TagGroupBy) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// groupRowKeys는 컬럼 값별로 행 키 목록을 시트 순서대로 묶습니다. 배열 컬럼은 원소마다 묶고, 빈 값은 제외합니다.
+func groupRowKeys(table Table, idx int) map[string][]string {
+	groups := make(map[string][]string)
+	col := table.Columns[idx]
+	for _, row := range table.Rows {
+		if idx >= len(row) || row[idx] == nil {
+			continue
+		}
+		key := RowKey(table, row)
+		for _, value := range cellValues(col, row[idx]) {
+			groups[value] = append(groups[value], key)
+		}
+	}
+	return groups
+}
+
+func writeJSONFile(path string, data interface{}) error {
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %v", filepath.Base(path), err)
+	}
+	return os.WriteFile(path, out, 0644)
+}
@@ -0,0 +1,302 @@
+// exporter/jsonl.go
+package exporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// JSONLResult는 JSON Lines 파일 하나의 출력 결과입니다.
+type JSONLResult struct {
+	Table    string
+	Path     string
+	Rows     int
+	Warnings []CellWarning // 헤더 범위 밖에 있어 무시한 셀
+}
+
+// StreamJSONL은 워크북의 데이터 시트를 테이블마다 한 줄에 한 행 객체인 <Table>.jsonl 파일로 씁니다.
+// 표준 배치 시트는 Table.Rows에 모으지 않고 시트에서 한 행씩 읽어 바로 쓰므로 매우 큰 테이블도 일정한 메모리로 처리합니다.
+// 전치된 시트와 매트릭스 시트는 시트 전체를 읽어야 변환할 수 있으므로 파싱한 뒤 씁니다.
+// 그룹이 설정된 테이블은 그룹 이름의 하위 디렉토리에 쓰며, selected는 ParseExcelFileSelected와 같습니다.
+// 행은 ParseExcelFile과 같이 헤더의 열 수에 맞추며, 헤더 범위 밖의 값은 결과의 Warnings로 반환합니다.
+func StreamJSONL(filePath, outputDir string, selected map[string]bool) ([]JSONLResult, error) {
+	f, err := openWorkbook(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries, err := parseConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+
+	optionalGroups, err := parseOptionalGroups(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []JSONLResult
+	for _, sheetName := range f.GetSheetList() {
+		if strings.HasPrefix(sheetName, "#") {
+			continue
+		}
+		if selected != nil && !selected[formatTableName(sheetName)] {
+			continue
+		}
+
+		dir := outputDir
+		if group := configValue(entries, sheetName, ConfigKeyGroup); group != "" {
+			dir = filepath.Join(outputDir, group)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %v", err)
+		}
+
+		groups, err := tableOptionalGroups(optionalGroups, entries, sheetName)
+		if err != nil {
+			return nil, fmt.Errorf("sheet %s: %v", sheetName, err)
+		}
+		result, err := streamSheetJSONL(f, sheetName, entries, groups, dir)
+		if err != nil {
+			return nil, fmt.Errorf("sheet %s: %v", sheetName, err)
+		}
+		if result != nil {
+			for i := range result.Warnings {
+				result.Warnings[i].Workbook = filePath
+			}
+			results = append(results, *result)
+		}
+	}
+
+	// 키-값 설정 시트는 한 행짜리 테이블이므로 파싱한 뒤 씀
+	settings, err := parseSettingsSheets(f, selected)
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range settings {
+		path := filepath.Join(outputDir, table.Name+".jsonl")
+		if err := writeJSONLTable(path, table); err != nil {
+			return nil, err
+		}
+		results = append(results, JSONLResult{Table: table.Name, Path: path, Rows: len(table.Rows)})
+	}
+
+	return results, nil
+}
+
+// streamSheetJSONL은 시트 하나를 JSON Lines 파일로 씁니다. 데이터 행이 없는 시트는 nil을 반환합니다.
+func streamSheetJSONL(f *excelize.File, sheetName string, entries []ConfigEntry, groups []OptionalGroup, dir string) (*JSONLResult, error) {
+	iter, err := f.Rows(sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sheet: %v", err)
+	}
+	defer iter.Close()
+
+	// 헤더 3줄(과 #layout 마커)까지만 먼저 읽어 배치를 판단
+	var header [][]string
+	var pos sheetPosition
+	for len(header) < 3 && iter.Next() {
+		cells, err := iter.Columns()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sheet: %v", err)
+		}
+		header = append(header, cells)
+
+		if len(header) == 1 {
+			if _, ok := matrixValueType(header); ok {
+				return parsedSheetJSONL(f, sheetName, entries, groups, dir)
+			}
+			layout, rest, p, err := sheetLayout(sheetName, header, entries)
+			if err != nil {
+				return nil, err
+			}
+			if layout == LayoutTransposed {
+				return parsedSheetJSONL(f, sheetName, entries, groups, dir)
+			}
+			header, pos = rest, p
+		}
+	}
+	if len(header) < 3 {
+		return nil, iter.Error()
+	}
+
+	// 행 길이를 헤더의 열 수에 맞춤 (헤더 범위 밖의 값은 경고)
+	normalizer := newRowNormalizer(sheetName, header[0], pos)
+	var warnings []CellWarning
+	for r := range header {
+		var rowWarnings []CellWarning
+		header[r], rowWarnings = normalizer.normalize(r, header[r])
+		warnings = append(warnings, rowWarnings...)
+	}
+
+	rules, err := sheetHeaderRules(entries, sheetName, pos)
+	if err != nil {
+		return nil, err
+	}
+	table, sources, err := parseHeader(sheetName, header, rules)
+	if err != nil {
+		return nil, err
+	}
+	// 시트에 없는 optional 그룹의 컬럼은 읽을 열이 없으므로 항상 NULL
+	if table, err = applyOptionalGroups(table, groups); err != nil {
+		return nil, err
+	}
+	for len(sources) < len(table.Columns) {
+		sources = append(sources, nil)
+	}
+	parsers := make([]ValueParser, len(table.Columns))
+	bounds := make([]Bounds, len(table.Columns))
+	transforms := make([]*Transform, len(table.Columns))
+	for i, col := range table.Columns {
+		parsers[i] = CreateParser(col)
+		bounds[i], _ = ColumnBounds(col)
+		transforms[i], _ = ColumnTransform(col)
+	}
+	required, err := compileRequiredIf(table.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	// 파일은 첫 데이터 행을 만났을 때 만듦 (ParseExcelFile과 마찬가지로 데이터 행이 없는 시트는 테이블이 아님)
+	var out *jsonlWriter
+	result := &JSONLResult{Table: table.Name, Path: filepath.Join(dir, table.Name+".jsonl"), Warnings: warnings}
+	for r := 3; iter.Next(); r++ {
+		cells, err := iter.Columns()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sheet: %v", err)
+		}
+		cells, rowWarnings := normalizer.normalize(r, cells)
+		result.Warnings = append(result.Warnings, rowWarnings...)
+		if out == nil {
+			if out, err = newJSONLWriter(result.Path); err != nil {
+				return nil, err
+			}
+			defer out.Close()
+		}
+
+		row, err := parseRow(cells, table.Columns, sources, parsers, bounds, transforms)
+		if err == nil && row != nil {
+			err = checkRequiredIf(table.Columns, required, row)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", pos.record(r), err)
+		}
+		if row == nil {
+			continue
+		}
+		if err := out.Write(table, row); err != nil {
+			return nil, err
+		}
+		result.Rows++
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read sheet: %v", err)
+	}
+	if out == nil {
+		return nil, nil
+	}
+	return result, out.Close()
+}
+
+// parsedSheetJSONL은 시트 전체를 파싱한 뒤 JSON Lines 파일로 씁니다.
+func parsedSheetJSONL(f *excelize.File, sheetName string, entries []ConfigEntry, groups []OptionalGroup, dir string) (*JSONLResult, error) {
+	rows, err := f.GetRows(sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sheet: %v", err)
+	}
+
+	var table Table
+	var warnings []CellWarning
+	if typeStr, ok := matrixValueType(rows); ok {
+		if table, err = parseMatrixSheet(sheetName, rows, typeStr); err != nil {
+			return nil, err
+		}
+	} else {
+		_, rows, pos, err := sheetLayout(sheetName, rows, entries)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) < 4 {
+			return nil, nil
+		}
+		// 행 길이를 헤더의 열 수에 맞춤 (헤더 범위 밖의 값은 경고)
+		rows, warnings = normalizeRows(sheetName, rows, pos)
+		rules, err := sheetHeaderRules(entries, sheetName, pos)
+		if err != nil {
+			return nil, err
+		}
+		if table, err = parseSheet(sheetName, rows, pos.record, rules); err != nil {
+			return nil, err
+		}
+		if table, err = applyOptionalGroups(table, groups); err != nil {
+			return nil, err
+		}
+	}
+
+	path := filepath.Join(dir, table.Name+".jsonl")
+	if err := writeJSONLTable(path, table); err != nil {
+		return nil, err
+	}
+	return &JSONLResult{Table: table.Name, Path: path, Rows: len(table.Rows), Warnings: warnings}, nil
+}
+
+func writeJSONLTable(path string, table Table) error {
+	out, err := newJSONLWriter(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	for _, row := range table.Rows {
+		if err := out.Write(table, row); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+// jsonlWriter는 행을 한 줄에 하나씩 JSON 객체로 씁니다.
+type jsonlWriter struct {
+	file   *os.File
+	buf    *bufio.Writer
+	enc    *json.Encoder
+	closed bool
+}
+
+func newJSONLWriter(path string) (*jsonlWriter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	buf := bufio.NewWriter(file)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	return &jsonlWriter{file: file, buf: buf, enc: enc}, nil
+}
+
+func (w *jsonlWriter) Write(table Table, row []interface{}) error {
+	if err := w.enc.Encode(patchValues(table, row)); err != nil {
+		return fmt.Errorf("failed to encode row of %s: %v", table.Name, err)
+	}
+	return nil
+}
+
+// Close는 버퍼를 비우고 파일을 닫습니다. 여러 번 호출해도 안전합니다.
+func (w *jsonlWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if err := w.buf.Flush(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
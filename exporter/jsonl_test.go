@@ -0,0 +1,98 @@
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// writeRaggedWorkbook은 행 길이가 제각각이고 헤더 범위 밖에 값이 있는 시트를 가진 워크북을 만듭니다.
+func writeRaggedWorkbook(t *testing.T) string {
+	t.Helper()
+	f := excelize.NewFile()
+	defer f.Close()
+
+	standard := [][]interface{}{
+		{"index", "name", "count"},
+		{"", "", "", "stray tag"},
+		{"int", "string", "int"},
+		{1, "Sword", 3, nil, "extra"},
+		{2, "Potion"},
+		{3},
+		{nil, nil, nil, "only extra"},
+	}
+	transposed := [][]interface{}{
+		{"#layout:transposed"},
+		{"index", "", "int", 1, 2},
+		{"name", "", "string", "a", "b"},
+		{nil, nil, nil, "outside"},
+	}
+	if err := f.SetSheetName(f.GetSheetName(0), "Item"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.NewSheet("Flip"); err != nil {
+		t.Fatal(err)
+	}
+	for sheet, rows := range map[string][][]interface{}{"Item": standard, "Flip": transposed} {
+		for r, row := range rows {
+			cell, _ := excelize.CoordinatesToCellName(1, r+1)
+			if err := f.SetSheetRow(sheet, cell, &row); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	path := filepath.Join(t.TempDir(), "ragged.xlsx")
+	if err := f.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestStreamJSONLRaggedRows는 StreamJSONL이 ParseExcelFile과 같은 행 정규화와 경고를 적용하는지 확인합니다.
+func TestStreamJSONLRaggedRows(t *testing.T) {
+	path := writeRaggedWorkbook(t)
+
+	tables, err := ParseExcelFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := make(map[string][]CellWarning)
+	for _, w := range CellWarnings(tables) {
+		want[w.Sheet] = append(want[w.Sheet], w)
+	}
+	if len(want["Item"]) != 3 || len(want["Flip"]) != 1 {
+		t.Fatalf("unexpected parse warnings: %v", CellWarnings(tables))
+	}
+
+	dir := t.TempDir()
+	results, err := StreamJSONL(path, dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	for _, result := range results {
+		if !reflect.DeepEqual(result.Warnings, want[result.Table]) {
+			t.Errorf("%s warnings\n got: %v\nwant: %v", result.Table, result.Warnings, want[result.Table])
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "Item.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	wantLines := []string{
+		`{"Count":3,"Index":1,"Name":"Sword"}`,
+		`{"Count":null,"Index":2,"Name":"Potion"}`,
+		`{"Count":null,"Index":3,"Name":null}`,
+	}
+	if !reflect.DeepEqual(lines, wantLines) {
+		t.Errorf("Item.jsonl\n got: %q\nwant: %q", lines, wantLines)
+	}
+}
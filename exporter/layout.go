@@ -0,0 +1,119 @@
+// exporter/layout.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// SheetLayout은 데이터 시트의 배치 방식입니다.
+type SheetLayout string
+
+const (
+	// LayoutStandard는 기본 배치입니다. 1~3행이 컬럼명/태그/타입이고 4행부터 한 행이 한 레코드입니다.
+	LayoutStandard SheetLayout = ""
+	// LayoutTransposed는 전치된 배치입니다. A~C열이 컬럼명/태그/타입이고 D열부터 한 열이 한 레코드입니다.
+	// 항목(속성)이 행으로 나열되는 밸런스 시트를 위한 배치입니다.
+	LayoutTransposed SheetLayout = "transposed"
+)
+
+// LayoutMarkerPrefix는 시트 첫 행 A열에 배치를 지정하는 마커의 접두사입니다. (예: #layout:transposed)
+// 마커가 있는 첫 행은 데이터로 읽지 않습니다.
+const LayoutMarkerPrefix = "#layout:"
+
+// ParseSheetLayout은 배치 이름을 SheetLayout으로 변환합니다.
+func ParseSheetLayout(s string) (SheetLayout, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "standard", "rows":
+		return LayoutStandard, nil
+	case "transposed", "columns":
+		return LayoutTransposed, nil
+	}
+	return LayoutStandard, fmt.Errorf("unknown sheet layout %q (expected standard or transposed)", s)
+}
+
+// sheetPosition은 표준 배치로 변환된 행/열 위치를 원본 시트 위치로 바꿉니다.
+type sheetPosition struct {
+	layout  SheetLayout
+	skipped int // 시트 위쪽에서 건너뛴 행 수 (#layout 마커 행)
+}
+
+// record는 변환된 행 인덱스(0부터)를 에러 메시지용 시트 위치("row 5", "column E")로 반환합니다.
+func (p sheetPosition) record(idx int) string {
+	if p.layout == LayoutTransposed {
+		name, err := excelize.ColumnNumberToName(idx + 1)
+		if err != nil {
+			return fmt.Sprintf("column %d", idx+1)
+		}
+		return "column " + name
+	}
+	return fmt.Sprintf("row %d", idx+p.skipped+1)
+}
+
+// cell은 변환된 위치(0부터)를 시트의 셀 이름(B4 등)으로 반환합니다.
+func (p sheetPosition) cell(r, c int) string {
+	if p.layout == LayoutTransposed {
+		r, c = c, r
+	}
+	name, err := excelize.CoordinatesToCellName(c+1, r+p.skipped+1)
+	if err != nil {
+		return fmt.Sprintf("R%dC%d", r+p.skipped+1, c+1)
+	}
+	return name
+}
+
+// sheetLayout은 시트의 배치를 결정하고, 표준 배치로 변환한 행들과 원본 시트 위치 정보를 반환합니다.
+// 첫 행의 #layout 마커가 #Config의 layout 설정보다 우선합니다.
+func sheetLayout(sheetName string, rows [][]string, entries []ConfigEntry) (SheetLayout, [][]string, sheetPosition, error) {
+	value := configValue(entries, sheetName, ConfigKeyLayout)
+
+	var pos sheetPosition
+	if len(rows) > 0 {
+		marker := cellAt(rows[0], 0)
+		if strings.HasPrefix(strings.ToLower(marker), LayoutMarkerPrefix) {
+			value = marker[len(LayoutMarkerPrefix):]
+			rows = rows[1:]
+			pos.skipped = 1
+		}
+	}
+
+	layout, err := ParseSheetLayout(value)
+	if err != nil {
+		return layout, nil, pos, err
+	}
+	pos.layout = layout
+
+	if layout == LayoutTransposed {
+		rows = transposeRows(rows)
+	}
+	return layout, rows, pos, nil
+}
+
+// transposeRows는 행과 열을 바꿉니다.
+// GetRows와 마찬가지로 각 행의 끝에 있는 빈 셀은 잘라냅니다.
+func transposeRows(rows [][]string) [][]string {
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	result := make([][]string, width)
+	for c := 0; c < width; c++ {
+		out := make([]string, len(rows))
+		for r, row := range rows {
+			if c < len(row) {
+				out[r] = row[c]
+			}
+		}
+		end := len(out)
+		for end > 0 && out[end-1] == "" {
+			end--
+		}
+		result[c] = out[:end]
+	}
+	return result
+}
@@ -0,0 +1,565 @@
+// exporter/lint.go
+package exporter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// LintSeverity는 린트 규칙 위반의 심각도입니다.
+type LintSeverity string
+
+const (
+	LintOff     LintSeverity = "off"
+	LintInfo    LintSeverity = "info"
+	LintWarning LintSeverity = "warning"
+	LintError   LintSeverity = "error"
+)
+
+// ParseLintSeverity는 심각도 이름을 변환합니다. 빈 문자열은 규칙의 기본 심각도를 뜻합니다.
+func ParseLintSeverity(s string) (LintSeverity, error) {
+	switch sev := LintSeverity(strings.ToLower(strings.TrimSpace(s))); sev {
+	case "", LintOff, LintInfo, LintWarning, LintError:
+		return sev, nil
+	}
+	return "", fmt.Errorf("unknown lint severity %q (expected off, info, warning or error)", s)
+}
+
+// LintIssue는 린트 규칙 위반 하나입니다. Cell이 비어있으면 시트 전체에 대한 위반입니다.
+type LintIssue struct {
+	Rule     string
+	Severity LintSeverity
+	File     string
+	Sheet    string
+	Cell     string
+	Message  string
+}
+
+func (i LintIssue) String() string {
+	loc := i.Sheet
+	if i.Cell != "" {
+		loc += "!" + i.Cell
+	}
+	return fmt.Sprintf("%s:%s: %s: %s (%s)", i.File, loc, i.Severity, i.Message, i.Rule)
+}
+
+// LintRuleConfig는 #Lint 시트의 한 행입니다.
+// Table/Column이 비어있으면 모든 테이블/컬럼에 적용됩니다.
+// 컬럼을 지정해야 하는 규칙(range 등)은 설정된 행마다 하나씩 실행됩니다.
+type LintRuleConfig struct {
+	Rule     string
+	Severity LintSeverity
+	Table    string
+	Column   string
+	Args     string
+}
+
+// LintRule은 린트 규칙입니다.
+type LintRule struct {
+	Name        string
+	Description string
+	Default     LintSeverity
+	NeedsColumn bool // #Lint 시트에서 Column(과 Args)을 지정해야 실행되는 규칙
+
+	// check는 위반을 report로 보고합니다. r, c는 표준 배치 기준 위치이며,
+	// 시트 자체에 대한 위반은 r = -1로 보고합니다.
+	check func(s *lintSheet, cfg LintRuleConfig, report func(r, c int, msg string)) error
+}
+
+// lintSheet는 규칙이 검사하는 데이터 시트입니다.
+type lintSheet struct {
+	name string
+	rows [][]string // 표준 배치로 변환된 원본 셀 (공백 제거 전)
+	pos  sheetPosition
+}
+
+// columnIndex는 이름이 name인 컬럼의 위치를 반환합니다. 없으면 -1입니다.
+func (s *lintSheet) columnIndex(name string) int {
+	if len(s.rows) == 0 {
+		return -1
+	}
+	for c, cell := range s.rows[0] {
+		if strings.EqualFold(strings.TrimSpace(cell), name) {
+			return c
+		}
+	}
+	return -1
+}
+
+// column은 c번째 열의 헤더(태그, 타입)로 컬럼 정의를 만듭니다.
+func (s *lintSheet) column(c int) Column {
+	var col Column
+	if len(s.rows) >= 3 {
+		col.Type = ParseColumnType(cellAt(s.rows[2], c))
+		col.Tags = ParseColumnTags(parseTags(cellAt(s.rows[1], c)))
+	}
+	return col
+}
+
+var pascalCasePattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// LintRules는 기본 제공 린트 규칙들입니다.
+var LintRules = []LintRule{
+	{
+		Name:        "index-first",
+		Description: "The Index column must be the first column",
+		Default:     LintWarning,
+		check: func(s *lintSheet, _ LintRuleConfig, report func(r, c int, msg string)) error {
+			idx := s.columnIndex("Index")
+			if idx <= 0 {
+				return nil
+			}
+			for c := 0; c < idx; c++ {
+				if cellAt(s.rows[0], c) != "" {
+					report(0, idx, "Index column should be the first column")
+					return nil
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "name-whitespace",
+		Description: "Sheet and column names must not have leading, trailing or repeated whitespace",
+		Default:     LintError,
+		check: func(s *lintSheet, _ LintRuleConfig, report func(r, c int, msg string)) error {
+			if hasStrayWhitespace(s.name) {
+				report(-1, -1, fmt.Sprintf("sheet name %q has stray whitespace", s.name))
+			}
+			if len(s.rows) > 0 {
+				for c, cell := range s.rows[0] {
+					if hasStrayWhitespace(cell) {
+						report(0, c, fmt.Sprintf("column name %q has stray whitespace", cell))
+					}
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "sheet-pascalcase",
+		Description: "Sheet names must be PascalCase",
+		Default:     LintWarning,
+		check: func(s *lintSheet, _ LintRuleConfig, report func(r, c int, msg string)) error {
+			if !pascalCasePattern.MatchString(s.name) {
+				report(-1, -1, fmt.Sprintf("sheet name %q is not PascalCase", s.name))
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "unknown-type",
+		Description: "Column types must be recognized (unknown types silently become string)",
+		Default:     LintError,
+		check: func(s *lintSheet, _ LintRuleConfig, report func(r, c int, msg string)) error {
+			if len(s.rows) < 3 {
+				return nil
+			}
+			for c, typeStr := range s.rows[2] {
+				if cellAt(s.rows[0], c) != "" && !KnownColumnType(typeStr) {
+					report(2, c, fmt.Sprintf("unknown type %q", strings.TrimSpace(typeStr)))
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "unknown-tag",
+		Description: "Column tags must be recognized",
+		Default:     LintWarning,
+		check: func(s *lintSheet, _ LintRuleConfig, report func(r, c int, msg string)) error {
+			if len(s.rows) < 2 {
+				return nil
+			}
+			for c := range s.rows[1] {
+				for _, tag := range parseTags(cellAt(s.rows[1], c)) {
+					if ParseTagWithValue(tag).Tag == TagNone {
+						report(1, c, fmt.Sprintf("unknown tag %q", tag))
+					}
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "range",
+		Description: "Numeric values of a column must be within Args (min..max, either side optional)",
+		Default:     LintError,
+		NeedsColumn: true,
+		check: func(s *lintSheet, cfg LintRuleConfig, report func(r, c int, msg string)) error {
+			min, max, hasMin, hasMax, err := parseLintRange(cfg.Args)
+			if err != nil {
+				return err
+			}
+			for _, c := range lintColumns(s, cfg) {
+				delim := ArrayDelimiter(s.column(c))
+				for r := 3; r < len(s.rows); r++ {
+					cell := cellAt(s.rows[r], c)
+					for _, item := range splitArrayCell(cell, delim) {
+						v, err := strconv.ParseFloat(strings.TrimSpace(item), 64)
+						if err != nil {
+							continue
+						}
+						if (hasMin && v < min) || (hasMax && v > max) {
+							report(r, c, fmt.Sprintf("%s value %s is outside %s", cellAt(s.rows[0], c), strings.TrimSpace(item), cfg.Args))
+						}
+					}
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "not-empty",
+		Description: "A column must have a value in every data row",
+		Default:     LintError,
+		NeedsColumn: true,
+		check: func(s *lintSheet, cfg LintRuleConfig, report func(r, c int, msg string)) error {
+			for _, c := range lintColumns(s, cfg) {
+				for r := 3; r < len(s.rows); r++ {
+					if !rowIsEmpty(s.rows[r]) && cellAt(s.rows[r], c) == "" {
+						report(r, c, fmt.Sprintf("%s is empty", cellAt(s.rows[0], c)))
+					}
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "key-case",
+		Description: "String Index and unique values must not differ only in case or whitespace (IronSword / ironsword / Iron  Sword)",
+		Default:     LintWarning,
+		check: func(s *lintSheet, _ LintRuleConfig, report func(r, c int, msg string)) error {
+			if len(s.rows) < 3 {
+				return nil
+			}
+			for c := range s.rows[0] {
+				col := s.column(c)
+				key := strings.EqualFold(strings.TrimSpace(cellAt(s.rows[0], c)), "Index")
+				// collate:nocase 컬럼은 검증(validateCollation)에서 에러로 보고됨
+				if !(key || HasTag(col.Tags, TagUnique)) || col.Type.IsArray || col.Type.Type != StringType.Type || sqliteCollation(col) == "NOCASE" {
+					continue
+				}
+				// normalize 태그가 있으면 정규화한 값을 비교
+				ops, err := columnNormalization(col)
+				if err != nil {
+					continue
+				}
+				seen := make(map[string]string)
+				for r := 3; r < len(s.rows); r++ {
+					value := normalizeText(ops, cellAt(s.rows[r], c))
+					if value == "" {
+						continue
+					}
+					folded := strings.ToLower(strings.Join(strings.Fields(value), ""))
+					if prev, ok := seen[folded]; !ok {
+						seen[folded] = value
+					} else if prev != value {
+						report(r, c, fmt.Sprintf("%s value %q differs from %q only in case or whitespace (use normalize or collate:nocase)", cellAt(s.rows[0], c), value, prev))
+					}
+				}
+			}
+			return nil
+		},
+	},
+}
+
+func findLintRule(name string) (LintRule, bool) {
+	for _, rule := range LintRules {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+	return LintRule{}, false
+}
+
+// LintWorkbook은 워크북의 데이터 시트에 린트 규칙을 적용합니다.
+// 규칙은 #Lint 시트(Rule, Severity, Table, Column, Args 헤더)로 켜고 끄거나 심각도를 바꿀 수 있습니다.
+// 셀(또는 컬럼 이름 셀)에 "lint:ignore" 또는 "lint:ignore rule1 rule2" 메모를 달면 해당 위반을 무시합니다.
+func LintWorkbook(path string) ([]LintIssue, error) {
+	f, err := openWorkbook(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	configs, err := parseLintConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lint config: %v", err)
+	}
+	entries, err := parseConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+
+	var issues []LintIssue
+	for _, sheetName := range f.GetSheetList() {
+		if strings.HasPrefix(sheetName, "#") {
+			continue
+		}
+
+		rows, err := f.GetRows(sheetName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sheet %s: %v", sheetName, err)
+		}
+		if _, ok := matrixValueType(rows); ok {
+			continue
+		}
+
+		_, rows, pos, err := sheetLayout(sheetName, rows, entries)
+		if err != nil {
+			return nil, fmt.Errorf("sheet %s: %v", sheetName, err)
+		}
+
+		ignores, err := lintIgnores(f, sheetName)
+		if err != nil {
+			return nil, err
+		}
+
+		sheet := &lintSheet{name: sheetName, rows: rows, pos: pos}
+		sheetIssues, err := lintSheetIssues(sheet, configs, ignores)
+		if err != nil {
+			return nil, fmt.Errorf("sheet %s: %v", sheetName, err)
+		}
+		for i := range sheetIssues {
+			sheetIssues[i].File = path
+		}
+		issues = append(issues, sheetIssues...)
+	}
+
+	return issues, nil
+}
+
+func lintSheetIssues(s *lintSheet, configs []LintRuleConfig, ignores map[string][]string) ([]LintIssue, error) {
+	var issues []LintIssue
+	table := formatTableName(s.name)
+
+	for _, rule := range LintRules {
+		rule := rule
+		run := func(cfg LintRuleConfig, severityOf func(c int) LintSeverity) error {
+			return rule.check(s, cfg, func(r, c int, msg string) {
+				severity := severityOf(c)
+				if severity == LintOff {
+					return
+				}
+
+				cell := ""
+				if r >= 0 {
+					cell = s.pos.cell(r, c)
+					if lintIgnored(ignores, rule.Name, cell, s.pos.cell(0, c)) {
+						return
+					}
+				}
+				issues = append(issues, LintIssue{Rule: rule.Name, Severity: severity, Sheet: s.name, Cell: cell, Message: msg})
+			})
+		}
+
+		if rule.NeedsColumn {
+			// 설정된 행마다 실행
+			for _, cfg := range configs {
+				if cfg.Rule != rule.Name || !lintTableMatches(cfg, s.name, table) {
+					continue
+				}
+				severity := cfg.Severity
+				if severity == LintOff {
+					continue
+				}
+				if severity == "" {
+					severity = rule.Default
+				}
+				if err := run(cfg, func(int) LintSeverity { return severity }); err != nil {
+					return nil, fmt.Errorf("lint rule %s: %v", rule.Name, err)
+				}
+			}
+			continue
+		}
+
+		// 가장 구체적인 설정(테이블+컬럼 > 테이블 > 전체)의 심각도를 적용
+		severityOf := func(c int) LintSeverity {
+			severity, best := rule.Default, -1
+			column := ""
+			if len(s.rows) > 0 && c >= 0 {
+				column = cellAt(s.rows[0], c)
+			}
+			for _, cfg := range configs {
+				if cfg.Rule != rule.Name || cfg.Severity == "" || !lintTableMatches(cfg, s.name, table) {
+					continue
+				}
+				if cfg.Column != "" && !strings.EqualFold(cfg.Column, column) {
+					continue
+				}
+				score := 0
+				if cfg.Table != "" && cfg.Table != "*" {
+					score++
+				}
+				if cfg.Column != "" {
+					score += 2
+				}
+				if score >= best {
+					severity, best = cfg.Severity, score
+				}
+			}
+			return severity
+		}
+		if err := run(LintRuleConfig{Rule: rule.Name}, severityOf); err != nil {
+			return nil, fmt.Errorf("lint rule %s: %v", rule.Name, err)
+		}
+	}
+
+	return issues, nil
+}
+
+// parseLintConfig는 #Lint 시트에서 규칙 설정을 읽습니다.
+func parseLintConfig(f *excelize.File) ([]LintRuleConfig, error) {
+	lintSheet := "#Lint"
+	if !contains(f.GetSheetList(), lintSheet) {
+		return nil, nil
+	}
+
+	rows, err := f.GetRows(lintSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lint sheet: %v", err)
+	}
+	if len(rows) < 2 {
+		return nil, nil
+	}
+
+	colIndexes := map[string]int{
+		"Rule":     -1,
+		"Severity": -1,
+		"Table":    -1,
+		"Column":   -1,
+		"Args":     -1,
+	}
+	for i, cell := range rows[0] {
+		colName := strings.TrimSpace(cell)
+		if _, ok := colIndexes[colName]; ok {
+			colIndexes[colName] = i
+		}
+	}
+	if colIndexes["Rule"] == -1 {
+		return nil, fmt.Errorf("required column Rule not found in lint sheet")
+	}
+
+	var configs []LintRuleConfig
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		cfg := LintRuleConfig{
+			Rule:   strings.ToLower(cellAt(row, colIndexes["Rule"])),
+			Table:  cellAt(row, colIndexes["Table"]),
+			Column: cellAt(row, colIndexes["Column"]),
+			Args:   cellAt(row, colIndexes["Args"]),
+		}
+		if cfg.Rule == "" {
+			continue
+		}
+
+		rule, ok := findLintRule(cfg.Rule)
+		if !ok {
+			return nil, fmt.Errorf("row %d: unknown lint rule %s", i+1, cfg.Rule)
+		}
+		if cfg.Severity, err = ParseLintSeverity(cellAt(row, colIndexes["Severity"])); err != nil {
+			return nil, fmt.Errorf("row %d: %v", i+1, err)
+		}
+		if rule.NeedsColumn && cfg.Column == "" {
+			return nil, fmt.Errorf("row %d: lint rule %s needs a Column", i+1, cfg.Rule)
+		}
+		configs = append(configs, cfg)
+	}
+	return configs, nil
+}
+
+// lintIgnores는 시트의 셀 메모에서 "lint:ignore" 지시를 읽습니다.
+// 결과는 셀 이름별 무시할 규칙 목록이며, 빈 목록은 모든 규칙을 뜻합니다.
+func lintIgnores(f *excelize.File, sheetName string) (map[string][]string, error) {
+	comments, err := f.GetComments(sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read comments of sheet %s: %v", sheetName, err)
+	}
+
+	ignores := make(map[string][]string)
+	for _, comment := range comments {
+		text := comment.Text
+		for _, run := range comment.Paragraph {
+			text += run.Text
+		}
+		idx := strings.Index(text, "lint:ignore")
+		if idx == -1 {
+			continue
+		}
+		line := strings.SplitN(text[idx+len("lint:ignore"):], "\n", 2)[0]
+		ignores[comment.Cell] = strings.FieldsFunc(line, func(r rune) bool {
+			return r == ' ' || r == ','
+		})
+	}
+	return ignores, nil
+}
+
+// lintIgnored는 위반 셀 또는 컬럼 이름 셀의 메모가 규칙을 무시하도록 지정했는지 확인합니다.
+func lintIgnored(ignores map[string][]string, rule string, cells ...string) bool {
+	for _, cell := range cells {
+		rules, ok := ignores[cell]
+		if !ok {
+			continue
+		}
+		if len(rules) == 0 || contains(rules, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+func lintTableMatches(cfg LintRuleConfig, sheetName, table string) bool {
+	return cfg.Table == "" || cfg.Table == "*" || cfg.Table == sheetName || formatTableName(cfg.Table) == table
+}
+
+// lintColumns는 설정의 Column(쉼표로 여러 개 지정 가능)에 해당하는 컬럼 위치들을 반환합니다.
+func lintColumns(s *lintSheet, cfg LintRuleConfig) []int {
+	var cols []int
+	for _, name := range strings.Split(cfg.Column, ",") {
+		if idx := s.columnIndex(strings.TrimSpace(name)); idx != -1 {
+			cols = append(cols, idx)
+		}
+	}
+	sort.Ints(cols)
+	return cols
+}
+
+// parseLintRange는 "min..max" 형태의 범위를 파싱합니다. 한쪽은 생략할 수 있습니다.
+func parseLintRange(s string) (min, max float64, hasMin, hasMax bool, err error) {
+	parts := strings.SplitN(s, "..", 2)
+	if len(parts) != 2 {
+		return 0, 0, false, false, fmt.Errorf("invalid range %q (expected min..max)", s)
+	}
+	if p := strings.TrimSpace(parts[0]); p != "" {
+		if min, err = strconv.ParseFloat(p, 64); err != nil {
+			return 0, 0, false, false, fmt.Errorf("invalid range minimum %q", p)
+		}
+		hasMin = true
+	}
+	if p := strings.TrimSpace(parts[1]); p != "" {
+		if max, err = strconv.ParseFloat(p, 64); err != nil {
+			return 0, 0, false, false, fmt.Errorf("invalid range maximum %q", p)
+		}
+		hasMax = true
+	}
+	return min, max, hasMin, hasMax, nil
+}
+
+func hasStrayWhitespace(s string) bool {
+	return s != strings.TrimSpace(s) || strings.Contains(s, "  ")
+}
+
+func rowIsEmpty(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
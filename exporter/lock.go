@@ -0,0 +1,246 @@
+// exporter/lock.go
+package exporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// LockSheet는 마일스톤 동안 내용이 바뀌면 안 되는 테이블/컬럼을 나열하는 시트입니다.
+// Table, Column, Hash 헤더를 가지며 Reason 컬럼은 생략할 수 있습니다. Column이 비어있으면 테이블 전체를 잠급니다.
+// Hash는 잠근 시점의 내용 해시이며 excelite lock --update로 기록합니다.
+const LockSheet = "#Lock"
+
+// lockHashLen은 기록하는 내용 해시의 길이(16진수 글자 수)입니다.
+const lockHashLen = 16
+
+// Lock은 잠긴 테이블 또는 컬럼 하나입니다.
+type Lock struct {
+	Column string // 비어있으면 테이블 전체
+	Hash   string // #Lock 시트에 기록된 해시
+	Reason string
+	Actual string // 파싱한 내용의 해시 (잠긴 컬럼이 없으면 빈 문자열)
+}
+
+// lockEntry는 #Lock 시트의 한 행입니다.
+type lockEntry struct {
+	Row   int // 시트의 행 번호 (1부터)
+	Table string
+	Lock
+}
+
+// parseLocks는 #Lock 시트를 파싱합니다.
+func parseLocks(f *excelize.File) ([]lockEntry, map[string]int, error) {
+	if !contains(f.GetSheetList(), LockSheet) {
+		return nil, nil, nil
+	}
+
+	rows, err := f.GetRows(LockSheet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read lock sheet: %v", err)
+	}
+	if len(rows) < 1 {
+		return nil, nil, nil
+	}
+
+	colIndexes := map[string]int{
+		"Table":  -1,
+		"Column": -1,
+		"Hash":   -1,
+		"Reason": -1,
+	}
+	for i, cell := range rows[0] {
+		colName := strings.TrimSpace(cell)
+		if _, ok := colIndexes[colName]; ok {
+			colIndexes[colName] = i
+		}
+	}
+	for _, col := range []string{"Table", "Column", "Hash"} {
+		if colIndexes[col] == -1 {
+			return nil, nil, fmt.Errorf("required column %s not found in lock sheet", col)
+		}
+	}
+
+	var entries []lockEntry
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		entry := lockEntry{
+			Row:   i + 1,
+			Table: formatTableName(cellAt(row, colIndexes["Table"])),
+			Lock: Lock{
+				Column: ParseColumnName(cellAt(row, colIndexes["Column"])),
+				Hash:   strings.ToLower(cellAt(row, colIndexes["Hash"])),
+				Reason: cellAt(row, colIndexes["Reason"]),
+			},
+		}
+		if entry.Table == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, colIndexes, nil
+}
+
+// assignLocks는 잠금을 테이블에 연결하고 현재 내용의 해시를 계산합니다.
+// 해시는 프로필의 행 필터나 transform이 적용되기 전의 워크북 내용으로 계산해야 하므로 파싱 직후에 구합니다.
+func assignLocks(tables []Table, entries []lockEntry, sheetNames []string) ([]Table, error) {
+	tableMap := make(map[string]int)
+	for i, table := range tables {
+		tableMap[table.Name] = i
+	}
+	knownSheets := make(map[string]bool)
+	for _, name := range sheetNames {
+		knownSheets[sheetTableName(name)] = true
+	}
+
+	for _, entry := range entries {
+		idx, ok := tableMap[entry.Table]
+		if !ok && knownSheets[entry.Table] {
+			continue
+		}
+		if !ok {
+			return nil, fmt.Errorf("lock in row %d refers to unknown table %s", entry.Row, entry.Table)
+		}
+
+		lock := entry.Lock
+		var err error
+		if lock.Actual, err = LockHash(tables[idx], lock.Column); err != nil {
+			return nil, fmt.Errorf("lock in row %d: %v", entry.Row, err)
+		}
+		tables[idx].Locks = append(tables[idx].Locks, lock)
+	}
+	return tables, nil
+}
+
+// LockHash는 테이블(column이 비어있으면 전체, 아니면 그 컬럼)의 내용 해시를 반환합니다.
+// 테이블 전체의 해시는 컬럼 이름과 타입을 포함하고, 컬럼의 해시는 행 키와 그 컬럼의 값만 포함합니다.
+// 행의 순서도 내용으로 취급합니다.
+func LockHash(table Table, column string) (string, error) {
+	h := sha256.New()
+	enc := json.NewEncoder(h)
+
+	if column == "" {
+		for _, col := range table.Columns {
+			fmt.Fprintf(h, "%s\x00%s\x00", col.Name, ColumnTypeName(col.Type))
+		}
+		for _, row := range table.Rows {
+			if err := enc.Encode(row); err != nil {
+				return "", err
+			}
+		}
+	} else {
+		idx := columnIndex(table, column)
+		if idx == -1 {
+			return "", fmt.Errorf("table %s has no column %s", table.Name, column)
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00", table.Columns[idx].Name, ColumnTypeName(table.Columns[idx].Type))
+		for _, row := range table.Rows {
+			var value interface{}
+			if idx < len(row) {
+				value = row[idx]
+			}
+			if err := enc.Encode([]interface{}{RowKey(table, row), value}); err != nil {
+				return "", err
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:lockHashLen], nil
+}
+
+// validateLocks는 잠긴 테이블/컬럼의 내용이 기록된 해시와 같은지 확인합니다.
+func validateLocks(table Table) []error {
+	var errs []error
+	for _, lock := range table.Locks {
+		target := table.Name
+		if lock.Column != "" {
+			target += "." + lock.Column
+		}
+		reason := ""
+		if lock.Reason != "" {
+			reason = " (" + lock.Reason + ")"
+		}
+
+		switch {
+		case lock.Hash == "":
+			errs = append(errs, fmt.Errorf("%s is locked%s but has no recorded hash; run excelite lock --update", target, reason))
+		case lock.Hash != lock.Actual:
+			errs = append(errs, fmt.Errorf("%s is locked%s but its content changed (hash %s, locked %s)", target, reason, lock.Actual, lock.Hash))
+		}
+	}
+	return errs
+}
+
+// LockStatus는 잠금 하나의 현재 상태입니다.
+type LockStatus struct {
+	Table  string
+	Column string
+	Hash   string // 기록된 해시
+	Actual string // 현재 내용의 해시
+	Reason string
+}
+
+// Changed는 기록된 해시와 현재 내용이 다른지 반환합니다. 해시가 기록되지 않은 잠금도 포함합니다.
+func (s LockStatus) Changed() bool {
+	return s.Hash != s.Actual
+}
+
+// WorkbookLocks는 워크북의 잠금마다 기록된 해시와 현재 내용의 해시를 반환합니다.
+// update가 참이면 현재 해시를 #Lock 시트의 Hash 컬럼에 기록하고 워크북을 저장합니다. (마일스톤을 잠그거나 의도한 변경을 승인할 때)
+func WorkbookLocks(path string, update bool) ([]LockStatus, error) {
+	tables, err := ParseExcelFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tableMap := make(map[string]Table, len(tables))
+	for _, table := range tables {
+		tableMap[table.Name] = table
+	}
+
+	f, err := openWorkbook(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries, colIndexes, err := parseLocks(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []LockStatus
+	changed := false
+	for _, entry := range entries {
+		table, ok := tableMap[entry.Table]
+		if !ok {
+			continue // 데이터 행이 없는 시트
+		}
+		actual, err := LockHash(table, entry.Column)
+		if err != nil {
+			return nil, fmt.Errorf("lock in row %d: %v", entry.Row, err)
+		}
+		statuses = append(statuses, LockStatus{Table: entry.Table, Column: entry.Column, Hash: entry.Hash, Actual: actual, Reason: entry.Reason})
+
+		if update && entry.Hash != actual {
+			cell, err := excelize.CoordinatesToCellName(colIndexes["Hash"]+1, entry.Row)
+			if err != nil {
+				return nil, err
+			}
+			if err := f.SetCellStr(LockSheet, cell, actual); err != nil {
+				return nil, err
+			}
+			changed = true
+		}
+	}
+
+	if changed {
+		if err := f.SaveAs(path); err != nil {
+			return nil, fmt.Errorf("failed to save %s: %v", path, err)
+		}
+	}
+	return statuses, nil
+}
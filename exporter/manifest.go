@@ -0,0 +1,110 @@
+// exporter/manifest.go
+package exporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ManifestFileName은 출력 디렉토리 루트에 생성되는 매니페스트 파일 이름입니다.
+const ManifestFileName = "manifest.json"
+
+// ManifestEntry는 하나의 산출물 파일 정보를 나타냅니다.
+type ManifestEntry struct {
+	Path   string `json:"path"` // 출력 디렉토리 기준 상대 경로 (슬래시 구분)
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest는 출력 디렉토리의 모든 산출물과 그 루트 해시를 담습니다.
+// 루트 해시는 경로순으로 정렬된 항목들로부터 계산되므로 같은 내용이면 항상 같은 값이 됩니다.
+type Manifest struct {
+	RootHash  string          `json:"rootHash"`
+	Artifacts []ManifestEntry `json:"artifacts"`
+}
+
+// BuildManifest는 디렉토리 내 모든 파일의 크기와 해시를 계산하여 매니페스트를 생성합니다.
+func BuildManifest(dir string) (Manifest, error) {
+	var manifest Manifest
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == ManifestFileName || rel == ManifestSignatureFileName || rel == PipelineStateFile || rel == ColumnStatsFile {
+			return nil
+		}
+
+		hash, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+
+		manifest.Artifacts = append(manifest.Artifacts, ManifestEntry{
+			Path:   rel,
+			Size:   info.Size(),
+			SHA256: hash,
+		})
+		return nil
+	})
+	if err != nil {
+		return manifest, err
+	}
+
+	sort.Slice(manifest.Artifacts, func(i, j int) bool {
+		return manifest.Artifacts[i].Path < manifest.Artifacts[j].Path
+	})
+
+	root := sha256.New()
+	for _, entry := range manifest.Artifacts {
+		io.WriteString(root, entry.Path)
+		root.Write([]byte{0})
+		io.WriteString(root, entry.SHA256)
+		root.Write([]byte{'\n'})
+	}
+	manifest.RootHash = hex.EncodeToString(root.Sum(nil))
+
+	return manifest, nil
+}
+
+// WriteManifest는 디렉토리의 매니페스트를 계산하여 manifest.json으로 저장합니다.
+func WriteManifest(dir string) (Manifest, error) {
+	manifest, err := BuildManifest(dir)
+	if err != nil {
+		return manifest, err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, err
+	}
+	return manifest, os.WriteFile(filepath.Join(dir, ManifestFileName), data, 0644)
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
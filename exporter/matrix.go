@@ -0,0 +1,115 @@
+// exporter/matrix.go
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MatrixMarker는 2차원 조회표(매트릭스) 시트를 나타내는 A1 셀 마커입니다.
+// "#matrix" 뒤에 값 타입을 붙일 수 있습니다. (예: #matrix:int, 기본값은 float)
+//
+//	#matrix:float | Fire | Water | ...   ← 열 키
+//	Fire          | 1.0  | 0.5   |
+//	Water         | 2.0  | 1.0   |
+//	↑ 행 키
+//
+// 매트릭스 시트는 RowKey, ColKey, Value 세 컬럼의 정규화된 테이블로 변환되며,
+// 빈 셀은 항목이 없는 것으로 취급합니다.
+const MatrixMarker = "#matrix"
+
+// 매트릭스 테이블의 컬럼 이름
+const (
+	MatrixRowKey = "RowKey"
+	MatrixColKey = "ColKey"
+	MatrixValue  = "Value"
+)
+
+// matrixValueType은 A1 셀이 매트릭스 마커이면 값 타입 이름을 반환합니다.
+func matrixValueType(rows [][]string) (string, bool) {
+	if len(rows) == 0 {
+		return "", false
+	}
+	marker := cellAt(rows[0], 0)
+	lower := strings.ToLower(marker)
+	if lower != MatrixMarker && !strings.HasPrefix(lower, MatrixMarker+":") {
+		return "", false
+	}
+
+	typeStr := strings.TrimSpace(strings.TrimPrefix(marker[len(MatrixMarker):], ":"))
+	if typeStr == "" {
+		typeStr = "float"
+	}
+	return typeStr, true
+}
+
+// parseMatrixSheet는 매트릭스 시트를 RowKey, ColKey, Value 테이블로 파싱합니다.
+func parseMatrixSheet(sheetName string, rows [][]string, typeStr string) (Table, error) {
+	valueType := ParseColumnType(typeStr)
+	switch valueType.Type.Kind() {
+	case reflect.Int32, reflect.Int64, reflect.Float64:
+	default:
+		return Table{}, fmt.Errorf("matrix value type must be numeric, got %s", typeStr)
+	}
+	if valueType.IsArray {
+		return Table{}, fmt.Errorf("matrix value type must be numeric, got %s", typeStr)
+	}
+
+	table := Table{
+		Name:      formatTableName(sheetName),
+		SheetName: sheetName,
+		IsMatrix:  true,
+		Columns: []Column{
+			{Name: MatrixRowKey, Type: StringType, Tags: []TagValue{{Tag: TagIndex}}},
+			{Name: MatrixColKey, Type: StringType},
+			{Name: MatrixValue, Type: valueType},
+		},
+	}
+	parser := CreateParser(table.Columns[2])
+
+	// 열 키 (1행의 B열부터)
+	colKeys := make([]string, len(rows[0]))
+	seenCols := make(map[string]bool)
+	for c := 1; c < len(rows[0]); c++ {
+		key := cellAt(rows[0], c)
+		if key == "" {
+			continue
+		}
+		if seenCols[key] {
+			return table, fmt.Errorf("duplicate column key %s", key)
+		}
+		seenCols[key] = true
+		colKeys[c] = key
+	}
+
+	seenRows := make(map[string]int)
+	for r := 1; r < len(rows); r++ {
+		rowKey := cellAt(rows[r], 0)
+		if rowKey == "" {
+			continue
+		}
+		if prev, ok := seenRows[rowKey]; ok {
+			return table, fmt.Errorf("row %d: duplicate row key %s (first defined in row %d)", r+1, rowKey, prev)
+		}
+		seenRows[rowKey] = r + 1
+
+		for c := 1; c < len(rows[r]); c++ {
+			cell := cellAt(rows[r], c)
+			if cell == "" {
+				continue
+			}
+			if c >= len(colKeys) || colKeys[c] == "" {
+				return table, fmt.Errorf("row %d: value %s has no column key", r+1, describeCell(cell))
+			}
+
+			value, err := parser.Parse(cell)
+			if err != nil {
+				return table, fmt.Errorf("row %d, column %s: %v", r+1, colKeys[c], err)
+			}
+			table.Rows = append(table.Rows, []interface{}{rowKey, colKeys[c], value.Interface()})
+		}
+	}
+
+	return table, nil
+}
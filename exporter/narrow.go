@@ -0,0 +1,269 @@
+// exporter/narrow.go
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// narrowType은 정수 컬럼을 생성 코드에서 담는 타입입니다.
+type narrowType struct {
+	Name     string // narrow 태그 값과 로그에 쓰는 이름
+	Min, Max int64
+	Go       string
+	Cpp      string
+	CS       string
+}
+
+// narrowTypes는 작은 타입부터 나열하며, 같은 크기에서는 음수가 없을 때 unsigned 타입이 먼저 선택됩니다.
+// uint32는 C++ 쿼리 레이어의 bind 오버로드가 모호해지므로 쓰지 않습니다.
+var narrowTypes = []narrowType{
+	{"uint8", 0, math.MaxUint8, "uint8", "uint8_t", "byte"},
+	{"int8", math.MinInt8, math.MaxInt8, "int8", "int8_t", "sbyte"},
+	{"uint16", 0, math.MaxUint16, "uint16", "uint16_t", "ushort"},
+	{"int16", math.MinInt16, math.MaxInt16, "int16", "int16_t", "short"},
+	{"int32", math.MinInt32, math.MaxInt32, "int32", "int32_t", "int"},
+	{"int64", math.MinInt64, math.MaxInt64, "int64", "int64_t", "long"},
+}
+
+func findNarrowType(name string) (narrowType, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, nt := range narrowTypes {
+		if nt.Name == name {
+			return nt, true
+		}
+	}
+	return narrowType{}, false
+}
+
+func narrowTypeNames() string {
+	names := make([]string, len(narrowTypes))
+	for i, nt := range narrowTypes {
+		names[i] = nt.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// intRange는 정수 컬럼이 가질 수 있는 값의 범위입니다.
+type intRange struct {
+	Min, Max int64
+	Set      bool
+}
+
+func (r *intRange) add(n int64) {
+	if !r.Set || n < r.Min {
+		r.Min = n
+	}
+	if !r.Set || n > r.Max {
+		r.Max = n
+	}
+	r.Set = true
+}
+
+func (r *intRange) merge(o intRange) {
+	if o.Set {
+		r.add(o.Min)
+		r.add(o.Max)
+	}
+}
+
+// narrowest는 범위를 담는 가장 작은 타입입니다.
+func (r intRange) narrowest() narrowType {
+	for _, nt := range narrowTypes {
+		if r.Min >= nt.Min && r.Max <= nt.Max {
+			return nt
+		}
+	}
+	return narrowTypes[len(narrowTypes)-1]
+}
+
+func isIntColumn(col Column) bool {
+	if col.Type.IsArray || col.Type.IsGeo() {
+		return false
+	}
+	kind := col.Type.Type.Kind()
+	return kind == reflect.Int32 || kind == reflect.Int64
+}
+
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	}
+	return 0, false
+}
+
+// columnIntRange는 정수 컬럼의 실제 데이터와 min/max 태그를 합친 범위입니다.
+// 선언된 범위까지 포함하므로 범위 안에서 바뀐 데이터는 다시 생성하지 않아도 담을 수 있습니다.
+func columnIntRange(table Table, c int) intRange {
+	var r intRange
+	for _, row := range table.Rows {
+		if c < len(row) {
+			if n, ok := toInt64(row[c]); ok {
+				r.add(n)
+			}
+		}
+	}
+	if b, err := ColumnBounds(table.Columns[c]); err == nil && r.Set {
+		if b.HasMin {
+			r.add(int64(math.Max(math.Floor(b.Min), math.MinInt64)))
+		}
+		if b.HasMax {
+			r.add(int64(math.Min(math.Ceil(b.Max), math.MaxInt64)))
+		}
+	}
+	return r
+}
+
+// narrowIntTypes는 테이블.컬럼마다 생성 코드에서 쓸 정수 타입을 정합니다.
+// narrow 태그로 타입을 지정한 컬럼은 항상 그 타입을 쓰고, auto이면 나머지 정수 컬럼도 데이터 범위에 맞는 가장 작은 타입을 씁니다.
+// 외래 키, ref, parent 컬럼과 참조되는 키 컬럼은 값을 주고받으므로 범위를 합쳐 같은 타입으로 맞춥니다.
+// 시트에 선언된 타입과 SQLite 저장 방식은 바뀌지 않습니다. (SQLite는 정수를 이미 값에 맞는 바이트 수로 저장합니다)
+func narrowIntTypes(tables []Table, auto bool) map[string]narrowType {
+	result := make(map[string]narrowType)
+	ranges := make(map[string]intRange)
+	parent := make(map[string]string)
+	byName := make(map[string]Table, len(tables))
+	for _, table := range tables {
+		byName[table.Name] = table
+	}
+
+	var find func(id string) string
+	find = func(id string) string {
+		if p, ok := parent[id]; ok && p != id {
+			root := find(p)
+			parent[id] = root
+			return root
+		}
+		return id
+	}
+	union := func(a, b string) {
+		if _, ok := ranges[a]; !ok {
+			return
+		}
+		if _, ok := ranges[b]; !ok {
+			return
+		}
+		if ra, rb := find(a), find(b); ra != rb {
+			parent[ra] = rb
+		}
+	}
+	columnID := func(table, column string) string {
+		return table + "." + column
+	}
+
+	for _, table := range tables {
+		for i, col := range table.Columns {
+			if !isIntColumn(col) {
+				continue
+			}
+			if value, ok := GetTagValue(col.Tags, TagNarrow); ok {
+				if nt, ok := findNarrowType(value); ok {
+					result[columnID(table.Name, col.Name)] = nt
+				}
+				continue
+			}
+			if auto {
+				ranges[columnID(table.Name, col.Name)] = columnIntRange(table, i)
+			}
+		}
+	}
+	if !auto {
+		return result
+	}
+
+	for _, table := range tables {
+		if len(table.Columns) == 0 {
+			continue
+		}
+		for _, rel := range table.Relations {
+			if rel.RelationType == "belongsTo" {
+				union(columnID(table.Name, rel.ForeignKey), columnID(rel.TargetTable, rel.ReferenceKey))
+			}
+		}
+		for _, col := range table.Columns {
+			if target, ok := byName[col.Type.RefTable]; ok && len(target.Columns) > 0 {
+				union(columnID(table.Name, col.Name), columnID(target.Name, target.Columns[IndexColumn(target)].Name))
+			}
+			if HasTag(col.Tags, TagParent) {
+				union(columnID(table.Name, col.Name), columnID(table.Name, table.Columns[IndexColumn(table)].Name))
+			}
+		}
+	}
+
+	merged := make(map[string]intRange)
+	for id, r := range ranges {
+		root := merged[find(id)]
+		root.merge(r)
+		merged[find(id)] = root
+	}
+	for id := range ranges {
+		// 값이 하나도 없고 범위도 선언되지 않은 컬럼은 선언된 타입을 유지
+		if r := merged[find(id)]; r.Set {
+			result[id] = r.narrowest()
+		}
+	}
+	return result
+}
+
+// narrowQueryColumn은 쿼리 레이어 컬럼의 언어별 타입을 좁힙니다. 선언된 타입과 같으면 그대로 둡니다.
+func narrowQueryColumn(qc *queryColumn, nt narrowType) {
+	if nt.Go == qc.GoType {
+		return
+	}
+	qc.GoType, qc.CppType, qc.CSType = nt.Go, nt.Cpp, nt.CS
+	qc.Narrowed = true
+}
+
+// logNarrowing은 선언된 타입과 다른 타입을 쓰는 컬럼을 알립니다.
+func logNarrowing(tables []Table, types map[string]narrowType) {
+	var changed []string
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			nt, ok := types[table.Name+"."+col.Name]
+			if ok && nt.Go != col.Type.Type.Kind().String() {
+				changed = append(changed, fmt.Sprintf("%s.%s %s", table.Name, col.Name, nt.Name))
+			}
+		}
+	}
+	if len(changed) > 0 {
+		log.Printf("narrowed %d integer columns: %s", len(changed), strings.Join(changed, ", "))
+	}
+}
+
+// validateNarrow는 narrow 태그의 타입을 확인하고, 그 타입에 담기지 않는 행을 모두 보고합니다.
+func validateNarrow(table Table, i int) []error {
+	col := table.Columns[i]
+	value, ok := GetTagValue(col.Tags, TagNarrow)
+	if !ok {
+		return nil
+	}
+	if !isIntColumn(col) {
+		return []error{fmt.Errorf("table %s column %s: narrow is only supported for int and int64 columns", table.Name, col.Name)}
+	}
+	nt, ok := findNarrowType(value)
+	if !ok {
+		return []error{fmt.Errorf("table %s column %s: unknown narrow type %q (expected one of %s)", table.Name, col.Name, value, narrowTypeNames())}
+	}
+
+	var errs []error
+	for _, row := range table.Rows {
+		if i >= len(row) {
+			continue
+		}
+		if n, ok := toInt64(row[i]); ok && (n < nt.Min || n > nt.Max) {
+			errs = append(errs, fmt.Errorf("table %s row %s column %s: value %d overflows %s (%d..%d)",
+				table.Name, RowKey(table, row), col.Name, n, nt.Name, nt.Min, nt.Max))
+		}
+	}
+	if b, err := ColumnBounds(col); err == nil && ((b.HasMin && b.Min < float64(nt.Min)) || (b.HasMax && b.Max > float64(nt.Max))) {
+		errs = append(errs, fmt.Errorf("table %s column %s: declared min/max exceed the range of %s (%d..%d)",
+			table.Name, col.Name, nt.Name, nt.Min, nt.Max))
+	}
+	return errs
+}
@@ -1,449 +1,420 @@
 // exporter/nodejs.go
 package exporter
 
-
-// // NodeJSExporter implements code generation for Node.js/TypeScript
-// type NodeJSExporter struct {
-// 	BaseExporter
-// }
-
-// func NewNodeJSExporter() Exporter {
-// 	return &NodeJSExporter{
-// 		BaseExporter: NewBaseExporter("nodejs"),
-// 	}
-// }
-
-// func (e *NodeJSExporter) Export(tables []Table, opts Options) error {
-// 	// TypeScript/TypeORM 사용 여부 확인
-// 	useTypeScript := e.GetBoolOption(opts, "useTypeScript", true)
-// 	useTypeORM := e.GetBoolOption(opts, "useTypeORM", true)
-
-// 	// 1. 출력 디렉토리 생성
-// 	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
-// 		return fmt.Errorf("failed to create output directory: %v", err)
-// 	}
-
-// 	// 2. 인터페이스 생성 (TypeScript)
-// 	if useTypeScript {
-// 		if err := e.generateInterfaces(tables, opts); err != nil {
-// 			return fmt.Errorf("failed to generate interfaces: %v", err)
-// 		}
-// 	}
-
-// 	// 3. 엔티티/모델 생성
-// 	if err := e.generateEntities(tables, opts, useTypeScript, useTypeORM); err != nil {
-// 		return fmt.Errorf("failed to generate entities: %v", err)
-// 	}
-
-// 	// 4. 저장소 생성
-// 	if err := e.generateRepositories(tables, opts, useTypeScript); err != nil {
-// 		return fmt.Errorf("failed to generate repositories: %v", err)
-// 	}
-
-// 	// 5. 마이그레이션 파일 생성
-// 	if e.GetBoolOption(opts, "generateMigrations", true) {
-// 		if err := e.generateMigrations(tables, opts); err != nil {
-// 			return fmt.Errorf("failed to generate migrations: %v", err)
-// 		}
-// 	}
-
-// 	return nil
-// }
-
-// func (e *NodeJSExporter) generateInterfaces(tables []Table, opts Options) error {
-// 	const interfaceTemplate = `// Code generated by excelite. DO NOT EDIT.
-
-// export interface Base {
-//     id?: number;
-//     createdAt?: Date;
-//     updatedAt?: Date;
-//     deletedAt?: Date | null;
-// }
-
-// {{range .Tables}}
-// export interface I{{.Name}} extends Base {
-//     {{range .Columns}}
-//     {{.Name}}{{if not .Required}}?{{end}}: {{.TSType}};
-//     {{end}}
-
-//     {{range .Relations}}
-//     {{if eq .RelationType "hasOne"}}
-//     {{.TargetTable}}?: I{{.TargetTable}};
-//     {{else if eq .RelationType "hasMany"}}
-//     {{.TargetTable}}List?: I{{.TargetTable}}[];
-//     {{end}}
-//     {{end}}
-// }
-// {{end}}`
-
-// 	tmpl, err := template.New("interfaces").Parse(interfaceTemplate)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	data := struct {
-// 		Tables []Table
-// 	}{
-// 		Tables: tables,
-// 	}
-
-// 	var buf bytes.Buffer
-// 	if err := tmpl.Execute(&buf, data); err != nil {
-// 		return err
-// 	}
-
-// 	outputFile := filepath.Join(opts.OutputDir, "interfaces.ts")
-// 	return os.WriteFile(outputFile, buf.Bytes(), 0644)
-// }
-
-// func (e *NodeJSExporter) generateEntities(tables []Table, opts Options, useTS, useTypeORM bool) error {
-// 	const entityTemplate = `{{if .UseTypeORM}}import { Entity, PrimaryGeneratedColumn, Column, CreateDateColumn, UpdateDateColumn, DeleteDateColumn{{range .Decorators}}, {{.}}{{end}} } from "typeorm";{{end}}
-// {{if .UseTypeScript}}import { I{{.Name}} } from "./interfaces";{{end}}
-
-// {{if .UseTypeORM}}@Entity("{{.TableName}}"){{end}}
-// export class {{.Name}}{{if .UseTypeScript}} implements I{{.Name}}{{end}} {
-//     {{if .UseTypeORM}}@PrimaryGeneratedColumn(){{end}}
-//     id!: number;
-
-//     {{range .Columns}}
-//     {{if $.UseTypeORM}}@Column({{.TypeORMOptions}}){{end}}
-//     {{.Name}}{{if not .Required}}?{{end}}: {{.TSType}};
-//     {{end}}
-
-//     {{range .Relations}}
-//     {{if eq .RelationType "hasOne"}}
-//     {{if $.UseTypeORM}}@OneToOne(() => {{.TargetTable}})
-//     @JoinColumn(){{end}}
-//     {{.TargetTable}}?: {{.TargetTable}};
-//     {{else if eq .RelationType "hasMany"}}
-//     {{if $.UseTypeORM}}@OneToMany(() => {{.TargetTable}}, {{.ForeignKey}} => {{.ForeignKey}}.{{.SourceTable}}){{end}}
-//     {{.TargetTable}}List?: {{.TargetTable}}[];
-//     {{end}}
-//     {{end}}
-
-//     {{if .UseTypeORM}}
-//     @CreateDateColumn()
-//     createdAt!: Date;
-
-//     @UpdateDateColumn()
-//     updatedAt!: Date;
-
-//     @DeleteDateColumn()
-//     deletedAt?: Date;
-//     {{end}}
-
-//     constructor(data?: Partial<{{if .UseTypeScript}}I{{.Name}}{{else}}{{.Name}}{{end}}>) {
-//         if (data) {
-//             Object.assign(this, data);
-//         }
-//     }
-// }
-// `
-
-// 	for _, table := range tables {
-// 		tmpl, err := template.New("entity").Parse(entityTemplate)
-// 		if err != nil {
-// 			return err
-// 		}
-
-// 		data := struct {
-// 			Name          string
-// 			TableName     string
-// 			UseTypeORM    bool
-// 			UseTypeScript bool
-// 			Columns       []tsColumn
-// 			Relations     []tsRelation
-// 			Decorators    []string
-// 		}{
-// 			Name:          table.Name,
-// 			TableName:     toSnakeCase(table.Name),
-// 			UseTypeORM:    useTypeORM,
-// 			UseTypeScript: useTS,
-// 			Columns:       convertColumns(table.Columns),
-// 			Relations:     convertRelations(table.Relations),
-// 		}
-
-// 		var buf bytes.Buffer
-// 		if err := tmpl.Execute(&buf, data); err != nil {
-// 			return err
-// 		}
-
-// 		extension := ".ts"
-// 		outputFile := filepath.Join(opts.OutputDir, strings.ToLower(table.Name)+".entity"+extension)
-// 		if err := os.WriteFile(outputFile, buf.Bytes(), 0644); err != nil {
-// 			return err
-// 		}
-// 	}
-
-// 	return nil
-// }
-
-// func (e *NodeJSExporter) generateRepositories(tables []Table, opts Options, useTS bool) error {
-// 	const repoTemplate = `{{if .UseTypeScript}}import { I{{.Name}} } from "./interfaces";{{end}}
-// import { {{.Name}} } from "./{{.LowerName}}.entity";
-// import { DataSource, Repository } from "typeorm";
-
-// export class {{.Name}}Repository {
-//     private repository: Repository<{{.Name}}>;
-
-//     constructor(dataSource: DataSource) {
-//         this.repository = dataSource.getRepository({{.Name}});
-//     }
-
-//     async create(data: {{if .UseTypeScript}}Partial<I{{.Name}}>{{else}}any{{end}}): Promise<{{.Name}}> {
-//         const entity = new {{.Name}}(data);
-//         return this.repository.save(entity);
-//     }
-
-//     async findById(id: number): Promise<{{.Name}} | null> {
-//         return this.repository.findOneBy({ id });
-//     }
-
-//     async findAll(): Promise<{{.Name}}[]> {
-//         return this.repository.find();
-//     }
-
-//     async update(id: number, data: {{if .UseTypeScript}}Partial<I{{.Name}}>{{else}}any{{end}}): Promise<{{.Name}} | null> {
-//         await this.repository.update(id, data);
-//         return this.findById(id);
-//     }
-
-//     async delete(id: number): Promise<boolean> {
-//         const result = await this.repository.softDelete(id);
-//         return result.affected ? result.affected > 0 : false;
-//     }
-
-//     {{range .Relations}}
-//     {{if eq .RelationType "hasOne"}}
-//     async findWith{{.TargetTable}}(id: number): Promise<{{$.Name}} | null> {
-//         return this.repository.findOne({
-//             where: { id },
-//             relations: ["{{.TargetTable}}"]
-//         });
-//     }
-//     {{else if eq .RelationType "hasMany"}}
-//     async findWith{{.TargetTable}}List(id: number): Promise<{{$.Name}} | null> {
-//         return this.repository.findOne({
-//             where: { id },
-//             relations: ["{{.TargetTable}}List"]
-//         });
-//     }
-//     {{end}}
-//     {{end}}
-// }
-// `
-
-// 	for _, table := range tables {
-// 		tmpl, err := template.New("repository").Parse(repoTemplate)
-// 		if err != nil {
-// 			return err
-// 		}
-
-// 		data := struct {
-// 			Name          string
-// 			LowerName     string
-// 			UseTypeScript bool
-// 			Relations     []tsRelation
-// 		}{
-// 			Name:          table.Name,
-// 			LowerName:     strings.ToLower(table.Name),
-// 			UseTypeScript: useTS,
-// 			Relations:     convertRelations(table.Relations),
-// 		}
-
-// 		var buf bytes.Buffer
-// 		if err := tmpl.Execute(&buf, data); err != nil {
-// 			return err
-// 		}
-
-// 		extension := ".ts"
-// 		outputFile := filepath.Join(opts.OutputDir, strings.ToLower(table.Name)+".repository"+extension)
-// 		if err := os.WriteFile(outputFile, buf.Bytes(), 0644); err != nil {
-// 			return err
-// 		}
-// 	}
-
-// 	return nil
-// }
-
-// func (e *NodeJSExporter) generateMigrations(tables []Table, opts Options) error {
-// 	const migrationTemplate = `import { MigrationInterface, QueryRunner, Table } from "typeorm";
-
-// export class InitialMigration implements MigrationInterface {
-//     public async up(queryRunner: QueryRunner): Promise<void> {
-//         {{range .Tables}}
-//         await queryRunner.createTable(new Table({
-//             name: "{{.TableName}}",
-//             columns: [
-//                 {
-//                     name: "id",
-//                     type: "int",
-//                     isPrimary: true,
-//                     isGenerated: true,
-//                     generationStrategy: "increment",
-//                 },
-//                 {{range .Columns}}
-//                 {
-//                     name: "{{.Name}}",
-//                     type: "{{.DBType}}",
-//                     {{if .IsUnique}}isUnique: true,{{end}}
-//                     {{if not .Required}}isNullable: true,{{end}}
-//                 },
-//                 {{end}}
-//                 {
-//                     name: "created_at",
-//                     type: "timestamp",
-//                     default: "now()",
-//                 },
-//                 {
-//                     name: "updated_at",
-//                     type: "timestamp",
-//                     default: "now()",
-//                 },
-//                 {
-//                     name: "deleted_at",
-//                     type: "timestamp",
-//                     isNullable: true,
-//                 },
-//             ],
-//         }));
-//         {{end}}
-//     }
-
-//     public async down(queryRunner: QueryRunner): Promise<void> {
-//         {{range .Tables}}
-//         await queryRunner.dropTable("{{.TableName}}");
-//         {{end}}
-//     }
-// }
-// `
-
-// 	tmpl, err := template.New("migration").Parse(migrationTemplate)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	data := struct {
-// 		Tables []Table
-// 	}{
-// 		Tables: tables,
-// 	}
-
-// 	var buf bytes.Buffer
-// 	if err := tmpl.Execute(&buf, data); err != nil {
-// 		return err
-// 	}
-
-// 	timestamp := time.Now().Format("20060102150405")
-// 	outputFile := filepath.Join(opts.OutputDir, fmt.Sprintf("%s-initial-migration.ts", timestamp))
-// 	return os.WriteFile(outputFile, buf.Bytes(), 0644)
-// }
-
-// // Helper types and functions
-// type tsColumn struct {
-// 	Name           string
-// 	TSType         string
-// 	DBType         string
-// 	Required       bool
-// 	IsUnique       bool
-// 	TypeORMOptions string
-// }
-
-// type tsRelation struct {
-// 	RelationType string
-// 	SourceTable  string
-// 	TargetTable  string
-// 	ForeignKey   string
-// }
-
-// func convertColumns(columns []Column) []tsColumn {
-// 	result := make([]tsColumn, len(columns))
-// 	for i, col := range columns {
-// 		result[i] = tsColumn{
-// 			Name:           col.Name,
-// 			TSType:         getTSType(col.Type),
-// 			DBType:         getDBType(col.Type),
-// 			Required:       !contains(col.Tags, "nullable"),
-// 			IsUnique:       col.IsUnique,
-// 			TypeORMOptions: buildTypeORMOptions(col),
-// 		}
-// 	}
-// 	return result
-// }
-
-// func convertRelations(relations []Relation) []tsRelation {
-// 	result := make([]tsRelation, len(relations))
-// 	for i, rel := range relations {
-// 		result[i] = tsRelation{
-// 			RelationType: rel.RelationType,
-// 			SourceTable:  rel.SourceTable,
-// 			TargetTable:  rel.TargetTable,
-// 			ForeignKey:   rel.ForeignKey,
-// 		}
-// 	}
-// 	return result
-// }
-
-// func getTSType(dbType string) string {
-// 	switch strings.ToLower(dbType) {
-// 	case "int", "integer", "int64":
-// 		return "number"
-// 	case "float", "real", "double":
-// 		return "number"
-// 	case "bool", "boolean":
-// 		return "boolean"
-// 	case "datetime":
-// 		return "Date"
-// 	default:
-// 		return "string"
-// 	}
-// }
-
-// func getDBType(dbType string) string {
-// 	switch strings.ToLower(dbType) {
-// 	case "int", "integer":
-// 		return "integer"
-// 	case "int64", "bigint":
-// 		return "bigint"
-// 	case "float", "real":
-// 		return "float"
-// 	case "double":
-// 		return "double precision"
-// 	case "bool", "boolean":
-// 		return "boolean"
-// 	case "datetime":
-// 		return "timestamp"
-// 	default:
-// 		return "varchar"
-// 	}
-// }
-
-// func buildTypeORMOptions(col Column) string {
-// 	var opts []string
-
-// 	// 타입 설정
-// 	opts = append(opts, fmt.Sprintf(`type: "%s"`, getDBType(col.Type)))
-
-// 	// 유니크 설정
-// 	if col.IsUnique {
-// 		opts = append(opts, "unique: true")
-// 	}
-
-// 	// Nullable 설정
-// 	if contains(col.Tags, "nullable") {
-// 		opts = append(opts, "nullable: true")
-// 	}
-
-// 	return fmt.Sprintf("{ %s }", strings.Join(opts, ", "))
-// }
-
-// func toSnakeCase(str string) string {
-// 	var result strings.Builder
-// 	for i, r := range str {
-// 		if i > 0 && 'A' <= r && r <= 'Z' {
-// 			result.WriteByte('_')
-// 		}
-// 		result.WriteRune(unicode.ToLower(r))
-// 	}
-// 	return result.String()
-// }
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// NodeJSExporter는 TypeORM 엔티티를 TypeScript로 생성합니다.
+// 테이블마다 <table>.entity.ts에는 엔티티 클래스가, index.ts에는 모든 엔티티 목록이, data-source.ts에는 SQLite(better-sqlite3) DataSource 설정이 들어갑니다.
+// seed 옵션이면 시트의 행을 seed/<Table>.json으로 쓰고 이를 DB에 넣는 seed.ts를 함께 생성합니다.
+type NodeJSExporter struct {
+	BaseExporter
+}
+
+func NewNodeJSExporter() Exporter {
+	return &NodeJSExporter{
+		BaseExporter: NewBaseExporter("nodejs"),
+	}
+}
+
+func (e *NodeJSExporter) Describe() ExporterInfo {
+	return ExporterInfo{
+		Description: "TypeScript TypeORM entities with a better-sqlite3 data source and a seed script",
+		Outputs:     []string{"index.ts", "<table>.entity.ts", "data-source.ts", "epoch.ts", "seed.ts", "seed/<Table>.json"},
+		Options: []OptionInfo{
+			{Key: OptNodeUseTypeORM, Type: "bool", Default: "true", Description: "generate TypeORM entities (false is not supported; use the zod or typebox exporter for plain schemas)"},
+			{Key: OptNodeSeed, Type: "bool", Default: "true", Description: "also write seed.ts and the sheet rows in seed/<Table>.json to load them into the data source"},
+			{Key: OptModelKey, Type: "string", Default: DefaultAuditPolicy.Key, Description: "primary key: id (injected auto-increment id) or sheet (the sheet's index column)"},
+			{Key: OptTimestamps, Type: "string", Default: DefaultAuditPolicy.Timestamps, Description: "injected created_at/updated_at type: time, unix, unixmilli or none"},
+			{Key: OptSoftDelete, Type: "bool", Default: fmt.Sprint(DefaultAuditPolicy.SoftDelete), Description: "inject deleted_at (a datetime, as TypeORM requires) for soft delete"},
+		},
+		Types: typeMappings(func(col Column) (string, error) {
+			return typeormTSType(col) + " (" + typeormColumnType(col, DateTimeRFC3339) + ")", nil
+		}),
+	}
+}
+
+// typeormReservedNames는 생성하는 파일이 선언하거나 가져오는 이름입니다. 같은 이름의 테이블은 컴파일되지 않습니다.
+var typeormReservedNames = []string{
+	"Entity", "Column", "PrimaryColumn", "PrimaryGeneratedColumn", "Index", "Unique",
+	"CreateDateColumn", "UpdateDateColumn", "DeleteDateColumn", "BeforeInsert", "BeforeUpdate",
+	"DataSource", "AppDataSource", "Date", "Buffer",
+}
+
+// typeormEntity는 엔티티 파일 하나의 템플릿 데이터입니다.
+type typeormEntity struct {
+	Name       string
+	File       string   // 출력 파일 이름
+	Module     string   // index.ts에서 가져올 모듈 경로 (./item.entity)
+	Imports    []string // typeorm에서 가져올 데코레이터
+	Indexes    []string // 클래스의 복합 인덱스 데코레이터
+	Fields     []string // 주입되는 프로퍼티 선언 (AuditPolicy)
+	Properties []typeormProperty
+	Now        string // 정수 타임스탬프를 채우는 식 (비어 있으면 리스너를 만들지 않음)
+	Epoch      bool   // epoch.ts의 epochTransformer를 사용 (datetimeStorage=epoch)
+}
+
+// typeormProperty는 시트 컬럼 하나의 프로퍼티입니다.
+type typeormProperty struct {
+	Name       string // 프로퍼티 이름 (식별자가 아니면 따옴표로 감쌈)
+	Type       string // TypeScript 타입 (nullable이면 | null 포함)
+	Decorators []string
+	Deprecated string // 폐기 예정 안내 메시지
+}
+
+// typeormSeed는 seed.ts가 읽는 테이블 하나입니다.
+type typeormSeed struct {
+	Name  string
+	File  string
+	Dates []string // RFC 3339 텍스트로 저장된 datetime 컬럼
+	Blobs []string // base64로 저장된 blob 컬럼
+}
+
+func (e *NodeJSExporter) Export(tables []Table, opts Options) error {
+	if !e.GetBoolOption(opts, OptNodeUseTypeORM, true) {
+		return fmt.Errorf("%s=false is not supported; use the zod or typebox exporter for TypeScript without TypeORM", OptNodeUseTypeORM)
+	}
+	if errs := rejectInterned(tables, e.Language()); len(errs) > 0 {
+		return errs[0]
+	}
+
+	// 1. 출력 디렉토리 생성
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	// 2. TypeORM 엔티티 생성
+	if err := e.generateEntities(tables, opts); err != nil {
+		return fmt.Errorf("failed to generate entities: %v", err)
+	}
+
+	return nil
+}
+
+func (e *NodeJSExporter) generateEntities(tables []Table, opts Options) error {
+	policy, err := auditPolicy(e.BaseExporter, opts)
+	if err != nil {
+		return err
+	}
+	auditFields := policy.typeormFields()
+
+	data := struct {
+		Database string
+		Epoch    bool
+		Entities []typeormEntity
+		Seeds    []typeormSeed
+		SeedExt  string // 산출물 단계가 seed 파일에 붙이는 압축 확장자 (.gz, .zst)
+		Encrypt  bool   // seed 파일이 암호화되어 loader/decrypt.ts로 읽어야 함
+	}{
+		Database: opts.PackageName + ".db",
+		Encrypt:  boolOption(opts, OptEncrypt, false),
+	}
+	if opts.PackageName == "" {
+		data.Database = "data.db"
+	}
+	// seed 파일도 데이터 산출물이라 --compress/--encrypt로 이름이 바뀌므로 seed.ts가 바뀐 파일을 풀어 읽음
+	if algo := stringOption(opts, OptCompress, ""); algo != "" {
+		ext, err := compressionExt(algo)
+		if err != nil {
+			return err
+		}
+		data.SeedExt = ext
+	}
+
+	files := make(map[string]string, len(tables))
+	for _, table := range tables {
+		if err := policy.validateTable(table, auditFields); err != nil {
+			return err
+		}
+		if containsString(typeormReservedNames, table.Name) {
+			return fmt.Errorf("table %s conflicts with the generated declaration of the same name", table.Name)
+		}
+		if len(table.Columns) == 0 {
+			continue
+		}
+
+		entity := typeormEntity{
+			Name:   table.Name,
+			File:   strings.ReplaceAll(toSnakeCase(table.Name), "_", "-") + ".entity.ts",
+			Now:    policy.typeormNow(),
+			Fields: make([]string, 0, len(auditFields)),
+		}
+		entity.Module = "./" + strings.TrimSuffix(entity.File, ".ts")
+		if prev, ok := files[entity.File]; ok {
+			return fmt.Errorf("tables %s and %s are both written to %s", prev, table.Name, entity.File)
+		}
+		files[entity.File] = table.Name
+		for _, f := range auditFields {
+			entity.Fields = append(entity.Fields, f.Decl)
+		}
+
+		// 이름이 있는 index 태그는 같은 이름의 컬럼들과 함께 클래스의 복합 인덱스가 됨
+		for _, idx := range CompositeIndexes(table) {
+			names := make([]string, len(idx.Columns))
+			for i, c := range idx.Columns {
+				names[i] = jsString(table.Columns[c].Name)
+			}
+			entity.Indexes = append(entity.Indexes, fmt.Sprintf("@Index(%s, [%s])", jsString(idx.Name), strings.Join(names, ", ")))
+		}
+
+		seed := typeormSeed{Name: table.Name, File: table.Name + ".json" + data.SeedExt}
+		if data.Encrypt {
+			seed.File += EncryptedExt
+		}
+		for j, col := range table.Columns {
+			if col.Name == "stampCreated" || col.Name == "stampUpdated" {
+				return fmt.Errorf("table %s: column %s conflicts with the generated listener of the same name", table.Name, col.Name)
+			}
+			primaryKey := policy.Key == ModelKeySheet && isSheetKey(table, j)
+			prop := typeormProperty{
+				Name:       tsPropertyName(col.Name),
+				Type:       typeormTSType(col),
+				Decorators: typeormDecorators(col, primaryKey, tableDateTimeStorage(table)),
+			}
+			if !primaryKey && !HasTag(col.Tags, TagNotNull) {
+				prop.Type += " | null"
+			}
+			prop.Deprecated, _ = DeprecationMessage(col)
+			entity.Properties = append(entity.Properties, prop)
+
+			switch {
+			case col.Type.IsArray:
+			case col.Type.Type == DateTimeType.Type:
+				seed.Dates = append(seed.Dates, jsString(col.Name))
+			case col.Type.Type == BytesType.Type:
+				seed.Blobs = append(seed.Blobs, jsString(col.Name))
+			}
+		}
+
+		entity.Epoch = tableDateTimeStorage(table) == DateTimeEpoch && hasDateTimeColumn(table)
+		data.Epoch = data.Epoch || entity.Epoch
+		entity.Imports = typeormImports(entity)
+		data.Entities = append(data.Entities, entity)
+		data.Seeds = append(data.Seeds, seed)
+	}
+
+	if err := writeTypeORMFile(opts, "nodejs/index.ts.tmpl", "index.ts", data); err != nil {
+		return err
+	}
+	for _, entity := range data.Entities {
+		if err := writeTypeORMFile(opts, "nodejs/entity.ts.tmpl", entity.File, entity); err != nil {
+			return err
+		}
+	}
+	if err := writeTypeORMFile(opts, "nodejs/data-source.ts.tmpl", "data-source.ts", data); err != nil {
+		return err
+	}
+	if data.Epoch {
+		if err := writeTypeORMFile(opts, "nodejs/epoch.ts.tmpl", "epoch.ts", data); err != nil {
+			return err
+		}
+	}
+	if !e.GetBoolOption(opts, OptNodeSeed, true) {
+		return nil
+	}
+
+	// 시드 데이터는 JSON exporter와 같은 형식(행 객체의 배열)이며, 그룹과 샤드와 관계없이 테이블마다 한 파일
+	if err := os.MkdirAll(filepath.Join(opts.OutputDir, "seed"), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+	for _, table := range tables {
+		if len(table.Columns) == 0 {
+			continue
+		}
+		rows := make([]map[string]interface{}, 0, len(table.Rows))
+		for _, row := range table.Rows {
+			rows = append(rows, patchValues(table, row))
+		}
+		if err := writeJSONFile(filepath.Join(opts.OutputDir, "seed", table.Name+".json"), rows); err != nil {
+			return err
+		}
+	}
+	return writeTypeORMFile(opts, "nodejs/seed.ts.tmpl", "seed.ts", data)
+}
+
+// typeormDecorators는 컬럼의 프로퍼티 데코레이터입니다.
+// 타입과 nullable, 그리고 태그의 TypeORM 매핑(tagInfoMap) 중 @Column({ ... })인 것들은 한 @Column(또는 @PrimaryColumn)으로 합치고,
+// @Unique(), @Index() 등은 그대로 붙입니다. 이름이 있는 index 태그는 클래스의 복합 인덱스이고, validate 태그는 zod exporter가 다룹니다.
+// storage는 테이블의 datetimeStorage이며, epoch이면 datetime 컬럼은 Date를 Unix 초로 바꾸는 epochTransformer를 씁니다.
+func typeormDecorators(col Column, primaryKey bool, storage string) []string {
+	options := []string{"type: " + jsString(typeormColumnType(col, storage))}
+	keys := map[string]bool{"type": true}
+	if isEpochDateTime(col, storage) {
+		options = append(options, "transformer: epochTransformer")
+		keys["transformer"] = true
+	}
+	var others []string
+	add := func(decorator string) {
+		body := strings.TrimSuffix(strings.TrimPrefix(decorator, "@Column({ "), " })")
+		if body == decorator {
+			if !containsString(others, decorator) {
+				others = append(others, decorator)
+			}
+			return
+		}
+		key := strings.TrimSpace(strings.SplitN(body, ":", 2)[0])
+		if !keys[key] {
+			keys[key] = true
+			options = append(options, body)
+		}
+	}
+
+	if !primaryKey && !HasTag(col.Tags, TagNotNull) {
+		add("@Column({ nullable: true })")
+	}
+	for _, tag := range col.Tags {
+		switch {
+		case tag.Tag == TagIndex && tag.Value != "", tag.Tag == TagValidate:
+			continue
+		case tag.Tag == TagNotNull && primaryKey:
+			continue
+		case tag.Tag == TagSize && (col.Type.IsArray || col.Type.Type.Kind() != reflect.String):
+			continue
+		case tag.Tag == TagDefault:
+			tag.Value = typeormDefault(col, tag.Value)
+		}
+		if decorator := tag.GetFrameworkTag(FrameworkTypeORM); decorator != "" {
+			add(decorator)
+		}
+	}
+	if keys["length"] {
+		options[0] = `type: "varchar"`
+	}
+
+	column := "@Column"
+	if primaryKey {
+		column = "@PrimaryColumn"
+	}
+	return append([]string{column + "({ " + strings.Join(options, ", ") + " })"}, others...)
+}
+
+// typeormDefault는 default 태그의 값을 TypeScript 리터럴로 바꿉니다. 숫자와 bool 컬럼의 값이 아니면 문자열입니다.
+func typeormDefault(col Column, value string) string {
+	value = strings.TrimSpace(value)
+	if !col.Type.IsArray {
+		switch col.Type.Type.Kind() {
+		case reflect.Int32, reflect.Int64, reflect.Float64:
+			if _, err := strconv.ParseFloat(value, 64); err == nil {
+				return value
+			}
+		case reflect.Bool:
+			if b, err := strconv.ParseBool(value); err == nil {
+				return strconv.FormatBool(b)
+			}
+		}
+	}
+	return jsString(value)
+}
+
+// typeormColumnType은 컬럼의 TypeORM 컬럼 타입입니다. 배열, 좌표, 커브는 JSON 텍스트(simple-json)로 저장합니다.
+// epoch로 저장하는 datetime은 SQLite exporter와 같이 Unix 초의 integer입니다.
+func typeormColumnType(col Column, storage string) string {
+	switch {
+	case isEpochDateTime(col, storage):
+		return "integer"
+	case col.Type.IsArray || col.Type.IsGeo() || col.Type.IsCurve():
+		return "simple-json"
+	case col.Type.IsFormula():
+		return "text"
+	case col.Type.Type == DateTimeType.Type:
+		return "datetime"
+	case col.Type.Type == BytesType.Type:
+		return "blob"
+	}
+	switch col.Type.Type.Kind() {
+	case reflect.Int32:
+		return "integer"
+	case reflect.Int64:
+		return "bigint"
+	case reflect.Float64:
+		return "real"
+	case reflect.Bool:
+		return "boolean"
+	}
+	return "text"
+}
+
+// typeormTSType은 컬럼의 프로퍼티 타입입니다. (null 제외)
+func typeormTSType(col Column) string {
+	if col.Type.IsArray {
+		return typeormTSType(Column{Type: *col.Type.BaseType}) + "[]"
+	}
+	switch {
+	case col.Type.IsGeo():
+		return "[number, number]"
+	case col.Type.IsCurve():
+		return `{ mode: "linear" | "bezier"; keys: number[][] }`
+	case col.Type.IsFormula():
+		return "string"
+	case col.Type.Type == DateTimeType.Type:
+		return "Date"
+	case col.Type.Type == BytesType.Type:
+		return "Buffer"
+	}
+	switch col.Type.Type.Kind() {
+	case reflect.Int32, reflect.Int64, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	}
+	return "string"
+}
+
+var typeormDecoratorName = regexp.MustCompile(`@(\w+)\(`)
+
+// typeormImports는 엔티티 파일이 typeorm에서 가져올 데코레이터들입니다.
+func typeormImports(entity typeormEntity) []string {
+	used := map[string]bool{"Entity": true}
+	scan := func(decl string) {
+		for _, m := range typeormDecoratorName.FindAllStringSubmatch(decl, -1) {
+			used[m[1]] = true
+		}
+	}
+	for _, decl := range entity.Indexes {
+		scan(decl)
+	}
+	for _, decl := range entity.Fields {
+		scan(decl)
+	}
+	for _, prop := range entity.Properties {
+		for _, decl := range prop.Decorators {
+			scan(decl)
+		}
+	}
+	if entity.Now != "" {
+		used["BeforeInsert"], used["BeforeUpdate"] = true, true
+	}
+	imports := make([]string, 0, len(used))
+	for name := range used {
+		imports = append(imports, name)
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// writeTypeORMFile은 템플릿을 실행한 결과를 출력 디렉토리에 씁니다.
+func writeTypeORMFile(opts Options, tmplName, fileName string, data interface{}) error {
+	tmplText, err := loadTemplate(opts.TemplateDir, tmplName)
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("nodejs").Funcs(template.FuncMap{"js": jsString, "join": strings.Join}).Parse(tmplText)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(opts.OutputDir, fileName), buf.Bytes(), 0644)
+}
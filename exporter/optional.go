@@ -0,0 +1,150 @@
+// exporter/optional.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// OptionalGroupSheetPrefix는 선택적 컬럼 그룹을 선언하는 시트 이름의 접두사입니다.
+// 엑셀 시트 이름에는 :를 쓸 수 없으므로 #optional:weapon_stats 대신 #optional.weapon_stats로 씁니다.
+// #optional.weapon_stats 시트는 일반 시트와 같은 헤더 3줄(컬럼명, 태그, 타입)로 그룹의 컬럼을 한 번만 선언합니다.
+//
+// 그룹을 쓰는 테이블은 #Config에 optional 키로 그룹 이름을 지정합니다. (쉼표로 여러 개)
+// 시트에 그룹의 컬럼이 없으면 NULL 값의 컬럼으로 추가되므로, 스키마를 공유하는 변형 시트들이 모든 컬럼을 가질 필요가 없습니다.
+const OptionalGroupSheetPrefix = "#optional."
+
+// OptionalGroup은 #optional.<이름> 시트로 선언한 선택적 컬럼 그룹입니다.
+type OptionalGroup struct {
+	Name    string
+	Columns []Column
+}
+
+// parseOptionalGroups는 워크북의 #optional.<이름> 시트들을 파싱합니다.
+func parseOptionalGroups(f *excelize.File) (map[string]OptionalGroup, error) {
+	groups := make(map[string]OptionalGroup)
+	for _, sheetName := range f.GetSheetList() {
+		name, ok := strings.CutPrefix(sheetName, OptionalGroupSheetPrefix)
+		if !ok {
+			continue
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			return nil, fmt.Errorf("sheet %s: optional group needs a name", sheetName)
+		}
+
+		rows, err := f.GetRows(sheetName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sheet %s: %v", sheetName, err)
+		}
+		if len(rows) < 3 {
+			return nil, fmt.Errorf("sheet %s: optional group needs name, tag and type rows", sheetName)
+		}
+
+		table, _, err := parseHeader(sheetName, rows[:3], headerRules{})
+		if err != nil {
+			return nil, fmt.Errorf("sheet %s: %v", sheetName, err)
+		}
+		if len(table.Columns) == 0 {
+			return nil, fmt.Errorf("sheet %s: optional group declares no columns", sheetName)
+		}
+		for i := range table.Columns {
+			table.Columns[i].Optional = name
+		}
+		groups[name] = OptionalGroup{Name: name, Columns: table.Columns}
+	}
+	return groups, nil
+}
+
+// tableOptionalGroups는 시트에 설정된 optional 그룹들을 반환합니다.
+func tableOptionalGroups(groups map[string]OptionalGroup, entries []ConfigEntry, sheetName string) ([]OptionalGroup, error) {
+	value := configValue(entries, sheetName, ConfigKeyOptional)
+	if value == "" {
+		return nil, nil
+	}
+
+	var result []OptionalGroup
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		group, ok := groups[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown optional group %s (declare it in a %s%s sheet)", name, OptionalGroupSheetPrefix, name)
+		}
+		result = append(result, group)
+	}
+	return result, nil
+}
+
+// applyOptionalGroups는 테이블에 optional 그룹의 컬럼을 반영합니다.
+// 시트에 있는 컬럼은 선언된 타입과 같아야 하며 그룹 소속으로 표시되고,
+// 시트에 없는 컬럼은 끝에 추가되어 모든 행에서 NULL입니다.
+func applyOptionalGroups(table Table, groups []OptionalGroup) (Table, error) {
+	for _, group := range groups {
+		for _, col := range group.Columns {
+			if idx := columnIndex(table, col.Name); idx != -1 {
+				if ColumnTypeName(table.Columns[idx].Type) != ColumnTypeName(col.Type) {
+					return table, fmt.Errorf("column %s: type %s does not match %s declared by optional group %s",
+						col.Name, ColumnTypeName(table.Columns[idx].Type), ColumnTypeName(col.Type), group.Name)
+				}
+				table.Columns[idx].Optional = group.Name
+				continue
+			}
+
+			table.Columns = append(table.Columns, col)
+			for r := range table.Rows {
+				table.Rows[r] = append(table.Rows[r], nil)
+			}
+		}
+	}
+	return table, nil
+}
+
+// FilterOptionalGroups는 features에 없는 optional 그룹의 컬럼을 모든 테이블에서 제거합니다.
+// 꺼진 그룹의 컬럼은 DB 스키마와 생성된 구조체 필드에서 빠집니다. features가 nil이면 모든 그룹이 켜진 것으로 봅니다.
+func FilterOptionalGroups(tables []Table, features []string) []Table {
+	if features == nil {
+		return tables
+	}
+
+	enabled := make(map[string]bool, len(features))
+	for _, feature := range features {
+		enabled[strings.ToLower(strings.TrimSpace(feature))] = true
+	}
+
+	result := make([]Table, len(tables))
+	for i, table := range tables {
+		var keep []int
+		for c, col := range table.Columns {
+			if col.Optional == "" || enabled[col.Optional] {
+				keep = append(keep, c)
+			}
+		}
+		if len(keep) == len(table.Columns) {
+			result[i] = table
+			continue
+		}
+
+		filtered := table
+		filtered.Columns = make([]Column, len(keep))
+		for j, c := range keep {
+			filtered.Columns[j] = table.Columns[c]
+		}
+		filtered.Rows = make([][]interface{}, len(table.Rows))
+		for r, row := range table.Rows {
+			values := make([]interface{}, len(keep))
+			for j, c := range keep {
+				if c < len(row) {
+					values[j] = row[c]
+				}
+			}
+			filtered.Rows[r] = values
+		}
+		result[i] = filtered
+	}
+	return result
+}
@@ -0,0 +1,183 @@
+// exporter/outputdir.go
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OutputMarkerFile은 excelite가 생성한 출력 디렉토리임을 표시하는 파일입니다.
+// --clean은 이 파일이 있는 디렉토리만 교체합니다.
+const OutputMarkerFile = ".excelite-output"
+
+// OutputDir는 스테이징 디렉토리에 생성한 뒤 최종 출력 디렉토리로 옮기는 과정을 관리합니다.
+type OutputDir struct {
+	Target  string // 최종 출력 디렉토리
+	Staging string // 생성 중인 산출물이 쓰이는 디렉토리
+}
+
+// PrepareOutputDir는 target 옆에 비어있는 스테이징 디렉토리를 만듭니다.
+// target은 어디든 될 수 있지만, 디렉토리를 지우거나 교체하는 작업(--clean, 백업 제거, 남은 스테이징 디렉토리 제거)은
+// 현재 작업 디렉토리(워크스페이스) 하위이거나 excelite가 생성한 디렉토리일 때만 합니다.
+func PrepareOutputDir(target string) (*OutputDir, error) {
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return nil, err
+	}
+
+	staging := filepath.Join(filepath.Dir(absTarget), "."+filepath.Base(absTarget)+".staging")
+	if err := removeStale(staging); err != nil {
+		return nil, fmt.Errorf("failed to clear staging directory: %v", err)
+	}
+	if err := os.MkdirAll(staging, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %v", err)
+	}
+	// 중단된 실행이 남긴 스테이징 디렉토리를 다음 실행에서 알아볼 수 있도록 처음부터 표시
+	if err := writeOutputMarker(staging); err != nil {
+		return nil, err
+	}
+
+	return &OutputDir{Target: absTarget, Staging: staging}, nil
+}
+
+// Commit은 스테이징 디렉토리의 산출물을 최종 출력 디렉토리에 반영합니다.
+// clean이면 기존 출력 디렉토리를 <target>.bak으로 백업하고 스테이징 디렉토리로 교체합니다.
+// 그렇지 않으면 생성된 파일들만 덮어쓰고 기존의 다른 파일은 그대로 둡니다.
+func (o *OutputDir) Commit(clean bool) error {
+	if err := writeOutputMarker(o.Staging); err != nil {
+		return err
+	}
+
+	if clean {
+		return o.swap()
+	}
+	return o.merge()
+}
+
+// Abort는 스테이징 디렉토리를 제거합니다.
+func (o *OutputDir) Abort() error {
+	return os.RemoveAll(o.Staging)
+}
+
+func (o *OutputDir) swap() error {
+	if _, err := os.Stat(o.Target); os.IsNotExist(err) {
+		return os.Rename(o.Staging, o.Target)
+	}
+
+	if err := ensureReplaceable(o.Target); err != nil {
+		return err
+	}
+
+	backup := o.Target + ".bak"
+	if _, err := os.Stat(backup); err == nil {
+		if err := ensureReplaceable(backup); err != nil {
+			return err
+		}
+		if err := os.RemoveAll(backup); err != nil {
+			return fmt.Errorf("failed to remove old backup: %v", err)
+		}
+	}
+
+	if err := os.Rename(o.Target, backup); err != nil {
+		return fmt.Errorf("failed to back up output directory: %v", err)
+	}
+	if err := os.Rename(o.Staging, o.Target); err != nil {
+		// 교체에 실패하면 백업을 되돌림
+		os.Rename(backup, o.Target)
+		return fmt.Errorf("failed to replace output directory: %v", err)
+	}
+	return nil
+}
+
+func (o *OutputDir) merge() error {
+	// 사용자 파일이 있던 디렉토리에는 마커를 남기지 않아 이후 --clean으로 지워지지 않도록 함
+	if _, err := os.Stat(o.Target); err == nil && ensureGenerated(o.Target) != nil {
+		if err := os.Remove(filepath.Join(o.Staging, OutputMarkerFile)); err != nil {
+			return err
+		}
+	}
+
+	err := filepath.Walk(o.Staging, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(o.Staging, path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(o.Target, rel)
+		if info.IsDir() {
+			return os.MkdirAll(dest, 0755)
+		}
+		return os.Rename(path, dest)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to move generated files: %v", err)
+	}
+	return os.RemoveAll(o.Staging)
+}
+
+// writeOutputMarker는 dir이 excelite가 생성한 디렉토리임을 표시합니다.
+func writeOutputMarker(dir string) error {
+	return os.WriteFile(filepath.Join(dir, OutputMarkerFile), []byte("generated by excelite\n"), 0644)
+}
+
+// removeStale은 이전 실행이 남긴 디렉토리를 지웁니다. excelite가 생성했다는 표시가 없는 디렉토리는 워크스페이스 하위일 때만 지웁니다.
+func removeStale(dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	if ensureGenerated(dir) != nil {
+		if err := ensureInsideWorkspace(dir); err != nil {
+			return err
+		}
+	}
+	return os.RemoveAll(dir)
+}
+
+// ensureInsideWorkspace는 경로가 현재 작업 디렉토리 하위에 있고 작업 디렉토리 자체가 아닌지 확인합니다.
+// 워크스페이스 밖의 디렉토리를 지우거나 교체하지 않기 위한 확인입니다.
+func ensureInsideWorkspace(absPath string) error {
+	workspace, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(workspace, absPath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("refusing to remove %s: it is not inside the workspace %s", absPath, workspace)
+	}
+	return nil
+}
+
+// ensureReplaceable은 --clean으로 지우거나 교체할 디렉토리가 워크스페이스 하위이고, 비어있거나 excelite가 생성한 디렉토리인지 확인합니다.
+func ensureReplaceable(dir string) error {
+	if err := ensureInsideWorkspace(dir); err != nil {
+		return err
+	}
+	return ensureGenerated(dir)
+}
+
+// ensureGenerated는 디렉토리가 비어있거나 excelite가 생성한 디렉토리인지 확인합니다.
+func ensureGenerated(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	// 파이프라인 상태 파일과 컬럼 통계 파일만 있는 디렉토리는 첫 생성이 끝나기 전의 출력 디렉토리이므로 비어있는 것으로 봄
+	onlyState := true
+	for _, entry := range entries {
+		if entry.Name() != PipelineStateFile && entry.Name() != ColumnStatsFile {
+			onlyState = false
+		}
+	}
+	if onlyState {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(dir, OutputMarkerFile)); err != nil {
+		return fmt.Errorf("refusing to replace %s: it was not generated by excelite (missing %s)", dir, OutputMarkerFile)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// chdirTemp는 워크스페이스로 쓸 임시 디렉토리로 작업 디렉토리를 옮깁니다.
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	return dir
+}
+
+// stageOutput은 target의 스테이징 디렉토리에 파일 하나를 생성하고 반영합니다.
+func stageOutput(t *testing.T, target string, clean bool) error {
+	t.Helper()
+	staged, err := PrepareOutputDir(target)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(staged.Staging, "data.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := staged.Commit(clean); err != nil {
+		staged.Abort()
+		return err
+	}
+	return nil
+}
+
+func TestOutputDirOutsideWorkspace(t *testing.T) {
+	chdirTemp(t)
+	outside := filepath.Join(t.TempDir(), "out")
+
+	// 워크스페이스 밖에도 생성할 수 있음
+	if err := stageOutput(t, outside, false); err != nil {
+		t.Fatalf("merge outside workspace: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "data.json")); err != nil {
+		t.Fatal(err)
+	}
+	// 현재 디렉토리에도 생성할 수 있음
+	if err := stageOutput(t, ".", false); err != nil {
+		t.Fatalf("merge into workspace: %v", err)
+	}
+
+	// 워크스페이스 밖의 디렉토리는 --clean으로 교체하지 않음
+	err := stageOutput(t, outside, true)
+	if err == nil || !strings.Contains(err.Error(), "not inside the workspace") {
+		t.Fatalf("clean outside workspace: got %v", err)
+	}
+	if _, err := os.Stat(outside + ".bak"); !os.IsNotExist(err) {
+		t.Errorf("backup of %s was created", outside)
+	}
+}
+
+func TestOutputDirClean(t *testing.T) {
+	chdirTemp(t)
+	if err := stageOutput(t, "out", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := stageOutput(t, "out", true); err != nil {
+		t.Fatalf("clean: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join("out.bak", OutputMarkerFile)); err != nil {
+		t.Errorf("previous output was not backed up: %v", err)
+	}
+
+	// excelite가 생성하지 않은 디렉토리는 교체하지 않음
+	if err := os.MkdirAll("user", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("user", "notes.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := stageOutput(t, "user", true); err == nil {
+		t.Error("clean replaced a directory without the output marker")
+	}
+}
+
+func TestOutputDirStaleStaging(t *testing.T) {
+	chdirTemp(t)
+	parent := t.TempDir()
+	target := filepath.Join(parent, "out")
+	staging := filepath.Join(parent, ".out.staging")
+
+	// 표시가 없는 워크스페이스 밖의 디렉토리는 지우지 않음
+	if err := os.MkdirAll(staging, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(staging, "keep.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := PrepareOutputDir(target); err == nil {
+		t.Fatal("removed an unmarked directory outside the workspace")
+	}
+
+	// 중단된 실행이 남긴 스테이징 디렉토리는 표시가 있으므로 지움
+	if err := writeOutputMarker(staging); err != nil {
+		t.Fatal(err)
+	}
+	staged, err := PrepareOutputDir(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(staged.Staging, "keep.txt")); !os.IsNotExist(err) {
+		t.Error("stale staging directory was not cleared")
+	}
+	staged.Abort()
+}
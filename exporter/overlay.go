@@ -0,0 +1,250 @@
+// exporter/overlay.go
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// 패치 연산 종류
+const (
+	PatchInsert = "insert"
+	PatchUpdate = "update"
+	PatchDelete = "delete"
+)
+
+// PatchOp는 기본 데이터 위에 적용될 하나의 행 단위 변경을 나타냅니다.
+type PatchOp struct {
+	Op     string                 `json:"op"`
+	Table  string                 `json:"table"`
+	Index  string                 `json:"index"`
+	Values map[string]interface{} `json:"values,omitempty"`
+}
+
+// Overlay는 변경/추가된 행만 담고 있는 오버레이 워크북들의 파싱 결과입니다.
+type Overlay struct {
+	Tables  []Table
+	Deletes map[string][]string // 테이블 이름 -> 삭제할 인덱스 목록
+}
+
+// ParseOverlayFiles는 오버레이 워크북들을 파싱합니다.
+// 삭제할 행은 #Delete 시트(Table, Index 헤더)에 나열합니다.
+func ParseOverlayFiles(files []string) (Overlay, error) {
+	overlay := Overlay{Deletes: make(map[string][]string)}
+
+	for _, file := range files {
+		tables, err := ParseExcelFile(file)
+		if err != nil {
+			return overlay, fmt.Errorf("failed to parse %s: %v", file, err)
+		}
+		overlay.Tables = append(overlay.Tables, tables...)
+
+		deletes, err := parseDeleteSheet(file)
+		if err != nil {
+			return overlay, fmt.Errorf("failed to parse %s: %v", file, err)
+		}
+		for table, indexes := range deletes {
+			overlay.Deletes[table] = append(overlay.Deletes[table], indexes...)
+		}
+	}
+
+	return overlay, nil
+}
+
+func parseDeleteSheet(filePath string) (map[string][]string, error) {
+	f, err := openWorkbook(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	deletes := make(map[string][]string)
+
+	deleteSheet := "#Delete"
+	if !contains(f.GetSheetList(), deleteSheet) {
+		return deletes, nil
+	}
+
+	rows, err := f.GetRows(deleteSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read delete sheet: %v", err)
+	}
+
+	if len(rows) < 2 {
+		return deletes, nil
+	}
+
+	tableIdx, indexIdx := -1, -1
+	for i, cell := range rows[0] {
+		switch strings.TrimSpace(cell) {
+		case "Table":
+			tableIdx = i
+		case "Index":
+			indexIdx = i
+		}
+	}
+	if tableIdx == -1 || indexIdx == -1 {
+		return nil, fmt.Errorf("delete sheet requires Table and Index columns")
+	}
+
+	for _, row := range rows[1:] {
+		table := formatTableName(cellAt(row, tableIdx))
+		index := cellAt(row, indexIdx)
+		if table == "" || index == "" {
+			continue
+		}
+		deletes[table] = append(deletes[table], index)
+	}
+
+	return deletes, nil
+}
+
+// ComputePatch는 기본 테이블과 오버레이를 비교하여 인덱스 기준의 패치 연산 목록을 생성합니다.
+// 기본 데이터와 동일한 오버레이 행은 무시됩니다.
+func ComputePatch(base []Table, overlay Overlay) ([]PatchOp, error) {
+	baseMap := make(map[string]Table)
+	for _, table := range base {
+		baseMap[table.Name] = table
+	}
+
+	var ops []PatchOp
+
+	for _, table := range overlay.Tables {
+		baseTable, ok := baseMap[table.Name]
+		if !ok {
+			return nil, fmt.Errorf("overlay table %s does not exist in base data", table.Name)
+		}
+
+		baseRows := make(map[string][]interface{})
+		for _, row := range baseTable.Rows {
+			baseRows[RowKey(baseTable, row)] = row
+		}
+
+		for _, row := range table.Rows {
+			key := RowKey(table, row)
+			if key == "" {
+				return nil, fmt.Errorf("overlay table %s has a row without index", table.Name)
+			}
+
+			values := patchValues(table, row)
+			baseRow, exists := baseRows[key]
+			if !exists {
+				ops = append(ops, PatchOp{Op: PatchInsert, Table: table.Name, Index: key, Values: values})
+				continue
+			}
+
+			if !reflect.DeepEqual(values, patchValues(baseTable, baseRow)) {
+				ops = append(ops, PatchOp{Op: PatchUpdate, Table: table.Name, Index: key, Values: values})
+			}
+		}
+	}
+
+	tableNames := make([]string, 0, len(overlay.Deletes))
+	for name := range overlay.Deletes {
+		tableNames = append(tableNames, name)
+	}
+	sort.Strings(tableNames)
+
+	for _, name := range tableNames {
+		if _, ok := baseMap[name]; !ok {
+			return nil, fmt.Errorf("delete refers to unknown table %s", name)
+		}
+		for _, index := range overlay.Deletes[name] {
+			ops = append(ops, PatchOp{Op: PatchDelete, Table: name, Index: index})
+		}
+	}
+
+	return ops, nil
+}
+
+// DiffTables는 두 데이터셋을 비교하여 old를 new로 바꾸는 패치 연산 목록을 생성합니다.
+// ComputePatch와 달리 new에 없는 행과 테이블은 삭제로 간주합니다.
+func DiffTables(oldTables, newTables []Table) []PatchOp {
+	oldMap := make(map[string]Table)
+	for _, table := range oldTables {
+		oldMap[table.Name] = table
+	}
+	newMap := make(map[string]Table)
+	for _, table := range newTables {
+		newMap[table.Name] = table
+	}
+
+	var ops []PatchOp
+
+	for _, table := range newTables {
+		oldTable, exists := oldMap[table.Name]
+
+		oldRows := make(map[string][]interface{})
+		if exists {
+			for _, row := range oldTable.Rows {
+				oldRows[RowKey(oldTable, row)] = row
+			}
+		}
+
+		seen := make(map[string]bool)
+		for _, row := range table.Rows {
+			key := RowKey(table, row)
+			seen[key] = true
+
+			values := patchValues(table, row)
+			oldRow, ok := oldRows[key]
+			if !ok {
+				ops = append(ops, PatchOp{Op: PatchInsert, Table: table.Name, Index: key, Values: values})
+				continue
+			}
+			if !reflect.DeepEqual(values, patchValues(oldTable, oldRow)) {
+				ops = append(ops, PatchOp{Op: PatchUpdate, Table: table.Name, Index: key, Values: values})
+			}
+		}
+
+		if exists {
+			for _, row := range oldTable.Rows {
+				if key := RowKey(oldTable, row); !seen[key] {
+					ops = append(ops, PatchOp{Op: PatchDelete, Table: table.Name, Index: key})
+				}
+			}
+		}
+	}
+
+	for _, table := range oldTables {
+		if _, ok := newMap[table.Name]; ok {
+			continue
+		}
+		for _, row := range table.Rows {
+			ops = append(ops, PatchOp{Op: PatchDelete, Table: table.Name, Index: RowKey(table, row)})
+		}
+	}
+
+	return ops
+}
+
+// patchValues는 행을 컬럼 이름 기준의 맵으로 변환합니다. 배열 컬럼은 JSON 그대로 담습니다.
+func patchValues(table Table, row []interface{}) map[string]interface{} {
+	values := make(map[string]interface{}, len(table.Columns))
+	for i, col := range table.Columns {
+		var value interface{}
+		if i < len(row) {
+			value = row[i]
+		}
+		if s, ok := value.(string); ok && col.Type.IsArray {
+			value = json.RawMessage(s)
+		}
+		values[col.Name] = value
+	}
+	return values
+}
+
+// WritePatchFile은 패치 연산 목록을 JSON 파일로 저장합니다.
+func WritePatchFile(path string, ops []PatchOp) error {
+	data, err := json.MarshalIndent(struct {
+		Operations []PatchOp `json:"operations"`
+	}{Operations: ops}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,230 @@
+// exporter/pipeline.go
+package exporter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// PipelineStateFile은 파이프라인이 단계별 입력 해시를 기록하는 파일 이름입니다.
+const PipelineStateFile = ".excelite-pipeline.json"
+
+// PipelineStage는 생성 파이프라인(parse → transform → validate → export → package)의 단계 하나입니다.
+type PipelineStage struct {
+	Name  string
+	Needs []string // 먼저 실행되어야 하는 단계
+
+	// Key는 단계의 입력(워크북 내용, 설정 등)을 나타내는 문자열을 반환합니다.
+	// 의존 단계의 키와 합친 해시가 이전 실행과 같으면 단계를 건너뜁니다. nil이면 항상 실행합니다.
+	Key func() (string, error)
+
+	// Outputs는 단계가 만드는 파일입니다. 하나라도 없으면 키가 같아도 다시 실행합니다.
+	Outputs []string
+
+	Run func(ctx context.Context) error
+}
+
+// StageResult는 단계 하나의 실행 결과입니다.
+type StageResult struct {
+	Name     string
+	Cached   bool // 입력이 이전 실행과 같아 건너뜀
+	Duration time.Duration
+	Err      error
+}
+
+// Pipeline은 단계들을 의존 순서대로 실행합니다.
+// 단계별 입력 해시를 StatePath에 기록하므로, 실패한 뒤 다시 실행하면 입력이 바뀌지 않고 결과가 남아있는 단계는 건너뛰고 이어서 실행합니다.
+// 메모리에만 결과를 남기는 단계(parse 등)는 이후 단계가 실행될 때 항상 다시 실행됩니다.
+type Pipeline struct {
+	StatePath string // 단계별 해시를 기록할 파일 (비어있으면 캐시하지 않음)
+	Force     bool   // 캐시를 무시하고 모든 단계를 실행
+	Until     string // 이 단계(와 의존 단계)까지만 실행 (비어있으면 전체)
+
+	stages []PipelineStage
+}
+
+// Add는 단계를 추가합니다. Needs의 단계는 먼저 추가되어 있어야 합니다.
+func (p *Pipeline) Add(stage PipelineStage) error {
+	if p.index(stage.Name) != -1 {
+		return fmt.Errorf("pipeline stage %s is already defined", stage.Name)
+	}
+	for _, need := range stage.Needs {
+		if p.index(need) == -1 {
+			return fmt.Errorf("pipeline stage %s needs unknown stage %s", stage.Name, need)
+		}
+	}
+	p.stages = append(p.stages, stage)
+	return nil
+}
+
+// Stages는 단계 이름을 실행 순서대로 반환합니다.
+func (p *Pipeline) Stages() []string {
+	names := make([]string, len(p.stages))
+	for i, stage := range p.stages {
+		names[i] = stage.Name
+	}
+	return names
+}
+
+func (p *Pipeline) index(name string) int {
+	for i, stage := range p.stages {
+		if stage.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Run은 단계들을 실행합니다. 단계가 실패하면 그 단계의 결과까지 반환하고 멈춥니다.
+// 단계는 의존 단계보다 나중에 추가되므로 추가된 순서가 곧 실행 순서입니다.
+func (p *Pipeline) Run(ctx context.Context) ([]StageResult, error) {
+	// Until 단계와 그 의존 단계만 실행
+	selected := make([]bool, len(p.stages))
+	if p.Until == "" {
+		for i := range selected {
+			selected[i] = true
+		}
+	} else {
+		last := p.index(p.Until)
+		if last == -1 {
+			return nil, fmt.Errorf("unknown pipeline stage %s (stages: %v)", p.Until, p.Stages())
+		}
+		selected[last] = true
+		for i := last; i >= 0; i-- {
+			if !selected[i] {
+				continue
+			}
+			for _, need := range p.stages[i].Needs {
+				selected[p.index(need)] = true
+			}
+		}
+	}
+
+	// 단계별 키는 의존 단계의 키를 포함하므로 앞 단계의 입력이 바뀌면 뒤 단계도 다시 실행됨
+	state := p.loadState()
+	keys := make([]string, len(p.stages))
+	run := make([]bool, len(p.stages))
+	for i, stage := range p.stages {
+		if !selected[i] {
+			continue
+		}
+		key, err := p.stageKey(stage, keys)
+		if err != nil {
+			return nil, fmt.Errorf("stage %s: %v", stage.Name, err)
+		}
+		keys[i] = key
+		run[i] = p.Force || key == "" || state[stage.Name] != key || !outputsExist(stage.Outputs)
+	}
+	// 실행할 단계가 필요로 하는 단계도 실행
+	for i := len(p.stages) - 1; i >= 0; i-- {
+		if !run[i] {
+			continue
+		}
+		for _, need := range p.stages[i].Needs {
+			run[p.index(need)] = true
+		}
+	}
+
+	var results []StageResult
+	for i, stage := range p.stages {
+		if !selected[i] {
+			continue
+		}
+		if !run[i] {
+			results = append(results, StageResult{Name: stage.Name, Cached: true})
+			continue
+		}
+
+		start := time.Now()
+		err := stage.Run(ctx)
+		results = append(results, StageResult{Name: stage.Name, Duration: time.Since(start), Err: err})
+		if err != nil {
+			delete(state, stage.Name)
+			p.saveState(state)
+			return results, fmt.Errorf("stage %s: %w", stage.Name, err)
+		}
+		if keys[i] != "" {
+			state[stage.Name] = keys[i]
+		}
+		if err := p.saveState(state); err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// stageKey는 단계의 키와 의존 단계의 키를 합친 해시를 반환합니다. 키가 없는 단계나 키가 없는 단계에 의존하면 빈 문자열입니다.
+func (p *Pipeline) stageKey(stage PipelineStage, keys []string) (string, error) {
+	if stage.Key == nil || p.StatePath == "" {
+		return "", nil
+	}
+	key, err := stage.Key()
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00", stage.Name, key)
+	for _, need := range stage.Needs {
+		needKey := keys[p.index(need)]
+		if needKey == "" {
+			return "", nil
+		}
+		fmt.Fprintf(h, "%s\x00", needKey)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (p *Pipeline) loadState() map[string]string {
+	state := make(map[string]string)
+	if p.StatePath == "" {
+		return state
+	}
+	if data, err := os.ReadFile(p.StatePath); err == nil {
+		json.Unmarshal(data, &state)
+	}
+	return state
+}
+
+// saveState는 단계별 키를 기록합니다. 출력 디렉토리가 교체되어도 남도록 매 단계 뒤에 전체를 다시 씁니다.
+func (p *Pipeline) saveState(state map[string]string) error {
+	if p.StatePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p.StatePath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(p.StatePath, data, 0644)
+}
+
+func outputsExist(paths []string) bool {
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// HashFiles는 파일들의 경로와 내용을 하나의 해시로 만듭니다. 단계의 Key에 사용합니다.
+func HashFiles(paths []string) (string, error) {
+	h := sha256.New()
+	for _, path := range paths {
+		sum, err := hashFile(path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00", path, sum)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,175 @@
+// exporter/probability.go
+package exporter
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// probabilityTolerance는 확률 합계가 목표값과 같다고 보는 오차입니다. (목표값에 대한 비율)
+const probabilityTolerance = 1e-6
+
+// probabilitySpec은 probability 태그의 설정입니다.
+// probability:Box|percent|normalize 이면 Box 컬럼 값이 같은 행끼리 합계가 100이어야 하고, 아니면 읽을 때 100이 되도록 비율을 맞춥니다.
+// 값은 |로 구분하며 percent, normalize가 아닌 값은 그룹 컬럼 이름입니다. 그룹 컬럼이 없으면 테이블 전체가 한 그룹입니다.
+type probabilitySpec struct {
+	Group     int     // 그룹 컬럼 위치 (-1이면 테이블 전체)
+	Total     float64 // 그룹의 합계 (1 또는 percent이면 100)
+	Normalize bool    // 합계가 다르면 에러 대신 비율을 맞춤
+}
+
+// columnProbability는 컬럼의 probability 태그를 파싱합니다. 태그가 없으면 nil입니다.
+func columnProbability(table Table, c int) (*probabilitySpec, error) {
+	col := table.Columns[c]
+	value, ok := GetTagValue(col.Tags, TagProbability)
+	if !ok {
+		return nil, nil
+	}
+	if col.Type.IsArray || (col.Type.Type.Kind() != reflect.Int32 && col.Type.Type.Kind() != reflect.Int64 && col.Type.Type.Kind() != reflect.Float64) {
+		return nil, fmt.Errorf("probability is only supported for int and float columns")
+	}
+
+	spec := &probabilitySpec{Group: -1, Total: 1}
+	for _, part := range strings.Split(value, "|") {
+		part = strings.TrimSpace(part)
+		switch strings.ToLower(part) {
+		case "":
+		case "percent":
+			spec.Total = 100
+		case "normalize":
+			spec.Normalize = true
+		default:
+			if spec.Group != -1 {
+				return nil, fmt.Errorf("probability has more than one group column (%s, %s)", table.Columns[spec.Group].Name, part)
+			}
+			if spec.Group = columnIndex(table, part); spec.Group == -1 {
+				return nil, fmt.Errorf("probability refers to unknown column %s", part)
+			}
+			if spec.Group == c {
+				return nil, fmt.Errorf("probability cannot be grouped by the column itself")
+			}
+		}
+	}
+	if spec.Normalize && col.Type.Type.Kind() != reflect.Float64 {
+		return nil, fmt.Errorf("probability normalize is only supported for float columns")
+	}
+	return spec, nil
+}
+
+// probabilityGroup은 같은 그룹 값을 가진 행들입니다.
+type probabilityGroup struct {
+	Key  string
+	Rows []int
+	Sum  float64
+}
+
+// probabilityGroups는 컬럼 c의 값을 그룹 컬럼 값별로 합산합니다. 빈 셀은 0이고, 그룹은 처음 나온 순서입니다.
+func probabilityGroups(table Table, c int, spec *probabilitySpec) ([]*probabilityGroup, error) {
+	var groups []*probabilityGroup
+	byKey := make(map[string]*probabilityGroup)
+	for i, row := range table.Rows {
+		key := ""
+		if spec.Group != -1 && spec.Group < len(row) && row[spec.Group] != nil {
+			key = fmt.Sprint(row[spec.Group])
+		}
+		g, ok := byKey[key]
+		if !ok {
+			g = &probabilityGroup{Key: key}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		v := 0.0
+		if c < len(row) {
+			v = probabilityValue(row[c])
+		}
+		if v < 0 {
+			location := table.Source.RowLocation(i)
+			if location == "" {
+				location = fmt.Sprintf("row %d", i+1)
+			}
+			return nil, fmt.Errorf("%s has negative probability %g", location, v)
+		}
+		g.Rows = append(g.Rows, i)
+		g.Sum += v
+	}
+	return groups, nil
+}
+
+func probabilityValue(v interface{}) float64 {
+	switch v := v.(type) {
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+// describe는 에러 메시지에 쓸 그룹 설명입니다.
+func (g *probabilityGroup) describe(table Table, spec *probabilitySpec) string {
+	if spec.Group == -1 {
+		return "rows"
+	}
+	return fmt.Sprintf("rows with %s=%s", table.Columns[spec.Group].Name, g.Key)
+}
+
+// normalizeProbabilities는 probability:normalize 컬럼의 값을 그룹별 합계가 목표값이 되도록 비율을 맞춥니다.
+// 파싱 직후에 호출하며, 태그 설정이 잘못된 컬럼은 건너뛰고 validateProbabilities에서 보고합니다.
+func normalizeProbabilities(table *Table) {
+	for c := range table.Columns {
+		spec, err := columnProbability(*table, c)
+		if err != nil || spec == nil || !spec.Normalize {
+			continue
+		}
+		groups, err := probabilityGroups(*table, c, spec)
+		if err != nil {
+			continue
+		}
+		for _, g := range groups {
+			if g.Sum == 0 {
+				continue
+			}
+			for _, i := range g.Rows {
+				if row := table.Rows[i]; c < len(row) && row[c] != nil {
+					row[c] = probabilityValue(row[c]) * spec.Total / g.Sum
+				}
+			}
+		}
+	}
+}
+
+// validateProbabilities는 probability 컬럼의 그룹별 합계가 1(percent이면 100)인지 확인합니다.
+// normalize 컬럼은 읽을 때 비율을 맞췄으므로 합계가 0인 그룹만 에러입니다.
+func validateProbabilities(table Table) []error {
+	var errs []error
+	for c, col := range table.Columns {
+		spec, err := columnProbability(table, c)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("table %s column %s: %v", table.Name, col.Name, err))
+			continue
+		}
+		if spec == nil {
+			continue
+		}
+		groups, err := probabilityGroups(table, c, spec)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("table %s column %s: %v", table.Name, col.Name, err))
+			continue
+		}
+		for _, g := range groups {
+			if math.Abs(g.Sum-spec.Total) <= spec.Total*probabilityTolerance {
+				continue
+			}
+			if spec.Normalize && g.Sum != 0 {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("table %s column %s: probabilities of %s sum to %g, expected %g",
+				table.Name, col.Name, g.describe(table, spec), g.Sum, spec.Total))
+		}
+	}
+	return errs
+}
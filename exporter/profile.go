@@ -0,0 +1,98 @@
+// exporter/profile.go
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultProfilesFile은 프로필 파일의 기본 경로입니다.
+const DefaultProfilesFile = "excelite.profiles.json"
+
+// Profile은 배포 환경(dev, staging, prod 등)별 설정 오버레이입니다.
+// 같은 워크북으로 여러 환경의 산출물을 만들 때 환경마다 다른 값만 지정합니다.
+type Profile struct {
+	Name     string        `json:"-"`
+	Output   string        `json:"output,omitempty"`   // 출력 디렉토리 (비어있으면 <output>/<프로필 이름>)
+	DBDriver string        `json:"dbDriver,omitempty"` // 데이터베이스 드라이버
+	DSN      string        `json:"dsn,omitempty"`      // 데이터베이스 연결 문자열 (SQLite는 DB 파일 경로)
+	Tables   []string      `json:"tables,omitempty"`   // 포함할 테이블 (관계로 연결된 테이블도 포함, 비어있으면 전체)
+	Config   []ConfigEntry `json:"config,omitempty"`   // #Config 시트 위에 덮어쓸 설정 (dataVersion 등)
+	Features []string      `json:"features,omitempty"` // 켤 optional 컬럼 그룹 (지정하지 않으면 전체)
+}
+
+// LoadProfile은 프로필 파일에서 name 프로필을 읽습니다.
+// 파일은 {"profiles": {"prod": {...}, ...}} 형식의 JSON입니다.
+func LoadProfile(path, name string) (Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Profile{}, fmt.Errorf("failed to read profiles file: %v", err)
+	}
+
+	var file struct {
+		Profiles map[string]Profile `json:"profiles"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Profile{}, fmt.Errorf("failed to parse profiles file %s: %v", path, err)
+	}
+
+	profile, ok := file.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(file.Profiles))
+		for n := range file.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return Profile{}, fmt.Errorf("profile %q not found in %s (available: %s)", name, path, strings.Join(names, ", "))
+	}
+
+	profile.Name = name
+	for i := range profile.Config {
+		profile.Config[i].Key = strings.ToLower(strings.TrimSpace(profile.Config[i].Key))
+		if profile.Config[i].Key == "" {
+			return Profile{}, fmt.Errorf("profile %q: config entry %d has no key", name, i+1)
+		}
+	}
+	return profile, nil
+}
+
+// Apply는 프로필의 설정 오버레이를 파싱된 테이블들에 반영한 뒤 행 필터를 적용합니다.
+// 워크북의 #Config 설정이 먼저 적용된 뒤이므로 프로필의 값이 우선합니다.
+// 프로필 설정의 filter 항목은 이 프로필의 필터(filter:<프로필>)로 취급합니다.
+// 프로필을 지정하지 않은 빈 프로필도 모든 빌드에 적용되는 행 필터를 적용합니다.
+// Features가 지정되면 나머지 optional 컬럼 그룹의 컬럼은 제거됩니다.
+// 마지막으로 워크북과 프로필에 선언된 테이블 변환(transform)을 적용합니다.
+func (p Profile) Apply(tables []Table) ([]Table, error) {
+	if len(p.Config) > 0 {
+		sheetNames := make([]string, 0, len(tables))
+		for _, table := range tables {
+			sheetNames = append(sheetNames, table.SheetName)
+		}
+
+		entries := make([]ConfigEntry, len(p.Config))
+		for i, entry := range p.Config {
+			if entry.Key == ConfigKeyFilter {
+				entry.Key = ConfigKeyFilter + ":" + p.Name
+			}
+			entries[i] = entry
+		}
+
+		var err error
+		if tables, err = applyConfig(tables, entries, sheetNames); err != nil {
+			return nil, fmt.Errorf("profile %s: %v", p.Name, err)
+		}
+	}
+
+	tables, errs := ApplyRowFilters(tables, p.Name)
+	if len(errs) > 0 {
+		messages := make([]string, len(errs))
+		for i, err := range errs {
+			messages[i] = err.Error()
+		}
+		return nil, fmt.Errorf("row filters failed with %d error(s):\n  %s", len(errs), strings.Join(messages, "\n  "))
+	}
+	return ApplyTableTransforms(FilterOptionalGroups(tables, p.Features))
+}
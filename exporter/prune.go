@@ -0,0 +1,182 @@
+// exporter/prune.go
+package exporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// excelite가 생성한 Go 파일의 첫 줄. 생성된 쿼리 레이어 자신의 식별자는 사용으로 세지 않음
+const generatedHeader = "Code generated by excelite."
+
+// GoReferences는 dir 아래 Go 패키지(생성 코드를 쓰는 서버 코드베이스)가 사용하는 excelite 생성 코드의 식별자들을 모읍니다.
+// go/packages로 타입 정보와 함께 읽어 식별자와 선택자(models.PrepareItemQueries, q.Get)를 실제 객체로 풀고,
+// excelite가 생성한 파일에 선언된 객체만 셉니다. 패키지 수준 객체는 이름(PrepareItemQueries, ItemRow),
+// 메서드는 "<타입>.<메서드>"(ItemQueries.Get)로 기록하므로 다른 타입의 같은 이름 메서드는 사용으로 보지 않습니다.
+// 풀 수 없는 선택자(타입 에러, 이전 prune으로 지워진 조회 함수)와 인터페이스를 통한 메서드 호출은 이름만 기록하며,
+// 이 이름은 모든 테이블에서 사용한 것으로 봅니다. (필요한 코드를 지우지 않는 쪽으로 틀림)
+// 테스트 파일을 포함하며, vendor, testdata, 숨김 디렉토리(./... 패턴이 제외)와 excelite가 생성한 파일은 건너뜁니다.
+func GoReferences(dir string) (map[string]bool, error) {
+	// 의존 패키지도 소스에서 타입 검사하므로 툴체인의 export data 형식과 관계없이 동작함
+	cfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:   dir,
+		Tests: true,
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Go packages in %s: %v", dir, err)
+	}
+
+	refs := make(map[string]bool)
+	generated := generatedFiles{}
+	seen := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo == nil {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			// 테스트를 포함한 변형 패키지는 같은 파일을 다시 가짐
+			name := pkg.Fset.Position(file.Pos()).Filename
+			if seen[name] || isGeneratedFile(file) {
+				continue
+			}
+			seen[name] = true
+			collectGoReferences(file, pkg.TypesInfo, pkg.Fset, generated, refs)
+		}
+	}
+	return refs, nil
+}
+
+// collectGoReferences는 파일 하나에서 사용한 생성 코드의 객체를 refs에 기록합니다.
+func collectGoReferences(file *ast.File, info *types.Info, fset *token.FileSet, generated generatedFiles, refs map[string]bool) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if info.Uses[n.Sel] == nil {
+				refs[n.Sel.Name] = true
+			}
+		case *ast.Ident:
+			obj := info.Uses[n]
+			if obj == nil {
+				return true
+			}
+			if fn, ok := obj.(*types.Func); ok && isInterfaceMethod(fn) {
+				refs[fn.Name()] = true
+				return true
+			}
+			if obj.Pkg() == nil || !generated.contains(fset.Position(obj.Pos()).Filename) {
+				return true
+			}
+			if key := goReferenceKey(obj); key != "" {
+				refs[key] = true
+			}
+		}
+		return true
+	})
+}
+
+// goReferenceKey는 생성 코드의 객체를 refs에 기록할 이름입니다. 메서드는 "<타입>.<메서드>", 구조체 필드는 기록하지 않습니다.
+func goReferenceKey(obj types.Object) string {
+	switch obj := obj.(type) {
+	case *types.Var:
+		if obj.IsField() {
+			return ""
+		}
+	case *types.Func:
+		if recv := obj.Type().(*types.Signature).Recv(); recv != nil {
+			t := recv.Type()
+			if ptr, ok := t.(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+			if named, ok := t.(*types.Named); ok {
+				return named.Obj().Name() + "." + obj.Name()
+			}
+			return obj.Name()
+		}
+	}
+	return obj.Name()
+}
+
+// isInterfaceMethod는 인터페이스에 선언된 메서드인지 확인합니다. 어떤 타입의 메서드가 호출될지 알 수 없습니다.
+func isInterfaceMethod(fn *types.Func) bool {
+	recv := fn.Type().(*types.Signature).Recv()
+	return recv != nil && types.IsInterface(recv.Type())
+}
+
+// isGeneratedFile은 excelite가 생성한 파일인지 확인합니다.
+func isGeneratedFile(file *ast.File) bool {
+	return len(file.Comments) > 0 && strings.Contains(file.Comments[0].Text(), generatedHeader)
+}
+
+// generatedFiles는 객체가 선언된 파일이 excelite가 생성한 파일인지 캐시합니다. (의존 패키지는 구문 트리 없이 파일의 첫 주석만 읽음)
+type generatedFiles map[string]bool
+
+func (g generatedFiles) contains(path string) bool {
+	if path == "" {
+		return false
+	}
+	if result, ok := g[path]; ok {
+		return result
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments|parser.PackageClauseOnly)
+	g[path] = err == nil && isGeneratedFile(file)
+	return g[path]
+}
+
+// HashGoReferences는 GoReferences 결과의 해시입니다. 사용하는 식별자가 바뀔 때만 생성 결과가 달라지므로 캐시 키로 사용합니다.
+func HashGoReferences(dir string) (string, error) {
+	refs, err := GoReferences(dir)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(strings.Join(sortedMapKeys(refs), "\n")))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// pruneQueryTables는 Go 쿼리 레이어에서 사용하지 않는 테이블과 조회 함수를 뺍니다.
+// 테이블은 Prepare<T>Queries, <T>Queries, <T>Row 중 하나를 사용하면 남기고, 남긴 테이블의 조회 함수는
+// 그 테이블의 메서드(<T>Queries.<메서드>)를 사용하거나 GoReferences가 이름만 기록한 경우에 남깁니다.
+// All과 Close는 항상 남깁니다.
+func pruneQueryTables(tables []queryTable, refs map[string]bool) []queryTable {
+	var result []queryTable
+	lookups, kept := 0, 0
+	for _, qt := range tables {
+		lookups += len(qt.Lookups)
+		if !refs["Prepare"+qt.Name+"Queries"] && !refs[qt.Name+"Queries"] && !refs[qt.Name+"Row"] {
+			continue
+		}
+		pruned := qt
+		pruned.Lookups = nil
+		for _, lookup := range qt.Lookups {
+			if refs[qt.Name+"Queries."+lookup.Method] || refs[lookup.Method] {
+				pruned.Lookups = append(pruned.Lookups, lookup)
+			}
+		}
+		kept += len(pruned.Lookups)
+		result = append(result, pruned)
+	}
+	log.Printf("Pruned Go query layer: kept %d of %d tables and %d of %d lookups", len(result), len(tables), kept, lookups)
+	return result
+}
+
+// loadPruneReferences는 prune 옵션의 디렉토리에서 식별자를 읽습니다. 옵션이 없으면 nil입니다.
+func loadPruneReferences(dir string) (map[string]bool, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%s %q is not a directory", OptSQLitePrune, dir)
+	}
+	return GoReferences(dir)
+}
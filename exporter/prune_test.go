@@ -0,0 +1,168 @@
+package exporter
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const pruneTestModels = `// Code generated by excelite. DO NOT EDIT.
+package models
+
+import "context"
+
+type ItemRow struct{ Index int32 }
+
+type ItemQueries struct{}
+
+func PrepareItemQueries(ctx context.Context) (*ItemQueries, error) { return &ItemQueries{}, nil }
+func (q *ItemQueries) Get(index int32) (ItemRow, error) { return ItemRow{}, nil }
+func (q *ItemQueries) ByName(name string) ([]ItemRow, error) { return nil, nil }
+func (q *ItemQueries) Close() error { return nil }
+
+type ShopRow struct{ Index int32 }
+
+type ShopQueries struct{}
+
+func PrepareShopQueries() (*ShopQueries, error) { return &ShopQueries{}, nil }
+func (q *ShopQueries) Get(index int32) (ShopRow, error) { return ShopRow{}, nil }
+func (q *ShopQueries) Close() error { return nil }
+`
+
+// writePruneTestModule은 생성된 models 패키지와 이를 사용하는 main 패키지로 된 모듈을 만듭니다.
+func writePruneTestModule(t *testing.T, main string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":           "module app\n\ngo 1.21\n",
+		"models/models.go": pruneTestModels,
+		"main.go":          main,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGoReferences(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+	t.Setenv("GOWORK", "off")
+	t.Setenv("GOFLAGS", "")
+
+	tests := []struct {
+		name   string
+		main   string
+		want   []string // 기록되어야 하는 이름
+		absent []string // 기록되지 않아야 하는 이름
+	}{
+		{
+			name: "resolved",
+			main: `package main
+
+import (
+	"context"
+
+	"app/models"
+)
+
+// cache는 생성 코드와 같은 이름의 메서드(ByName, Get)를 가진 다른 타입
+type cache struct{}
+
+func (cache) ByName(string) int { return 0 }
+func (cache) Get() int          { return 0 }
+
+type closer interface{ Close() error }
+
+func main() {
+	q, _ := models.PrepareItemQueries(context.Background())
+	_, _ = q.Get(1)
+	var c cache
+	_ = c.ByName("x") + c.Get()
+	var cl closer = q
+	_ = cl.Close()
+}
+`,
+			want:   []string{"PrepareItemQueries", "ItemQueries.Get", "Close"},
+			absent: []string{"Get", "ByName", "ItemQueries.ByName", "ItemRow", "PrepareShopQueries", "ShopQueries.Get", "cache", "main"},
+		},
+		{
+			// 이전 prune으로 지워진 조회 함수(ShopQueries.ByName)는 풀 수 없으므로 이름만 기록
+			name: "unresolved",
+			main: `package main
+
+import "app/models"
+
+func main() {
+	q, _ := models.PrepareShopQueries()
+	_, _ = q.ByName("x")
+}
+`,
+			want:   []string{"PrepareShopQueries", "ByName"},
+			absent: []string{"ShopQueries.Get", "PrepareItemQueries"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			refs, err := GoReferences(writePruneTestModule(t, tc.main))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range tc.want {
+				if !refs[name] {
+					t.Errorf("missing reference %s in %v", name, sortedMapKeys(refs))
+				}
+			}
+			for _, name := range tc.absent {
+				if refs[name] {
+					t.Errorf("unexpected reference %s in %v", name, sortedMapKeys(refs))
+				}
+			}
+		})
+	}
+}
+
+func TestPruneQueryTables(t *testing.T) {
+	tables := []queryTable{
+		{Name: "Item", Lookups: []queryLookup{{Method: "Get"}, {Method: "ByName"}}},
+		{Name: "Shop", Lookups: []queryLookup{{Method: "Get"}}},
+	}
+	tests := []struct {
+		name string
+		refs map[string]bool
+		want map[string][]string
+	}{
+		{
+			name: "typed",
+			refs: map[string]bool{"PrepareItemQueries": true, "ItemQueries.Get": true, "ShopRow": true},
+			want: map[string][]string{"Item": {"Get"}, "Shop": nil},
+		},
+		{
+			name: "name only",
+			refs: map[string]bool{"PrepareItemQueries": true, "ShopQueries": true, "ByName": true},
+			want: map[string][]string{"Item": {"ByName"}, "Shop": nil},
+		},
+	}
+	for _, tc := range tests {
+		got := make(map[string][]string)
+		for _, qt := range pruneQueryTables(tables, tc.refs) {
+			var methods []string
+			for _, lookup := range qt.Lookups {
+				methods = append(methods, lookup.Method)
+			}
+			got[qt.Name] = methods
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -2,8 +2,15 @@
 package exporter
 
 import (
+	"context"
 	"fmt"
+	"runtime/debug"
+	"sort"
 	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
 )
 
 // Registry는 모든 exporter들을 관리하는 중앙 레지스트리입니다.
@@ -78,8 +85,64 @@ func (r *Registry) Export(lang string, tables []Table, opts Options) error {
 	// 기본 옵션과 사용자 옵션을 병합
 	defaultOpts, _ := r.GetOptions(lang)
 	mergedOpts := mergeOptions(defaultOpts, opts)
+	if err := r.ValidateOptions(lang, mergedOpts); err != nil {
+		return err
+	}
 
-	return exp.Export(tables, mergedOpts)
+	if err := exp.Export(tables, mergedOpts); err != nil {
+		return err
+	}
+
+	// 검증은 압축, 암호화 전의 산출물을 읽음
+	if boolOption(mergedOpts, OptVerify, false) {
+		if err := verifyExport(exp, tables, mergedOpts); err != nil {
+			return err
+		}
+	}
+
+	return finalizeArtifacts(mergedOpts)
+}
+
+// ExportResult는 한 언어의 export 결과입니다.
+type ExportResult struct {
+	Lang     string
+	Duration time.Duration
+	Err      error
+}
+
+// ExportAll은 요청된 언어들의 exporter를 동시에 실행합니다.
+// 각 exporter는 독립적으로 실행되므로 하나가 실패하거나 panic이 발생해도 나머지는 계속 진행됩니다.
+// optsFor는 언어별 사용자 옵션을 반환하며, 결과는 언어 이름 순으로 정렬됩니다.
+// exporter들은 tables를 공유하므로 tables를 수정해서는 안 됩니다.
+func (r *Registry) ExportAll(ctx context.Context, langs []string, tables []Table, optsFor func(lang string) Options) []ExportResult {
+	results := make([]ExportResult, len(langs))
+
+	var g errgroup.Group
+	for i, lang := range langs {
+		i, lang := i, lang
+		g.Go(func() error {
+			stageCtx, stage := StartStage(ctx, StageExport, attribute.String("excelite.lang", lang))
+			start := time.Now()
+			err := r.safeExport(lang, tables, optsFor(lang))
+			stage.End(stageCtx, CountRows(tables), err)
+			results[i] = ExportResult{Lang: lang, Duration: time.Since(start), Err: err}
+			return nil
+		})
+	}
+	g.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Lang < results[j].Lang })
+	return results
+}
+
+// safeExport는 exporter에서 발생한 panic을 에러로 변환합니다.
+func (r *Registry) safeExport(lang string, tables []Table, opts Options) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("exporter panicked: %v\n%s", p, debug.Stack())
+		}
+	}()
+	return r.Export(lang, tables, opts)
 }
 
 // 옵션 병합을 위한 헬퍼 함수
@@ -104,8 +167,10 @@ func mergeOptions(defaultOpts, userOpts Options) Options {
 	}
 
 	// ExtraOptions 병합
-	if result.ExtraOptions == nil {
-		result.ExtraOptions = make(map[string]interface{})
+	// 기본 옵션의 맵은 여러 export가 공유하므로 복사본에 병합
+	result.ExtraOptions = make(map[string]interface{}, len(defaultOpts.ExtraOptions)+len(userOpts.ExtraOptions))
+	for k, v := range defaultOpts.ExtraOptions {
+		result.ExtraOptions[k] = v
 	}
 	for k, v := range userOpts.ExtraOptions {
 		result.ExtraOptions[k] = v
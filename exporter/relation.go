@@ -82,6 +82,68 @@ func parseRelations(f *excelize.File) ([]Relation, error) {
 	return relations, nil
 }
 
+// ExpandTableSelection은 선택된 테이블과 #Relation으로 직접 연결된 테이블들을 포함한 집합을 반환합니다.
+// 관계 정보만 읽으므로 데이터 시트는 파싱하지 않습니다.
+func ExpandTableSelection(files []string, names []string) (map[string]bool, error) {
+	selected := make(map[string]bool)
+	for _, name := range names {
+		if name = formatTableName(name); name != "" {
+			selected[name] = true
+		}
+	}
+
+	var relations []Relation
+	for _, file := range files {
+		f, err := openWorkbook(file)
+		if err != nil {
+			return nil, err
+		}
+		rels, err := parseRelations(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse relations in %s: %v", file, err)
+		}
+		relations = append(relations, rels...)
+	}
+
+	return expandSelection(selected, relations), nil
+}
+
+// ExpandTableSelectionFromTables는 ExpandTableSelection과 같으며, 워크북 대신 이미 파싱된 테이블들의 관계를 사용합니다.
+func ExpandTableSelectionFromTables(tables []Table, names []string) map[string]bool {
+	selected := make(map[string]bool)
+	for _, name := range names {
+		if name = formatTableName(name); name != "" {
+			selected[name] = true
+		}
+	}
+
+	var relations []Relation
+	for _, table := range tables {
+		relations = append(relations, table.Relations...)
+	}
+	return expandSelection(selected, relations)
+}
+
+// expandSelection은 selected(formatTableName으로 정규화된 테이블 이름)에 관계로 연결된 테이블을 더합니다.
+// #Relation 시트의 테이블 이름은 적힌 그대로이므로 같은 방식으로 정규화해서 비교합니다.
+func expandSelection(selected map[string]bool, relations []Relation) map[string]bool {
+	result := make(map[string]bool)
+	for name := range selected {
+		result[name] = true
+	}
+	for _, rel := range relations {
+		source, target := formatTableName(rel.SourceTable), formatTableName(rel.TargetTable)
+		if selected[source] {
+			result[target] = true
+		}
+		if selected[target] {
+			result[source] = true
+		}
+	}
+	return result
+}
+
 // normalizeRelationType은 관계 타입을 표준 형식으로 변환합니다.
 func normalizeRelationType(relType string) string {
 	relType = strings.ToLower(strings.TrimSpace(relType))
@@ -114,12 +176,9 @@ func assignRelationsToTables(tables []Table, relations []Relation) []Table {
 	tableMap := make(map[string]int)
 	for i, table := range tables {
 		// Copy the original table
-		result[i] = Table{
-			Name:      table.Name,
-			Columns:   append([]Column(nil), table.Columns...),
-			Relations: make([]Relation, 0),
-			SheetName: table.SheetName,
-		}
+		result[i] = table
+		result[i].Columns = append([]Column(nil), table.Columns...)
+		result[i].Relations = make([]Relation, 0)
 		tableMap[table.Name] = i
 	}
 
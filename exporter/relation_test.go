@@ -0,0 +1,31 @@
+package exporter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandTableSelectionFromTables(t *testing.T) {
+	// #Relation 시트에는 테이블 이름이 적힌 그대로(MonsterDrop) 들어있고, 파싱된 테이블 이름은 Monsterdrop
+	tables := []Table{
+		{Name: "Monster", Relations: []Relation{{SourceTable: "Monster", TargetTable: "MonsterDrop", RelationType: "hasMany"}}},
+		{Name: "Monsterdrop", Relations: []Relation{{SourceTable: "MonsterDrop", TargetTable: "item ", RelationType: "belongsTo"}}},
+		{Name: "Item"},
+		{Name: "Shop"},
+	}
+	tests := []struct {
+		names []string
+		want  []string
+	}{
+		{[]string{"Monster"}, []string{"Monster", "Monsterdrop"}},
+		{[]string{"MonsterDrop"}, []string{"Item", "Monster", "Monsterdrop"}},
+		{[]string{"item"}, []string{"Item", "Monsterdrop"}},
+		{[]string{"Shop"}, []string{"Shop"}},
+	}
+	for _, tc := range tests {
+		got := sortedMapKeys(ExpandTableSelectionFromTables(tables, tc.names))
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: got %v, want %v", tc.names, got, tc.want)
+		}
+	}
+}
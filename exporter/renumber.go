@@ -0,0 +1,325 @@
+// exporter/renumber.go
+package exporter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// IDOccurrence는 충돌하는 인덱스를 쓰는 한 워크북 시트의 행(들)입니다.
+// 변형(variant)이나 적용 기간(valid_from)이 있는 테이블은 같은 시트에서 인덱스를 공유하는 행들이 하나의 항목입니다.
+type IDOccurrence struct {
+	Workbook string `json:"workbook"`
+	Sheet    string `json:"sheet"`
+	Location string `json:"location"`
+
+	table int   // tables 안의 위치
+	rows  []int // 파싱된 순서의 행 위치
+}
+
+// IDCollision은 병합되는 테이블(여러 워크북의 같은 이름 테이블 포함)에서 두 번 이상 쓰인 인덱스입니다.
+type IDCollision struct {
+	Table       string         `json:"table"`
+	Index       string         `json:"index"`
+	Occurrences []IDOccurrence `json:"occurrences"`
+}
+
+// IDRemap은 충돌한 행 하나의 인덱스 변경입니다.
+type IDRemap struct {
+	Table    string   `json:"table"`
+	Workbook string   `json:"workbook"`
+	Sheet    string   `json:"sheet"`
+	Location string   `json:"location"`
+	Old      int64    `json:"old"`
+	New      int64    `json:"new"`
+	Cells    []string `json:"cells,omitempty"` // 바꾼 인덱스 셀 (ApplyRenumber)
+	Refs     []string `json:"refs,omitempty"`  // 새 인덱스로 바꾼 참조 셀 (Sheet!A1)
+
+	occ IDOccurrence
+	// updateRefs는 같은 워크북의 참조를 옮긴 행으로 바꿀지 여부입니다.
+	// 같은 시트 안의 중복은 참조가 어느 행을 가리키는지 알 수 없으므로 유지하는 행을 가리키는 것으로 둡니다.
+	updateRefs bool
+}
+
+// FindIDCollisions는 파싱 직후의 테이블들에서 인덱스 충돌을 찾습니다.
+// 같은 이름의 테이블은 워크북이 달라도 하나로 합쳐지므로 워크북 사이의 중복도 충돌입니다.
+// 설정 시트와 매트릭스 시트는 검사하지 않습니다.
+func FindIDCollisions(tables []Table) []IDCollision {
+	var names []string
+	occurrences := make(map[string]map[string][]IDOccurrence) // 테이블 → 인덱스 → 항목
+	for t, table := range tables {
+		if table.IsSettings || table.IsMatrix || len(table.Columns) == 0 {
+			continue
+		}
+		if _, ok := occurrences[table.Name]; !ok {
+			names = append(names, table.Name)
+			occurrences[table.Name] = make(map[string][]IDOccurrence)
+		}
+		byIndex := occurrences[table.Name]
+
+		from, _ := EffectiveDateColumns(table)
+		grouped := VariantColumn(table) != -1 || from != -1
+		workbook, sheet := tableWorkbook(table)
+		for i, row := range table.Rows {
+			key := RowKey(table, row)
+			if key == "" {
+				continue
+			}
+			occs := byIndex[key]
+			if grouped && len(occs) > 0 && occs[len(occs)-1].table == t {
+				occs[len(occs)-1].rows = append(occs[len(occs)-1].rows, i)
+				continue
+			}
+			byIndex[key] = append(occs, IDOccurrence{
+				Workbook: workbook, Sheet: sheet, Location: table.Source.RowLocation(i),
+				table: t, rows: []int{i},
+			})
+		}
+	}
+
+	var collisions []IDCollision
+	for _, name := range names {
+		byIndex := occurrences[name]
+		var keys []string
+		for key, occs := range byIndex {
+			if len(occs) > 1 {
+				keys = append(keys, key)
+			}
+		}
+		sortIndexKeys(keys)
+		for _, key := range keys {
+			collisions = append(collisions, IDCollision{Table: name, Index: key, Occurrences: byIndex[key]})
+		}
+	}
+	return collisions
+}
+
+func tableWorkbook(table Table) (workbook, sheet string) {
+	if table.Source == nil {
+		return "", table.SheetName
+	}
+	return table.Source.Workbook, table.Source.Sheet
+}
+
+// sortIndexKeys는 정수 인덱스는 숫자 순서로, 나머지는 문자열 순서로 정렬합니다.
+func sortIndexKeys(keys []string) {
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.ParseInt(keys[i], 10, 64)
+		b, errB := strconv.ParseInt(keys[j], 10, 64)
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+}
+
+// PlanRenumber는 충돌마다 유지할 항목을 정하고 나머지 항목의 새 인덱스를 워크북의 범위에서 배정합니다.
+// 인덱스가 자기 워크북의 범위 안에 있는 항목(범위가 없는 워크북이면 처음 나온 항목)이 인덱스를 유지하며,
+// 옮겨지는 항목은 그 워크북의 범위에서 테이블에 아직 쓰이지 않은 가장 작은 인덱스를 받습니다.
+// 정수가 아닌 인덱스, 범위가 없는 워크북의 항목, 범위가 가득 찬 경우는 에러로 모두 보고합니다.
+func PlanRenumber(tables []Table, collisions []IDCollision, policy IDRangePolicy) ([]IDRemap, []error) {
+	used := make(map[string]map[int64]bool)
+	for _, table := range tables {
+		if len(table.Columns) == 0 {
+			continue
+		}
+		if used[table.Name] == nil {
+			used[table.Name] = make(map[int64]bool)
+		}
+		idx := IndexColumn(table)
+		for _, row := range table.Rows {
+			if idx < len(row) {
+				if id, ok := toInt64(row[idx]); ok {
+					used[table.Name][id] = true
+				}
+			}
+		}
+	}
+
+	var remaps []IDRemap
+	var errs []error
+	for _, c := range collisions {
+		first := tables[c.Occurrences[0].table]
+		if !isIntColumn(first.Columns[IndexColumn(first)]) {
+			errs = append(errs, fmt.Errorf("table %s index %s: only integer indexes can be renumbered", c.Table, c.Index))
+			continue
+		}
+		id, err := strconv.ParseInt(c.Index, 10, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("table %s index %s: %v", c.Table, c.Index, err))
+			continue
+		}
+
+		keep := 0
+		for i, occ := range c.Occurrences {
+			if r, ok := policy.RangeFor(occ.Workbook, c.Table); !ok || r.Contains(id) {
+				keep = i
+				break
+			}
+		}
+
+		kept := c.Occurrences[keep]
+		for i, occ := range c.Occurrences {
+			if i == keep {
+				continue
+			}
+			r, ok := policy.RangeFor(occ.Workbook, c.Table)
+			if !ok {
+				errs = append(errs, fmt.Errorf("table %s index %d at %s %s %s: no ID range for the workbook to renumber it into (kept at %s %s)",
+					c.Table, id, occ.Workbook, occ.Sheet, occ.Location, kept.Workbook, kept.Location))
+				continue
+			}
+			next, ok := nextFreeID(used[c.Table], r)
+			if !ok {
+				errs = append(errs, fmt.Errorf("table %s: ID range %s of %s is full", c.Table, r, occ.Workbook))
+				continue
+			}
+			used[c.Table][next] = true
+			remaps = append(remaps, IDRemap{
+				Table: c.Table, Workbook: occ.Workbook, Sheet: occ.Sheet, Location: occ.Location,
+				Old: id, New: next, occ: occ, updateRefs: occ.Workbook != kept.Workbook,
+			})
+		}
+	}
+	return remaps, errs
+}
+
+func nextFreeID(used map[int64]bool, r IDRange) (int64, bool) {
+	for id := r.Start; r.Contains(id); id++ {
+		if !used[id] {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// referencingColumns는 table에서 target의 인덱스를 담는 컬럼들입니다. (ref<target>, belongsTo 외래 키, 자기 테이블의 parent)
+func referencingColumns(table, target Table) []int {
+	if len(target.Columns) == 0 {
+		return nil
+	}
+	targetIndex := target.Columns[IndexColumn(target)].Name
+
+	var cols []int
+	for i, col := range table.Columns {
+		refs := elementType(col.Type).RefTable == target.Name || (HasTag(col.Tags, TagParent) && table.Name == target.Name)
+		for _, rel := range table.Relations {
+			if rel.RelationType == "belongsTo" && rel.TargetTable == target.Name &&
+				strings.EqualFold(rel.ForeignKey, col.Name) && strings.EqualFold(rel.ReferenceKey, targetIndex) {
+				refs = true
+			}
+		}
+		if refs {
+			cols = append(cols, i)
+		}
+	}
+	return cols
+}
+
+// ApplyRenumber는 인덱스 변경을 워크북에 기록합니다.
+// 옮긴 행의 인덱스 셀과, 같은 워크북에서 그 행을 가리키는 참조 셀(배열 셀의 원소 포함)을 바꾸고 워크북을 저장합니다.
+// tables는 FindIDCollisions에 넘긴 파싱 직후의 테이블이어야 하며, 바꾼 셀은 remaps에 기록됩니다.
+func ApplyRenumber(tables []Table, remaps []IDRemap) error {
+	var workbooks []string
+	byWorkbook := make(map[string][]int)
+	for i, remap := range remaps {
+		if _, ok := byWorkbook[remap.Workbook]; !ok {
+			workbooks = append(workbooks, remap.Workbook)
+		}
+		byWorkbook[remap.Workbook] = append(byWorkbook[remap.Workbook], i)
+	}
+
+	for _, path := range workbooks {
+		if err := applyWorkbookRenumber(path, tables, remaps, byWorkbook[path]); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+func applyWorkbookRenumber(path string, tables []Table, remaps []IDRemap, indexes []int) error {
+	f, err := openWorkbook(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for _, i := range indexes {
+		remap := &remaps[i]
+		table := tables[remap.occ.table]
+		indexName := table.Columns[IndexColumn(table)].Name
+		for _, row := range remap.occ.rows {
+			for _, cell := range table.Source.RowCells(row, indexName) {
+				if err := f.SetCellInt(remap.Sheet, cell, int(remap.New)); err != nil {
+					return err
+				}
+				remap.Cells = append(remap.Cells, remap.Sheet+"!"+cell)
+			}
+		}
+		if !remap.updateRefs {
+			continue
+		}
+
+		for _, other := range tables {
+			if workbook, _ := tableWorkbook(other); workbook != path {
+				continue
+			}
+			for _, c := range referencingColumns(other, table) {
+				col := other.Columns[c]
+				for r := range other.Rows {
+					for _, cell := range other.Source.RowCells(r, col.Name) {
+						changed, err := replaceRefCell(f, other.Source.Sheet, cell, col, remap.Old, remap.New)
+						if err != nil {
+							return err
+						}
+						if changed {
+							remap.Refs = append(remap.Refs, other.Source.Sheet+"!"+cell)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("failed to save: %v", err)
+	}
+	return nil
+}
+
+// replaceRefCell은 셀(배열 컬럼이면 셀의 각 원소)에서 old 인덱스를 new로 바꿉니다.
+func replaceRefCell(f *excelize.File, sheet, cell string, col Column, old, new int64) (bool, error) {
+	raw, err := f.GetCellValue(sheet, cell)
+	if err != nil {
+		return false, err
+	}
+	isOld := func(s string) bool {
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		return err == nil && n == old
+	}
+
+	if !col.Type.IsArray {
+		if !isOld(raw) {
+			return false, nil
+		}
+		return true, f.SetCellInt(sheet, cell, int(new))
+	}
+
+	delim := ArrayDelimiter(col)
+	items := splitArrayCell(raw, delim)
+	changed := false
+	for i, item := range items {
+		if isOld(item) {
+			items[i] = strings.Replace(item, strings.TrimSpace(item), strconv.FormatInt(new, 10), 1)
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+	return true, f.SetCellStr(sheet, cell, joinArrayCell(items, delim))
+}
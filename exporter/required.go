@@ -0,0 +1,85 @@
+// exporter/required.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// requiredIf는 requiredIf 태그로 선언한 조건부 필수 조건입니다.
+// requiredIf:Type=weapon 이면 Type 컬럼이 weapon인 행에서 이 컬럼을 비워둘 수 없습니다.
+// 값은 |로 여러 개를 줄 수 있고(Type=weapon|armor), !=는 값이 아닐 때 필수입니다.
+type requiredIf struct {
+	Column int // 조건을 검사할 컬럼 위치
+	Values []string
+	Negate bool
+}
+
+// compileRequiredIf는 테이블 컬럼들의 requiredIf 태그를 파싱합니다. 태그가 없는 컬럼은 nil입니다.
+func compileRequiredIf(columns []Column) ([]*requiredIf, error) {
+	conds := make([]*requiredIf, len(columns))
+	for i, col := range columns {
+		value, ok := GetTagValue(col.Tags, TagRequiredIf)
+		if !ok {
+			continue
+		}
+
+		negate := false
+		name, values, found := strings.Cut(value, "!=")
+		if found {
+			negate = true
+		} else if name, values, found = strings.Cut(value, "="); !found {
+			return nil, fmt.Errorf("column %s: requiredIf expects Column=value, got %q", col.Name, value)
+		}
+
+		cond := &requiredIf{Column: -1, Negate: negate}
+		name = strings.TrimSpace(name)
+		for j, other := range columns {
+			if strings.EqualFold(other.Name, name) {
+				cond.Column = j
+				break
+			}
+		}
+		if cond.Column == -1 {
+			return nil, fmt.Errorf("column %s: requiredIf refers to unknown column %s", col.Name, name)
+		}
+		if cond.Column == i {
+			return nil, fmt.Errorf("column %s: requiredIf cannot refer to the column itself", col.Name)
+		}
+		for _, v := range strings.Split(values, "|") {
+			cond.Values = append(cond.Values, strings.TrimSpace(v))
+		}
+		conds[i] = cond
+	}
+	return conds, nil
+}
+
+// applies는 행이 조건을 만족하여 컬럼이 필수인지 확인합니다. 조건 컬럼이 비어있으면 빈 문자열과 비교합니다.
+func (c *requiredIf) applies(row []interface{}) bool {
+	actual := ""
+	if c.Column < len(row) && row[c.Column] != nil {
+		actual = fmt.Sprintf("%v", row[c.Column])
+	}
+	return contains(c.Values, actual) != c.Negate
+}
+
+func (c *requiredIf) String(columns []Column) string {
+	op := "="
+	if c.Negate {
+		op = "!="
+	}
+	return columns[c.Column].Name + op + strings.Join(c.Values, "|")
+}
+
+// checkRequiredIf는 조건을 만족하는 행에서 조건부 필수 컬럼이 비어있으면 에러를 반환합니다.
+func checkRequiredIf(columns []Column, conds []*requiredIf, row []interface{}) error {
+	for i, cond := range conds {
+		if cond == nil || !cond.applies(row) {
+			continue
+		}
+		if i >= len(row) || row[i] == nil {
+			return fmt.Errorf("column %s is required when %s", columns[i].Name, cond.String(columns))
+		}
+	}
+	return nil
+}
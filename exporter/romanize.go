@@ -0,0 +1,147 @@
+// exporter/romanize.go
+package exporter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 한글 음절(가~힣)의 초성, 중성, 종성 로마자 표기 (국어의 로마자 표기법, 자음 동화 등 음운 변화는 반영하지 않음)
+var (
+	hangulInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulMedials  = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	hangulFinals   = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+)
+
+const (
+	hangulBase       = 0xAC00 // 가
+	hangulLast       = 0xD7A3 // 힣
+	hangulMedialSize = 21     // 중성 수
+	hangulFinalSize  = 28     // 종성 수 (받침 없음 포함)
+	hangulRieul      = 5      // 초성 ㄹ
+	hangulFinalRieul = 8      // 종성 ㄹ
+)
+
+// 히라가나의 헵번식 로마자 표기. 가타카나는 히라가나로 바꿔서 찾습니다.
+var kanaRomaji = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n", 'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o", 'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo",
+}
+
+// 앞 글자와 합쳐 요음이 되는 작은 ゃ, ゅ, ょ
+var kanaYoon = map[rune]string{'ゃ': "a", 'ゅ': "u", 'ょ': "o"}
+
+// Romanize는 이름 안의 한글과 가나를 로마자로 바꿉니다. 한글이나 가나가 이어진 부분은 첫 글자가 대문자인 한 단어가 됩니다. (아이템 → Aitem, 최대HP → ChoedaeHP)
+// 그 밖의 문자는 그대로 두며, 로마자로 바꿀 수 없는 문자(한자 등)가 남으면 false를 반환합니다.
+func Romanize(name string) (string, bool) {
+	var b strings.Builder
+	var word []string
+	ok := true
+
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+		text := strings.Join(word, "")
+		if text != "" {
+			b.WriteString(strings.ToUpper(text[:1]) + text[1:])
+		}
+		word = nil
+	}
+
+	prevFinal := -1 // 바로 앞 한글 음절의 종성 (한글이 아니면 -1)
+	double := false // 작은 っ 다음 자음은 두 번 씀
+	for _, r := range name {
+		if r >= hangulBase && r <= hangulLast {
+			s := int(r - hangulBase)
+			initial, medial, final := s/(hangulMedialSize*hangulFinalSize), s%(hangulMedialSize*hangulFinalSize)/hangulFinalSize, s%hangulFinalSize
+			lead := hangulInitials[initial]
+			if initial == hangulRieul && prevFinal == hangulFinalRieul {
+				lead = "l" // ㄹㄹ은 ll (달리 → dalli)
+			}
+			word = append(word, lead+hangulMedials[medial]+hangulFinals[final])
+			prevFinal = final
+			continue
+		}
+		prevFinal = -1
+
+		if kana := toHiragana(r); kana != 0 {
+			switch {
+			case kana == 'っ':
+				double = true
+				continue
+			case kana == 'ー':
+				continue
+			case kanaYoon[kana] != "" && len(word) > 0:
+				last := word[len(word)-1]
+				if strings.HasSuffix(last, "i") {
+					stem := strings.TrimSuffix(last, "i")
+					if stem != "sh" && stem != "ch" && stem != "j" {
+						stem += "y"
+					}
+					word[len(word)-1] = stem + kanaYoon[kana]
+					continue
+				}
+			}
+			romaji := kanaRomaji[kana]
+			if double && romaji != "" {
+				if strings.HasPrefix(romaji, "ch") {
+					romaji = "t" + romaji
+				} else if c := romaji[0]; !strings.ContainsRune("aiueon", rune(c)) {
+					romaji = string(c) + romaji
+				}
+			}
+			double = false
+			word = append(word, romaji)
+			continue
+		}
+
+		flush()
+		if r == '・' {
+			continue // 가나 단어 사이의 가운뎃점
+		}
+		if r > unicode.MaxASCII && unicode.IsLetter(r) {
+			ok = false
+		}
+		b.WriteRune(r)
+	}
+	flush()
+	return b.String(), ok
+}
+
+// toHiragana는 가나를 히라가나로 바꿉니다. 가나가 아니면 0입니다. 장음 부호 ー는 그대로 반환합니다.
+func toHiragana(r rune) rune {
+	switch {
+	case r == 'ー':
+		return r
+	case r >= 'ぁ' && r <= 'ゔ':
+		return r
+	case r >= 'ァ' && r <= 'ヴ':
+		return r - 'ァ' + 'ぁ'
+	}
+	return 0
+}
+
+// hasRomanizable는 이름에 한글이나 가나가 있는지 반환합니다.
+func hasRomanizable(name string) bool {
+	for _, r := range name {
+		if (r >= hangulBase && r <= hangulLast) || toHiragana(r) != 0 {
+			return true
+		}
+	}
+	return false
+}
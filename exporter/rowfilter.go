@@ -0,0 +1,329 @@
+// exporter/rowfilter.go
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RowFilter는 테이블에 남길 행의 조건입니다. 조건이 거짓인 행은 생성 결과에서 제외됩니다.
+// #Config의 filter 키(모든 빌드) 또는 filter:<프로필> 키(해당 프로필로 빌드할 때만)로 지정합니다.
+//
+// 조건식은 변환식(transform)과 같은 문법에 컬럼 이름, 비교와 논리 연산을 더한 것입니다.
+//
+//	비교:  == (또는 =), !=, <, <=, >, >=  양쪽이 숫자이면 숫자로, 아니면 문자열로 비교
+//	논리:  and (&&), or (||), not (!)
+//
+// 예: rarity <= 3, type == 'weapon' and not (level > 50)
+type RowFilter struct {
+	Profile string // 비어있으면 모든 빌드에 적용
+	Source  string
+}
+
+// compareNode는 비교 연산 노드입니다.
+type compareNode struct {
+	op          string
+	left, right exprNode
+}
+
+// logicNode는 and/or 노드입니다.
+type logicNode struct {
+	and         bool
+	left, right exprNode
+}
+
+type notNode struct{ operand exprNode }
+
+// columnNode는 행 필터에서 컬럼 값을 읽습니다. eval의 value는 컬럼 이름(소문자) → 값 맵입니다.
+type columnNode struct{ name string }
+
+func (n columnNode) eval(value interface{}) (interface{}, error) {
+	row, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("column %s is not available here", n.name)
+	}
+	return row[n.name], nil
+}
+
+func (n compareNode) eval(value interface{}) (interface{}, error) {
+	l, err := n.left.eval(value)
+	if err != nil {
+		return nil, err
+	}
+	r, err := n.right.eval(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cmp int
+	a, errA := exprNumber(l)
+	b, errB := exprNumber(r)
+	if errA == nil && errB == nil {
+		switch {
+		case a < b:
+			cmp = -1
+		case a > b:
+			cmp = 1
+		}
+	} else {
+		cmp = strings.Compare(exprString(l), exprString(r))
+	}
+
+	switch n.op {
+	case "==", "=":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", n.op)
+}
+
+func (n logicNode) eval(value interface{}) (interface{}, error) {
+	l, err := n.left.eval(value)
+	if err != nil {
+		return nil, err
+	}
+	a, err := exprBool(l)
+	if err != nil {
+		return nil, err
+	}
+	// 단락 평가
+	if a != n.and {
+		return a, nil
+	}
+	r, err := n.right.eval(value)
+	if err != nil {
+		return nil, err
+	}
+	return exprBool(r)
+}
+
+func (n notNode) eval(value interface{}) (interface{}, error) {
+	v, err := n.operand.eval(value)
+	if err != nil {
+		return nil, err
+	}
+	b, err := exprBool(v)
+	if err != nil {
+		return nil, err
+	}
+	return !b, nil
+}
+
+// exprBool은 조건식 값을 불리언으로 변환합니다. bool 컬럼 값도 그대로 조건으로 쓸 수 있습니다.
+func exprBool(v interface{}) (bool, error) {
+	switch v := v.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "1":
+			return true, nil
+		case "false", "0", "":
+			return false, nil
+		}
+	case float64:
+		return v != 0, nil
+	}
+	return false, fmt.Errorf("%v is not a condition", v)
+}
+
+func (p *exprParser) isKeyword(s string) bool {
+	return p.tok.kind == tokIdent && p.tok.text == s
+}
+
+func (p *exprParser) parseCondition() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") || p.isPunct("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicNode{and: false, left: left, right: right}
+	}
+	return left, p.err
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") || p.isPunct("&&") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = logicNode{and: true, left: left, right: right}
+	}
+	return left, p.err
+}
+
+func (p *exprParser) parseNot() (exprNode, error) {
+	if p.isKeyword("not") || p.isPunct("!") {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "=", "!=", "<=", ">=", "<", ">"} {
+		if p.isPunct(op) {
+			p.next()
+			right, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return compareNode{op: op, left: left, right: right}, nil
+		}
+	}
+	return left, p.err
+}
+
+// rowCondition은 컴파일된 행 필터입니다.
+type rowCondition struct {
+	source string
+	root   exprNode
+}
+
+// compileRowFilter는 테이블의 컬럼을 참조하는 조건식을 파싱합니다.
+func compileRowFilter(table Table, source string) (*rowCondition, error) {
+	columns := make(map[string]bool, len(table.Columns))
+	for _, col := range table.Columns {
+		columns[strings.ToLower(col.Name)] = true
+	}
+
+	p := &exprParser{input: source, columns: columns}
+	p.next()
+
+	root, err := p.parseCondition()
+	if err == nil && p.tok.kind != tokEOF {
+		err = fmt.Errorf("unexpected %q", p.tok.text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid row filter %q for table %s: %v", source, table.Name, err)
+	}
+	return &rowCondition{source: source, root: root}, nil
+}
+
+func (c *rowCondition) match(table Table, row []interface{}) (bool, error) {
+	result, err := c.root.eval(rowFilterValues(table, row))
+	if err != nil {
+		return false, fmt.Errorf("row filter %q: %v", c.source, err)
+	}
+	return exprBool(result)
+}
+
+// rowFilterValues는 행을 조건식에서 사용할 컬럼 이름(소문자) → 값 맵으로 변환합니다.
+// 숫자는 float64, 배열은 JSON 문자열, 빈 셀은 빈 문자열입니다.
+func rowFilterValues(table Table, row []interface{}) map[string]interface{} {
+	values := make(map[string]interface{}, len(table.Columns))
+	for i, col := range table.Columns {
+		var value interface{} = ""
+		if i < len(row) && row[i] != nil {
+			value = row[i]
+			rv := reflect.ValueOf(value)
+			switch rv.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				value = float64(rv.Int())
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				value = float64(rv.Uint())
+			case reflect.Float32, reflect.Float64:
+				value = rv.Float()
+			case reflect.Bool, reflect.String:
+			default:
+				value = fmt.Sprintf("%v", value)
+			}
+		}
+		values[strings.ToLower(col.Name)] = value
+	}
+	return values
+}
+
+// ApplyRowFilters는 테이블의 행 필터 중 모든 빌드에 적용되는 것과 profile에 해당하는 것을 적용합니다.
+// 필터로 제외된 행을 다른 행이 ref<>/관계로 참조하고 있으면 참조 무결성 에러를 반환합니다.
+func ApplyRowFilters(tables []Table, profile string) ([]Table, []error) {
+	var errs []error
+	filtered := make([]Table, len(tables))
+	removed := 0
+	for i, table := range tables {
+		filtered[i] = table
+
+		var conditions []*rowCondition
+		for _, filter := range table.RowFilters {
+			if filter.Profile != "" && !strings.EqualFold(filter.Profile, profile) {
+				continue
+			}
+			cond, err := compileRowFilter(table, filter.Source)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			conditions = append(conditions, cond)
+		}
+		if len(conditions) == 0 {
+			continue
+		}
+
+		rows := make([][]interface{}, 0, len(table.Rows))
+	rowLoop:
+		for _, row := range table.Rows {
+			for _, cond := range conditions {
+				ok, err := cond.match(table, row)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("table %s row %s: %v", table.Name, RowKey(table, row), err))
+					continue rowLoop
+				}
+				if !ok {
+					removed++
+					continue rowLoop
+				}
+			}
+			rows = append(rows, row)
+		}
+		filtered[i].Rows = rows
+	}
+	if removed == 0 || len(errs) > 0 {
+		return filtered, errs
+	}
+
+	// 필터 전에는 없던 끊어진 참조는 필터로 제외된 행을 가리키는 것
+	before := danglingEdges(tables)
+	for _, edge := range BuildRefGraph(filtered).Dangling {
+		if !before[edge] {
+			errs = append(errs, fmt.Errorf("%s.%s refers to %s which is excluded by a row filter", edge.From, edge.Column, edge.To))
+		}
+	}
+	return filtered, errs
+}
+
+func danglingEdges(tables []Table) map[RefEdge]bool {
+	edges := make(map[RefEdge]bool)
+	for _, edge := range BuildRefGraph(tables).Dangling {
+		edges[edge] = true
+	}
+	return edges
+}
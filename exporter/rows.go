@@ -0,0 +1,98 @@
+// exporter/rows.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CellWarning은 파싱은 계속하지만 작성자가 확인해야 하는 셀입니다. (헤더 범위 밖의 값 등)
+type CellWarning struct {
+	Workbook string
+	Sheet    string
+	Cell     string // 시트의 셀 이름 (F5 등)
+	Message  string
+}
+
+func (w CellWarning) String() string {
+	return fmt.Sprintf("%s!%s: %s", w.Sheet, w.Cell, w.Message)
+}
+
+// headerWidth는 컬럼명 행에서 마지막으로 이름이 있는 열까지의 열 수입니다.
+func headerWidth(names []string) int {
+	width := len(names)
+	for width > 0 && strings.TrimSpace(names[width-1]) == "" {
+		width--
+	}
+	return width
+}
+
+// normalizeRows는 excelize가 반환한 길이가 제각각인 행들을 헤더의 열 수에 맞춥니다.
+// 짧은 행(뒤쪽 빈 셀이 생략된 행)은 빈 문자열로 채우고, 헤더 범위 밖의 열은 잘라냅니다.
+// 잘라낸 셀에 값이 있으면 조용히 버리지 않고 경고로 반환합니다.
+func normalizeRows(sheetName string, rows [][]string, pos sheetPosition) ([][]string, []CellWarning) {
+	if len(rows) == 0 {
+		return rows, nil
+	}
+	n := newRowNormalizer(sheetName, rows[0], pos)
+
+	var warnings []CellWarning
+	result := make([][]string, len(rows))
+	for r, row := range rows {
+		var rowWarnings []CellWarning
+		result[r], rowWarnings = n.normalize(r, row)
+		warnings = append(warnings, rowWarnings...)
+	}
+	return result, warnings
+}
+
+// rowNormalizer는 normalizeRows의 규칙을 행 하나씩 적용합니다. 시트 전체를 읽지 않는 스트리밍(StreamJSONL)에서 사용합니다.
+type rowNormalizer struct {
+	sheetName  string
+	pos        sheetPosition
+	width      int    // 헤더의 열 수
+	lastColumn string // 마지막 컬럼명이 있는 셀
+}
+
+// newRowNormalizer는 컬럼명 행(names)의 열 수에 맞추는 rowNormalizer를 만듭니다.
+func newRowNormalizer(sheetName string, names []string, pos sheetPosition) rowNormalizer {
+	n := rowNormalizer{sheetName: sheetName, pos: pos, width: headerWidth(names)}
+	if n.width > 0 {
+		n.lastColumn = pos.cell(0, n.width-1)
+	}
+	return n
+}
+
+// normalize는 r번째 행(컬럼명 행이 0)을 헤더의 열 수에 맞추고, 잘라낸 값이 있는 셀의 경고를 반환합니다.
+func (n rowNormalizer) normalize(r int, row []string) ([]string, []CellWarning) {
+	var warnings []CellWarning
+	for c := n.width; c < len(row); c++ {
+		if strings.TrimSpace(row[c]) == "" {
+			continue
+		}
+		message := fmt.Sprintf("value %q is outside the header columns (the last column name is in %s) and is ignored", row[c], n.lastColumn)
+		if n.width == 0 {
+			message = fmt.Sprintf("value %q is ignored because the sheet has no column names", row[c])
+		}
+		warnings = append(warnings, CellWarning{Sheet: n.sheetName, Cell: n.pos.cell(r, c), Message: message})
+	}
+
+	normalized := make([]string, n.width)
+	copy(normalized, row)
+	return normalized, warnings
+}
+
+// CellWarnings는 테이블들을 파싱하면서 모은 셀 경고를 반환합니다.
+func CellWarnings(tables []Table) []CellWarning {
+	var warnings []CellWarning
+	for _, table := range tables {
+		if table.Source == nil {
+			continue
+		}
+		for _, w := range table.Source.Warnings {
+			w.Workbook = table.Source.Workbook
+			warnings = append(warnings, w)
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,212 @@
+// exporter/runcompare.go
+package exporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// 생성 결과 비교에서 항목의 변경 종류
+const (
+	RunAdded    = "added"
+	RunRemoved  = "removed"
+	RunModified = "modified"
+)
+
+// RunComparison은 두 생성 결과(출력 디렉토리)의 차이입니다.
+// 산출물 파일은 manifest.json(없으면 디렉토리에서 계산)으로, 테이블은 tables.json으로 비교합니다.
+type RunComparison struct {
+	Base       string           `json:"base"`
+	Target     string           `json:"target"`
+	BaseRoot   string           `json:"baseRoot"`   // 산출물 전체의 루트 해시
+	TargetRoot string           `json:"targetRoot"` // 산출물 전체의 루트 해시
+	Tables     []TableChange    `json:"tables"`
+	Artifacts  []ArtifactChange `json:"artifacts"`
+
+	// 두 결과 모두 tables.json이 있어서 테이블 단위로 비교했는지 여부 (--tables-manifest로 생성)
+	TablesCompared bool `json:"tablesCompared"`
+}
+
+// TableChange는 테이블 하나의 변경입니다.
+type TableChange struct {
+	Name       string   `json:"name"`
+	Change     string   `json:"change"`
+	BaseRows   int      `json:"baseRows"`
+	TargetRows int      `json:"targetRows"`
+	Schema     []string `json:"schema,omitempty"` // 컬럼 변경 (+Col, -Col, Col: int -> int64)
+	Data       bool     `json:"data"`             // 행 내용이 바뀌었는지
+}
+
+// ArtifactChange는 산출물 파일 하나의 변경입니다.
+type ArtifactChange struct {
+	Path       string `json:"path"`
+	Change     string `json:"change"`
+	BaseSize   int64  `json:"baseSize"`
+	TargetSize int64  `json:"targetSize"`
+}
+
+// Changed는 두 결과에 차이가 있는지 확인합니다.
+func (c RunComparison) Changed() bool {
+	return c.BaseRoot != c.TargetRoot || len(c.Tables) > 0 || len(c.Artifacts) > 0
+}
+
+// CompareRuns는 두 출력 디렉토리의 산출물과 테이블을 비교합니다.
+// 어느 데이터 빌드에서 문제가 생겼는지 빌드 결과들을 차례로 비교하며 찾을 때 사용합니다.
+func CompareRuns(base, target string) (RunComparison, error) {
+	c := RunComparison{Base: base, Target: target, Tables: []TableChange{}, Artifacts: []ArtifactChange{}}
+
+	baseManifest, err := loadRunManifest(base)
+	if err != nil {
+		return c, err
+	}
+	targetManifest, err := loadRunManifest(target)
+	if err != nil {
+		return c, err
+	}
+	c.BaseRoot, c.TargetRoot = baseManifest.RootHash, targetManifest.RootHash
+	c.Artifacts = compareArtifacts(baseManifest.Artifacts, targetManifest.Artifacts)
+
+	baseTables, baseOK, err := readTablesManifest(base)
+	if err != nil {
+		return c, err
+	}
+	targetTables, targetOK, err := readTablesManifest(target)
+	if err != nil {
+		return c, err
+	}
+	if baseOK && targetOK {
+		c.TablesCompared = true
+		c.Tables = compareTableManifests(baseTables.Tables, targetTables.Tables)
+	}
+	return c, nil
+}
+
+// loadRunManifest는 출력 디렉토리의 manifest.json을 읽고, 없으면 디렉토리의 파일들로 계산합니다.
+func loadRunManifest(dir string) (Manifest, error) {
+	var manifest Manifest
+	if info, err := os.Stat(dir); err != nil {
+		return manifest, err
+	} else if !info.IsDir() {
+		return manifest, fmt.Errorf("%s is not an output directory", dir)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return BuildManifest(dir)
+	}
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse %s: %v", filepath.Join(dir, ManifestFileName), err)
+	}
+	return manifest, nil
+}
+
+// readTablesManifest는 출력 디렉토리의 tables.json을 읽습니다. 없으면 ok가 false입니다.
+func readTablesManifest(dir string) (manifest TablesManifest, ok bool, err error) {
+	path := filepath.Join(dir, TablesManifestFileName)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, false, nil
+	}
+	if err != nil {
+		return manifest, false, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, false, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return manifest, true, nil
+}
+
+func compareArtifacts(base, target []ManifestEntry) []ArtifactChange {
+	changes := []ArtifactChange{}
+	baseByPath := make(map[string]ManifestEntry, len(base))
+	for _, entry := range base {
+		baseByPath[entry.Path] = entry
+	}
+	for _, entry := range target {
+		// 매니페스트 자체와 같이 항상 같은 표시 파일은 비교하지 않음
+		if entry.Path == OutputMarkerFile || entry.Path == ManifestFileName {
+			continue
+		}
+		old, ok := baseByPath[entry.Path]
+		delete(baseByPath, entry.Path)
+		switch {
+		case !ok:
+			changes = append(changes, ArtifactChange{Path: entry.Path, Change: RunAdded, TargetSize: entry.Size})
+		case old.SHA256 != entry.SHA256:
+			changes = append(changes, ArtifactChange{Path: entry.Path, Change: RunModified, BaseSize: old.Size, TargetSize: entry.Size})
+		}
+	}
+	for path, entry := range baseByPath {
+		if path == OutputMarkerFile || path == ManifestFileName {
+			continue
+		}
+		changes = append(changes, ArtifactChange{Path: path, Change: RunRemoved, BaseSize: entry.Size})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func compareTableManifests(base, target []TableManifest) []TableChange {
+	changes := []TableChange{}
+	baseByName := make(map[string]TableManifest, len(base))
+	for _, table := range base {
+		baseByName[table.Name] = table
+	}
+	for _, table := range target {
+		old, ok := baseByName[table.Name]
+		delete(baseByName, table.Name)
+		if !ok {
+			changes = append(changes, TableChange{Name: table.Name, Change: RunAdded, TargetRows: table.Rows, Data: true})
+			continue
+		}
+		change := TableChange{
+			Name:       table.Name,
+			Change:     RunModified,
+			BaseRows:   old.Rows,
+			TargetRows: table.Rows,
+			Schema:     compareColumnManifests(old.Columns, table.Columns),
+			Data:       old.Hash != table.Hash || old.Rows != table.Rows,
+		}
+		if change.Data || len(change.Schema) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	for _, table := range baseByName {
+		changes = append(changes, TableChange{Name: table.Name, Change: RunRemoved, BaseRows: table.Rows, Data: true})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// compareColumnManifests는 추가되거나 삭제되거나 타입이 바뀐 컬럼을 나열합니다.
+func compareColumnManifests(base, target []ColumnManifest) []string {
+	var changes []string
+	baseByName := make(map[string]ColumnManifest, len(base))
+	for _, col := range base {
+		baseByName[col.Name] = col
+	}
+	targetNames := make(map[string]bool, len(target))
+	for _, col := range target {
+		targetNames[col.Name] = true
+		old, ok := baseByName[col.Name]
+		switch {
+		case !ok:
+			changes = append(changes, "+"+col.Name)
+		case old.Type != col.Type:
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", col.Name, old.Type, col.Type))
+		}
+	}
+	for _, col := range base {
+		if !targetNames[col.Name] {
+			changes = append(changes, "-"+col.Name)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,79 @@
+// exporter/schedule.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EffectiveDateColumns는 valid_from/valid_to 태그가 붙은 컬럼의 위치를 반환합니다. 없으면 -1입니다.
+func EffectiveDateColumns(table Table) (from, to int) {
+	from, to = -1, -1
+	for i, col := range table.Columns {
+		if HasTag(col.Tags, TagValidFrom) {
+			from = i
+		}
+		if HasTag(col.Tags, TagValidTo) {
+			to = i
+		}
+	}
+	return from, to
+}
+
+// validateEffectiveDates는 스케줄 컬럼 정의가 올바른지 검사합니다.
+func validateEffectiveDates(table Table) error {
+	from, to := EffectiveDateColumns(table)
+	if from == -1 {
+		if to != -1 {
+			return fmt.Errorf("table %s: valid_to requires a valid_from column", table.Name)
+		}
+		return nil
+	}
+
+	for _, idx := range []int{from, to} {
+		if idx == -1 {
+			continue
+		}
+		if col := table.Columns[idx]; col.Type.Type != DateTimeType.Type {
+			return fmt.Errorf("table %s: column %s must be a datetime to be used as an effective date", table.Name, col.Name)
+		}
+	}
+	return nil
+}
+
+// buildActiveViewQuery는 현재 시각에 적용 중인 행을 인덱스별로 하나씩 반환하는 뷰를 생성합니다.
+// 같은 인덱스에 적용 가능한 행이 여러 개면 valid_from이 가장 늦은 행이 선택됩니다.
+func buildActiveViewQuery(table Table, d SQLDialect) string {
+	from, to := EffectiveDateColumns(table)
+	if from == -1 {
+		return ""
+	}
+
+	quotedTable := d.QuoteIdentifier(table.Name)
+	quotedIndex := d.QuoteIdentifier(table.Columns[IndexColumn(table)].Name)
+	quotedFrom := d.QuoteIdentifier(table.Columns[from].Name)
+	storage := tableDateTimeStorage(table)
+	minDate := "'0001-01-01'"
+	if storage == DateTimeEpoch {
+		minDate = fmt.Sprint(minEpoch)
+	}
+
+	activeCond := func(alias string) string {
+		conds := []string{fmt.Sprintf("(%s.%s IS NULL OR %s <= datetime('now'))", alias, quotedFrom, sqliteDateTime(alias+"."+quotedFrom, storage))}
+		if to != -1 {
+			quotedTo := d.QuoteIdentifier(table.Columns[to].Name)
+			conds = append(conds, fmt.Sprintf("(%s.%s IS NULL OR %s > datetime('now'))", alias, quotedTo, sqliteDateTime(alias+"."+quotedTo, storage)))
+		}
+		return strings.Join(conds, " AND ")
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("CREATE VIEW IF NOT EXISTS %s AS\n", d.QuoteIdentifier(table.Name+"_active")))
+	b.WriteString(fmt.Sprintf("SELECT t.* FROM %s t\nWHERE %s\n", quotedTable, activeCond("t")))
+	b.WriteString(fmt.Sprintf("  AND NOT EXISTS (\n    SELECT 1 FROM %s o\n    WHERE o.%s = t.%s AND %s\n", quotedTable, quotedIndex, quotedIndex, activeCond("o")))
+	oFrom := sqliteDateTime(fmt.Sprintf("COALESCE(o.%s, %s)", quotedFrom, minDate), storage)
+	tFrom := sqliteDateTime(fmt.Sprintf("COALESCE(t.%s, %s)", quotedFrom, minDate), storage)
+	b.WriteString(fmt.Sprintf("      AND (%s > %s OR (%s = %s AND o.id > t.id))\n  );\n", oFrom, tFrom, oFrom, tFrom))
+
+	return b.String()
+}
@@ -0,0 +1,322 @@
+// exporter/schemaregistry.go
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 스키마 레지스트리에 게시할 스키마 형식
+const (
+	SchemaFormatJSON = "JSON"
+	SchemaFormatAvro = "AVRO"
+)
+
+// TableJSONSchema는 테이블 한 행을 나타내는 JSON Schema(draft-07)를 생성합니다.
+// 빈 셀은 NULL로 저장되므로 모든 속성은 null을 허용합니다.
+func TableJSONSchema(table Table) map[string]interface{} {
+	properties := make(map[string]interface{}, len(table.Columns))
+	for _, col := range table.Columns {
+		prop := jsonSchemaType(col.Type)
+		prop["type"] = []interface{}{prop["type"], "null"}
+		properties[col.Name] = prop
+	}
+
+	return map[string]interface{}{
+		"$schema":              "http://json-schema.org/draft-07/schema#",
+		"title":                table.Name,
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+func jsonSchemaType(ct ColumnType) map[string]interface{} {
+	if ct.IsArray && ct.BaseType != nil {
+		return map[string]interface{}{"type": "array", "items": jsonSchemaType(*ct.BaseType)}
+	}
+	if ct.IsGeo() {
+		return map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "number"}, "minItems": 2, "maxItems": 2}
+	}
+	if ct.IsCurve() {
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"mode": map[string]interface{}{"enum": []string{CurveLinear, CurveBezier}},
+				"keys": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "number"}}},
+			},
+			"required": []string{"mode", "keys"},
+		}
+	}
+
+	switch ct.Type.Kind() {
+	case reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	}
+
+	switch ct.Type {
+	case DateTimeType.Type:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case BytesType.Type:
+		return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+	}
+	return map[string]interface{}{"type": "string"}
+}
+
+// TableAvroSchema는 테이블 한 행을 나타내는 Avro record 스키마를 생성합니다.
+// 모든 필드는 null과의 union이며 기본값은 null입니다.
+func TableAvroSchema(table Table, namespace string) map[string]interface{} {
+	fields := make([]interface{}, 0, len(table.Columns))
+	for _, col := range table.Columns {
+		fields = append(fields, map[string]interface{}{
+			"name":    col.Name,
+			"type":    []interface{}{"null", avroType(col.Type)},
+			"default": nil,
+		})
+	}
+
+	schema := map[string]interface{}{
+		"type":   "record",
+		"name":   table.Name,
+		"fields": fields,
+	}
+	if namespace != "" {
+		schema["namespace"] = namespace
+	}
+	return schema
+}
+
+func avroType(ct ColumnType) interface{} {
+	if ct.IsArray && ct.BaseType != nil {
+		return map[string]interface{}{"type": "array", "items": avroType(*ct.BaseType)}
+	}
+	if ct.IsGeo() {
+		return map[string]interface{}{"type": "array", "items": "double"}
+	}
+	// 커브의 보간 방식은 컬럼 타입으로 정해지므로 키프레임만 기술 (이름 있는 record는 스키마에 한 번만 정의할 수 있음)
+	if ct.IsCurve() {
+		return map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "array", "items": "double"}}
+	}
+
+	switch ct.Type.Kind() {
+	case reflect.Int32:
+		return "int"
+	case reflect.Int64:
+		return "long"
+	case reflect.Float64:
+		return "double"
+	case reflect.Bool:
+		return "boolean"
+	}
+
+	switch ct.Type {
+	case DateTimeType.Type:
+		return map[string]interface{}{"type": "long", "logicalType": "timestamp-millis"}
+	case BytesType.Type:
+		return "bytes"
+	}
+	return "string"
+}
+
+// TableSchema는 format에 맞는 테이블 스키마를 JSON 문자열로 반환합니다.
+func TableSchema(table Table, format, namespace string) (string, error) {
+	var schema map[string]interface{}
+	switch strings.ToUpper(format) {
+	case SchemaFormatJSON:
+		schema = TableJSONSchema(table)
+	case SchemaFormatAvro:
+		schema = TableAvroSchema(table, namespace)
+	default:
+		return "", fmt.Errorf("unsupported schema format: %s", format)
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// SchemaRegistryClient는 Confluent Schema Registry REST API 클라이언트입니다.
+// Apicurio Registry는 호환 API 경로(예: http://host/apis/ccompat/v7)를 BaseURL로 사용합니다.
+type SchemaRegistryClient struct {
+	BaseURL  string
+	Username string
+	Password string
+	Client   *http.Client
+}
+
+// NewSchemaRegistryClient는 기본 HTTP 클라이언트를 사용하는 레지스트리 클라이언트를 생성합니다.
+func NewSchemaRegistryClient(baseURL, username, password string) *SchemaRegistryClient {
+	return &SchemaRegistryClient{
+		BaseURL:  strings.TrimSuffix(baseURL, "/"),
+		Username: username,
+		Password: password,
+		Client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type registrySchemaRequest struct {
+	SchemaType string `json:"schemaType,omitempty"`
+	Schema     string `json:"schema"`
+}
+
+// CheckCompatibility는 스키마가 subject의 최신 버전과 호환되는지 확인합니다.
+// subject가 아직 없으면 호환되는 것으로 간주합니다.
+// 호환되지 않으면 레지스트리가 알려준 사유 목록을 함께 반환합니다.
+func (c *SchemaRegistryClient) CheckCompatibility(subject, format, schema string) (bool, []string, error) {
+	var result struct {
+		IsCompatible bool     `json:"is_compatible"`
+		Messages     []string `json:"messages"`
+	}
+
+	path := "/compatibility/subjects/" + url.PathEscape(subject) + "/versions/latest?verbose=true"
+	status, err := c.post(path, registrySchemaRequest{SchemaType: registrySchemaType(format), Schema: schema}, &result)
+	if status == http.StatusNotFound {
+		return true, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	return result.IsCompatible, result.Messages, nil
+}
+
+// Register는 스키마를 subject의 새 버전으로 등록하고 스키마 ID를 반환합니다.
+// 동일한 스키마가 이미 등록되어 있으면 기존 ID가 반환됩니다.
+func (c *SchemaRegistryClient) Register(subject, format, schema string) (int, error) {
+	var result struct {
+		ID int `json:"id"`
+	}
+
+	path := "/subjects/" + url.PathEscape(subject) + "/versions"
+	if _, err := c.post(path, registrySchemaRequest{SchemaType: registrySchemaType(format), Schema: schema}, &result); err != nil {
+		return 0, err
+	}
+	return result.ID, nil
+}
+
+// registrySchemaType은 레지스트리 API의 schemaType 값을 반환합니다. Avro는 기본값이므로 생략합니다.
+func registrySchemaType(format string) string {
+	if format = strings.ToUpper(format); format == SchemaFormatAvro {
+		return ""
+	}
+	return format
+}
+
+func (c *SchemaRegistryClient) post(path string, body interface{}, out interface{}) (int, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.BaseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	req.Header.Set("Accept", "application/vnd.schemaregistry.v1+json, application/json")
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var regErr struct {
+			ErrorCode int    `json:"error_code"`
+			Message   string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &regErr) == nil && regErr.Message != "" {
+			return resp.StatusCode, fmt.Errorf("schema registry error %d: %s", regErr.ErrorCode, regErr.Message)
+		}
+		return resp.StatusCode, fmt.Errorf("schema registry returned %s", resp.Status)
+	}
+
+	return resp.StatusCode, json.Unmarshal(respBody, out)
+}
+
+// PublishResult는 테이블 하나의 스키마 게시 결과입니다.
+type PublishResult struct {
+	Table    string
+	Subject  string
+	SchemaID int
+}
+
+// IncompatibleSchemaError는 레지스트리의 최신 스키마와 호환되지 않는 테이블 목록입니다.
+type IncompatibleSchemaError struct {
+	Subjects map[string][]string // subject -> 레지스트리가 알려준 사유
+}
+
+func (e *IncompatibleSchemaError) Error() string {
+	names := make([]string, 0, len(e.Subjects))
+	for subject := range e.Subjects {
+		names = append(names, subject)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("incompatible schema changes for %s", strings.Join(names, ", "))
+}
+
+// PublishSchemas는 모든 테이블의 스키마가 호환되는지 먼저 확인한 뒤 레지스트리에 등록합니다.
+// 하나라도 호환되지 않으면 아무것도 등록하지 않고 IncompatibleSchemaError를 반환합니다.
+// subject 이름은 "<subjectPrefix><테이블 이름>-value" 입니다. checkOnly이면 등록하지 않습니다.
+func PublishSchemas(client *SchemaRegistryClient, tables []Table, format, namespace, subjectPrefix string, checkOnly bool) ([]PublishResult, error) {
+	schemas := make([]string, len(tables))
+	incompatible := make(map[string][]string)
+
+	for i, table := range tables {
+		schema, err := TableSchema(table, format, namespace)
+		if err != nil {
+			return nil, err
+		}
+		schemas[i] = schema
+
+		subject := subjectPrefix + table.Name + "-value"
+		ok, messages, err := client.CheckCompatibility(subject, format, schema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check compatibility of %s: %v", subject, err)
+		}
+		if !ok {
+			incompatible[subject] = messages
+		}
+	}
+
+	if len(incompatible) > 0 {
+		return nil, &IncompatibleSchemaError{Subjects: incompatible}
+	}
+
+	results := make([]PublishResult, 0, len(tables))
+	for i, table := range tables {
+		result := PublishResult{Table: table.Name, Subject: subjectPrefix + table.Name + "-value"}
+		if !checkOnly {
+			id, err := client.Register(result.Subject, format, schemas[i])
+			if err != nil {
+				return results, fmt.Errorf("failed to register %s: %v", result.Subject, err)
+			}
+			result.SchemaID = id
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
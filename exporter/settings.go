@@ -0,0 +1,130 @@
+// exporter/settings.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// SettingsSheetPrefix는 키-값 설정 시트의 이름 접두사입니다.
+// "#Settings" 시트는 Settings 테이블이 되고, "#Settings.Battle" 시트는 Battle 테이블이 됩니다.
+//
+// 설정 시트는 Key, Type, Value 헤더(Tag, Description은 선택)를 가지며,
+// 한 행이 하나의 설정 값입니다. 각 키는 컬럼이 되어 한 행짜리 테이블로 변환되므로
+// 내보내기 결과로 타입이 지정된 설정 구조체와 한 행짜리 설정 테이블이 생성됩니다.
+const SettingsSheetPrefix = "#Settings"
+
+// isSettingsSheet는 시트가 키-값 설정 시트인지 확인합니다.
+func isSettingsSheet(sheetName string) bool {
+	return sheetName == SettingsSheetPrefix || strings.HasPrefix(sheetName, SettingsSheetPrefix+".")
+}
+
+// settingsTableName은 설정 시트의 테이블 이름을 반환합니다.
+func settingsTableName(sheetName string) string {
+	name := strings.TrimPrefix(strings.TrimPrefix(sheetName, SettingsSheetPrefix), ".")
+	if strings.TrimSpace(name) == "" {
+		name = "Settings"
+	}
+	return formatTableName(name)
+}
+
+// sheetTableName은 시트에서 만들어지는 테이블 이름을 반환합니다.
+func sheetTableName(sheetName string) string {
+	if isSettingsSheet(sheetName) {
+		return settingsTableName(sheetName)
+	}
+	return formatTableName(sheetName)
+}
+
+// parseSettingsSheets는 워크북의 설정 시트들을 한 행짜리 테이블로 파싱합니다.
+func parseSettingsSheets(f *excelize.File, selected map[string]bool) ([]Table, error) {
+	var tables []Table
+	for _, sheetName := range f.GetSheetList() {
+		if !isSettingsSheet(sheetName) {
+			continue
+		}
+		if selected != nil && !selected[settingsTableName(sheetName)] {
+			continue
+		}
+
+		rows, err := f.GetRows(sheetName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sheet %s: %v", sheetName, err)
+		}
+
+		table, err := parseSettingsSheet(sheetName, rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse settings sheet %s: %v", sheetName, err)
+		}
+		if len(table.Columns) == 0 {
+			continue
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// parseSettingsSheet는 Key/Type/Value 행들을 표준 배치(컬럼명/태그/타입/값 4행)로 바꾼 뒤 파싱합니다.
+func parseSettingsSheet(sheetName string, rows [][]string) (Table, error) {
+	if len(rows) < 2 {
+		return Table{Name: settingsTableName(sheetName), SheetName: sheetName, IsSettings: true}, nil
+	}
+
+	colIndexes := map[string]int{
+		"Key":   -1,
+		"Type":  -1,
+		"Value": -1,
+		"Tag":   -1,
+	}
+
+	for i, cell := range rows[0] {
+		colName := strings.TrimSpace(cell)
+		if _, ok := colIndexes[colName]; ok {
+			colIndexes[colName] = i
+		}
+	}
+
+	for _, col := range []string{"Key", "Type", "Value"} {
+		if colIndexes[col] == -1 {
+			return Table{}, fmt.Errorf("required column %s not found", col)
+		}
+	}
+
+	// 0: 컬럼명, 1: 태그, 2: 타입, 3: 값
+	standard := make([][]string, 4)
+	keyRows := make(map[string]int)
+
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		key := ParseColumnName(cellAt(row, colIndexes["Key"]))
+		if key == "" {
+			continue
+		}
+		if prev, ok := keyRows[key]; ok {
+			return Table{}, fmt.Errorf("row %d: duplicate key %s (first defined in row %d)", i+1, key, prev)
+		}
+		keyRows[key] = i + 1
+
+		standard[0] = append(standard[0], key)
+		standard[1] = append(standard[1], cellAt(row, colIndexes["Tag"]))
+		standard[2] = append(standard[2], cellAt(row, colIndexes["Type"]))
+		standard[3] = append(standard[3], cellAt(row, colIndexes["Value"]))
+	}
+
+	table, err := parseSheet(sheetName, standard, func(int) string { return "values" }, headerRules{})
+	if err != nil {
+		return table, err
+	}
+
+	table.Name = settingsTableName(sheetName)
+	table.IsSettings = true
+	table.Source = &TableSource{Sheet: sheetName}
+
+	// 값이 모두 비어있어도 설정 테이블은 항상 한 행을 가짐
+	if len(table.Rows) == 0 && len(table.Columns) > 0 {
+		table.Rows = [][]interface{}{make([]interface{}, len(table.Columns))}
+	}
+	return table, nil
+}
@@ -0,0 +1,437 @@
+// exporter/shard.go
+package exporter
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 샤딩 방식 (#Config의 shard 설정)
+//
+//	hash:<샤드 수>        행 키의 FNV-1a 해시로 샤드를 정함 (키 조회만 할 때)
+//	range:<샤드당 행 수>  키 순서로 정렬하여 연속된 범위로 나눔 (범위 조회, 순회)
+const (
+	ShardHash  = "hash"
+	ShardRange = "range"
+)
+
+// ShardManifestExt는 샤딩된 테이블의 샤드 목록 파일 확장자입니다. (<Table>.shards.json)
+const ShardManifestExt = ".shards.json"
+
+// ShardSpec은 파싱한 샤딩 설정입니다.
+type ShardSpec struct {
+	Strategy string // ShardHash 또는 ShardRange
+	Count    int    // hash: 샤드 수
+	Size     int    // range: 샤드당 최대 행 수
+}
+
+// ParseShardSpec은 "hash:8", "range:5000" 형식의 샤딩 설정을 파싱합니다.
+func ParseShardSpec(s string) (ShardSpec, error) {
+	strategy, value, _ := strings.Cut(strings.TrimSpace(s), ":")
+	strategy = strings.ToLower(strings.TrimSpace(strategy))
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n <= 0 {
+		return ShardSpec{}, fmt.Errorf("invalid shard %q (expected hash:<shards> or range:<rows per shard>)", s)
+	}
+
+	switch strategy {
+	case ShardHash:
+		return ShardSpec{Strategy: ShardHash, Count: n}, nil
+	case ShardRange:
+		return ShardSpec{Strategy: ShardRange, Size: n}, nil
+	}
+	return ShardSpec{}, fmt.Errorf("unknown shard strategy %q (expected hash or range)", strategy)
+}
+
+// ShardManifest는 샤딩된 테이블의 샤드 목록입니다. 로더는 이 파일만 먼저 읽고, 키가 속한 샤드는 필요할 때 엽니다.
+type ShardManifest struct {
+	Table    string      `json:"table"`
+	Strategy string      `json:"strategy"`
+	Numeric  bool        `json:"numeric,omitempty"` // range: 키를 숫자로 비교
+	Rows     int         `json:"rows"`
+	Shards   []ShardInfo `json:"shards"`
+}
+
+// ShardInfo는 샤드 파일 하나입니다. 샤드는 행 키를 키로 하는 객체(keyed JSON과 같은 형식)입니다.
+type ShardInfo struct {
+	File  string `json:"file"` // 매니페스트 기준 상대 경로
+	Rows  int    `json:"rows"`
+	First string `json:"first,omitempty"` // range: 첫 키
+	Last  string `json:"last,omitempty"`  // range: 마지막 키
+}
+
+// ShardIndex는 hash 샤딩에서 키가 속한 샤드 번호입니다. 생성된 로더도 같은 해시를 사용합니다.
+func ShardIndex(key string, count int) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(count))
+}
+
+// shardRows는 행들을 샤드별로 나눕니다. range 샤딩은 키 순서로 정렬하며, 모든 키가 숫자면 숫자로 비교합니다.
+func shardRows(table Table, spec ShardSpec) ([][][]interface{}, bool) {
+	if spec.Strategy == ShardHash {
+		shards := make([][][]interface{}, spec.Count)
+		for _, row := range table.Rows {
+			i := ShardIndex(RowKey(table, row), spec.Count)
+			shards[i] = append(shards[i], row)
+		}
+		return shards, false
+	}
+
+	numeric := true
+	for _, row := range table.Rows {
+		if _, err := strconv.ParseFloat(RowKey(table, row), 64); err != nil {
+			numeric = false
+			break
+		}
+	}
+	rows := append([][]interface{}(nil), table.Rows...)
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := RowKey(table, rows[i]), RowKey(table, rows[j])
+		if numeric {
+			x, _ := strconv.ParseFloat(a, 64)
+			y, _ := strconv.ParseFloat(b, 64)
+			return x < y
+		}
+		return a < b
+	})
+
+	var shards [][][]interface{}
+	for start := 0; start < len(rows); start += spec.Size {
+		end := start + spec.Size
+		if end > len(rows) {
+			end = len(rows)
+		}
+		shards = append(shards, rows[start:end])
+	}
+	return shards, numeric
+}
+
+// writeShardedTable은 테이블을 dir/<Table>/<Table>.<n>.json 샤드들과 dir/<Table>.shards.json 매니페스트로 씁니다.
+func writeShardedTable(dir string, table Table, spec ShardSpec) error {
+	shardDir := filepath.Join(dir, table.Name)
+	if err := os.MkdirAll(shardDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	shards, numeric := shardRows(table, spec)
+	manifest := ShardManifest{Table: table.Name, Strategy: spec.Strategy, Numeric: numeric, Rows: len(table.Rows)}
+	for i, rows := range shards {
+		shard := table
+		shard.Rows = rows
+		data, err := keyedRows(shard)
+		if err != nil {
+			return fmt.Errorf("table %s: %v", table.Name, err)
+		}
+
+		name := fmt.Sprintf("%s.%d.json", table.Name, i)
+		if err := writeJSONFile(filepath.Join(shardDir, name), data); err != nil {
+			return err
+		}
+		info := ShardInfo{File: table.Name + "/" + name, Rows: len(rows)}
+		if spec.Strategy == ShardRange {
+			info.First, info.Last = RowKey(table, rows[0]), RowKey(table, rows[len(rows)-1])
+		}
+		manifest.Shards = append(manifest.Shards, info)
+	}
+	return writeJSONFile(filepath.Join(dir, table.Name+ShardManifestExt), manifest)
+}
+
+// validateShard는 샤딩 설정이 올바른지 확인합니다. 샤드는 키로 조회하므로 모든 행에 유일한 키가 있어야 합니다.
+func validateShard(table Table) error {
+	if table.Shard == "" {
+		return nil
+	}
+	if _, err := ParseShardSpec(table.Shard); err != nil {
+		return fmt.Errorf("table %s: %v", table.Name, err)
+	}
+	if _, err := keyedRows(table); err != nil {
+		return fmt.Errorf("table %s is sharded but %v", table.Name, err)
+	}
+	return nil
+}
+
+// generateShardLoaders는 샤딩된 테이블을 키로 조회하며 필요한 샤드만 여는 Go/TypeScript 로더를 생성합니다.
+// 열어둔 샤드 수를 제한하고 오래 쓰지 않은 샤드부터 닫으므로, 테이블 크기와 관계없이 메모리 사용량이 일정합니다.
+func generateShardLoaders(opts Options) error {
+	const goLoader = `// Code generated by excelite. DO NOT EDIT.
+package %s
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// ShardManifest lists the shard files of a sharded table (<Table>%s).
+type ShardManifest struct {
+	Table    string ` + "`json:\"table\"`" + `
+	Strategy string ` + "`json:\"strategy\"`" + `
+	Numeric  bool   ` + "`json:\"numeric\"`" + `
+	Rows     int    ` + "`json:\"rows\"`" + `
+	Shards   []struct {
+		File  string ` + "`json:\"file\"`" + `
+		Rows  int    ` + "`json:\"rows\"`" + `
+		First string ` + "`json:\"first\"`" + `
+		Last  string ` + "`json:\"last\"`" + `
+	} ` + "`json:\"shards\"`" + `
+}
+
+// ShardedTable looks rows up by key and opens only the shard that holds the key.
+// At most MaxOpenShards shards are kept in memory; the least recently used one is dropped first.
+type ShardedTable[T any] struct {
+	Manifest      ShardManifest
+	MaxOpenShards int
+
+	// Open opens a shard file. It defaults to os.Open; replace it to read compressed or encrypted shards.
+	Open func(path string) (io.ReadCloser, error)
+
+	dir   string
+	mu    sync.Mutex
+	cache map[int]map[string]T
+	order []int
+}
+
+// OpenShardedTable reads the shard manifest of table in dir.
+func OpenShardedTable[T any](dir, table string, maxOpenShards int) (*ShardedTable[T], error) {
+	data, err := os.ReadFile(filepath.Join(dir, table+%q))
+	if err != nil {
+		return nil, err
+	}
+	t := &ShardedTable[T]{
+		MaxOpenShards: maxOpenShards,
+		Open:          func(path string) (io.ReadCloser, error) { return os.Open(path) },
+		dir:           dir,
+		cache:         make(map[int]map[string]T),
+	}
+	if err := json.Unmarshal(data, &t.Manifest); err != nil {
+		return nil, fmt.Errorf("invalid shard manifest of %%s: %%w", table, err)
+	}
+	if t.MaxOpenShards <= 0 {
+		t.MaxOpenShards = 1
+	}
+	return t, nil
+}
+
+// Get returns the row with the given key (the index column value formatted as text).
+func (t *ShardedTable[T]) Get(key string) (T, bool, error) {
+	var zero T
+	i := t.shardOf(key)
+	if i < 0 {
+		return zero, false, nil
+	}
+	rows, err := t.shard(i)
+	if err != nil {
+		return zero, false, err
+	}
+	row, ok := rows[key]
+	return row, ok, nil
+}
+
+// Each calls fn for every row, opening one shard at a time. It stops when fn returns false.
+func (t *ShardedTable[T]) Each(fn func(key string, row T) bool) error {
+	for i := range t.Manifest.Shards {
+		rows, err := t.shard(i)
+		if err != nil {
+			return err
+		}
+		for key, row := range rows {
+			if !fn(key, row) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func (t *ShardedTable[T]) shardOf(key string) int {
+	shards := t.Manifest.Shards
+	if len(shards) == 0 {
+		return -1
+	}
+	if t.Manifest.Strategy == %q {
+		h := fnv.New32a()
+		h.Write([]byte(key))
+		return int(h.Sum32() %% uint32(len(shards)))
+	}
+	// range: first shard whose last key is not less than key
+	i := sort.Search(len(shards), func(i int) bool { return !t.less(shards[i].Last, key) })
+	if i == len(shards) || t.less(key, shards[i].First) {
+		return -1
+	}
+	return i
+}
+
+func (t *ShardedTable[T]) less(a, b string) bool {
+	if t.Manifest.Numeric {
+		x, _ := strconv.ParseFloat(a, 64)
+		y, _ := strconv.ParseFloat(b, 64)
+		return x < y
+	}
+	return a < b
+}
+
+func (t *ShardedTable[T]) shard(i int) (map[string]T, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if rows, ok := t.cache[i]; ok {
+		t.touch(i)
+		return rows, nil
+	}
+
+	f, err := t.Open(filepath.Join(t.dir, filepath.FromSlash(t.Manifest.Shards[i].File)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var rows map[string]T
+	if err := json.NewDecoder(f).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("invalid shard %%s: %%w", t.Manifest.Shards[i].File, err)
+	}
+
+	for len(t.order) >= t.MaxOpenShards {
+		delete(t.cache, t.order[0])
+		t.order = t.order[1:]
+	}
+	t.cache[i] = rows
+	t.order = append(t.order, i)
+	return rows, nil
+}
+
+// touch marks shard i as the most recently used one.
+func (t *ShardedTable[T]) touch(i int) {
+	for j, k := range t.order {
+		if k == i {
+			t.order = append(append(t.order[:j:j], t.order[j+1:]...), i)
+			return
+		}
+	}
+}
+`
+
+	const tsLoader = `// Code generated by excelite. DO NOT EDIT.
+import { readFile } from "fs/promises";
+import { join } from "path";
+
+export interface ShardManifest {
+    table: string;
+    strategy: string;
+    numeric?: boolean;
+    rows: number;
+    shards: { file: string; rows: number; first?: string; last?: string }[];
+}
+
+// fnv32a is the FNV-1a hash of the UTF-8 bytes of key, as used by the generator.
+function fnv32a(key: string): number {
+    let h = 0x811c9dc5;
+    for (const b of Buffer.from(key, "utf8")) {
+        h ^= b;
+        h = Math.imul(h, 0x01000193) >>> 0;
+    }
+    return h >>> 0;
+}
+
+// ShardedTable looks rows up by key and loads only the shard that holds the key.
+// At most maxOpenShards shards are kept in memory; the least recently used one is dropped first.
+export class ShardedTable<T> {
+    private cache = new Map<number, Promise<Record<string, T>>>();
+
+    private constructor(
+        private dir: string,
+        readonly manifest: ShardManifest,
+        private maxOpenShards: number,
+        // read returns the contents of a shard file; replace it to read compressed or encrypted shards
+        private read: (path: string) => Promise<string>,
+    ) {}
+
+    // open reads the shard manifest of table in dir (<Table>%s).
+    static async open<T>(dir: string, table: string, maxOpenShards = 1,
+        read: (path: string) => Promise<string> = (path) => readFile(path, "utf8")): Promise<ShardedTable<T>> {
+        const manifest: ShardManifest = JSON.parse(await read(join(dir, table + %q)));
+        return new ShardedTable<T>(dir, manifest, Math.max(1, maxOpenShards), read);
+    }
+
+    // get returns the row with the given key (the index column value formatted as text).
+    async get(key: string): Promise<T | undefined> {
+        const i = this.shardOf(key);
+        if (i < 0) {
+            return undefined;
+        }
+        return (await this.shard(i))[key];
+    }
+
+    // each calls fn for every row, loading one shard at a time. It stops when fn returns false.
+    async each(fn: (key: string, row: T) => boolean | void): Promise<void> {
+        for (let i = 0; i < this.manifest.shards.length; i++) {
+            const rows = await this.shard(i);
+            for (const key of Object.keys(rows)) {
+                if (fn(key, rows[key]) === false) {
+                    return;
+                }
+            }
+        }
+    }
+
+    private shardOf(key: string): number {
+        const shards = this.manifest.shards;
+        if (shards.length === 0) {
+            return -1;
+        }
+        if (this.manifest.strategy === %q) {
+            return fnv32a(key) %% shards.length;
+        }
+        // range: first shard whose last key is not less than key
+        const i = shards.findIndex((s) => !this.less(s.last ?? "", key));
+        if (i < 0 || this.less(key, shards[i].first ?? "")) {
+            return -1;
+        }
+        return i;
+    }
+
+    private less(a: string, b: string): boolean {
+        return this.manifest.numeric ? Number(a) < Number(b) : a < b;
+    }
+
+    private shard(i: number): Promise<Record<string, T>> {
+        let rows = this.cache.get(i);
+        if (rows) {
+            // re-insert to mark as most recently used
+            this.cache.delete(i);
+        } else {
+            rows = this.read(join(this.dir, this.manifest.shards[i].file)).then((text) => JSON.parse(text));
+            rows.catch(() => this.cache.delete(i));
+            while (this.cache.size >= this.maxOpenShards) {
+                this.cache.delete(this.cache.keys().next().value as number);
+            }
+        }
+        this.cache.set(i, rows);
+        return rows;
+    }
+}
+`
+
+	loaderDir := filepath.Join(opts.OutputDir, "loader")
+	if err := os.MkdirAll(loaderDir, 0755); err != nil {
+		return err
+	}
+
+	goCode := fmt.Sprintf(goLoader, opts.PackageName, ShardManifestExt, ShardManifestExt, ShardHash)
+	if err := os.WriteFile(filepath.Join(loaderDir, "shards.go"), []byte(goCode), 0644); err != nil {
+		return err
+	}
+
+	tsCode := fmt.Sprintf(tsLoader, ShardManifestExt, ShardManifestExt, ShardHash)
+	return os.WriteFile(filepath.Join(loaderDir, "shards.ts"), []byte(tsCode), 0644)
+}
@@ -0,0 +1,219 @@
+// exporter/sign.go
+package exporter
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultSignKeyEnv는 매니페스트 서명 키를 읽어올 기본 환경 변수 이름입니다.
+const DefaultSignKeyEnv = "EXCELITE_SIGN_KEY"
+
+// ManifestSignatureFileName은 manifest.json의 ed25519 서명(base64) 파일 이름입니다.
+const ManifestSignatureFileName = "manifest.json.sig"
+
+// LoadSigningKey는 환경 변수에서 ed25519 개인 키를 읽습니다.
+// 32바이트 시드 또는 64바이트 개인 키를 hex나 base64로 인코딩한 값을 지원합니다.
+func LoadSigningKey(envName string) (ed25519.PrivateKey, error) {
+	encoded := strings.TrimSpace(os.Getenv(envName))
+	if encoded == "" {
+		return nil, fmt.Errorf("signing key environment variable %s is not set", envName)
+	}
+
+	var raw []byte
+	if b, err := hex.DecodeString(encoded); err == nil {
+		raw = b
+	} else if b, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+		raw = b
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(raw), nil
+	}
+	return nil, fmt.Errorf("signing key in %s must be a 32-byte ed25519 seed or 64-byte private key encoded as hex or base64", envName)
+}
+
+// SignManifest는 dir의 manifest.json을 서명하여 manifest.json.sig로 저장합니다.
+// 서명은 파일 내용 그대로에 대한 것이므로 매니페스트를 다시 쓴 뒤에는 다시 서명해야 합니다.
+func SignManifest(dir string, key ed25519.PrivateKey) error {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return err
+	}
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(key, data))
+	return os.WriteFile(filepath.Join(dir, ManifestSignatureFileName), []byte(sig+"\n"), 0644)
+}
+
+// VerifyManifest는 dir의 매니페스트 서명과 매니페스트에 나열된 모든 산출물의 해시를 확인합니다.
+// 생성된 verify 로더와 같은 검증을 하며, 배포 전에 산출물을 확인할 때 사용합니다.
+func VerifyManifest(dir string, pub ed25519.PublicKey) (Manifest, error) {
+	var manifest Manifest
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return manifest, err
+	}
+	encoded, err := os.ReadFile(filepath.Join(dir, ManifestSignatureFileName))
+	if err != nil {
+		return manifest, err
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return manifest, fmt.Errorf("invalid manifest signature: %v", err)
+	}
+	if !ed25519.Verify(pub, data, sig) {
+		return manifest, fmt.Errorf("manifest signature does not match the public key")
+	}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, err
+	}
+	for _, entry := range manifest.Artifacts {
+		hash, err := hashFile(filepath.Join(dir, filepath.FromSlash(entry.Path)))
+		if err != nil {
+			return manifest, err
+		}
+		if hash != entry.SHA256 {
+			return manifest, fmt.Errorf("artifact %s does not match the manifest", entry.Path)
+		}
+	}
+	return manifest, nil
+}
+
+// WriteVerifyLoaders는 서명된 매니페스트로 산출물을 검증하는 Go/TypeScript 코드를 dir/loader에 생성합니다.
+// 공개 키가 코드에 포함되므로 게임 서버는 시작할 때 별도 설정 없이 데이터의 무결성과 출처를 확인할 수 있습니다.
+func WriteVerifyLoaders(dir, packageName string, pub ed25519.PublicKey) error {
+	const goLoader = `// Code generated by excelite. DO NOT EDIT.
+package %s
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PublicKeyHex is the ed25519 public key the data manifest was signed with.
+const PublicKeyHex = %q
+
+// Manifest lists every generated artifact with its SHA-256 hash.
+type Manifest struct {
+	RootHash  string ` + "`json:\"rootHash\"`" + `
+	Artifacts []struct {
+		Path   string ` + "`json:\"path\"`" + `
+		Size   int64  ` + "`json:\"size\"`" + `
+		SHA256 string ` + "`json:\"sha256\"`" + `
+	} ` + "`json:\"artifacts\"`" + `
+}
+
+// VerifyData checks the signature of %s in dir and the hash of every artifact it lists.
+// Call it at startup before loading any data from dir.
+func VerifyData(dir string) (*Manifest, error) {
+	pub, err := hex.DecodeString(PublicKeyHex)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, %q))
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := os.ReadFile(filepath.Join(dir, %q))
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest signature: %%v", err)
+	}
+	if !ed25519.Verify(ed25519.PublicKey(pub), data, sig) {
+		return nil, fmt.Errorf("manifest signature does not match the data signing key")
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+	for _, a := range manifest.Artifacts {
+		f, err := os.Open(filepath.Join(dir, filepath.FromSlash(a.Path)))
+		if err != nil {
+			return nil, err
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		if hex.EncodeToString(h.Sum(nil)) != a.SHA256 {
+			return nil, fmt.Errorf("artifact %%s does not match the signed manifest", a.Path)
+		}
+	}
+	return &manifest, nil
+}
+`
+
+	const tsLoader = `// Code generated by excelite. DO NOT EDIT.
+import { createHash, createPublicKey, verify } from "crypto";
+import { readFileSync } from "fs";
+import { join } from "path";
+
+export const PUBLIC_KEY_HEX = %q;
+
+export interface Manifest {
+    rootHash: string;
+    artifacts: { path: string; size: number; sha256: string }[];
+}
+
+// verifyData checks the signature of %s in dir and the hash of every artifact it lists.
+// Call it at startup before loading any data from dir.
+export function verifyData(dir: string): Manifest {
+    const data = readFileSync(join(dir, %q));
+    const sig = Buffer.from(readFileSync(join(dir, %q), "utf8").trim(), "base64");
+    // wrap the raw ed25519 public key in an SPKI DER header
+    const key = createPublicKey({
+        key: Buffer.concat([Buffer.from("302a300506032b6570032100", "hex"), Buffer.from(PUBLIC_KEY_HEX, "hex")]),
+        format: "der",
+        type: "spki",
+    });
+    if (!verify(null, data, key, sig)) {
+        throw new Error("manifest signature does not match the data signing key");
+    }
+
+    const manifest: Manifest = JSON.parse(data.toString("utf8"));
+    for (const a of manifest.artifacts) {
+        const hash = createHash("sha256").update(readFileSync(join(dir, a.path))).digest("hex");
+        if (hash !== a.sha256) {
+            throw new Error(` + "`artifact ${a.path} does not match the signed manifest`" + `);
+        }
+    }
+    return manifest;
+}
+`
+
+	loaderDir := filepath.Join(dir, "loader")
+	if err := os.MkdirAll(loaderDir, 0755); err != nil {
+		return err
+	}
+
+	pubHex := hex.EncodeToString(pub)
+	goCode := fmt.Sprintf(goLoader, packageName, pubHex, ManifestFileName, ManifestFileName, ManifestSignatureFileName)
+	if err := os.WriteFile(filepath.Join(loaderDir, "verify.go"), []byte(goCode), 0644); err != nil {
+		return err
+	}
+
+	tsCode := fmt.Sprintf(tsLoader, pubHex, ManifestFileName, ManifestFileName, ManifestSignatureFileName)
+	return os.WriteFile(filepath.Join(loaderDir, "verify.ts"), []byte(tsCode), 0644)
+}
@@ -0,0 +1,182 @@
+// exporter/snapshot.go
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// SchemaSnapshot은 릴리스된 데이터 계약(테이블, 컬럼 타입, 제약)의 스냅샷입니다.
+// 이전 스냅샷과 비교하여 하위 호환성을 깨는 변경을 찾는 데 사용합니다.
+type SchemaSnapshot struct {
+	Tables map[string]TableSnapshot `json:"tables"`
+}
+
+// TableSnapshot은 테이블 하나의 컬럼 스냅샷입니다.
+type TableSnapshot struct {
+	Columns map[string]ColumnSnapshot `json:"columns"`
+}
+
+// ColumnSnapshot은 컬럼 하나의 타입과 제약입니다.
+type ColumnSnapshot struct {
+	Type   string `json:"type"`
+	Unique bool   `json:"unique,omitempty"`
+	Index  bool   `json:"index,omitempty"`
+}
+
+// BreakingChange는 스냅샷 대비 하위 호환성을 깨는 변경 하나를 나타냅니다.
+type BreakingChange struct {
+	Table   string
+	Column  string
+	Message string
+}
+
+func (c BreakingChange) String() string {
+	if c.Column == "" {
+		return fmt.Sprintf("%s: %s", c.Table, c.Message)
+	}
+	return fmt.Sprintf("%s.%s: %s", c.Table, c.Column, c.Message)
+}
+
+// TakeSnapshot은 테이블 정의로부터 스키마 스냅샷을 생성합니다.
+func TakeSnapshot(tables []Table) SchemaSnapshot {
+	snapshot := SchemaSnapshot{Tables: make(map[string]TableSnapshot, len(tables))}
+	for _, table := range tables {
+		columns := make(map[string]ColumnSnapshot, len(table.Columns))
+		for _, col := range table.Columns {
+			columns[col.Name] = ColumnSnapshot{
+				Type:   ColumnTypeName(col.Type),
+				Unique: col.IsUnique,
+				Index:  HasTag(col.Tags, TagIndex),
+			}
+		}
+		snapshot.Tables[table.Name] = TableSnapshot{Columns: columns}
+	}
+	return snapshot
+}
+
+// LoadSnapshot은 JSON 스냅샷 파일을 읽습니다.
+func LoadSnapshot(path string) (SchemaSnapshot, error) {
+	var snapshot SchemaSnapshot
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snapshot, err
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("invalid schema snapshot %s: %v", path, err)
+	}
+	return snapshot, nil
+}
+
+// WriteSnapshot은 스냅샷을 JSON 파일로 저장합니다.
+func WriteSnapshot(path string, snapshot SchemaSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// CompareSnapshots는 baseline 대비 current에서 하위 호환성을 깨는 변경을 찾습니다.
+// 테이블/컬럼 삭제, 컬럼 타입 변경, unique/index 제약 삭제가 해당됩니다.
+// 테이블/컬럼/제약의 추가는 호환되는 변경으로 간주합니다.
+func CompareSnapshots(baseline, current SchemaSnapshot) []BreakingChange {
+	var changes []BreakingChange
+
+	for _, tableName := range sortedMapKeys(baseline.Tables) {
+		oldTable := baseline.Tables[tableName]
+		newTable, ok := current.Tables[tableName]
+		if !ok {
+			changes = append(changes, BreakingChange{Table: tableName, Message: "table was removed"})
+			continue
+		}
+
+		for _, colName := range sortedMapKeys(oldTable.Columns) {
+			oldCol := oldTable.Columns[colName]
+			newCol, ok := newTable.Columns[colName]
+			if !ok {
+				changes = append(changes, BreakingChange{Table: tableName, Column: colName, Message: "column was removed"})
+				continue
+			}
+			if oldCol.Type != newCol.Type {
+				changes = append(changes, BreakingChange{Table: tableName, Column: colName,
+					Message: fmt.Sprintf("type changed from %s to %s", oldCol.Type, newCol.Type)})
+			}
+			if oldCol.Unique && !newCol.Unique {
+				changes = append(changes, BreakingChange{Table: tableName, Column: colName, Message: "unique constraint was dropped"})
+			}
+			if oldCol.Index && !newCol.Index {
+				changes = append(changes, BreakingChange{Table: tableName, Column: colName, Message: "index was dropped"})
+			}
+		}
+	}
+
+	return changes
+}
+
+// ColumnTypeName은 컬럼 타입을 시트의 타입 표기(int, array<string> 등)로 반환합니다.
+func ColumnTypeName(ct ColumnType) string {
+	if ct.IsArray && ct.BaseType != nil {
+		if ct.MaxLen > 0 {
+			return "array<" + ColumnTypeName(*ct.BaseType) + "," + strconv.Itoa(ct.MaxLen) + ">"
+		}
+		return "array<" + ColumnTypeName(*ct.BaseType) + ">"
+	}
+	if ct.IsGeo() {
+		return "geo"
+	}
+	if ct.IsCurve() {
+		if ct.Interp == CurveBezier {
+			return "curve<bezier>"
+		}
+		return "curve"
+	}
+	if ct.IsFormula() {
+		if ct.FormulaVarList != "" {
+			return "formula<" + ct.FormulaVarList + ">"
+		}
+		return "formula"
+	}
+	if ct.RefTable != "" {
+		key := ct
+		key.RefTable = ""
+		if name := ColumnTypeName(key); name != "int" {
+			return "ref<" + ct.RefTable + ":" + name + ">"
+		}
+		return "ref<" + ct.RefTable + ">"
+	}
+
+	switch ct.Type.Kind() {
+	case reflect.Int32:
+		return "int"
+	case reflect.Int64:
+		return "int64"
+	case reflect.Float64:
+		return "float"
+	case reflect.Bool:
+		return "bool"
+	case reflect.String:
+		return "string"
+	}
+
+	switch ct.Type {
+	case DateTimeType.Type:
+		return "datetime"
+	case BytesType.Type:
+		return "blob"
+	}
+	return ct.Type.String()
+}
+
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,218 @@
+// exporter/sourcemap.go
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SourceMapFileName은 생성 결과의 행/필드를 워크북 셀로 연결하는 소스맵 파일 이름입니다.
+const SourceMapFileName = "sourcemap.json"
+
+// TableSource는 테이블을 읽은 워크북 시트와, 행 키와 컬럼으로 원본 셀을 찾기 위한 위치 정보입니다.
+// 설정 시트와 매트릭스 시트는 시트까지만 기록합니다.
+type TableSource struct {
+	Workbook string
+	Sheet    string
+	Warnings []CellWarning // 파싱하면서 무시한 셀 (헤더 범위 밖의 값 등)
+
+	records map[string]int   // 행 키 → 표준 배치로 변환된 행 인덱스
+	rows    []int            // 파싱된 행 순서 → 표준 배치로 변환된 행 인덱스 (중복 키의 행도 포함)
+	columns map[string][]int // 컬럼 이름(소문자) → 표준 배치로 변환된 열 인덱스
+	pos     sheetPosition
+}
+
+// newTableSource는 파싱된 행들의 키와 헤더의 열 인덱스로 TableSource를 만듭니다.
+// 키가 중복된 행은 처음 나온 행을 기록합니다. (중복 키는 검증 단계에서 에러가 됨)
+func newTableSource(table Table, sources [][]int, records []int) *TableSource {
+	src := &TableSource{
+		Sheet:   table.SheetName,
+		records: make(map[string]int, len(table.Rows)),
+		columns: make(map[string][]int, len(table.Columns)),
+		rows:    records,
+	}
+	for i, col := range table.Columns {
+		if i < len(sources) {
+			src.columns[strings.ToLower(col.Name)] = sources[i]
+		}
+	}
+	for i, row := range table.Rows {
+		key := RowKey(table, row)
+		if _, ok := src.records[key]; key != "" && !ok {
+			src.records[key] = records[i]
+		}
+	}
+	return src
+}
+
+// Location은 행 키의 시트 위치("row 5", 전치된 시트는 "column E")를 반환합니다.
+func (s *TableSource) Location(key string) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	r, ok := s.records[key]
+	if !ok {
+		return "", false
+	}
+	return s.pos.record(r), true
+}
+
+// Cells는 행 키와 컬럼에 해당하는 셀 이름(C5 등)을 반환합니다. 반복된 배열 헤더의 컬럼은 원소마다 하나씩입니다.
+// 시트에 없는 컬럼(transform으로 계산한 컬럼, 시트에 없는 optional 컬럼)은 nil입니다.
+func (s *TableSource) Cells(key, column string) []string {
+	if s == nil {
+		return nil
+	}
+	r, ok := s.records[key]
+	if !ok {
+		return nil
+	}
+	var cells []string
+	for _, c := range s.columns[strings.ToLower(column)] {
+		cells = append(cells, s.pos.cell(r, c))
+	}
+	return cells
+}
+
+// RowLocation은 파싱된 순서로 i번째 행의 시트 위치입니다. 행 필터나 변환으로 행이 바뀌기 전의 테이블에만 쓸 수 있습니다.
+func (s *TableSource) RowLocation(i int) string {
+	if s == nil || i < 0 || i >= len(s.rows) {
+		return ""
+	}
+	return s.pos.record(s.rows[i])
+}
+
+// RowCells는 파싱된 순서로 i번째 행의 컬럼 셀 이름들을 반환합니다. 키가 중복된 행도 찾을 수 있습니다.
+// 행 필터나 변환으로 행이 바뀌기 전의 테이블에만 쓸 수 있습니다.
+func (s *TableSource) RowCells(i int, column string) []string {
+	if s == nil || i < 0 || i >= len(s.rows) {
+		return nil
+	}
+	var cells []string
+	for _, c := range s.columns[strings.ToLower(column)] {
+		cells = append(cells, s.pos.cell(s.rows[i], c))
+	}
+	return cells
+}
+
+// renameColumn은 컬럼 이름이 바뀐 뒤에도 원본 셀을 찾을 수 있도록 복사본을 반환합니다.
+func (s *TableSource) renameColumn(oldName, newName string) *TableSource {
+	if s == nil {
+		return nil
+	}
+	renamed := *s
+	renamed.columns = make(map[string][]int, len(s.columns))
+	for name, cols := range s.columns {
+		if name == strings.ToLower(oldName) {
+			name = strings.ToLower(newName)
+		}
+		renamed.columns[name] = cols
+	}
+	return &renamed
+}
+
+// SourceMap은 생성 결과의 행을 원본 워크북 셀로 연결합니다.
+// 런타임에 발견한 데이터 문제를 수정할 셀로 바로 찾아갈 수 있도록 출력 디렉토리에 함께 생성됩니다. (excelite where)
+type SourceMap struct {
+	Tables []TableSourceMap `json:"tables"`
+}
+
+// TableSourceMap은 테이블 하나의 원본 시트와 행별 위치입니다.
+type TableSourceMap struct {
+	Table    string         `json:"table"`
+	Workbook string         `json:"workbook,omitempty"`
+	Sheet    string         `json:"sheet"`
+	Rows     []RowSourceMap `json:"rows,omitempty"`
+}
+
+// RowSourceMap은 생성 결과의 행 하나와 원본 셀들입니다.
+type RowSourceMap struct {
+	Key      string              `json:"key"`
+	RowID    int                 `json:"rowid"`    // SQLite 테이블의 id 컬럼 값 (행 순서대로 1부터, WITHOUT ROWID 테이블은 key로 찾음)
+	JSONPath string              `json:"jsonPath"` // <Table>.json 안의 위치
+	Location string              `json:"location"` // 시트의 행(전치된 시트는 열)
+	Cells    map[string][]string `json:"cells"`    // 컬럼 → 셀 (시트에 없는 컬럼은 빠짐)
+}
+
+// BuildSourceMap은 테이블들의 소스맵을 생성합니다. keyed가 참이면 JSON 경로는 인덱스 값을 키로 하는 형식(--json-keyed)입니다.
+func BuildSourceMap(tables []Table, keyed bool) SourceMap {
+	sm := SourceMap{Tables: make([]TableSourceMap, 0, len(tables))}
+	for _, table := range tables {
+		tm := TableSourceMap{Table: table.Name, Sheet: table.SheetName}
+		if table.Source != nil {
+			tm.Workbook = table.Source.Workbook
+		}
+
+		for i, row := range table.Rows {
+			key := RowKey(table, row)
+			location, ok := table.Source.Location(key)
+			if !ok {
+				continue
+			}
+
+			rm := RowSourceMap{
+				Key:      key,
+				RowID:    i + 1,
+				JSONPath: fmt.Sprintf("$[%d]", i),
+				Location: location,
+				Cells:    make(map[string][]string),
+			}
+			if keyed {
+				rm.JSONPath = "$[" + strconv.Quote(key) + "]"
+			}
+			for _, col := range table.Columns {
+				if cells := table.Source.Cells(key, col.Name); len(cells) > 0 {
+					rm.Cells[col.Name] = cells
+				}
+			}
+			tm.Rows = append(tm.Rows, rm)
+		}
+		sm.Tables = append(sm.Tables, tm)
+	}
+	return sm
+}
+
+// WriteSourceMap은 dir에 소스맵 파일을 씁니다.
+func WriteSourceMap(dir string, tables []Table, keyed bool) error {
+	data, err := json.MarshalIndent(BuildSourceMap(tables, keyed), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, SourceMapFileName), append(data, '\n'), 0644)
+}
+
+// ReadSourceMap은 출력 디렉토리의 소스맵 파일을 읽습니다.
+func ReadSourceMap(dir string) (SourceMap, error) {
+	var sm SourceMap
+	data, err := os.ReadFile(filepath.Join(dir, SourceMapFileName))
+	if err != nil {
+		return sm, fmt.Errorf("failed to read source map (generate with --sourcemap): %v", err)
+	}
+	if err := json.Unmarshal(data, &sm); err != nil {
+		return sm, fmt.Errorf("failed to parse %s: %v", SourceMapFileName, err)
+	}
+	return sm, nil
+}
+
+// Lookup은 테이블과 행 키로 행을 찾습니다. 테이블 이름은 대소문자를 구분하지 않으며 시트 이름으로도 찾습니다.
+func (sm SourceMap) Lookup(table, key string) (TableSourceMap, RowSourceMap, error) {
+	for _, tm := range sm.Tables {
+		if !strings.EqualFold(tm.Table, table) && !strings.EqualFold(tm.Sheet, table) {
+			continue
+		}
+		for _, rm := range tm.Rows {
+			if rm.Key == key {
+				return tm, rm, nil
+			}
+		}
+		return tm, RowSourceMap{}, fmt.Errorf("table %s has no row with key %s", tm.Table, key)
+	}
+	return TableSourceMap{}, RowSourceMap{}, fmt.Errorf("table %s not found in %s", table, SourceMapFileName)
+}
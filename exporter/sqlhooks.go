@@ -0,0 +1,73 @@
+// exporter/sqlhooks.go
+package exporter
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// sqlSnippet은 SQL 파일에서 읽은 사용자 SQL입니다. 여러 문장을 담을 수 있습니다.
+type sqlSnippet struct {
+	File string
+	SQL  string
+}
+
+func loadSQLSnippet(path string) (sqlSnippet, error) {
+	if path == "" {
+		return sqlSnippet{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return sqlSnippet{}, fmt.Errorf("failed to read SQL file: %v", err)
+	}
+	return sqlSnippet{File: path, SQL: strings.TrimSpace(string(data))}, nil
+}
+
+// exec은 스니펫을 DB에 실행합니다. 실패하면 어느 파일인지 알립니다.
+func (s sqlSnippet) exec(db *sql.DB) error {
+	if s.SQL == "" {
+		return nil
+	}
+	if _, err := db.Exec(s.SQL); err != nil {
+		return fmt.Errorf("%s: %v", s.File, err)
+	}
+	return nil
+}
+
+// write는 스니펫을 출처 주석과 함께 스키마 파일에 씁니다.
+func (s sqlSnippet) write(schema *strings.Builder, stage string) {
+	if s.SQL == "" {
+		return
+	}
+	fmt.Fprintf(schema, "-- %s: %s\n%s", stage, s.File, s.SQL)
+	if !strings.HasSuffix(s.SQL, ";") {
+		schema.WriteString("\n;")
+	}
+	schema.WriteString("\n\n")
+}
+
+// sqliteSQLHooks는 생성되는 DB와 schema.sql에 끼워 넣는 사용자 SQL입니다. (pragma, 추가 뷰, 트리거, 인덱스 등)
+// 모든 그룹 DB에 같은 SQL이 실행되며, 증분 생성에서도 매번 실행되므로 IF NOT EXISTS 형태로 작성해야 합니다.
+type sqliteSQLHooks struct {
+	BeforeSchema sqlSnippet // 테이블 생성 전
+	AfterSchema  sqlSnippet // 테이블 생성 후, 데이터 적재 전 (트리거는 적재되는 행에도 적용됩니다)
+	AfterData    sqlSnippet // 데이터 적재와 #View 뷰 생성 후
+}
+
+// loadSQLiteSQLHooks는 exporter 옵션에 지정된 SQL 파일들을 읽습니다.
+func loadSQLiteSQLHooks(beforeSchema, afterSchema, afterData string) (sqliteSQLHooks, error) {
+	var hooks sqliteSQLHooks
+	var err error
+	if hooks.BeforeSchema, err = loadSQLSnippet(beforeSchema); err != nil {
+		return hooks, err
+	}
+	if hooks.AfterSchema, err = loadSQLSnippet(afterSchema); err != nil {
+		return hooks, err
+	}
+	if hooks.AfterData, err = loadSQLSnippet(afterData); err != nil {
+		return hooks, err
+	}
+	return hooks, nil
+}
@@ -284,18 +284,17 @@ var sqliteKeywords = map[string]bool{
 	"without": true,
 }
 
-// QuoteIdentifier는 SQLite 식별자를 안전하게 만듭니다.
+// QuoteIdentifier는 SQLite 식별자를 필요할 때만 큰따옴표로 감쌉니다.
+// 예약어, 빈 이름, 숫자로 시작하거나 영문자·숫자·_ 외의 문자(공백, 따옴표, 한글 등)가 있는 이름을 감쌉니다.
 func QuoteIdentifier(name string) string {
 	// SQLite는 대소문자 구분이 없으므로 소문자로 변환하여 검사
-	lowered := strings.ToLower(name)
-	if sqliteKeywords[lowered] {
-		return fmt.Sprintf(`"%s"`, name)
-	}
-
-	// 특수문자나 공백이 있는 경우도 따옴표로 감싸기
-	if strings.ContainsAny(name, " -+()[]{}.,;") {
-		return fmt.Sprintf(`"%s"`, name)
+	if indexNamePattern.MatchString(name) && !sqliteKeywords[strings.ToLower(name)] {
+		return name
 	}
+	return QuoteIdentifierAlways(name)
+}
 
-	return name
+// QuoteIdentifierAlways는 SQLite 식별자를 항상 큰따옴표로 감쌉니다. 이름 안의 큰따옴표는 두 번 씁니다.
+func QuoteIdentifierAlways(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
 }
@@ -9,7 +9,6 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -17,51 +16,332 @@ import (
 // SQLiteExporter implements database and schema generation for SQLite
 type SQLiteExporter struct {
 	BaseExporter
+	dialect SQLDialect
 }
 
 func NewSQLiteExporter() Exporter {
 	return &SQLiteExporter{
 		BaseExporter: NewBaseExporter("sqlite"),
+		dialect:      SQLiteDialect,
+	}
+}
+
+func (e *SQLiteExporter) Describe() ExporterInfo {
+	return ExporterInfo{
+		Description: "SQLite database with every table's rows, plus the schema and optional typed query layers",
+		Outputs:     []string{"<package>.db", "schema.sql", "<group>.db", "<group>.schema.sql", SQLiteMasterDB, SQLiteMasterScript, "queries.go", "queries.hpp", "Queries.cs"},
+		Options: []OptionInfo{
+			{Key: OptSQLiteIncremental, Type: "bool", Default: "false", Description: "keep the existing DB and recreate only the given tables"},
+			{Key: OptSQLiteStrict, Type: "bool", Default: "false", Description: "create STRICT tables (SQLite 3.37+)"},
+			{Key: OptSQLiteQuoteAll, Type: "bool", Default: "false", Description: "quote every identifier in the generated SQL (tables, indexes, views, inserts and query layers), not only keywords and special names"},
+			{Key: OptSQLiteWithoutRowID, Type: "bool", Default: "false", Description: "create WITHOUT ROWID tables keyed by the index column"},
+			{Key: OptSQLiteQueries, Type: "string", Default: "", Description: "languages of prepared-query helpers (" + strings.Join(sqliteQueryLanguages, ", ") + ")"},
+			{Key: OptSQLitePrune, Type: "string", Default: "", Description: "directory of the Go code base using the Go query layer; only the tables and lookups it references are generated"},
+			{Key: OptSQLiteBeforeSchema, Type: "string", Default: "", Description: "SQL file run before the tables are created (pragmas); also written to schema.sql"},
+			{Key: OptSQLiteAfterSchema, Type: "string", Default: "", Description: "SQL file run after the tables are created and before rows are loaded (indexes, triggers); also written to schema.sql"},
+			{Key: OptSQLiteAfterData, Type: "string", Default: "", Description: "SQL file run after rows are loaded and views created (extra views, ANALYZE); also written to schema.sql"},
+			{Key: OptSQLiteMaster, Type: "bool", Default: "false", Description: "also write " + SQLiteMasterDB + " listing the group DBs (packs) to ATTACH at runtime and the cross-pack views of #View Scope master"},
+			{Key: OptNarrowInts, Type: "bool", Default: "false", Description: "use the smallest integer type that holds each column's data and min/max range in query helpers (uint8, int16, ...)"},
+		},
+		Types: typeMappings(func(col Column) (string, error) {
+			return buildColumnDefinition(col)[len(col.Name)+1:], nil
+		}),
 	}
 }
 
 func (e *SQLiteExporter) Export(tables []Table, opts Options) error {
-	// 1. Create database file
-	dbPath := filepath.Join(opts.OutputDir, opts.PackageName+".db")
 	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %v", err)
 	}
 
-	// 2. Connect to SQLite database
+	// 그룹별로 별도의 데이터베이스를 생성합니다. 그룹이 없는 테이블은 기본 DB에 들어갑니다.
+	groups, err := GroupTables(tables)
+	if err != nil {
+		return err
+	}
+
+	// quoteAll이면 생성하는 모든 SQL(테이블, 인덱스, 뷰, INSERT, 쿼리 레이어)의 식별자를 감쌈
+	e.dialect = NewSQLiteDialect(e.GetBoolOption(opts, OptSQLiteQuoteAll, false))
+
+	incremental := e.GetBoolOption(opts, OptSQLiteIncremental, false)
+	queryLangs, err := parseQueryLanguages(e.GetStringOption(opts, OptSQLiteQueries, ""))
+	if err != nil {
+		return err
+	}
+	// 증분 생성은 일부 테이블만 받으므로 쿼리 레이어를 다시 쓰지 않음
+	if incremental && len(queryLangs) > 0 {
+		log.Printf("query layers are not regenerated in incremental mode; run a full generate to update them")
+		queryLangs = nil
+	}
+	var refs map[string]bool
+	if containsString(queryLangs, "go") {
+		if refs, err = loadPruneReferences(e.GetStringOption(opts, OptSQLitePrune, "")); err != nil {
+			return err
+		}
+	}
+	master := e.GetBoolOption(opts, OptSQLiteMaster, false)
+	if incremental && master {
+		log.Printf("the master DB is not regenerated in incremental mode; run a full generate to update it")
+		master = false
+	}
+	tableOpts := sqliteTableOptions{Strict: e.GetBoolOption(opts, OptSQLiteStrict, false)}
+	if e.GetBoolOption(opts, OptSQLiteWithoutRowID, false) {
+		tableOpts.WithoutRowID = withoutRowIDTables(tables, e.dialect)
+	}
+	hooks, err := loadSQLiteSQLHooks(e.GetStringOption(opts, OptSQLiteBeforeSchema, ""),
+		e.GetStringOption(opts, OptSQLiteAfterSchema, ""), e.GetStringOption(opts, OptSQLiteAfterData, ""))
+	if err != nil {
+		return err
+	}
+
+	var packs []sqlitePack
+	for group, groupTables := range groups {
+		schemaName := "schema.sql"
+		if group != "" {
+			schemaName = group + ".schema.sql"
+		}
+
+		dbPath := sqliteDatabasePath(opts, group)
+		schemaPath := filepath.Join(opts.OutputDir, schemaName)
+		if err := e.exportDatabase(groupTables, dbPath, schemaPath, incremental, tableOpts, hooks); err != nil {
+			if group != "" {
+				return fmt.Errorf("group %s: %v", group, err)
+			}
+			return err
+		}
+		if err := e.generateQueryLayers(groupTables, queryLangs, opts.OutputDir, group, refs, opts); err != nil {
+			return err
+		}
+		packs = append(packs, sqlitePack{Name: sqlitePackName(group, opts.PackageName), Path: dbPath})
+	}
+
+	if master {
+		return e.exportMaster(tables, packs, opts.OutputDir)
+	}
+	if n := countMasterViews(tables); n > 0 && !incremental {
+		log.Printf("%d cross-pack views (#View Scope %s) are only created in the master DB; enable the %s option", n, ViewScopeMaster, OptSQLiteMaster)
+	}
+	return nil
+}
+
+// sqliteDatabasePath는 그룹의 DB 파일 경로입니다. 그룹이 없는 테이블의 DB는 DBName(DSN)이 있으면 그 경로를 씁니다.
+func sqliteDatabasePath(opts Options, group string) string {
+	if group != "" {
+		return filepath.Join(opts.OutputDir, group+".db")
+	}
+	if opts.DBName != "" {
+		return sqliteDSNPath(opts.OutputDir, opts.DBName)
+	}
+	return filepath.Join(opts.OutputDir, opts.PackageName+".db")
+}
+
+// Verify는 생성된 DB들의 모든 테이블을 다시 읽어 파싱된 시트 값과 비교합니다.
+// intern 컬럼은 문자열 테이블의 값으로, bool과 datetime은 저장 형식에서 되돌려 비교합니다.
+func (e *SQLiteExporter) Verify(tables []Table, opts Options) ([]CellMismatch, error) {
+	groups, err := GroupTables(tables)
+	if err != nil {
+		return nil, err
+	}
+	e.dialect = NewSQLiteDialect(e.GetBoolOption(opts, OptSQLiteQuoteAll, false))
+	withoutRowID := map[string]string{}
+	if e.GetBoolOption(opts, OptSQLiteWithoutRowID, false) {
+		withoutRowID = withoutRowIDTables(tables, e.dialect)
+	}
+
+	var mismatches []CellMismatch
+	for _, group := range sortedMapKeys(groups) {
+		dbPath := sqliteDatabasePath(opts, group)
+		output := dbPath
+		if rel, err := filepath.Rel(opts.OutputDir, dbPath); err == nil {
+			output = rel
+		}
+
+		db, err := sql.Open("sqlite3", dbPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %v", output, err)
+		}
+		for _, table := range groups[group] {
+			_, noRowID := withoutRowID[table.Name]
+			rows, err := readSQLiteRows(db, table, !noRowID, e.dialect)
+			if err != nil {
+				db.Close()
+				return nil, fmt.Errorf("%s: table %s: %v", output, table.Name, err)
+			}
+			mismatches = append(mismatches, verifyRows(output, table, rows, tableDateTimeStorage(table))...)
+		}
+		db.Close()
+	}
+	return mismatches, nil
+}
+
+// readSQLiteRows는 테이블의 모든 행을 컬럼 순서대로 읽습니다. intern 컬럼은 문자열 테이블의 값으로 바꿉니다.
+// rowid가 있는 테이블은 삽입 순서대로 읽습니다.
+func readSQLiteRows(db *sql.DB, table Table, byRowID bool, d SQLDialect) ([][]interface{}, error) {
+	exprs := make([]string, len(table.Columns))
+	for i, col := range table.Columns {
+		exprs[i] = d.QuoteIdentifier(table.Name) + "." + d.QuoteIdentifier(col.Name)
+		if isInterned(col) {
+			exprs[i] = fmt.Sprintf("(SELECT value FROM %s WHERE id = %s)", d.QuoteIdentifier(StringTableName), exprs[i])
+		}
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(exprs, ", "), d.QuoteIdentifier(table.Name))
+	if byRowID {
+		query += " ORDER BY rowid"
+	}
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result [][]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(table.Columns))
+		ptrs := make([]interface{}, len(values))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		result = append(result, values)
+	}
+	return result, rows.Err()
+}
+
+// sqliteDSNPath는 DSN("file:" 접두사와 쿼리 문자열 허용)에서 DB 파일 경로를 얻습니다.
+// 상대 경로는 출력 디렉토리 기준입니다.
+func sqliteDSNPath(outputDir, dsn string) string {
+	path := strings.TrimPrefix(dsn, "file:")
+	if idx := strings.Index(path, "?"); idx != -1 {
+		path = path[:idx]
+	}
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(outputDir, path)
+}
+
+// exportDatabase는 주어진 테이블들로 하나의 데이터베이스 파일과 스키마 파일을 생성합니다.
+// incremental이면 기존 DB 파일을 유지한 채 주어진 테이블만 다시 생성하고,
+// 스키마 파일은 DB에 남아있는 전체 스키마로부터 작성합니다.
+// hooks의 사용자 SQL은 테이블 생성 전, 테이블 생성 후, 데이터 적재 후에 실행됩니다.
+func (e *SQLiteExporter) exportDatabase(tables []Table, dbPath, schemaPath string, incremental bool, tableOpts sqliteTableOptions, hooks sqliteSQLHooks) error {
+	// 0. Start from a fresh database unless updating in place
+	if !incremental {
+		if err := os.Remove(dbPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove previous database: %v", err)
+		}
+	}
+
+	// 1. Connect to SQLite database
 	db, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %v", err)
 	}
 	defer db.Close()
 
+	// 2. Drop tables being regenerated (before foreign keys are enforced)
+	if incremental {
+		if err := e.dropTables(db, tables); err != nil {
+			return fmt.Errorf("failed to drop tables: %v", err)
+		}
+	}
+
 	// 3. Enable foreign key support
 	if _, err := db.Exec("PRAGMA foreign_keys = ON;"); err != nil {
 		return fmt.Errorf("failed to enable foreign keys: %v", err)
 	}
 
-	// 4. Create tables
-	if err := e.createTables(db, tables); err != nil {
+	// 4. Create tables, running the user SQL before and after
+	if err := hooks.BeforeSchema.exec(db); err != nil {
+		return fmt.Errorf("failed to run SQL before schema: %v", err)
+	}
+	if err := e.createTables(db, tables, tableOpts); err != nil {
 		return fmt.Errorf("failed to create tables: %v", err)
 	}
+	if err := hooks.AfterSchema.exec(db); err != nil {
+		return fmt.Errorf("failed to run SQL after schema: %v", err)
+	}
 
 	// 5. Insert data
 	if err := e.insertData(db, tables); err != nil {
 		return fmt.Errorf("failed to insert data: %v", err)
 	}
 
-	// 5. Generate schema file (optional)
-	if err := e.generateSchemaFile(tables, opts); err != nil {
+	// 6. Fill spatial indexes of geo columns
+	for _, table := range tables {
+		for _, query := range buildSpatialIndexFillQueries(table, e.dialect) {
+			if _, err := db.Exec(query); err != nil {
+				return fmt.Errorf("failed to fill spatial index of %s: %v", table.Name, err)
+			}
+		}
+	}
+
+	// 7. Create views declared in #View (after all tables exist)
+	if err := e.createViews(db, tables); err != nil {
+		return fmt.Errorf("failed to create views: %v", err)
+	}
+	if err := hooks.AfterData.exec(db); err != nil {
+		return fmt.Errorf("failed to run SQL after data load: %v", err)
+	}
+
+	// 8. Generate schema file (optional)
+	if incremental {
+		if err := e.dumpSchemaFile(db, schemaPath); err != nil {
+			return fmt.Errorf("failed to generate schema file: %v", err)
+		}
+		return nil
+	}
+	if err := e.generateSchemaFile(tables, schemaPath, tableOpts, hooks); err != nil {
 		return fmt.Errorf("failed to generate schema file: %v", err)
 	}
 
 	return nil
 }
 
+// createViews는 #View 시트에 선언된 뷰를 다시 생성합니다.
+func (e *SQLiteExporter) createViews(db *sql.DB, tables []Table) error {
+	queries, err := buildViewQueries(tables, false, e.dialect)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		for _, view := range table.Views {
+			if view.Master {
+				continue
+			}
+			if _, err := db.Exec(fmt.Sprintf("DROP VIEW IF EXISTS %s;", e.dialect.QuoteIdentifier(view.Name))); err != nil {
+				return fmt.Errorf("view %s: %v", view.Name, err)
+			}
+		}
+	}
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("%v\n%s", err, query)
+		}
+	}
+	return nil
+}
+
+// dropTables는 다시 생성할 테이블들을 기존 DB에서 제거합니다.
+func (e *SQLiteExporter) dropTables(db *sql.DB, tables []Table) error {
+	for _, table := range tables {
+		if _, err := db.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s;", e.dialect.QuoteIdentifier(table.Name))); err != nil {
+			return fmt.Errorf("table %s: %v", table.Name, err)
+		}
+		for _, idx := range GeoColumns(table) {
+			rtree := SpatialIndexName(table, table.Columns[idx])
+			if _, err := db.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s;", e.dialect.QuoteIdentifier(rtree))); err != nil {
+				return fmt.Errorf("table %s: %v", rtree, err)
+			}
+		}
+	}
+	return nil
+}
+
 func (e *SQLiteExporter) insertData(db *sql.DB, tables []Table) error {
 	// Begin transaction for all data insertion
 	tx, err := db.Begin()
@@ -70,9 +350,17 @@ func (e *SQLiteExporter) insertData(db *sql.DB, tables []Table) error {
 	}
 	defer tx.Rollback()
 
+	var interner *stringInterner
+	if hasInternedColumns(tables) {
+		if interner, err = newStringInterner(tx, e.dialect); err != nil {
+			return err
+		}
+		defer interner.Close()
+	}
+
 	// Insert data for each table
 	for _, table := range tables {
-		if err := e.insertTableData(tx, table); err != nil {
+		if err := e.insertTableData(tx, table, interner); err != nil {
 			return fmt.Errorf("failed to insert data for table %s: %v", table.Name, err)
 		}
 	}
@@ -80,23 +368,18 @@ func (e *SQLiteExporter) insertData(db *sql.DB, tables []Table) error {
 	return tx.Commit()
 }
 
-func (e *SQLiteExporter) insertTableData(tx *sql.Tx, table Table) error {
+func (e *SQLiteExporter) insertTableData(tx *sql.Tx, table Table, interner *stringInterner) error {
 	// Build insert statement
-	var quotedColumns []string
-	var placeholders []string
+	var columns []string
 	var columnTypes []SQLiteType
 
 	for _, col := range table.Columns {
-		quotedColumns = append(quotedColumns, QuoteIdentifier(col.Name))
-		placeholders = append(placeholders, "?")
+		columns = append(columns, col.Name)
 		columnTypes = append(columnTypes, GetSQLiteType(col.Type))
 	}
 
-	quotedTableName := QuoteIdentifier(table.Name)
-	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
-		quotedTableName,
-		strings.Join(quotedColumns, ", "),
-		strings.Join(placeholders, ", "))
+	query := e.dialect.Insert(table.Name, columns)
+	dateTimeStorage := tableDateTimeStorage(table)
 
 	// Prepare statement for bulk insert
 	stmt, err := tx.Prepare(query)
@@ -107,15 +390,25 @@ func (e *SQLiteExporter) insertTableData(tx *sql.Tx, table Table) error {
 
 	// Insert each row
 	for rowIdx, row := range table.Rows {
-		values := make([]interface{}, len(quotedColumns))
+		values := make([]interface{}, len(columns))
 
 		// Convert values according to SQLite types
 		for i, col := range table.Columns {
 			value := row[i]
 			sqliteType := columnTypes[i]
 
+			// intern 컬럼은 문자열 테이블의 id를 저장
+			if s, ok := value.(string); ok && isInterned(col) {
+				id, err := interner.id(s)
+				if err != nil {
+					return fmt.Errorf("error interning value at row %d, column %s: %v", rowIdx+1, col.Name, err)
+				}
+				values[i] = id
+				continue
+			}
+
 			// Convert value based on SQLite type
-			convertedValue, err := convertToSQLiteValue(value, sqliteType, col)
+			convertedValue, err := convertToSQLiteValue(value, sqliteType, col, dateTimeStorage)
 			if err != nil {
 				return fmt.Errorf("error converting value at row %d, column %s: %v", rowIdx+1, col.Name, err)
 			}
@@ -132,7 +425,9 @@ func (e *SQLiteExporter) insertTableData(tx *sql.Tx, table Table) error {
 	return nil
 }
 
-func convertToSQLiteValue(value interface{}, sqliteType SQLiteType, col Column) (interface{}, error) {
+// convertToSQLiteValue는 셀 값을 DB에 저장할 값으로 바꿉니다.
+// bool은 0 또는 1, datetime은 테이블의 저장 형식(UTC RFC 3339 텍스트 또는 Unix 초)으로 저장합니다.
+func convertToSQLiteValue(value interface{}, sqliteType SQLiteType, col Column, dateTimeStorage string) (interface{}, error) {
 	// Handle nil values
 	if value == nil {
 		return nil, nil
@@ -159,25 +454,31 @@ func convertToSQLiteValue(value interface{}, sqliteType SQLiteType, col Column)
 		}
 
 	case SQLiteBoolean:
-		switch v := value.(type) {
-		case string:
-			return strconv.ParseBool(v)
-		case int:
-			return v != 0, nil
-		default:
-			return value, nil
-		}
+		return encodeBool(value)
 
 	case SQLiteDateTime:
-		switch v := value.(type) {
-		case string:
-			return time.Parse("2006-01-02 15:04:05", v)
-		default:
-			return value, nil
-		}
+		return encodeDateTime(value, dateTimeStorage)
 
 	case SQLiteText:
+		if point, ok := value.(GeoPoint); ok {
+			jsonBytes, err := json.Marshal(point)
+			if err != nil {
+				return nil, err
+			}
+			return string(jsonBytes), nil
+		}
+		if curve, ok := value.(Curve); ok {
+			jsonBytes, err := json.Marshal(curve)
+			if err != nil {
+				return nil, err
+			}
+			return string(jsonBytes), nil
+		}
 		if col.Type.IsArray {
+			// 파서가 이미 JSON 문자열로 변환한 경우 그대로 사용
+			if s, ok := value.(string); ok {
+				return s, nil
+			}
 			// Handle array types by converting to JSON
 			jsonBytes, err := json.Marshal(value)
 			if err != nil {
@@ -200,7 +501,7 @@ func convertToSQLiteValue(value interface{}, sqliteType SQLiteType, col Column)
 	}
 }
 
-func (e *SQLiteExporter) createTables(db *sql.DB, tables []Table) error {
+func (e *SQLiteExporter) createTables(db *sql.DB, tables []Table, tableOpts sqliteTableOptions) error {
 	// Begin transaction
 	tx, err := db.Begin()
 	if err != nil {
@@ -208,9 +509,23 @@ func (e *SQLiteExporter) createTables(db *sql.DB, tables []Table) error {
 	}
 	defer tx.Rollback()
 
+	// intern 컬럼이 참조하는 문자열 테이블 (증분 생성에서도 기존 문자열과 id를 유지)
+	if hasInternedColumns(tables) {
+		if _, err := tx.Exec(buildStringTableQuery(tableOpts.Strict, e.dialect)); err != nil {
+			return fmt.Errorf("failed to create table %s: %v", StringTableName, err)
+		}
+	}
+
 	// Create each table
 	for _, table := range tables {
-		query := e.buildCreateTableQuery(table)
+		if err := validateEffectiveDates(table); err != nil {
+			return err
+		}
+		if err := validateVariants(table); err != nil {
+			return err
+		}
+
+		query := e.buildCreateTableQuery(table, tableOpts)
 
 		log.Println("query:", query)
 
@@ -228,14 +543,56 @@ func (e *SQLiteExporter) createTables(db *sql.DB, tables []Table) error {
 	return tx.Commit()
 }
 
-func (e *SQLiteExporter) buildCreateTableQuery(table Table) string {
+// sqliteTableOptions는 CREATE TABLE 문의 테이블 옵션입니다.
+type sqliteTableOptions struct {
+	Strict       bool              // STRICT: 컬럼 타입을 강제 (DATETIME 컬럼은 TEXT로 선언)
+	WithoutRowID map[string]string // WITHOUT ROWID로 생성할 테이블 이름 → 외래 키가 가리킬 인덱스 컬럼
+}
+
+// withoutRowIDTables는 WITHOUT ROWID로 생성할 수 있는 테이블을 반환합니다.
+// 변형, 적용 기간, geo 컬럼이 있는 테이블은 인덱스가 행을 유일하게 식별하지 않거나
+// 뷰와 R*Tree가 id 컬럼을 사용하므로 id 기본 키를 유지합니다.
+func withoutRowIDTables(tables []Table, d SQLDialect) map[string]string {
+	result := make(map[string]string)
+	for _, table := range tables {
+		from, _ := EffectiveDateColumns(table)
+		if len(table.Columns) == 0 || VariantColumn(table) != -1 || from != -1 || len(GeoColumns(table)) > 0 {
+			log.Printf("table %s keeps its rowid id column (variant, effective date or geo columns)", table.Name)
+			continue
+		}
+		result[table.Name] = d.QuoteIdentifier(table.Columns[IndexColumn(table)].Name)
+	}
+	return result
+}
+
+// primaryKeyColumns는 WITHOUT ROWID 테이블의 기본 키 컬럼을 반환합니다.
+func primaryKeyColumns(table Table, d SQLDialect) []string {
+	if table.IsMatrix {
+		return []string{d.QuoteIdentifier(MatrixRowKey), d.QuoteIdentifier(MatrixColKey)}
+	}
+	return []string{d.QuoteIdentifier(table.Columns[IndexColumn(table)].Name)}
+}
+
+// referenceColumn은 외래 키가 가리킬 대상 테이블의 컬럼을 반환합니다.
+// WITHOUT ROWID 테이블은 id 대신 인덱스 컬럼이 기본 키입니다.
+func (o sqliteTableOptions) referenceColumn(target string, d SQLDialect) string {
+	if col, ok := o.WithoutRowID[target]; ok {
+		return col
+	}
+	return d.QuoteIdentifier("id")
+}
+
+func (e *SQLiteExporter) buildCreateTableQuery(table Table, tableOpts sqliteTableOptions) string {
 	var b strings.Builder
+	_, withoutRowID := tableOpts.WithoutRowID[table.Name]
 
-	quotedTableName := QuoteIdentifier(table.Name)
+	quotedTableName := e.dialect.QuoteIdentifier(table.Name)
 	b.WriteString(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n", quotedTableName))
 
 	// Add id column as primary key
-	b.WriteString("  id INTEGER PRIMARY KEY AUTOINCREMENT,\n")
+	if !withoutRowID {
+		b.WriteString("  " + e.dialect.AutoIncrementKey("id") + ",\n")
+	}
 
 	// check array column
 
@@ -251,9 +608,9 @@ func (e *SQLiteExporter) buildCreateTableQuery(table Table) string {
 
 	// Add columns
 	for i, col := range table.Columns {
-		quotedColName := QuoteIdentifier(col.Name)
+		quotedColName := e.dialect.QuoteIdentifier(col.Name)
 		constraints := e.buildColumnConstraints(col)
-		sqlType := GetSQLiteType(col.Type).String()
+		sqlType := e.dialect.ColumnType(col, SQLTypeOptions{Strict: tableOpts.Strict, DateTimeStorage: tableDateTimeStorage(table)})
 
 		b.WriteString(fmt.Sprintf("  %s %s%s", quotedColName, sqlType, constraints))
 
@@ -270,15 +627,63 @@ func (e *SQLiteExporter) buildCreateTableQuery(table Table) string {
 	// Add foreign key constraints
 	for _, rel := range table.Relations {
 		if rel.RelationType == "belongsTo" {
-			quotedFK := QuoteIdentifier(rel.ForeignKey)
-			quotedTargetTable := QuoteIdentifier(rel.TargetTable)
+			quotedFK := e.dialect.QuoteIdentifier(rel.ForeignKey)
+			quotedTargetTable := e.dialect.QuoteIdentifier(rel.TargetTable)
 
-			b.WriteString(fmt.Sprintf(",\n  FOREIGN KEY(%s) REFERENCES %s(id)",
-				quotedFK, quotedTargetTable))
+			b.WriteString(fmt.Sprintf(",\n  FOREIGN KEY(%s) REFERENCES %s(%s)",
+				quotedFK, quotedTargetTable, tableOpts.referenceColumn(rel.TargetTable, e.dialect)))
 		}
 	}
 
-	b.WriteString(");\n")
+	// 매트릭스 테이블은 (행 키, 열 키)마다 하나의 값만 가짐
+	if withoutRowID {
+		b.WriteString(fmt.Sprintf(",\n  PRIMARY KEY(%s)", strings.Join(primaryKeyColumns(table, e.dialect), ", ")))
+	} else if table.IsMatrix {
+		b.WriteString(fmt.Sprintf(",\n  UNIQUE(%s, %s)", e.dialect.QuoteIdentifier(MatrixRowKey), e.dialect.QuoteIdentifier(MatrixColKey)))
+	}
+
+	b.WriteString(")")
+	var tableOptions []string
+	if tableOpts.Strict {
+		tableOptions = append(tableOptions, "STRICT")
+	}
+	if withoutRowID {
+		tableOptions = append(tableOptions, "WITHOUT ROWID")
+	}
+	if len(tableOptions) > 0 {
+		b.WriteString(" " + strings.Join(tableOptions, ", "))
+	}
+	b.WriteString(";\n")
+
+	// 적용 기간 컬럼이 있으면 현재 적용 중인 행만 보여주는 뷰를 추가
+	if view := buildActiveViewQuery(table, e.dialect); view != "" {
+		b.WriteString("\n")
+		b.WriteString(view)
+	}
+
+	// 변형 컬럼이 있으면 변형별 데이터셋 뷰를 추가
+	if views := buildVariantViewQueries(table, e.dialect); views != "" {
+		b.WriteString("\n")
+		b.WriteString(views)
+	}
+
+	// 부모 컬럼이 있으면 트리를 펼친 재귀 뷰를 추가
+	if view := buildTreeViewQuery(table, e.dialect); view != "" {
+		b.WriteString("\n")
+		b.WriteString(view)
+	}
+
+	// geo 컬럼의 범위 검색용 R*Tree 인덱스를 추가
+	if rtrees := buildSpatialIndexQueries(table, e.dialect); rtrees != "" {
+		b.WriteString("\n")
+		b.WriteString(rtrees)
+	}
+
+	// 이전 이름으로도 조회할 수 있도록 별칭 뷰를 추가
+	if views := buildAliasViewQueries(table, e.dialect); views != "" {
+		b.WriteString("\n")
+		b.WriteString(views)
+	}
 
 	// 	// Add trigger for updated_at
 	// 	b.WriteString(fmt.Sprintf(`
@@ -309,6 +714,18 @@ func (e *SQLiteExporter) buildColumnConstraints(col Column) string {
 		constraints = append(constraints, fmt.Sprintf("DEFAULT %s", defaultVal))
 	}
 
+	// Handle COLLATE (SQLite built-in collations only)
+	if collation := sqliteCollation(col); collation != "" {
+		constraints = append(constraints, "COLLATE "+collation)
+	}
+
+	// Handle min/max
+	if bounds, err := ColumnBounds(col); err == nil {
+		if check := buildCheckConstraint(col, bounds, e.dialect); check != "" {
+			constraints = append(constraints, check)
+		}
+	}
+
 	if len(constraints) > 0 {
 		return " " + strings.Join(constraints, " ")
 	}
@@ -316,40 +733,11 @@ func (e *SQLiteExporter) buildColumnConstraints(col Column) string {
 }
 
 func (e *SQLiteExporter) createIndices(tx *sql.Tx, table Table) error {
-	// Create index for indexed columns
-
-	for _, col := range table.Columns {
-		if HasTag(col.Tags, TagIndex) {
-			quotedTableName := QuoteIdentifier(table.Name)
-			quotedColumnName := QuoteIdentifier(col.Name)
-
-			indexName := fmt.Sprintf("idx_%s_%s", quotedTableName, quotedColumnName)
-			query := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s(%s);",
-				indexName, quotedTableName, quotedColumnName)
-
-			if _, err := tx.Exec(query); err != nil {
-				return err
-			}
-		}
-	}
-
-	// Create indices for foreign keys
-	for _, rel := range table.Relations {
-		if rel.RelationType == "belongsTo" {
-
-			quotedTableName := QuoteIdentifier(table.Name)
-			quotedFK := QuoteIdentifier(rel.ForeignKey)
-
-			indexName := fmt.Sprintf("idx_%s_%s", quotedTableName, quotedFK)
-			query := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s(%s);",
-				indexName, quotedTableName, quotedFK)
-
-			if _, err := tx.Exec(query); err != nil {
-				return err
-			}
+	for _, query := range buildIndexQueries(table, e.dialect) {
+		if _, err := tx.Exec(query); err != nil {
+			return err
 		}
 	}
-
 	return nil
 }
 
@@ -377,17 +765,65 @@ func buildColumnDefinition(col Column) string {
 }
 
 // generateSchemaFile creates a SQL file with the schema definition
-func (e *SQLiteExporter) generateSchemaFile(tables []Table, opts Options) error {
+// User SQL is placed where it runs against the database: before the tables, after the tables, and at the end.
+func (e *SQLiteExporter) generateSchemaFile(tables []Table, schemaPath string, tableOpts sqliteTableOptions, hooks sqliteSQLHooks) error {
 	var schema strings.Builder
 
 	schema.WriteString("-- Schema generated by excelite\n\n")
 	schema.WriteString("PRAGMA foreign_keys=ON;\n\n")
+	hooks.BeforeSchema.write(&schema, "before schema")
 
+	if hasInternedColumns(tables) {
+		schema.WriteString(buildStringTableQuery(tableOpts.Strict, e.dialect))
+		schema.WriteString("\n")
+	}
 	for _, table := range tables {
-		schema.WriteString(e.buildCreateTableQuery(table))
+		schema.WriteString(e.buildCreateTableQuery(table, tableOpts))
 		schema.WriteString("\n\n")
+		if queries := buildIndexQueries(table, e.dialect); len(queries) > 0 {
+			schema.WriteString(strings.Join(queries, "\n"))
+			schema.WriteString("\n\n")
+		}
+	}
+
+	hooks.AfterSchema.write(&schema, "after schema")
+
+	views, err := buildViewQueries(tables, false, e.dialect)
+	if err != nil {
+		return err
+	}
+	for _, view := range views {
+		schema.WriteString(view)
+		schema.WriteString("\n")
+	}
+	hooks.AfterData.write(&schema, "after data load")
+
+	return os.WriteFile(schemaPath, []byte(schema.String()), 0644)
+}
+
+// dumpSchemaFile은 DB에 실제로 존재하는 전체 스키마를 SQL 파일로 저장합니다.
+func (e *SQLiteExporter) dumpSchemaFile(db *sql.DB, schemaPath string) error {
+	rows, err := db.Query("SELECT sql FROM sqlite_master WHERE sql IS NOT NULL AND name NOT LIKE 'sqlite_%' ORDER BY CASE type WHEN 'table' THEN 0 WHEN 'index' THEN 1 ELSE 2 END, name")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var schema strings.Builder
+	schema.WriteString("-- Schema generated by excelite\n\n")
+	schema.WriteString("PRAGMA foreign_keys=ON;\n\n")
+
+	for rows.Next() {
+		var stmt string
+		if err := rows.Scan(&stmt); err != nil {
+			return err
+		}
+		schema.WriteString(stmt)
+		schema.WriteString(";\n\n")
+	}
+	if err := rows.Err(); err != nil {
+		return err
 	}
 
-	schemaPath := filepath.Join(opts.OutputDir, "schema.sql")
 	return os.WriteFile(schemaPath, []byte(schema.String()), 0644)
 }
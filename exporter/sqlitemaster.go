@@ -0,0 +1,160 @@
+// exporter/sqlitemaster.go
+package exporter
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 마스터 DB 파일과 마스터 DB에 기록되는 테이블
+const (
+	SQLiteMasterDB     = "master.db"
+	SQLiteMasterScript = "master.sql"
+	MasterPacksTable   = "_packs" // 팩 이름(ATTACH 별칭)과 마스터 DB 기준 파일 경로
+	MasterViewsTable   = "_views" // 팩을 ATTACH한 뒤 실행할 팩 간 뷰의 CREATE TEMP VIEW 문
+)
+
+// sqlitePack은 그룹 하나의 DB 파일입니다. 기본 그룹은 패키지 이름을 팩 이름으로 씁니다.
+type sqlitePack struct {
+	Name string
+	Path string
+}
+
+// sqlitePackName은 그룹의 팩 이름(ATTACH 별칭)입니다.
+func sqlitePackName(group, packageName string) string {
+	if group == "" {
+		return packageName
+	}
+	return group
+}
+
+// exportMaster는 팩들을 ATTACH해서 함께 조회하기 위한 마스터 DB와 스크립트를 생성합니다.
+// SQLite의 일반 뷰는 다른 DB의 테이블을 참조할 수 없으므로, 마스터 DB에는 팩 목록과 팩 간 뷰의 SQL만 저장하고
+// 실행 시 마스터 DB를 연 연결에서 팩을 ATTACH한 뒤 _views의 TEMP VIEW를 순서대로 생성합니다.
+// master.sql은 같은 작업을 하는 스크립트입니다. (sqlite3 master.db로 연 뒤 .read master.sql)
+// 생성한 뒤 팩을 실제로 ATTACH해서 팩 간 뷰를 조회할 수 있는지 확인합니다.
+func (e *SQLiteExporter) exportMaster(tables []Table, packs []sqlitePack, outputDir string) error {
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	for _, pack := range packs {
+		switch strings.ToLower(pack.Name) {
+		case "main", "temp":
+			return fmt.Errorf("pack name %s is reserved by SQLite; rename the group or package", pack.Name)
+		}
+		if pack.Path == filepath.Join(outputDir, SQLiteMasterDB) {
+			return fmt.Errorf("pack %s would overwrite the master DB %s", pack.Name, SQLiteMasterDB)
+		}
+	}
+
+	views, err := buildViewQueries(tables, true, e.dialect)
+	if err != nil {
+		return err
+	}
+
+	masterPath := filepath.Join(outputDir, SQLiteMasterDB)
+	if err := os.Remove(masterPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove previous master database: %v", err)
+	}
+	db, err := sql.Open("sqlite3", masterPath)
+	if err != nil {
+		return fmt.Errorf("failed to open master database: %v", err)
+	}
+	defer db.Close()
+	// ATTACH와 TEMP VIEW는 연결마다 적용되므로 하나의 연결만 사용
+	db.SetMaxOpenConns(1)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		fmt.Sprintf("CREATE TABLE %s (Name TEXT PRIMARY KEY, File TEXT NOT NULL)", e.dialect.QuoteIdentifier(MasterPacksTable)),
+		fmt.Sprintf("CREATE TABLE %s (Seq INTEGER PRIMARY KEY, Name TEXT NOT NULL, SQL TEXT NOT NULL)", e.dialect.QuoteIdentifier(MasterViewsTable)),
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to create master tables: %v", err)
+		}
+	}
+
+	var script strings.Builder
+	script.WriteString("-- Master generated by excelite\n")
+	script.WriteString("-- Open " + SQLiteMasterDB + " from this directory and run this script (sqlite3 " + SQLiteMasterDB + " \".read " + SQLiteMasterScript + "\").\n")
+	script.WriteString("-- Runtimes do the same with the " + MasterPacksTable + " and " + MasterViewsTable + " tables of the master DB.\n\n")
+
+	files := make(map[string]string, len(packs))
+	for _, pack := range packs {
+		file, err := filepath.Rel(outputDir, pack.Path)
+		if err != nil {
+			file = pack.Path
+		}
+		file = filepath.ToSlash(file)
+		files[pack.Name] = file
+		if _, err := tx.Exec(fmt.Sprintf("INSERT INTO %s (Name, File) VALUES (?, ?)", e.dialect.QuoteIdentifier(MasterPacksTable)), pack.Name, file); err != nil {
+			return fmt.Errorf("failed to record pack %s: %v", pack.Name, err)
+		}
+		fmt.Fprintf(&script, "ATTACH DATABASE '%s' AS %s;\n", strings.ReplaceAll(file, "'", "''"), e.dialect.QuoteIdentifier(pack.Name))
+	}
+	script.WriteString("\n")
+
+	var names []string
+	for _, table := range tables {
+		for _, view := range table.Views {
+			if view.Master {
+				names = append(names, view.Name)
+			}
+		}
+	}
+	for i, query := range views {
+		if _, err := tx.Exec(fmt.Sprintf("INSERT INTO %s (Seq, Name, SQL) VALUES (?, ?, ?)", e.dialect.QuoteIdentifier(MasterViewsTable)),
+			i+1, names[i], query); err != nil {
+			return fmt.Errorf("failed to record view %s: %v", names[i], err)
+		}
+		script.WriteString(query)
+		script.WriteString("\n")
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// 팩을 ATTACH하고 팩 간 뷰를 만들어 본 뒤 정리
+	for _, pack := range packs {
+		if _, err := db.Exec("ATTACH DATABASE ? AS "+e.dialect.QuoteIdentifier(pack.Name), pack.Path); err != nil {
+			return fmt.Errorf("failed to attach pack %s (%s): %v", pack.Name, files[pack.Name], err)
+		}
+	}
+	// CREATE VIEW는 컬럼을 확인하지 않으므로 뷰를 한 번 조회
+	for i, query := range views {
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("%v\n%s", err, query)
+		}
+		if _, err := db.Exec(fmt.Sprintf("SELECT * FROM %s LIMIT 0", e.dialect.QuoteIdentifier(names[i]))); err != nil {
+			return fmt.Errorf("view %s: %v", names[i], err)
+		}
+	}
+	for _, pack := range packs {
+		if _, err := db.Exec("DETACH DATABASE " + e.dialect.QuoteIdentifier(pack.Name)); err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(filepath.Join(outputDir, SQLiteMasterScript), []byte(script.String()), 0644)
+}
+
+// countMasterViews는 마스터 DB에만 생성되는 팩 간 뷰의 수입니다.
+func countMasterViews(tables []Table) int {
+	n := 0
+	for _, table := range tables {
+		for _, view := range table.Views {
+			if view.Master {
+				n++
+			}
+		}
+	}
+	return n
+}
@@ -0,0 +1,347 @@
+// exporter/sqlitequery.go
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// 쿼리 레이어를 생성할 수 있는 언어
+var sqliteQueryLanguages = []string{"go", "cpp", "csharp"}
+
+// queryColumn은 쿼리 레이어의 행 구조체 필드입니다.
+type queryColumn struct {
+	Name    string // 필드 이름 (컬럼 이름)
+	Expr    string // SELECT 식
+	Kind    string // int32, int64, real, bool, text, datetime, json, blob
+	GoType  string
+	CppType string
+	CSType  string
+	GoTags  string // Go 행 구조체 필드의 구조체 태그 (#Config의 structTags 설정)
+
+	Narrowed bool // 선언된 타입보다 작은 정수 타입 (읽을 때 변환이 필요)
+}
+
+// queryLookup은 WHERE 조건이 있는 조회 함수 하나입니다.
+type queryLookup struct {
+	Method string        // 함수 이름 (Get, ByName 등)
+	Field  string        // 준비된 문장을 담는 필드 이름
+	SQL    string        // 쿼리
+	Params []queryColumn // 파라미터 (WHERE 조건 순서)
+	Unique bool          // 결과가 최대 한 행인지
+}
+
+// queryTable은 테이블 하나의 쿼리 레이어입니다.
+type queryTable struct {
+	Name    string
+	Columns []queryColumn
+	AllSQL  string
+	Lookups []queryLookup
+}
+
+// parseQueryLanguages는 쉼표로 구분된 쿼리 레이어 언어 목록을 검사합니다.
+func parseQueryLanguages(value string) ([]string, error) {
+	var langs []string
+	for _, lang := range strings.Split(value, ",") {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if lang == "" {
+			continue
+		}
+		if lang == "c#" || lang == "cs" {
+			lang = "csharp"
+		}
+		if !containsString(sqliteQueryLanguages, lang) {
+			return nil, fmt.Errorf("unsupported query layer language %q (supported: %s)", lang, strings.Join(sqliteQueryLanguages, ", "))
+		}
+		if !containsString(langs, lang) {
+			langs = append(langs, lang)
+		}
+	}
+	return langs, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// newQueryColumn은 컬럼의 SELECT 식과 언어별 타입을 정합니다.
+// NULL 셀은 SELECT 단계에서 0이나 빈 문자열로 바꿔 생성된 코드가 널 검사 없이 값을 읽도록 합니다.
+// 날짜는 저장 형식(dateTimeStorage)이나 STRICT 여부와 관계없이 같은 형식으로 읽도록 RFC 3339 문자열로 변환합니다.
+func newQueryColumn(col Column, dateTimeStorage string, d SQLDialect) queryColumn {
+	quoted := d.QuoteIdentifier(col.Name)
+	qc := queryColumn{Name: col.Name}
+
+	switch {
+	case col.Type.IsArray || col.Type.IsGeo():
+		qc.Kind = "json"
+		qc.Expr = fmt.Sprintf("COALESCE(%s, '')", quoted)
+		if col.Type.IsArray {
+			qc.GoType = "[]" + getGoTypeFromColumnType(*col.Type.BaseType)
+		} else {
+			qc.GoType = "[2]float64"
+		}
+		qc.CppType, qc.CSType = "std::string", "string"
+	case col.Type.IsCurve():
+		// Go와 C#은 생성된 Curve 타입으로 읽고, C++는 JSON 텍스트 그대로 읽음
+		qc.Kind = "curve"
+		qc.Expr = fmt.Sprintf("COALESCE(%s, '')", quoted)
+		qc.GoType, qc.CppType, qc.CSType = "Curve", "std::string", "Curve?"
+	case col.Type.Type == DateTimeType.Type:
+		qc.Kind = "datetime"
+		qc.Expr = fmt.Sprintf("strftime('%%Y-%%m-%%dT%%H:%%M:%%SZ', %s)", quoted)
+		if dateTimeStorage == DateTimeEpoch {
+			qc.Expr = fmt.Sprintf("strftime('%%Y-%%m-%%dT%%H:%%M:%%SZ', %s, 'unixepoch')", quoted)
+		}
+		qc.GoType, qc.CppType, qc.CSType = "time.Time", "std::string", "DateTime?"
+	case col.Type.Type == BytesType.Type:
+		qc.Kind = "blob"
+		qc.Expr = quoted
+		qc.GoType, qc.CppType, qc.CSType = "[]byte", "std::vector<uint8_t>", "byte[]?"
+	default:
+		switch col.Type.Type.Kind() {
+		case reflect.Int32:
+			qc.Kind, qc.GoType, qc.CppType, qc.CSType = "int32", "int32", "int32_t", "int"
+		case reflect.Int64:
+			qc.Kind, qc.GoType, qc.CppType, qc.CSType = "int64", "int64", "int64_t", "long"
+		case reflect.Float64:
+			qc.Kind, qc.GoType, qc.CppType, qc.CSType = "real", "float64", "double", "double"
+		case reflect.Bool:
+			qc.Kind, qc.GoType, qc.CppType, qc.CSType = "bool", "bool", "bool", "bool"
+		default:
+			qc.Kind, qc.GoType, qc.CppType, qc.CSType = "text", "string", "std::string", "string"
+		}
+		if col.Type.IsFormula() {
+			// 수식은 텍스트 그대로 읽되 Go는 Eval 메서드가 있는 Formula 타입으로 읽음
+			qc.GoType = "Formula"
+		}
+		if qc.Kind == "text" {
+			qc.Expr = fmt.Sprintf("COALESCE(%s, '')", quoted)
+		} else {
+			qc.Expr = fmt.Sprintf("COALESCE(%s, 0)", quoted)
+		}
+	}
+	return qc
+}
+
+// lookupable은 컬럼 값으로 조회 함수를 만들 수 있는지 확인합니다.
+func (qc queryColumn) lookupable() bool {
+	return qc.Kind != "json" && qc.Kind != "curve" && qc.Kind != "datetime" && qc.Kind != "blob"
+}
+
+// buildQueryTables는 테이블마다 기본 키, 인덱스 컬럼, 외래 키 조회와 전체 조회 쿼리를 만듭니다.
+// narrowInts이면 정수 컬럼을 데이터 범위에 맞는 가장 작은 타입으로 생성합니다. (narrow 태그는 항상 적용)
+func buildQueryTables(tables []Table, narrowInts bool, d SQLDialect) []queryTable {
+	narrowed := narrowIntTypes(tables, narrowInts)
+	var result []queryTable
+	for _, table := range tables {
+		if len(table.Columns) == 0 {
+			continue
+		}
+
+		qt := queryTable{Name: table.Name}
+		exprs := make([]string, len(table.Columns))
+		tagRules := tableStructTags(table)
+		for i, col := range table.Columns {
+			qt.Columns = append(qt.Columns, newQueryColumn(col, tableDateTimeStorage(table), d))
+			qt.Columns[i].GoTags = strings.Join(goStructTags(tagRules, col, false), " ")
+			if nt, ok := narrowed[table.Name+"."+col.Name]; ok {
+				narrowQueryColumn(&qt.Columns[i], nt)
+			}
+			// intern 컬럼은 문자열 테이블에서 값을 읽음
+			if isInterned(col) {
+				qt.Columns[i].Expr = internedExpr(table, col, d)
+			}
+			// 이름으로 매핑하는 라이브러리(sqlx 등)를 위해 식에 컬럼 이름을 붙임
+			exprs[i] = qt.Columns[i].Expr
+			if quoted := d.QuoteIdentifier(col.Name); exprs[i] != quoted {
+				exprs[i] += " AS " + quoted
+			}
+		}
+
+		keys := []int{IndexColumn(table)}
+		if table.IsMatrix {
+			keys = []int{0, 1}
+		}
+		var orderBy []string
+		for _, k := range keys {
+			orderBy = append(orderBy, d.QuoteIdentifier(table.Columns[k].Name))
+		}
+
+		selectFrom := fmt.Sprintf("SELECT %s FROM %s", strings.Join(exprs, ", "), d.QuoteIdentifier(table.Name))
+		order := " ORDER BY " + strings.Join(orderBy, ", ")
+		qt.AllSQL = selectFrom + order
+
+		lookup := func(method string, cols []int, unique bool) {
+			var conds []string
+			var params []queryColumn
+			for i, c := range cols {
+				if !qt.Columns[c].lookupable() {
+					return
+				}
+				if isInterned(table.Columns[c]) {
+					conds = append(conds, internedCondition(table.Columns[c], fmt.Sprintf("$p%d", i+1), d))
+				} else {
+					conds = append(conds, fmt.Sprintf("%s = $p%d", d.QuoteIdentifier(table.Columns[c].Name), i+1))
+				}
+				params = append(params, qt.Columns[c])
+			}
+			qt.Lookups = append(qt.Lookups, queryLookup{
+				Method: method,
+				Field:  strings.ToLower(method[:1]) + method[1:],
+				SQL:    selectFrom + " WHERE " + strings.Join(conds, " AND ") + order,
+				Params: params,
+				Unique: unique,
+			})
+		}
+
+		// 변형이나 적용 기간이 있으면 같은 인덱스의 행이 여러 개일 수 있음
+		from, _ := EffectiveDateColumns(table)
+		lookup("Get", keys, table.IsMatrix || (VariantColumn(table) == -1 && from == -1))
+
+		seen := map[string]bool{"Get": true}
+		for i, col := range table.Columns {
+			if containsInt(keys, i) {
+				continue
+			}
+			indexed := isSingleIndexed(col)
+			unique := col.IsUnique || HasTag(col.Tags, TagUnique)
+			for _, rel := range table.Relations {
+				if rel.RelationType == "belongsTo" && rel.ForeignKey == col.Name {
+					indexed = true
+				}
+			}
+			if (indexed || unique) && !seen["By"+col.Name] {
+				seen["By"+col.Name] = true
+				lookup("By"+col.Name, []int{i}, unique)
+			}
+		}
+		// 이름 있는 인덱스는 모든 컬럼을 조건으로 하는 조회 (예: ByTypeAndRarity)
+		for _, idx := range CompositeIndexes(table) {
+			method := "By" + strings.Join(idx.ColumnNames(table), "And")
+			if !seen[method] {
+				seen[method] = true
+				lookup(method, idx.Columns, false)
+			}
+		}
+
+		result = append(result, qt)
+	}
+	return result
+}
+
+func containsInt(list []int, n int) bool {
+	for _, item := range list {
+		if item == n {
+			return true
+		}
+	}
+	return false
+}
+
+// generateQueryLayers는 DB 파일 옆에 언어별 쿼리 레이어를 작성합니다.
+// 그룹 DB는 파일 이름 앞에 그룹 이름을 붙입니다. refs(prune 옵션)가 있으면 Go 쿼리 레이어는 사용하는 테이블과 조회 함수만 포함합니다.
+func (e *SQLiteExporter) generateQueryLayers(tables []Table, langs []string, outputDir, group string, refs map[string]bool, opts Options) error {
+	narrowInts := e.GetBoolOption(opts, OptNarrowInts, false)
+	data := struct {
+		Package    string
+		Namespace  string
+		HasTime    bool
+		HasJSON    bool
+		HasCurve   bool
+		HasFormula bool
+		Tables     []queryTable
+	}{
+		Package:   opts.PackageName,
+		Namespace: formatTableName(opts.PackageName),
+		Tables:    buildQueryTables(tables, narrowInts, e.dialect),
+	}
+	if narrowInts {
+		logNarrowing(tables, narrowIntTypes(tables, true))
+	}
+	queryTables := data.Tables
+
+	for _, lang := range langs {
+		data.Tables = queryTables
+		if lang == "go" && refs != nil {
+			data.Tables = pruneQueryTables(queryTables, refs)
+		}
+		data.HasTime, data.HasJSON, data.HasCurve, data.HasFormula = false, false, false, false
+		for _, table := range data.Tables {
+			for _, col := range table.Columns {
+				data.HasTime = data.HasTime || col.Kind == "datetime"
+				data.HasJSON = data.HasJSON || col.Kind == "json" || col.Kind == "curve"
+				data.HasCurve = data.HasCurve || col.Kind == "curve"
+				data.HasFormula = data.HasFormula || col.GoType == "Formula"
+			}
+		}
+
+		var tmplName, name string
+		switch lang {
+		case "go":
+			tmplName, name = "sqlite/queries.go.tmpl", "queries.go"
+			if group != "" {
+				name = group + "_queries.go"
+			}
+		case "cpp":
+			tmplName, name = "sqlite/queries.hpp.tmpl", "queries.hpp"
+			if group != "" {
+				name = group + "_queries.hpp"
+			}
+		case "csharp":
+			tmplName, name = "sqlite/Queries.cs.tmpl", "Queries.cs"
+			if group != "" {
+				name = formatTableName(group) + "Queries.cs"
+			}
+		}
+
+		tmplText, err := loadTemplate(opts.TemplateDir, tmplName)
+		if err != nil {
+			return err
+		}
+		tmpl, err := template.New(lang).Funcs(template.FuncMap{
+			"inc": func(i int) int { return i + 1 },
+		}).Parse(tmplText)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to generate %s query layer: %v", lang, err)
+		}
+		out := buf.Bytes()
+		if lang == "go" {
+			if out, err = format.Source(out); err != nil {
+				return fmt.Errorf("failed to format go query layer: %v", err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(outputDir, name), out, 0644); err != nil {
+			return err
+		}
+		if data.HasCurve {
+			namespace := data.Package
+			if lang == "csharp" {
+				namespace = data.Namespace
+			}
+			if err := writeCurveSource(outputDir, lang, namespace); err != nil {
+				return err
+			}
+		}
+		if data.HasFormula && lang == "go" {
+			if err := writeFormulaSource(outputDir, data.Package); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+// exporter/sqlx.go
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// SQLXExporter는 ORM 없이 database/sql과 sqlx로 읽는 Go 구조체와 로더 함수를 생성합니다.
+// 구조체는 `db:` 태그와 #Config의 structTags로 설정한 태그를 가지며(writeonly 컬럼은 `json:"-"`), 로더는 SQLite exporter가 만든 DB를 읽습니다.
+// reload 옵션을 켜면 전체 테이블을 메모리에 올리는 Dataset과, DB 파일이 바뀌면 다시 읽어 원자적으로 교체하는 Store를 함께 생성합니다.
+// columnar 옵션을 켜면 매 프레임 테이블 전체를 순회하는 시스템을 위해 컬럼별 슬라이스(struct of arrays) 타입을 함께 생성합니다.
+type SQLXExporter struct {
+	BaseExporter
+}
+
+func NewSQLXExporter() Exporter {
+	return &SQLXExporter{
+		BaseExporter: NewBaseExporter("sqlx"),
+	}
+}
+
+// sqlxField는 구조체 필드 하나입니다.
+type sqlxField struct {
+	Name       string
+	GoType     string
+	Column     string
+	Deprecated string
+	Tags       string // db 태그 뒤에 붙는 구조체 태그 (structTags 설정, writeonly 컬럼은 json:"-")
+}
+
+func (e *SQLXExporter) Describe() ExporterInfo {
+	return ExporterInfo{
+		Description: "plain Go structs with db tags and sqlx loader functions reading the SQLite DB",
+		Outputs:     []string{"models.go", "dataset.go", "columns.go", "interfaces.go", "table.go", "cache.go", "hooks.go", "otel.go"},
+		Options: []OptionInfo{
+			{Key: OptSQLXReload, Type: "bool", Default: "false", Description: "also write dataset.go: an in-memory Dataset in an atomically swapped Store with Reload() and fsnotify-based Watch()"},
+			{Key: OptSQLXColumnar, Type: "bool", Default: "false", Description: "also write columns.go: a struct of parallel column slices per table with Len/Row/IndexOf accessors"},
+			{Key: OptSQLXGeneric, Type: "bool", Default: "false", Description: "also write table.go: a generic Table[K, T] container with Get/All/Filter/Find and a Load<Table>Table loader per table (the Dataset uses it with reload)"},
+			{Key: OptSQLXCache, Type: "int", Default: "0", Description: "also write cache.go: a read-through LRU Cache over the primary and unique key loaders keeping this many rows per lookup, dropped by Cache.Reload()"},
+			{Key: OptSQLXHooks, Type: "bool", Default: "false", Description: "also write hooks.go: the loaders report every query and unique key miss to a QueryHook installed with SetQueryHook"},
+			{Key: OptSQLXOTel, Type: "bool", Default: "false", Description: "also write otel.go (implies hooks): an OpenTelemetry QueryHook recording query spans, durations and misses per table"},
+			{Key: OptSQLXInterface, Type: "string", Default: "", Description: "also write interfaces.go: an interface of this name with TableName() and GetIndex() implemented by every row type"},
+			{Key: OptSQLXMarkers, Type: "bool", Default: "false", Description: "also write interfaces.go: a <Table>Record marker interface per table implemented only by its row type"},
+			{Key: OptNarrowInts, Type: "bool", Default: "false", Description: "use the smallest integer type that holds each column's data and min/max range (uint8, int16, ...)"},
+		},
+		Types: typeMappings(func(col Column) (string, error) {
+			return sqlxGoType(newQueryColumn(col, DateTimeRFC3339, SQLiteDialect)), nil
+		}),
+	}
+}
+
+// sqlxGoType은 컬럼의 구조체 필드 타입을 반환합니다. 날짜와 JSON 컬럼은 생성된 파일의 Scanner 타입을 사용합니다.
+func sqlxGoType(qc queryColumn) string {
+	switch qc.Kind {
+	case "datetime":
+		return "Time"
+	case "curve":
+		return "Curve"
+	case "json":
+		if qc.GoType == "[2]float64" {
+			return "Point"
+		}
+		return "JSONSlice[" + qc.GoType[2:] + "]"
+	}
+	return qc.GoType
+}
+
+func (e *SQLXExporter) Export(tables []Table, opts Options) error {
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	type sqlxTable struct {
+		queryTable
+		Fields    []sqlxField
+		KeyField  string // Dataset의 키 조회에 사용할 필드 (키가 유일한 단일 컬럼일 때)
+		KeyType   string
+		KeyFields []string // 행의 키 필드 (매트릭스는 행 키와 열 키)
+	}
+	data := struct {
+		PackageName string
+		Interface   string // 모든 행 타입이 구현하는 공통 인터페이스 이름 (interface 옵션)
+		Markers     bool   // 테이블별 마커 인터페이스 생성 (markers 옵션)
+		Generic     bool   // 제네릭 Table 컨테이너 생성 (generic 옵션)
+		CacheSize   int    // 읽기 캐시의 조회별 기본 행 수 (cache 옵션, 0이면 생성하지 않음)
+		Hooks       bool   // 로더가 QueryHook에 조회를 알림 (hooks, otel 옵션)
+		HasTime     bool
+		HasJSON     bool
+		HasGeo      bool
+		HasCurve    bool
+		HasFormula  bool
+		Tables      []sqlxTable
+	}{
+		PackageName: opts.PackageName,
+		Interface:   e.GetStringOption(opts, OptSQLXInterface, ""),
+		Markers:     e.GetBoolOption(opts, OptSQLXMarkers, false),
+		Generic:     e.GetBoolOption(opts, OptSQLXGeneric, false),
+		CacheSize:   e.GetIntOption(opts, OptSQLXCache, 0),
+		Hooks:       e.GetBoolOption(opts, OptSQLXHooks, false) || e.GetBoolOption(opts, OptSQLXOTel, false),
+	}
+
+	narrowInts := e.GetBoolOption(opts, OptNarrowInts, false)
+	queryTables := buildQueryTables(tables, narrowInts, SQLiteDialect)
+	if narrowInts {
+		logNarrowing(tables, narrowIntTypes(tables, true))
+	}
+	columns := make(map[string][]Column, len(tables))
+	tagRules := make(map[string][]StructTagRule, len(tables))
+	for _, table := range tables {
+		columns[table.Name] = table.Columns
+		tagRules[table.Name] = tableStructTags(table)
+	}
+	for _, qt := range queryTables {
+		t := sqlxTable{queryTable: qt}
+		for i, qc := range qt.Columns {
+			field := sqlxField{Name: qc.Name, GoType: sqlxGoType(qc), Column: qc.Name}
+			switch qc.Kind {
+			case "datetime":
+				data.HasTime = true
+			case "json":
+				data.HasGeo = data.HasGeo || field.GoType == "Point"
+				data.HasJSON = true
+			case "curve":
+				data.HasCurve, data.HasJSON = true, true
+			}
+			data.HasFormula = data.HasFormula || field.GoType == "Formula"
+			field.Deprecated, _ = DeprecationMessage(columns[qt.Name][i])
+			field.Tags = strings.Join(goStructTags(tagRules[qt.Name], columns[qt.Name][i], true), " ")
+			t.Fields = append(t.Fields, field)
+		}
+		for _, lookup := range qt.Lookups {
+			if lookup.Method == "Get" {
+				for _, p := range lookup.Params {
+					t.KeyFields = append(t.KeyFields, p.Name)
+				}
+			}
+			if lookup.Method == "Get" && lookup.Unique && len(lookup.Params) == 1 && sqlxGoType(lookup.Params[0]) == lookup.Params[0].GoType {
+				t.KeyField, t.KeyType = lookup.Params[0].Name, lookup.Params[0].GoType
+			}
+		}
+		data.Tables = append(data.Tables, t)
+	}
+
+	if err := writeSQLXFile(opts, "sqlx/models.go.tmpl", "models.go", data); err != nil {
+		return err
+	}
+	if data.HasCurve {
+		if err := writeCurveSource(opts.OutputDir, "go", opts.PackageName); err != nil {
+			return err
+		}
+	}
+	if data.HasFormula {
+		if err := writeFormulaSource(opts.OutputDir, opts.PackageName); err != nil {
+			return err
+		}
+	}
+	if data.Generic {
+		// 제네릭 컨테이너 타입이 테이블과 이름이 같거나 로더가 다른 테이블의 함수와 겹치면 컴파일되지 않음
+		for _, t := range data.Tables {
+			if t.Name == "Table" || t.Name == "NoKey" {
+				return fmt.Errorf("table %s conflicts with the generic container type of the same name", t.Name)
+			}
+		}
+		if err := writeSQLXFile(opts, "sqlx/table.go.tmpl", "table.go", data); err != nil {
+			return err
+		}
+	}
+	if data.Hooks {
+		if err := writeSQLXFile(opts, "sqlx/hooks.go.tmpl", "hooks.go", data); err != nil {
+			return err
+		}
+	}
+	if e.GetBoolOption(opts, OptSQLXOTel, false) {
+		if err := writeSQLXFile(opts, "sqlx/otel.go.tmpl", "otel.go", data); err != nil {
+			return err
+		}
+	}
+	if data.CacheSize > 0 {
+		for _, t := range data.Tables {
+			if t.Name == "Cache" {
+				return fmt.Errorf("table %s conflicts with the generated Cache type of the same name", t.Name)
+			}
+		}
+		if err := writeSQLXFile(opts, "sqlx/cache.go.tmpl", "cache.go", data); err != nil {
+			return err
+		}
+	}
+	if e.GetBoolOption(opts, OptSQLXReload, false) {
+		if err := writeSQLXFile(opts, "sqlx/dataset.go.tmpl", "dataset.go", data); err != nil {
+			return err
+		}
+	}
+	if data.Interface != "" || data.Markers {
+		// 인터페이스 메서드와 마커 인터페이스가 필드나 테이블과 이름이 같으면 컴파일되지 않음
+		if data.Interface != "" && !indexNamePattern.MatchString(data.Interface) {
+			return fmt.Errorf("invalid %s name %q", OptSQLXInterface, data.Interface)
+		}
+		names := make(map[string]bool)
+		for _, t := range data.Tables {
+			names[t.Name] = true
+		}
+		if names[data.Interface] {
+			return fmt.Errorf("interface %s conflicts with the table of the same name", data.Interface)
+		}
+		for _, t := range data.Tables {
+			if data.Markers && names[t.Name+"Record"] {
+				return fmt.Errorf("table %s: marker interface %sRecord conflicts with the table of the same name", t.Name, t.Name)
+			}
+			if data.Interface == "" {
+				continue
+			}
+			if len(t.KeyFields) == 0 {
+				return fmt.Errorf("table %s has no key for %s.GetIndex", t.Name, data.Interface)
+			}
+			for _, field := range t.Fields {
+				if field.Name == "TableName" || field.Name == "GetIndex" {
+					return fmt.Errorf("table %s: column %s conflicts with the %s method of the same name", t.Name, field.Name, data.Interface)
+				}
+			}
+		}
+		if err := writeSQLXFile(opts, "sqlx/interfaces.go.tmpl", "interfaces.go", data); err != nil {
+			return err
+		}
+	}
+	if e.GetBoolOption(opts, OptSQLXColumnar, false) {
+		// 컬럼 슬라이스 필드가 접근자 메서드와 이름이 같으면 컴파일되지 않음
+		for _, t := range data.Tables {
+			for _, field := range t.Fields {
+				if field.Name == "Len" || field.Name == "Row" || field.Name == "IndexOf" {
+					return fmt.Errorf("table %s: column %s conflicts with the columnar accessor of the same name", t.Name, field.Name)
+				}
+			}
+		}
+		return writeSQLXFile(opts, "sqlx/columns.go.tmpl", "columns.go", data)
+	}
+	return nil
+}
+
+// writeSQLXFile은 템플릿을 실행하고 gofmt한 결과를 출력 디렉토리에 씁니다.
+func writeSQLXFile(opts Options, tmplName, fileName string, data interface{}) error {
+	tmplText, err := loadTemplate(opts.TemplateDir, tmplName)
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("sqlx").Funcs(template.FuncMap{"lower": lowerFirst}).Parse(tmplText)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated %s: %v", fileName, err)
+	}
+	return os.WriteFile(filepath.Join(opts.OutputDir, fileName), src, 0644)
+}
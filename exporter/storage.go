@@ -0,0 +1,110 @@
+// exporter/storage.go
+package exporter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// datetime 컬럼을 DB에 저장하는 형식 (#Config의 datetimeStorage 설정)
+const (
+	DateTimeRFC3339 = "rfc3339" // UTC RFC 3339 텍스트 (2024-05-01T09:00:00Z, 기본값)
+	DateTimeEpoch   = "epoch"   // Unix 초 (INTEGER)
+)
+
+var dateTimeStorages = []string{DateTimeRFC3339, DateTimeEpoch}
+
+// minEpoch는 0001-01-01T00:00:00Z의 Unix 초입니다. epoch 컬럼의 NULL을 가장 이른 시각으로 비교할 때 씁니다.
+const minEpoch = -62135596800
+
+// tableDateTimeStorage는 테이블의 datetime 저장 형식입니다. 잘못된 설정은 validateStorage에서 보고하므로 기본값으로 봅니다.
+func tableDateTimeStorage(table Table) string {
+	if storage := strings.ToLower(strings.TrimSpace(table.DateTimeStorage)); containsString(dateTimeStorages, storage) {
+		return storage
+	}
+	return DateTimeRFC3339
+}
+
+// validateStorage는 datetimeStorage 설정을 확인합니다.
+func validateStorage(table Table) error {
+	storage := strings.ToLower(strings.TrimSpace(table.DateTimeStorage))
+	if storage != "" && !containsString(dateTimeStorages, storage) {
+		return fmt.Errorf("table %s: unknown %s %q (expected %s)", table.Name, ConfigKeyDateTimeStorage, table.DateTimeStorage, strings.Join(dateTimeStorages, ", "))
+	}
+	return nil
+}
+
+// encodeBool은 bool 컬럼 값을 DB에 저장할 0 또는 1로 바꿉니다.
+func encodeBool(value interface{}) (int64, error) {
+	var b bool
+	switch v := value.(type) {
+	case bool:
+		b = v
+	case string:
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return 0, fmt.Errorf("not a boolean value %q", v)
+		}
+		b = parsed
+	case int:
+		b = v != 0
+	case int32:
+		b = v != 0
+	case int64:
+		b = v != 0
+	default:
+		return 0, fmt.Errorf("not a boolean value %v (%T)", value, value)
+	}
+	if b {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// encodeDateTime은 datetime 컬럼 값을 저장 형식에 맞게 UTC RFC 3339 텍스트나 Unix 초로 바꿉니다.
+func encodeDateTime(value interface{}, storage string) (interface{}, error) {
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			if parsed, err = time.Parse("2006-01-02 15:04:05", v); err != nil {
+				return nil, fmt.Errorf("not a datetime value %q", v)
+			}
+		}
+		t = parsed
+	default:
+		return nil, fmt.Errorf("not a datetime value %v (%T)", value, value)
+	}
+	if storage == DateTimeEpoch {
+		return t.Unix(), nil
+	}
+	return t.UTC().Format(time.RFC3339Nano), nil
+}
+
+// sqliteDateTime은 저장된 datetime 값 expr을 SQLite datetime() 식으로 읽습니다.
+func sqliteDateTime(expr, storage string) string {
+	if storage == DateTimeEpoch {
+		return fmt.Sprintf("datetime(%s, 'unixepoch')", expr)
+	}
+	return fmt.Sprintf("datetime(%s)", expr)
+}
+
+// hasDateTimeColumn은 테이블에 (배열이 아닌) datetime 컬럼이 있는지 반환합니다.
+func hasDateTimeColumn(table Table) bool {
+	for _, col := range table.Columns {
+		if !col.Type.IsArray && col.Type.Type == DateTimeType.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// isEpochDateTime은 컬럼이 Unix 초로 저장되는 datetime 컬럼인지 반환합니다.
+func isEpochDateTime(col Column, storage string) bool {
+	return storage == DateTimeEpoch && !col.Type.IsArray && col.Type.Type == DateTimeType.Type
+}
@@ -0,0 +1,131 @@
+// exporter/structtag.go
+package exporter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// 구조체 태그 값의 이름 규칙
+const (
+	TagNamingColumn = "column" // 컬럼 이름 그대로 (ItemId)
+	TagNamingCamel  = "camel"  // itemId
+	TagNamingPascal = "pascal" // ItemId
+	TagNamingSnake  = "snake"  // item_id
+	TagNamingKebab  = "kebab"  // item-id
+	TagNamingLower  = "lower"  // itemid
+)
+
+var tagNamings = []string{TagNamingColumn, TagNamingCamel, TagNamingPascal, TagNamingSnake, TagNamingKebab, TagNamingLower}
+
+var structTagKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// exporter가 직접 만드는 구조체 태그 (설정으로 덮어쓸 수 없음)
+var reservedStructTags = []string{"gorm", "db", "validate"}
+
+// StructTagRule은 #Config의 structTags 설정 하나입니다. json:camel,omitempty 이면 json:"itemId,omitempty" 태그를 붙입니다.
+type StructTagRule struct {
+	Key     string   // 태그 키 (json, yaml, bson, mapstructure 등)
+	Naming  string   // 태그 값의 이름 규칙
+	Options []string // 이름 뒤에 붙는 옵션 (omitempty 등)
+}
+
+// ParseStructTags는 "json:camel,omitempty yaml:snake" 형식의 structTags 설정을 파싱합니다. 규칙은 공백으로 구분합니다.
+func ParseStructTags(value string) ([]StructTagRule, error) {
+	var rules []StructTagRule
+	seen := make(map[string]bool)
+	for _, field := range strings.Fields(value) {
+		key, rest, ok := strings.Cut(field, ":")
+		if !ok || !structTagKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("invalid struct tag %q (expected key:naming[,option...], e.g. json:camel,omitempty)", field)
+		}
+		if containsString(reservedStructTags, key) {
+			return nil, fmt.Errorf("struct tag %s is generated by the exporters and cannot be configured", key)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("struct tag %s is configured more than once", key)
+		}
+		seen[key] = true
+
+		parts := strings.Split(rest, ",")
+		rule := StructTagRule{Key: key, Naming: strings.ToLower(parts[0]), Options: parts[1:]}
+		if !containsString(tagNamings, rule.Naming) {
+			return nil, fmt.Errorf("unknown naming %q of struct tag %s (expected %s)", parts[0], key, strings.Join(tagNamings, ", "))
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// tableStructTags는 테이블의 structTags 설정입니다. 잘못된 설정은 validateStructTags에서 보고하므로 여기서는 무시합니다.
+func tableStructTags(table Table) []StructTagRule {
+	rules, _ := ParseStructTags(table.StructTags)
+	return rules
+}
+
+// validateStructTags는 structTags 설정을 확인합니다.
+func validateStructTags(table Table) error {
+	if _, err := ParseStructTags(table.StructTags); err != nil {
+		return fmt.Errorf("table %s: %v", table.Name, err)
+	}
+	return nil
+}
+
+// tagName은 컬럼 이름을 이름 규칙에 맞게 바꿉니다.
+func tagName(naming, name string) string {
+	var words []string
+	for _, word := range strings.Split(toSnakeCase(name), "_") {
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	title := func(s string) string {
+		r := []rune(s)
+		r[0] = unicode.ToUpper(r[0])
+		return string(r)
+	}
+
+	switch naming {
+	case TagNamingCamel, TagNamingPascal:
+		var b strings.Builder
+		for i, word := range words {
+			if i == 0 && naming == TagNamingCamel {
+				b.WriteString(word)
+			} else {
+				b.WriteString(title(word))
+			}
+		}
+		return b.String()
+	case TagNamingSnake:
+		return strings.Join(words, "_")
+	case TagNamingKebab:
+		return strings.Join(words, "-")
+	case TagNamingLower:
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// goStructTags는 컬럼 필드에 붙일 설정된 구조체 태그들입니다. writeonly 컬럼의 json 태그는 "-"입니다.
+// hideWriteOnly이면 json 규칙이 없어도 writeonly 컬럼에 json:"-"를 붙입니다.
+func goStructTags(rules []StructTagRule, col Column, hideWriteOnly bool) []string {
+	writeOnly := IsWriteOnly(col)
+	var tags []string
+	hasJSON := false
+	for _, rule := range rules {
+		value := strings.Join(append([]string{tagName(rule.Naming, col.Name)}, rule.Options...), ",")
+		if rule.Key == "json" {
+			hasJSON = true
+			if writeOnly {
+				value = "-"
+			}
+		}
+		tags = append(tags, fmt.Sprintf("%s:%q", rule.Key, value))
+	}
+	if writeOnly && hideWriteOnly && !hasJSON {
+		tags = append(tags, `json:"-"`)
+	}
+	return tags
+}
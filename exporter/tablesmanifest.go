@@ -0,0 +1,156 @@
+// exporter/tablesmanifest.go
+package exporter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// TablesManifestFileName은 데이터 모델 매니페스트 파일 이름입니다.
+const TablesManifestFileName = "tables.json"
+
+// TablesManifest는 모든 테이블의 데이터 모델을 기술합니다.
+// 에디터나 관리 도구가 Excel을 다시 파싱하지 않고 테이블 구조를 알 수 있도록 출력 디렉토리에 함께 생성됩니다.
+type TablesManifest struct {
+	Tables []TableManifest `json:"tables"`
+}
+
+// TableManifest는 테이블 하나의 정의입니다. 컬럼은 시트에 나오는 순서대로 나열됩니다.
+type TableManifest struct {
+	Name        string             `json:"name"`
+	Sheet       string             `json:"sheet"`
+	Kind        string             `json:"kind"` // table, settings, matrix
+	Group       string             `json:"group,omitempty"`
+	DataVersion string             `json:"dataVersion,omitempty"`
+	Layout      string             `json:"layout,omitempty"`
+	Index       string             `json:"index"` // 행 키 컬럼
+	Rows        int                `json:"rows"`
+	Hash        string             `json:"hash,omitempty"` // 컬럼 정의와 행 내용의 해시 (LockHash)
+	Columns     []ColumnManifest   `json:"columns"`
+	Relations   []RelationManifest `json:"relations,omitempty"`
+	Aliases     []string           `json:"aliases,omitempty"`
+	Views       []string           `json:"views,omitempty"`
+}
+
+// ColumnManifest는 컬럼 하나의 정의입니다.
+type ColumnManifest struct {
+	Name       string        `json:"name"`
+	Order      int           `json:"order"` // 테이블 안에서의 위치 (0부터)
+	Type       string        `json:"type"`  // 시트의 타입 표기 (int, array<string>, ref<Item> 등)
+	SQLType    string        `json:"sqlType"`
+	Array      bool          `json:"array,omitempty"`
+	Ref        string        `json:"ref,omitempty"` // ref<Table> 타입이 참조하는 테이블
+	Nullable   bool          `json:"nullable"`
+	Unique     bool          `json:"unique,omitempty"`
+	Deprecated string        `json:"deprecated,omitempty"` // 폐기 예정이면 메시지
+	Optional   string        `json:"optional,omitempty"`   // 속한 optional 컬럼 그룹
+	Min        *float64      `json:"min,omitempty"`
+	Max        *float64      `json:"max,omitempty"`
+	Compress   string        `json:"compress,omitempty"` // 컬럼 인코딩 (delta, rle), binary exporter가 적용
+	Tags       []TagManifest `json:"tags,omitempty"`
+}
+
+// TagManifest는 컬럼에 붙은 태그 하나입니다.
+type TagManifest struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+}
+
+// RelationManifest는 테이블에서 시작하는 관계 하나입니다.
+type RelationManifest struct {
+	Target       string `json:"target"`
+	Type         string `json:"type"`
+	ForeignKey   string `json:"foreignKey"`
+	ReferenceKey string `json:"referenceKey"`
+}
+
+// BuildTablesManifest는 테이블 정의로부터 매니페스트를 생성합니다. 테이블은 주어진 순서를 유지합니다.
+func BuildTablesManifest(tables []Table) TablesManifest {
+	manifest := TablesManifest{Tables: make([]TableManifest, 0, len(tables))}
+	for _, table := range tables {
+		tm := TableManifest{
+			Name:        table.Name,
+			Sheet:       table.SheetName,
+			Kind:        "table",
+			Group:       table.Group,
+			DataVersion: table.DataVersion,
+			Layout:      string(table.Layout),
+			Aliases:     table.Aliases,
+			Rows:        len(table.Rows),
+			Columns:     make([]ColumnManifest, 0, len(table.Columns)),
+		}
+		tm.Hash, _ = LockHash(table, "")
+		switch {
+		case table.IsSettings:
+			tm.Kind = "settings"
+		case table.IsMatrix:
+			tm.Kind = "matrix"
+		}
+
+		index := IndexColumn(table)
+		if index < len(table.Columns) {
+			tm.Index = table.Columns[index].Name
+		}
+
+		for i, col := range table.Columns {
+			tm.Columns = append(tm.Columns, columnManifest(col, i, i == index))
+		}
+		for _, rel := range table.Relations {
+			tm.Relations = append(tm.Relations, RelationManifest{
+				Target:       rel.TargetTable,
+				Type:         rel.RelationType,
+				ForeignKey:   rel.ForeignKey,
+				ReferenceKey: rel.ReferenceKey,
+			})
+		}
+		for _, view := range table.Views {
+			tm.Views = append(tm.Views, view.Name)
+		}
+
+		manifest.Tables = append(manifest.Tables, tm)
+	}
+	return manifest
+}
+
+func columnManifest(col Column, order int, isIndex bool) ColumnManifest {
+	cm := ColumnManifest{
+		Name:     col.Name,
+		Order:    order,
+		Type:     ColumnTypeName(col.Type),
+		SQLType:  col.Type.SQLTypeString(),
+		Array:    col.Type.IsArray,
+		Ref:      elementType(col.Type).RefTable,
+		Nullable: !isIndex && !HasTag(col.Tags, TagNotNull),
+		Unique:   col.IsUnique,
+		Optional: col.Optional,
+	}
+	cm.Deprecated, _ = DeprecationMessage(col)
+	if value, ok := GetTagValue(col.Tags, TagCompress); ok {
+		cm.Compress = normalizeColumnEncoding(value)
+	}
+	if b, err := ColumnBounds(col); err == nil {
+		if b.HasMin {
+			cm.Min = &b.Min
+		}
+		if b.HasMax {
+			cm.Max = &b.Max
+		}
+	}
+	for _, tag := range col.Tags {
+		cm.Tags = append(cm.Tags, TagManifest{Name: tagInfoMap[tag.Tag].Name, Value: tag.Value})
+	}
+	return cm
+}
+
+// WriteTablesManifest는 테이블들의 매니페스트를 dir/tables.json으로 저장합니다.
+func WriteTablesManifest(dir string, tables []Table) error {
+	data, err := json.MarshalIndent(BuildTablesManifest(tables), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, TablesManifestFileName), append(data, '\n'), 0644)
+}
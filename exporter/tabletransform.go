@@ -0,0 +1,393 @@
+// exporter/tabletransform.go
+package exporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TableTransformFunc는 테이블 하나를 다시 쓰는 변환입니다. args는 #Config 값에서 변환 이름을 뺀 나머지입니다.
+// 반환한 테이블들이 입력 테이블을 대신합니다. (split처럼 여러 개를 반환하거나, 비워서 테이블을 제거할 수 있음)
+type TableTransformFunc func(table Table, args string) ([]Table, error)
+
+var (
+	tableTransformsMu sync.RWMutex
+	tableTransforms   = map[string]TableTransformFunc{
+		"rename":       renameTableTransform,
+		"renamecolumn": renameColumnTransform,
+		"derive":       deriveColumnTransform,
+		"drop":         dropColumnsTransform,
+		"filter":       filterRowsTransform,
+		"split":        splitTableTransform,
+	}
+)
+
+// RegisterTableTransform은 #Config의 transform 항목에서 사용할 변환을 등록합니다.
+// excelite를 라이브러리로 사용하는 프로그램이 내장 변환(rename, renamecolumn, derive, drop, filter, split) 외의 변환을 추가할 때 사용합니다.
+// 이름은 대소문자를 구분하지 않으며, 같은 이름으로 등록하면 기존 변환을 대체합니다.
+func RegisterTableTransform(name string, fn TableTransformFunc) {
+	tableTransformsMu.Lock()
+	defer tableTransformsMu.Unlock()
+	tableTransforms[strings.ToLower(name)] = fn
+}
+
+// TableTransformNames는 등록된 변환 이름을 정렬하여 반환합니다.
+func TableTransformNames() []string {
+	tableTransformsMu.RLock()
+	defer tableTransformsMu.RUnlock()
+	names := make([]string, 0, len(tableTransforms))
+	for name := range tableTransforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupTableTransform(name string) (TableTransformFunc, bool) {
+	tableTransformsMu.RLock()
+	defer tableTransformsMu.RUnlock()
+	fn, ok := tableTransforms[strings.ToLower(name)]
+	return fn, ok
+}
+
+// ApplyTableTransforms는 테이블마다 #Config의 transform 항목을 선언된 순서대로 적용합니다.
+// 각 항목은 "<변환> <인자>" 형식입니다.
+//
+//	rename Weapons                     테이블 이름 변경 (다른 테이블의 ref<>, 관계, 뷰도 따라감)
+//	renamecolumn Atk Attack            컬럼 이름 변경
+//	derive Power:int = attack * 2      다른 컬럼으로 계산한 컬럼 추가 (행 필터와 같은 식 문법)
+//	drop Memo, Note                    컬럼 제거
+//	filter rarity <= 3                 조건이 참인 행만 남김
+//	split Type                         컬럼 값마다 <테이블><값> 테이블로 나눔
+//
+// exporter는 변환된 테이블만 보므로, 데이터 모양을 바꾸는 일을 exporter마다 구현하지 않아도 됩니다.
+func ApplyTableTransforms(tables []Table) ([]Table, error) {
+	result := make([]Table, 0, len(tables))
+	renames := make(map[string]string)
+	var errs []string
+
+	for _, table := range tables {
+		current := []Table{table}
+		for _, source := range table.Transforms {
+			name, args, _ := strings.Cut(strings.TrimSpace(source), " ")
+			fn, ok := lookupTableTransform(name)
+			if !ok {
+				errs = append(errs, fmt.Sprintf("table %s: unknown transform %q (available: %s)", table.Name, name, strings.Join(TableTransformNames(), ", ")))
+				current = nil
+				break
+			}
+
+			var next []Table
+			for _, t := range current {
+				out, err := fn(t, strings.TrimSpace(args))
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("table %s: transform %q: %v", t.Name, source, err))
+					continue
+				}
+				next = append(next, out...)
+			}
+			current = next
+		}
+
+		if len(current) == 1 && current[0].Name != table.Name {
+			renames[table.Name] = current[0].Name
+		}
+		for i := range current {
+			current[i].Transforms = nil
+		}
+		result = append(result, current...)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("table transforms failed with %d error(s):\n  %s", len(errs), strings.Join(errs, "\n  "))
+	}
+
+	seen := make(map[string]bool, len(result))
+	for _, table := range result {
+		if seen[table.Name] {
+			return nil, fmt.Errorf("table transforms produced duplicate table %s", table.Name)
+		}
+		seen[table.Name] = true
+	}
+
+	if len(renames) > 0 {
+		for i := range result {
+			renameTableReferences(&result[i], renames)
+		}
+	}
+
+	// split 등으로 없어진 테이블을 남은 테이블이 참조하면 생성 결과의 참조가 끊어짐
+	for _, table := range tables {
+		if seen[table.Name] || renames[table.Name] != "" {
+			continue
+		}
+		for _, other := range result {
+			for _, col := range other.Columns {
+				if elementType(col.Type).RefTable == table.Name {
+					return nil, fmt.Errorf("%s.%s refers to table %s which is removed by a transform", other.Name, col.Name, table.Name)
+				}
+			}
+			for _, rel := range other.Relations {
+				if rel.TargetTable == table.Name {
+					return nil, fmt.Errorf("relation %s -> %s refers to table %s which is removed by a transform", rel.SourceTable, rel.TargetTable, table.Name)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// renameTableReferences는 이름이 바뀐 테이블을 가리키는 ref<> 타입, 관계, 뷰를 새 이름으로 바꿉니다.
+func renameTableReferences(table *Table, renames map[string]string) {
+	rename := func(name string) string {
+		if renamed, ok := renames[name]; ok {
+			return renamed
+		}
+		return name
+	}
+
+	columns := make([]Column, len(table.Columns))
+	for i, col := range table.Columns {
+		col.Type.RefTable = rename(col.Type.RefTable)
+		if col.Type.BaseType != nil {
+			base := *col.Type.BaseType
+			base.RefTable = rename(base.RefTable)
+			col.Type.BaseType = &base
+		}
+		columns[i] = col
+	}
+	table.Columns = columns
+
+	relations := make([]Relation, len(table.Relations))
+	for i, rel := range table.Relations {
+		rel.SourceTable = rename(rel.SourceTable)
+		rel.TargetTable = rename(rel.TargetTable)
+		relations[i] = rel
+	}
+	table.Relations = relations
+
+	views := make([]View, len(table.Views))
+	for i, view := range table.Views {
+		view.Base = rename(view.Base)
+		joins := make([]string, len(view.Joins))
+		for j, join := range view.Joins {
+			joins[j] = rename(join)
+		}
+		view.Joins = joins
+		views[i] = view
+	}
+	table.Views = views
+}
+
+func renameTableTransform(table Table, args string) ([]Table, error) {
+	name := formatTableName(args)
+	if name == "" || len(strings.Fields(args)) != 1 {
+		return nil, fmt.Errorf("expected: rename <NewTable>")
+	}
+	table.Name = name
+	return []Table{table}, nil
+}
+
+func renameColumnTransform(table Table, args string) ([]Table, error) {
+	fields := strings.Fields(args)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("expected: renamecolumn <Column> <NewColumn>")
+	}
+	idx := columnIndex(table, fields[0])
+	if idx == -1 {
+		return nil, fmt.Errorf("unknown column %s", fields[0])
+	}
+	newName := ParseColumnName(fields[1])
+	if other := columnIndex(table, newName); other != -1 && other != idx {
+		return nil, fmt.Errorf("column %s already exists", newName)
+	}
+
+	oldName := table.Columns[idx].Name
+	table.Columns = append([]Column(nil), table.Columns...)
+	table.Columns[idx].Name = newName
+	table.Source = table.Source.renameColumn(oldName, newName)
+
+	relations := make([]Relation, len(table.Relations))
+	for i, rel := range table.Relations {
+		if rel.ForeignKey == oldName {
+			rel.ForeignKey = newName
+		}
+		relations[i] = rel
+	}
+	table.Relations = relations
+	return []Table{table}, nil
+}
+
+// deriveColumnTransform은 "Name:type = 식"으로 선언된 컬럼을 추가합니다. 식의 결과는 컬럼 타입의 셀 값 규칙으로 변환됩니다.
+func deriveColumnTransform(table Table, args string) ([]Table, error) {
+	decl, source, ok := strings.Cut(args, "=")
+	if !ok {
+		return nil, fmt.Errorf("expected: derive <Column>:<type> = <expression>")
+	}
+	name, typeStr, ok := strings.Cut(strings.TrimSpace(decl), ":")
+	if !ok {
+		typeStr = "string"
+	}
+	if !KnownColumnType(typeStr) {
+		return nil, fmt.Errorf("unknown type %q", strings.TrimSpace(typeStr))
+	}
+	col := Column{Name: ParseColumnName(name), Type: ParseColumnType(typeStr)}
+	if col.Name == "" {
+		return nil, fmt.Errorf("expected: derive <Column>:<type> = <expression>")
+	}
+	if columnIndex(table, col.Name) != -1 {
+		return nil, fmt.Errorf("column %s already exists", col.Name)
+	}
+
+	expr, err := compileRowFilter(table, strings.TrimSpace(source))
+	if err != nil {
+		return nil, err
+	}
+	parser := CreateParser(col)
+
+	rows := make([][]interface{}, len(table.Rows))
+	for i, row := range table.Rows {
+		result, err := expr.root.eval(rowFilterValues(table, row))
+		if err != nil {
+			return nil, fmt.Errorf("row %s: %v", RowKey(table, row), err)
+		}
+
+		var value interface{}
+		if text := exprString(result); text != "" {
+			parsed, err := parser.Parse(text)
+			if err != nil {
+				return nil, fmt.Errorf("row %s: %v", RowKey(table, row), err)
+			}
+			value = parsed.Interface()
+		}
+		derived := make([]interface{}, len(table.Columns)+1)
+		copy(derived, row)
+		derived[len(table.Columns)] = value
+		rows[i] = derived
+	}
+
+	table.Columns = append(append([]Column(nil), table.Columns...), col)
+	table.Rows = rows
+	return []Table{table}, nil
+}
+
+func dropColumnsTransform(table Table, args string) ([]Table, error) {
+	drop := make(map[int]bool)
+	for _, name := range strings.Split(args, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		idx := columnIndex(table, name)
+		if idx == -1 {
+			return nil, fmt.Errorf("unknown column %s", name)
+		}
+		if idx == IndexColumn(table) {
+			return nil, fmt.Errorf("cannot drop index column %s", table.Columns[idx].Name)
+		}
+		drop[idx] = true
+	}
+	if len(drop) == 0 {
+		return nil, fmt.Errorf("expected: drop <Column>[, <Column>...]")
+	}
+
+	var columns []Column
+	for i, col := range table.Columns {
+		if !drop[i] {
+			columns = append(columns, col)
+		}
+	}
+	rows := make([][]interface{}, len(table.Rows))
+	for r, row := range table.Rows {
+		kept := make([]interface{}, 0, len(columns))
+		for i := range table.Columns {
+			if drop[i] {
+				continue
+			}
+			if i < len(row) {
+				kept = append(kept, row[i])
+			} else {
+				kept = append(kept, nil)
+			}
+		}
+		rows[r] = kept
+	}
+
+	var relations []Relation
+	for _, rel := range table.Relations {
+		if idx := columnIndex(table, rel.ForeignKey); idx != -1 && drop[idx] {
+			continue
+		}
+		relations = append(relations, rel)
+	}
+
+	table.Columns = columns
+	table.Rows = rows
+	table.Relations = relations
+	return []Table{table}, nil
+}
+
+func filterRowsTransform(table Table, args string) ([]Table, error) {
+	cond, err := compileRowFilter(table, args)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([][]interface{}, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		ok, err := cond.match(table, row)
+		if err != nil {
+			return nil, fmt.Errorf("row %s: %v", RowKey(table, row), err)
+		}
+		if ok {
+			rows = append(rows, row)
+		}
+	}
+	table.Rows = rows
+	return []Table{table}, nil
+}
+
+// splitTableTransform은 컬럼 값마다 <테이블><값> 테이블을 만듭니다. 값이 빈 행은 원래 이름의 테이블에 남습니다.
+// 나뉜 테이블은 원래 테이블의 컬럼과 설정을 그대로 가지며, 값이 처음 나온 순서대로 반환됩니다.
+func splitTableTransform(table Table, args string) ([]Table, error) {
+	if args == "" || len(strings.Fields(args)) != 1 {
+		return nil, fmt.Errorf("expected: split <Column>")
+	}
+	idx := columnIndex(table, args)
+	if idx == -1 {
+		return nil, fmt.Errorf("unknown column %s", args)
+	}
+
+	var order []string
+	parts := make(map[string]*Table)
+	for _, row := range table.Rows {
+		var value string
+		if idx < len(row) && row[idx] != nil {
+			value = fmt.Sprintf("%v", row[idx])
+		}
+		name := table.Name
+		if value != "" {
+			name = table.Name + formatTableName(value)
+		}
+		part, ok := parts[name]
+		if !ok {
+			t := table
+			t.Name = name
+			t.Rows = nil
+			t.Relations = make([]Relation, len(table.Relations))
+			for i, rel := range table.Relations {
+				rel.SourceTable = name
+				t.Relations[i] = rel
+			}
+			part = &t
+			parts[name] = part
+			order = append(order, name)
+		}
+		part.Rows = append(part.Rows, row)
+	}
+
+	result := make([]Table, len(order))
+	for i, name := range order {
+		result[i] = *parts[name]
+	}
+	return result, nil
+}
@@ -23,6 +23,29 @@ const (
 	TagReadOnly          // 읽기 전용
 	TagWriteOnly         // 쓰기 전용
 	TagValidate          // 검증 규칙
+	TagValidFrom         // 행 적용 시작 시각
+	TagValidTo           // 행 적용 종료 시각
+	TagVariant           // 실험(A/B) 변형 구분 컬럼
+	TagDeprecated        // 폐기 예정 컬럼
+	TagMin               // 최솟값 (문자열은 최소 길이)
+	TagMax               // 최댓값 (문자열은 최대 길이)
+	TagTransform         // 셀 값 변환식
+	TagParent            // 같은 테이블의 부모 행을 가리키는 컬럼 (트리 구조)
+	TagGroupBy           // JSON keyed 출력에서 이 컬럼 값별로 행 키를 묶은 보조 맵 생성
+	TagRequiredIf        // 다른 컬럼이 주어진 값일 때만 필수
+	TagDelim             // 배열 셀의 원소 구분자
+	TagEnum              // #Enum 시트의 enum 코드 컬럼
+	TagLocalized         // #Locale 시트의 로컬라이제이션 키 컬럼
+	TagIntern            // 값을 공유 문자열 테이블의 id로 저장 (SQLite)
+	TagNarrow            // 생성 코드에서 쓸 정수 타입 (uint8, int16 등)
+	TagCompress          // 바이너리 exporter의 컬럼 인코딩 (delta, rle)
+	TagCollate           // 텍스트 비교 규칙 (SQLite의 nocase, rtrim 또는 서버 DB의 collation 이름)
+	TagNormalize         // 읽을 때 텍스트 정규화 (lower, upper, space)
+	TagFile              // 셀의 파일 경로가 가리키는 파일 내용을 blob으로 저장
+	TagAsset             // 셀의 파일 경로가 가리키는 파일을 산출물 assets 폴더에 복사하고 해시 이름을 저장
+	TagProbability       // 그룹별 합계가 1(또는 100)이어야 하는 확률 컬럼
+	TagPity              // 가챠 아이템의 천장 (이 뽑기 횟수 안에 반드시 나옴)
+	TagCost              // 가챠 풀의 1회 뽑기 비용 또는 재화 소모처의 비용 (값은 재화 이름)
 )
 
 // TagInfo contains metadata about a tag
@@ -63,7 +86,8 @@ var tagInfoMap = map[Tag]TagInfo{
 	},
 	TagIndex: {
 		Name:        "index",
-		Description: "Index creation",
+		HasValue:    true,
+		Description: "Index creation; columns tagged with the same name (index:idx_type_rarity) form one composite index in column order",
 		Framework: map[string]string{
 			string(FrameworkGorm):       "index",
 			string(FrameworkTypeORM):    "@Index()",
@@ -114,6 +138,148 @@ var tagInfoMap = map[Tag]TagInfo{
 			string(FrameworkSQLAlchemy): "validate=%s",
 		},
 	},
+	TagReadOnly: {
+		Name:        "readonly",
+		Description: "Column is set when the row is created and never updated by generated repository/ORM code",
+		Framework: map[string]string{
+			string(FrameworkGorm):    "<-:create",
+			string(FrameworkTypeORM): "@Column({ update: false })",
+		},
+	},
+	TagWriteOnly: {
+		Name:        "writeonly",
+		Description: "Column is stored but left out of serialized output (JSON tags, admin pages, output schemas) of generated code",
+		Framework: map[string]string{
+			string(FrameworkGorm):    "->:false;<-",
+			string(FrameworkTypeORM): "@Column({ select: false })",
+		},
+	},
+	TagValidFrom: {
+		Name:        "validfrom",
+		Description: "Row becomes effective at this time",
+	},
+	TagValidTo: {
+		Name:        "validto",
+		Description: "Row stops being effective at this time",
+	},
+	TagVariant: {
+		Name:        "variant",
+		HasValue:    true,
+		Description: "Experiment variant of the row (value is the experiment group)",
+	},
+	TagDeprecated: {
+		Name:        "deprecated",
+		HasValue:    true,
+		Description: "Column is kept but scheduled for removal (value is an optional message)",
+	},
+	TagMin: {
+		Name:        "min",
+		HasValue:    true,
+		ValueType:   "number",
+		Description: "Minimum value (minimum length for strings)",
+	},
+	TagMax: {
+		Name:        "max",
+		HasValue:    true,
+		ValueType:   "number",
+		Description: "Maximum value (maximum length for strings)",
+	},
+	TagTransform: {
+		Name:        "transform",
+		HasValue:    true,
+		ValueType:   "expression",
+		Description: "Expression applied to every cell before type conversion (e.g. value*1000)",
+	},
+	TagParent: {
+		Name:        "parent",
+		Description: "Refers to the index of the parent row in the same table (tree data)",
+	},
+	TagGroupBy: {
+		Name:        "groupby",
+		Description: "Emit a secondary map from this column's values to row keys (keyed JSON output)",
+	},
+	TagRequiredIf: {
+		Name:        "requiredif",
+		HasValue:    true,
+		ValueType:   "condition",
+		Description: "Column must not be empty when another column has a value (e.g. requiredIf:Type=weapon|armor)",
+	},
+	TagDelim: {
+		Name:        "delim",
+		HasValue:    true,
+		Description: "Delimiter between elements of an array cell (default \",\"; escape with \\)",
+	},
+	TagEnum: {
+		Name:        "enum",
+		HasValue:    true,
+		ValueType:   "string",
+		Description: "Cells are codes of this enum in the #Enum sheet (e.g. enum:ItemKind); shown as labels by the display exporter",
+	},
+	TagLocalized: {
+		Name:        "localized",
+		Description: "Cells are localization keys of the #Locale sheet; shown as text by the display exporter",
+	},
+	TagIntern: {
+		Name:        "intern",
+		Description: "Store repeated string values once and reference them by id: in the shared _strings table (SQLite) or a per-column string table (binary); not supported by the go, ent, admin and nodejs exporters",
+	},
+	TagNarrow: {
+		Name:        "narrow",
+		HasValue:    true,
+		ValueType:   "string",
+		Description: "Integer type of the column in generated query layers and sqlx structs (uint8, int8, uint16, int16, int32, int64); rows that overflow it are errors",
+	},
+	TagCollate: {
+		Name:        "collate",
+		HasValue:    true,
+		ValueType:   "string",
+		Description: "Text collation: nocase, rtrim or binary for SQLite (e.g. collate:nocase), other names are passed to ORM code for Postgres/MySQL",
+		Framework: map[string]string{
+			string(FrameworkTypeORM):    "@Column({ collation: \"%s\" })",
+			string(FrameworkSQLAlchemy): "collation=\"%s\"",
+			string(FrameworkEntity):     "COLLATE %s",
+		},
+	},
+	TagNormalize: {
+		Name:        "normalize",
+		HasValue:    true,
+		ValueType:   "string",
+		Description: "Normalize text cells when reading the sheet: lower, upper or space (collapse whitespace runs), combined with | (e.g. normalize:lower|space)",
+	},
+	TagFile: {
+		Name:        "file",
+		HasValue:    true,
+		ValueType:   "string",
+		Description: "Blob cells hold a file path relative to the workbook (or to the given directory, e.g. file:curves); the file content is embedded as the blob",
+	},
+	TagAsset: {
+		Name:        "asset",
+		HasValue:    true,
+		ValueType:   "string",
+		Description: "String cells hold a file path relative to the workbook (or to the given directory, e.g. asset:icons); the file is copied to the assets output folder under a content-hashed name, which becomes the cell value",
+	},
+	TagProbability: {
+		Name:        "probability",
+		HasValue:    true,
+		ValueType:   "string",
+		Description: "Drop rates and gacha weights: values must sum to 1 within each group of the given column (e.g. probability:Box), or to 100 with percent; normalize rescales each group when reading instead of failing (e.g. probability:Box|percent|normalize)",
+	},
+	TagPity: {
+		Name:        "pity",
+		Description: "Gacha pity: the item is guaranteed by this many pulls if it has not dropped earlier (int column in a table with a probability column)",
+	},
+	TagCost: {
+		Name:        "cost",
+		HasValue:    true,
+		ValueType:   "string",
+		Description: "Price of one pull of each gacha pool, or a currency sink in tables without a probability column; the value is the currency name (e.g. cost:gem)",
+	},
+	TagCompress: {
+		Name:        "compress",
+		HasValue:    true,
+		ValueType:   "string",
+		Description: "Column encoding in the binary exporter (and a hint in tables.json for other binary encoders): delta (increasing integers) or rle (runs of repeated values)",
+	},
 }
 
 // GetFrameworkTag returns the framework-specific tag string
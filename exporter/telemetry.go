@@ -0,0 +1,83 @@
+// exporter/telemetry.go
+package exporter
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName은 excelite가 생성하는 span과 메트릭의 계측 이름입니다.
+// 전역 TracerProvider/MeterProvider가 설정되지 않으면 아무것도 기록되지 않습니다.
+const InstrumentationName = "excelite"
+
+// 단계 이름 상수
+const (
+	StageParse     = "parse"
+	StageValidate  = "validate"
+	StageTransform = "transform"
+	StageExport    = "export"
+	StagePackage   = "package"
+)
+
+var (
+	tracer = otel.Tracer(InstrumentationName)
+	meter  = otel.Meter(InstrumentationName)
+
+	stageDuration, _ = meter.Float64Histogram("excelite.stage.duration",
+		metric.WithDescription("Duration of a generation stage"), metric.WithUnit("s"))
+	stageRows, _ = meter.Int64Counter("excelite.stage.rows",
+		metric.WithDescription("Number of rows processed by a generation stage"))
+	stageRowsPerSecond, _ = meter.Float64Histogram("excelite.stage.rows_per_second",
+		metric.WithDescription("Row throughput of a generation stage"), metric.WithUnit("{row}/s"))
+	stageErrors, _ = meter.Int64Counter("excelite.stage.errors",
+		metric.WithDescription("Number of failed generation stages"))
+)
+
+// Stage는 진행 중인 생성 단계 하나의 span과 메트릭을 기록합니다.
+type Stage struct {
+	span  trace.Span
+	attrs []attribute.KeyValue
+	start time.Time
+}
+
+// StartStage는 생성 단계(parse, validate, export 등)의 span을 시작합니다.
+func StartStage(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, *Stage) {
+	attrs = append([]attribute.KeyValue{attribute.String("excelite.stage", name)}, attrs...)
+	ctx, span := tracer.Start(ctx, "excelite."+name, trace.WithAttributes(attrs...))
+	return ctx, &Stage{span: span, attrs: attrs, start: time.Now()}
+}
+
+// End는 단계를 종료하고 처리한 행 수, 소요 시간, 에러 여부를 기록합니다.
+func (s *Stage) End(ctx context.Context, rows int, err error) {
+	elapsed := time.Since(s.start)
+	set := metric.WithAttributes(s.attrs...)
+
+	stageDuration.Record(ctx, elapsed.Seconds(), set)
+	stageRows.Add(ctx, int64(rows), set)
+	if elapsed > 0 {
+		stageRowsPerSecond.Record(ctx, float64(rows)/elapsed.Seconds(), set)
+	}
+
+	s.span.SetAttributes(attribute.Int("excelite.rows", rows))
+	if err != nil {
+		stageErrors.Add(ctx, 1, set)
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+// CountRows는 테이블들의 전체 데이터 행 수를 반환합니다.
+func CountRows(tables []Table) int {
+	rows := 0
+	for _, table := range tables {
+		rows += len(table.Rows)
+	}
+	return rows
+}
@@ -0,0 +1,73 @@
+// exporter/templates.go
+package exporter
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// 기본 템플릿은 바이너리에 포함되므로 배포된 실행 파일 하나만으로 모든 exporter를 실행할 수 있습니다.
+// Options.TemplateDir에 같은 상대 경로(예: sqlx/models.go.tmpl)의 파일을 두면 그 템플릿만 덮어씁니다.
+//
+//go:embed templates
+var builtinTemplates embed.FS
+
+// loadTemplate은 name 템플릿의 내용을 반환합니다. templateDir에 같은 이름의 파일이 있으면 그 파일을 사용합니다.
+func loadTemplate(templateDir, name string) (string, error) {
+	if templateDir != "" {
+		data, err := os.ReadFile(filepath.Join(templateDir, filepath.FromSlash(name)))
+		if err == nil {
+			return string(data), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to read template %s: %v", name, err)
+		}
+	}
+
+	data, err := builtinTemplates.ReadFile("templates/" + name)
+	if err != nil {
+		return "", fmt.Errorf("unknown template %s", name)
+	}
+	return string(data), nil
+}
+
+// TemplateNames는 기본 템플릿의 이름(templates 디렉토리 기준 상대 경로)을 정렬하여 반환합니다.
+func TemplateNames() []string {
+	var names []string
+	fs.WalkDir(builtinTemplates, "templates", func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			names = append(names, path[len("templates/"):])
+		}
+		return nil
+	})
+	return names
+}
+
+// EmitTemplates는 기본 템플릿을 dir 아래에 씁니다. dir을 TemplateDir로 지정한 뒤 필요한 템플릿만 고쳐 쓸 수 있습니다.
+// 이미 있는 파일은 사용자가 고친 것일 수 있으므로 overwrite가 아니면 건너뜁니다. 쓴 파일의 이름을 반환합니다.
+func EmitTemplates(dir string, overwrite bool) ([]string, error) {
+	var written []string
+	for _, name := range TemplateNames() {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if _, err := os.Stat(path); err == nil && !overwrite {
+			continue
+		}
+
+		data, err := builtinTemplates.ReadFile("templates/" + name)
+		if err != nil {
+			return written, err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return written, fmt.Errorf("failed to create template directory: %v", err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return written, err
+		}
+		written = append(written, name)
+	}
+	return written, nil
+}
@@ -0,0 +1,27 @@
+-- Schema generated by excelite
+
+PRAGMA foreign_keys=ON;
+
+CREATE TABLE IF NOT EXISTS "Item" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "Index" INTEGER UNIQUE,
+  "Name" TEXT,
+  "Price" INTEGER,
+  "Weight" REAL,
+  "Stackable" INTEGER,
+  "Tags" TEXT,
+  "ReleasedAt" DATETIME,
+  "Memo" TEXT);
+
+
+CREATE TABLE IF NOT EXISTS "ShopItem" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "Index" INTEGER UNIQUE,
+  "ItemIndex" INTEGER,
+  "Stock" INTEGER,
+  "Discounts" TEXT,
+  FOREIGN KEY("ItemIndex") REFERENCES "Item"("id"));
+
+
+CREATE INDEX IF NOT EXISTS "idx_ShopItem_ItemIndex" ON "ShopItem"("ItemIndex");
+
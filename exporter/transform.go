@@ -0,0 +1,543 @@
+// exporter/transform.go
+package exporter
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Transform은 transform:<expr> 태그로 지정한 셀 변환식입니다.
+// 변환은 타입 변환 전의 셀 텍스트에 적용되며, 배열 컬럼은 원소마다 적용됩니다.
+// 빈 셀에는 적용되지 않습니다.
+//
+// 식에서 value는 셀 값입니다. string 컬럼에서는 항상 문자열이고,
+// 그 외 컬럼에서는 숫자로 읽을 수 있으면 숫자, 아니면 문자열입니다.
+//
+//	연산자:  + - * / % (문자열에 +를 사용하면 이어 붙임), 단항 -, 괄호
+//	리터럴:  123, 1.5, 'text', "text"
+//	함수:    lower, upper, trim, replace(s, old, new),
+//	         round(x[, digits]), floor, ceil, abs, min(a, b, ...), max(a, b, ...),
+//	         pow(x, y), clamp(x, lo, hi)
+//
+// 예: transform:value*1000, transform:lower(value), transform:round(value/3, 2)
+type Transform struct {
+	source    string
+	root      exprNode
+	normalize []string // 식보다 먼저 적용하는 normalize 태그의 정규화
+}
+
+// ColumnTransform은 컬럼의 transform 태그를 컴파일합니다. normalize 태그가 있으면 식보다 먼저 적용됩니다.
+// 두 태그가 모두 없으면 nil을 반환합니다.
+func ColumnTransform(col Column) (*Transform, error) {
+	normalize, err := columnNormalization(col)
+	if err != nil {
+		return nil, err
+	}
+	source, ok := GetTagValue(col.Tags, TagTransform)
+	if !ok {
+		if normalize == nil {
+			return nil, nil
+		}
+		return &Transform{source: "value", root: valueNode{}, normalize: normalize}, nil
+	}
+	t, err := CompileTransform(source)
+	if err != nil {
+		return nil, err
+	}
+	t.normalize = normalize
+	return t, nil
+}
+
+// CompileTransform은 변환식을 파싱합니다.
+func CompileTransform(source string) (*Transform, error) {
+	p := &exprParser{input: source}
+	p.next()
+
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid transform %q: %v", source, err)
+	}
+	if p.tok.kind != tokEOF {
+		return nil, fmt.Errorf("invalid transform %q: unexpected %q", source, p.tok.text)
+	}
+	return &Transform{source: source, root: root}, nil
+}
+
+// Apply는 셀 텍스트에 변환식을 적용한 결과를 셀 텍스트로 반환합니다.
+// numeric이 true이면 숫자로 읽을 수 있는 셀 값을 숫자로 취급합니다.
+func (t *Transform) Apply(cell string, numeric bool) (string, error) {
+	cell = normalizeText(t.normalize, cell)
+	var value interface{} = cell
+	if numeric {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(cell), 64); err == nil {
+			value = f
+		}
+	}
+
+	result, err := t.root.eval(value)
+	if err != nil {
+		return "", fmt.Errorf("transform %q: %v", t.source, err)
+	}
+	return exprString(result), nil
+}
+
+// exprNode는 변환식의 구문 트리 노드입니다. 값은 float64 또는 string입니다.
+type exprNode interface {
+	eval(value interface{}) (interface{}, error)
+}
+
+type literalNode struct{ value interface{} }
+
+type valueNode struct{}
+
+type unaryNode struct{ operand exprNode }
+
+type binaryNode struct {
+	op          byte
+	left, right exprNode
+}
+
+type callNode struct {
+	name string
+	args []exprNode
+}
+
+func (n literalNode) eval(interface{}) (interface{}, error) { return n.value, nil }
+
+func (valueNode) eval(value interface{}) (interface{}, error) { return value, nil }
+
+func (n unaryNode) eval(value interface{}) (interface{}, error) {
+	v, err := n.operand.eval(value)
+	if err != nil {
+		return nil, err
+	}
+	f, err := exprNumber(v)
+	if err != nil {
+		return nil, err
+	}
+	return -f, nil
+}
+
+func (n binaryNode) eval(value interface{}) (interface{}, error) {
+	l, err := n.left.eval(value)
+	if err != nil {
+		return nil, err
+	}
+	r, err := n.right.eval(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == '+' {
+		_, ls := l.(string)
+		_, rs := r.(string)
+		if ls || rs {
+			return exprString(l) + exprString(r), nil
+		}
+	}
+
+	a, err := exprNumber(l)
+	if err != nil {
+		return nil, err
+	}
+	b, err := exprNumber(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case '+':
+		return a + b, nil
+	case '-':
+		return a - b, nil
+	case '*':
+		return a * b, nil
+	case '/':
+		if b == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return a / b, nil
+	case '%':
+		if b == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return math.Mod(a, b), nil
+	}
+	return nil, fmt.Errorf("unknown operator %c", n.op)
+}
+
+func (n callNode) eval(value interface{}) (interface{}, error) {
+	args := make([]interface{}, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(value)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+
+	fn, ok := transformFuncs[n.name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s", n.name)
+	}
+	return fn(args)
+}
+
+// transformFuncs는 변환식에서 사용할 수 있는 함수들입니다.
+var transformFuncs = map[string]func(args []interface{}) (interface{}, error){
+	"lower": stringFunc(strings.ToLower),
+	"upper": stringFunc(strings.ToUpper),
+	"trim":  stringFunc(strings.TrimSpace),
+	"replace": func(args []interface{}) (interface{}, error) {
+		if len(args) != 3 {
+			return nil, fmt.Errorf("replace expects 3 arguments")
+		}
+		return strings.ReplaceAll(exprString(args[0]), exprString(args[1]), exprString(args[2])), nil
+	},
+	"round": func(args []interface{}) (interface{}, error) {
+		if len(args) != 1 && len(args) != 2 {
+			return nil, fmt.Errorf("round expects 1 or 2 arguments")
+		}
+		x, err := exprNumber(args[0])
+		if err != nil {
+			return nil, err
+		}
+		digits := 0.0
+		if len(args) == 2 {
+			if digits, err = exprNumber(args[1]); err != nil {
+				return nil, err
+			}
+		}
+		scale := math.Pow(10, digits)
+		return math.Round(x*scale) / scale, nil
+	},
+	"floor": numberFunc(math.Floor),
+	"ceil":  numberFunc(math.Ceil),
+	"abs":   numberFunc(math.Abs),
+	"min":   reduceFunc(math.Min),
+	"max":   reduceFunc(math.Max),
+	"pow": func(args []interface{}) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("pow expects 2 arguments")
+		}
+		x, err := exprNumber(args[0])
+		if err != nil {
+			return nil, err
+		}
+		y, err := exprNumber(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return math.Pow(x, y), nil
+	},
+	"clamp": func(args []interface{}) (interface{}, error) {
+		if len(args) != 3 {
+			return nil, fmt.Errorf("clamp expects 3 arguments")
+		}
+		var v [3]float64
+		for i, arg := range args {
+			f, err := exprNumber(arg)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = f
+		}
+		return math.Max(v[1], math.Min(v[2], v[0])), nil
+	},
+}
+
+func stringFunc(fn func(string) string) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected 1 argument")
+		}
+		return fn(exprString(args[0])), nil
+	}
+}
+
+func numberFunc(fn func(float64) float64) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected 1 argument")
+		}
+		x, err := exprNumber(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return fn(x), nil
+	}
+}
+
+func reduceFunc(fn func(a, b float64) float64) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("expected at least 1 argument")
+		}
+		result, err := exprNumber(args[0])
+		if err != nil {
+			return nil, err
+		}
+		for _, arg := range args[1:] {
+			x, err := exprNumber(arg)
+			if err != nil {
+				return nil, err
+			}
+			result = fn(result, x)
+		}
+		return result, nil
+	}
+}
+
+func exprNumber(v interface{}) (float64, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s is not a number", describeCell(v))
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("unsupported value %v", v)
+}
+
+func exprString(v interface{}) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// 토큰 종류
+const (
+	tokEOF = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokPunct
+)
+
+type exprToken struct {
+	kind int
+	text string
+}
+
+// exprParser는 변환식을 위한 재귀 하강 파서입니다.
+type exprParser struct {
+	input   string
+	pos     int
+	tok     exprToken
+	err     error
+	columns map[string]bool                     // 행 필터에서 참조할 수 있는 컬럼 이름 (소문자). nil이면 value만 허용
+	ident   func(name string) (exprNode, error) // 함수 호출이 아닌 식별자를 노드로 바꿈 (수식의 변수). 지정하면 value와 columns 대신 사용
+}
+
+func (p *exprParser) next() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+	if p.pos >= len(p.input) {
+		p.tok = exprToken{kind: tokEOF}
+		return
+	}
+
+	start := p.pos
+	c := p.input[p.pos]
+	switch {
+	case c >= '0' && c <= '9' || c == '.':
+		for p.pos < len(p.input) && (p.input[p.pos] >= '0' && p.input[p.pos] <= '9' || p.input[p.pos] == '.') {
+			p.pos++
+		}
+		p.tok = exprToken{kind: tokNumber, text: p.input[start:p.pos]}
+	case c == '\'' || c == '"':
+		p.pos++
+		for p.pos < len(p.input) && p.input[p.pos] != c {
+			p.pos++
+		}
+		if p.pos >= len(p.input) {
+			p.err = fmt.Errorf("unterminated string")
+			p.tok = exprToken{kind: tokEOF}
+			return
+		}
+		p.pos++
+		p.tok = exprToken{kind: tokString, text: p.input[start+1 : p.pos-1]}
+	case c == '_' || unicode.IsLetter(rune(c)):
+		for p.pos < len(p.input) && (p.input[p.pos] == '_' || unicode.IsLetter(rune(p.input[p.pos])) || unicode.IsDigit(rune(p.input[p.pos]))) {
+			p.pos++
+		}
+		p.tok = exprToken{kind: tokIdent, text: strings.ToLower(p.input[start:p.pos])}
+	default:
+		p.pos++
+		p.tok = exprToken{kind: tokPunct, text: string(c)}
+		if p.pos < len(p.input) {
+			if op := p.input[start : p.pos+1]; op == "<=" || op == ">=" || op == "==" || op == "!=" || op == "&&" || op == "||" {
+				p.pos++
+				p.tok.text = op
+			}
+		}
+	}
+}
+
+func (p *exprParser) isPunct(s string) bool {
+	return p.tok.kind == tokPunct && p.tok.text == s
+}
+
+func (p *exprParser) parseExpr() (exprNode, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("+") || p.isPunct("-") {
+		op := p.tok.text[0]
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+	return left, p.err
+}
+
+func (p *exprParser) parseTerm() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("*") || p.isPunct("/") || p.isPunct("%") {
+		op := p.tok.text[0]
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+	return left, p.err
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.isPunct("-") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	tok := p.tok
+	switch tok.kind {
+	case tokNumber:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", tok.text)
+		}
+		p.next()
+		return literalNode{value: f}, nil
+
+	case tokString:
+		p.next()
+		return literalNode{value: tok.text}, nil
+
+	case tokIdent:
+		p.next()
+		if !p.isPunct("(") {
+			if p.ident != nil {
+				return p.ident(tok.text)
+			}
+			if p.columns != nil {
+				if !p.columns[tok.text] {
+					return nil, fmt.Errorf("unknown column %q", tok.text)
+				}
+				return columnNode{name: tok.text}, nil
+			}
+			if tok.text != "value" {
+				return nil, fmt.Errorf("unknown identifier %q", tok.text)
+			}
+			return valueNode{}, nil
+		}
+		if _, ok := transformFuncs[tok.text]; !ok {
+			return nil, fmt.Errorf("unknown function %s", tok.text)
+		}
+
+		p.next()
+		var args []exprNode
+		for !p.isPunct(")") {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.isPunct(",") {
+				p.next()
+			} else if !p.isPunct(")") {
+				return nil, fmt.Errorf("expected , or ) in call to %s", tok.text)
+			}
+		}
+		p.next()
+		return callNode{name: tok.text, args: args}, nil
+
+	case tokPunct:
+		if tok.text == "(" {
+			p.next()
+			parse := p.parseExpr
+			if p.columns != nil {
+				parse = p.parseCondition
+			}
+			inner, err := parse()
+			if err != nil {
+				return nil, err
+			}
+			if !p.isPunct(")") {
+				return nil, fmt.Errorf("missing )")
+			}
+			p.next()
+			return inner, nil
+		}
+	}
+
+	if tok.kind == tokEOF {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q", tok.text)
+}
+
+// applyTransform은 셀 텍스트에 변환식을 적용합니다. 배열 컬럼은 원소마다 적용합니다.
+func applyTransform(col Column, t *Transform, text string) (string, error) {
+	elemType := col.Type
+	if col.Type.IsArray && col.Type.BaseType != nil {
+		elemType = *col.Type.BaseType
+	}
+	numeric := elemType.Type.Kind() != reflect.String
+
+	if !col.Type.IsArray {
+		return t.Apply(text, numeric)
+	}
+
+	delim := ArrayDelimiter(col)
+	items := splitArrayCell(text, delim)
+	for i, item := range items {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		out, err := t.Apply(strings.TrimSpace(item), numeric)
+		if err != nil {
+			return "", err
+		}
+		items[i] = out
+	}
+	return joinArrayCell(items, delim), nil
+}
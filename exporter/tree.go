@@ -0,0 +1,112 @@
+// exporter/tree.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 트리 뷰가 추가로 제공하는 컬럼 이름
+const (
+	TreeDepthColumn = "TreeDepth" // 루트로부터의 깊이 (루트는 0)
+	TreeRootColumn  = "TreeRoot"  // 루트 행의 인덱스
+	TreePathColumn  = "TreePath"  // 루트부터 자신까지의 인덱스 경로 ("1/4/9")
+)
+
+// ParentColumn은 부모 행을 가리키는 컬럼의 위치를 반환합니다. 없으면 -1입니다.
+// parent 태그가 붙은 컬럼만 부모 컬럼입니다. (이름이 Parent여도 태그가 없으면 일반 컬럼)
+// 부모 컬럼의 값은 같은 테이블의 인덱스 값이며, 비어있거나 0이면 루트 행입니다.
+func ParentColumn(table Table) int {
+	for i, col := range table.Columns {
+		if HasTag(col.Tags, TagParent) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parentOf는 행의 부모 인덱스 값을 문자열로 반환합니다. 루트 행(빈 값 또는 0)이면 빈 문자열입니다.
+func parentOf(row []interface{}, parentIdx int) string {
+	parent := variantOf(row, parentIdx)
+	if parent == "0" {
+		return ""
+	}
+	return parent
+}
+
+// TreeViewName은 트리 테이블의 재귀 뷰 이름을 반환합니다.
+func TreeViewName(table Table) string {
+	return table.Name + "_tree"
+}
+
+// validateTree는 부모 컬럼이 존재하는 인덱스를 가리키고 순환이 없는지 검사합니다.
+func validateTree(table Table) error {
+	parentIdx := ParentColumn(table)
+	if parentIdx == -1 {
+		return nil
+	}
+
+	indexIdx := IndexColumn(table)
+	if parentIdx == indexIdx {
+		return fmt.Errorf("table %s: parent column %s cannot be the index column", table.Name, table.Columns[parentIdx].Name)
+	}
+	parentType := ColumnTypeName(table.Columns[parentIdx].Type)
+	indexType := ColumnTypeName(table.Columns[indexIdx].Type)
+	if parentType != indexType {
+		return fmt.Errorf("table %s: parent column %s has type %s but index column %s has type %s",
+			table.Name, table.Columns[parentIdx].Name, parentType, table.Columns[indexIdx].Name, indexType)
+	}
+
+	parents := make(map[string]string, len(table.Rows))
+	for _, row := range table.Rows {
+		parents[RowKey(table, row)] = parentOf(row, parentIdx)
+	}
+
+	for rowIdx, row := range table.Rows {
+		key := RowKey(table, row)
+		parent := parents[key]
+		if parent == "" {
+			continue
+		}
+		if _, ok := parents[parent]; !ok {
+			return fmt.Errorf("table %s: row %d (%s) refers to unknown parent %s", table.Name, rowIdx+1, key, parent)
+		}
+
+		// 부모를 따라 올라가며 자기 자신을 다시 만나면 순환
+		visited := map[string]bool{key: true}
+		for p := parent; p != ""; p = parents[p] {
+			if visited[p] {
+				return fmt.Errorf("table %s: parent cycle detected at %s", table.Name, key)
+			}
+			visited[p] = true
+		}
+	}
+	return nil
+}
+
+// buildTreeViewQuery는 루트부터 모든 행을 재귀적으로 펼친 뷰를 생성합니다.
+// 뷰는 원본 컬럼에 TreeDepth, TreeRoot, TreePath 컬럼을 더해 제공합니다. 부모 컬럼이 NULL, 빈 문자열, 0인 행이 루트입니다.
+func buildTreeViewQuery(table Table, d SQLDialect) string {
+	parentIdx := ParentColumn(table)
+	if parentIdx == -1 {
+		return ""
+	}
+
+	quotedTable := d.QuoteIdentifier(table.Name)
+	quotedIndex := d.QuoteIdentifier(table.Columns[IndexColumn(table)].Name)
+	quotedParent := d.QuoteIdentifier(table.Columns[parentIdx].Name)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("CREATE VIEW IF NOT EXISTS %s AS\n", d.QuoteIdentifier(TreeViewName(table))))
+	b.WriteString("WITH RECURSIVE tree AS (\n")
+	b.WriteString(fmt.Sprintf("  SELECT t.*, 0 AS %s, t.%s AS %s, CAST(t.%s AS TEXT) AS %s\n",
+		TreeDepthColumn, quotedIndex, TreeRootColumn, quotedIndex, TreePathColumn))
+	b.WriteString(fmt.Sprintf("  FROM %s t WHERE t.%s IS NULL OR t.%s = '' OR t.%s = '0'\n", quotedTable, quotedParent, quotedParent, quotedParent))
+	b.WriteString("  UNION ALL\n")
+	b.WriteString(fmt.Sprintf("  SELECT c.*, tree.%s + 1, tree.%s, tree.%s || '/' || c.%s\n",
+		TreeDepthColumn, TreeRootColumn, TreePathColumn, quotedIndex))
+	// 루트 행은 인덱스가 0인 행의 자식으로 다시 펼치지 않음
+	b.WriteString(fmt.Sprintf("  FROM %s c JOIN tree ON c.%s = tree.%s AND c.%s <> '0'\n", quotedTable, quotedParent, quotedIndex, quotedParent))
+	b.WriteString(")\nSELECT * FROM tree;\n")
+	return b.String()
+}
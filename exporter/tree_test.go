@@ -0,0 +1,50 @@
+package exporter
+
+import "testing"
+
+func TestParentColumn(t *testing.T) {
+	// 이름이 Parent여도 parent 태그가 없으면 부모 컬럼이 아님
+	mail := Table{
+		Name:    "Mail",
+		Columns: []Column{binaryTestColumn("Index", "int"), binaryTestColumn("Parent", "string")},
+		Rows:    [][]interface{}{{int32(1), "guild"}},
+	}
+	if idx := ParentColumn(mail); idx != -1 {
+		t.Errorf("untagged Parent column is a parent column (%d)", idx)
+	}
+	if err := validateTree(mail); err != nil {
+		t.Errorf("validateTree: %v", err)
+	}
+
+	node := Table{
+		Name:    "Node",
+		Columns: []Column{binaryTestColumn("Index", "int"), binaryTestColumn("Up", "int", "parent")},
+	}
+	if idx := ParentColumn(node); idx != 1 {
+		t.Errorf("ParentColumn = %d, want 1", idx)
+	}
+}
+
+func TestValidateTreeRoots(t *testing.T) {
+	node := Table{
+		Name:    "Node",
+		Columns: []Column{binaryTestColumn("Index", "int"), binaryTestColumn("Up", "int", "parent")},
+	}
+	tests := []struct {
+		name string
+		rows [][]interface{}
+		ok   bool
+	}{
+		{"zero parents are roots", [][]interface{}{{int32(1), int32(0)}, {int32(2), int32(1)}}, true},
+		{"zero parent with index zero", [][]interface{}{{int32(0), int32(0)}, {int32(1), int32(0)}}, true},
+		{"empty parent", [][]interface{}{{int32(1), nil}, {int32(2), int32(1)}}, true},
+		{"unknown parent", [][]interface{}{{int32(1), int32(7)}}, false},
+		{"cycle", [][]interface{}{{int32(1), int32(2)}, {int32(2), int32(1)}}, false},
+	}
+	for _, tc := range tests {
+		node.Rows = tc.rows
+		if err := validateTree(node); (err == nil) != tc.ok {
+			t.Errorf("%s: validateTree = %v", tc.name, err)
+		}
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -42,8 +41,13 @@ func NewReflectParser(columnName string, columnType ColumnType, parse func(strin
 	}
 }
 
+// Parse는 coerce.go의 셀 값 변환 규칙에 따라 값을 변환합니다.
 func (p *ReflectParser) Parse(value string) (Value, error) {
-	if value = strings.TrimSpace(value); value == "" {
+	value, err := normalizeCell(value)
+	if err != nil {
+		return ZeroValue(p.columnType), fmt.Errorf("column %s: %v", p.columnName, err)
+	}
+	if value == "" {
 		return ZeroValue(p.columnType), nil
 	}
 
@@ -64,31 +68,49 @@ func CreateParser(column Column) ValueParser {
 }
 
 func createValueParser(column Column) ValueParser {
+	if column.Type.IsGeo() {
+		return NewReflectParser(column.Name, column.Type, func(s string) (interface{}, error) {
+			return parseGeoPoint(s)
+		})
+	}
+	if column.Type.IsFormula() {
+		return NewReflectParser(column.Name, column.Type, func(s string) (interface{}, error) {
+			s = cleanString(s)
+			_, err := compileFormula(s, column.Type.FormulaVars())
+			return s, err
+		})
+	}
+	if column.Type.IsCurve() {
+		return NewReflectParser(column.Name, column.Type, func(s string) (interface{}, error) {
+			return parseCurve(s, column.Type.Interp)
+		})
+	}
+
 	switch column.Type.Type.Kind() {
 	case reflect.Int32:
 		return NewReflectParser(column.Name, column.Type, func(s string) (interface{}, error) {
-			val, err := strconv.ParseInt(s, 10, 32)
+			val, err := coerceInt(s, 32)
 			return int32(val), err
 		})
 
 	case reflect.Int64:
 		return NewReflectParser(column.Name, column.Type, func(s string) (interface{}, error) {
-			return strconv.ParseInt(s, 10, 64)
+			return coerceInt(s, 64)
 		})
 
 	case reflect.Float64:
 		return NewReflectParser(column.Name, column.Type, func(s string) (interface{}, error) {
-			return strconv.ParseFloat(s, 64)
+			return coerceFloat(s)
 		})
 
 	case reflect.Bool:
 		return NewReflectParser(column.Name, column.Type, func(s string) (interface{}, error) {
-			return strconv.ParseBool(s)
+			return coerceBool(s)
 		})
 
 	case reflect.String:
 		return NewReflectParser(column.Name, column.Type, func(s string) (interface{}, error) {
-			return s, nil
+			return cleanString(s), nil
 		})
 	}
 
@@ -99,7 +121,7 @@ func createValueParser(column Column) ValueParser {
 
 	// 기본값은 문자열 파서
 	return NewReflectParser(column.Name, StringType, func(s string) (interface{}, error) {
-		return s, nil
+		return cleanString(s), nil
 	})
 }
 
@@ -134,10 +156,22 @@ func NewTimeParser(columnName string, columnType ColumnType) *TimeParser {
 }
 
 func (p *TimeParser) Parse(value string) (Value, error) {
-	if value = strings.TrimSpace(value); value == "" {
+	value, err := normalizeCell(value)
+	if err == nil {
+		value, err = scalarText(value)
+	}
+	if err != nil {
+		return ZeroValue(p.columnType), fmt.Errorf("column %s: %v", p.columnName, err)
+	}
+	if value == "" {
 		return ZeroValue(p.columnType), nil
 	}
 
+	// Excel 날짜 일련번호
+	if t, ok := excelSerialTime(value); ok {
+		return NewValue(p.columnType, t), nil
+	}
+
 	var lastErr error
 	for _, tf := range p.formats {
 		t, err := time.Parse(tf.format, value)
@@ -163,18 +197,24 @@ func createArrayParser(column Column) ValueParser {
 		},
 	})
 
+	delim := ArrayDelimiter(column)
 	return NewReflectParser(column.Name, column.Type, func(s string) (interface{}, error) {
-		items := strings.Split(s, ",")
+		items := splitArrayCell(s, delim)
 		values := make([]interface{}, 0, len(items))
 
+		// 원소는 시트에 나온 순서를 유지하며, 빈 원소(빈 셀, 연속된 구분자)는 건너뜀
 		for _, item := range items {
+			if strings.TrimSpace(item) == "" {
+				continue
+			}
 			parsed, err := baseParser.Parse(item)
 			if err != nil {
 				return nil, err
 			}
-			if !parsed.IsZero() {
-				values = append(values, parsed.Interface())
-			}
+			values = append(values, parsed.Interface())
+		}
+		if max := column.Type.MaxLen; max > 0 && len(values) > max {
+			return nil, fmt.Errorf("%d elements exceed the declared maximum of %d", len(values), max)
 		}
 
 		jsonData, err := json.Marshal(values)
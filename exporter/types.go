@@ -3,6 +3,7 @@ package exporter
 
 import (
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -28,6 +29,7 @@ type Options struct {
 	PackageName string
 
 	// 타겟 언어별 추가 옵션들
+	// 키와 값의 타입은 exporter의 옵션 스키마(Describe)로 검사되므로 오타는 에러가 됩니다.
 	ExtraOptions map[string]interface{}
 
 	// 템플릿 디렉토리 경로
@@ -45,6 +47,32 @@ type Table struct {
 	Columns   []Column
 	Relations []Relation
 	Rows      [][]interface{} // 실제 데이터를 저장할 필드 추가
+	Group     string          // 출력 그룹 (#Config의 group 설정)
+
+	DataVersion     string // 데이터 버전 (#Config의 dataVersion 설정)
+	DeprecatedUntil string // 폐기 예정 컬럼을 허용하는 마지막 데이터 버전 (#Config의 deprecatedUntil 설정)
+
+	Aliases []string // 이름 변경 전의 테이블 이름들 (#Config의 alias 설정)
+	Views   []View   // 이 테이블을 기준으로 하는 뷰 (#View 시트)
+
+	RowFilters []RowFilter // 생성 결과에 남길 행의 조건 (#Config의 filter 설정, ApplyRowFilters에서 적용)
+	Transforms []string    // 선언된 순서대로 적용할 테이블 변환 (#Config의 transform 설정, ApplyTableTransforms에서 적용)
+	Locks      []Lock      // 내용이 바뀌면 안 되는 테이블/컬럼 (#Lock 시트)
+	Budget     Budget      // 최대 행 수와 직렬화 크기 (#Config의 maxRows, maxSize 설정)
+	Shard      string      // 데이터 출력을 여러 파일로 나누는 방식 (#Config의 shard 설정, 예: hash:8, range:5000)
+	StructTags string      // 생성된 Go 모델 필드에 붙일 구조체 태그 규칙 (#Config의 structTags 설정, 예: json:camel,omitempty yaml:snake)
+
+	DateTimeStorage string // datetime 컬럼을 DB에 저장하는 형식 (#Config의 datetimeStorage 설정, rfc3339 또는 epoch)
+
+	Layout     SheetLayout // 원본 시트의 배치 (#layout 마커 또는 #Config의 layout 설정)
+	IsSettings bool        // 키-값 설정 시트(#Settings)에서 만든 한 행짜리 테이블
+	IsMatrix   bool        // 매트릭스 시트(#matrix)에서 만든 RowKey, ColKey, Value 테이블
+
+	Source  *TableSource       // 원본 워크북 시트와 행별 셀 위치 (소스맵에 사용)
+	Display *DisplayDictionary // 워크북의 enum 표시 이름과 로컬라이제이션 텍스트 (#Enum, #Locale 시트)
+
+	Assets   map[string][]byte // 산출물 assets 폴더에 복사할 파일 (해시 이름 -> 내용, asset 컬럼)
+	FileRefs []string          // 셀이 참조한 파일 경로 (file, asset 컬럼)
 }
 
 // Relation represents a table relationship
@@ -62,6 +90,7 @@ type Column struct {
 	Type     ColumnType // 컬럼 타입
 	Tags     []TagValue //  태그
 	IsUnique bool       // 유니크 컬럼 여부
+	Optional string     // 속한 optional 컬럼 그룹 이름 (없으면 빈 문자열)
 }
 
 // ColumnType은 컬럼의 타입 정보를 나타냅니다
@@ -70,6 +99,12 @@ type ColumnType struct {
 	SQLType  string       // SQL 타입
 	IsArray  bool         // 배열 여부
 	BaseType *ColumnType  // 배열인 경우 기본 타입
+	MaxLen   int          // 배열의 최대 원소 수 (array<string,8>, 0이면 제한 없음)
+	RefTable string       // ref<Table> 타입인 경우 참조하는 테이블 이름
+	Interp   string       // curve 타입의 보간 방식 (linear, bezier)
+
+	Formula        bool   // formula 타입 (수식 텍스트)
+	FormulaVarList string // formula<a,b>에서 선언한 변수 이름 (쉼표로 구분, 비어있으면 제한 없음)
 }
 
 // 기본 타입 정의
@@ -114,18 +149,41 @@ var (
 func ParseColumnType(typeStr string) ColumnType {
 	typeStr = strings.TrimSpace(strings.ToLower(typeStr))
 
-	// 배열 타입 처리
+	// 배열 타입 처리: array<string,8>처럼 최대 원소 수를 지정할 수 있음
 	if strings.HasPrefix(typeStr, "array<") && strings.HasSuffix(typeStr, ">") {
-		baseTypeStr := strings.TrimSuffix(strings.TrimPrefix(typeStr, "array<"), ">")
+		baseTypeStr, maxLen, _ := splitArrayMaxLen(strings.TrimSuffix(strings.TrimPrefix(typeStr, "array<"), ">"))
 		baseType := ParseColumnType(baseTypeStr)
 		return ColumnType{
 			Type:     reflect.SliceOf(baseType.Type),
 			SQLType:  "TEXT", // 배열은 JSON으로 저장되므로 TEXT
 			IsArray:  true,
 			BaseType: &baseType,
+			MaxLen:   maxLen,
 		}
 	}
 
+	// 참조 타입 처리: ref<Item>은 Item 테이블의 인덱스(int)를 가리킴
+	// 인덱스가 int가 아니면 ref<Item:string>처럼 키 타입을 지정
+	if strings.HasPrefix(typeStr, "ref<") && strings.HasSuffix(typeStr, ">") {
+		target := strings.TrimSuffix(strings.TrimPrefix(typeStr, "ref<"), ">")
+		keyType := Int32Type
+		if idx := strings.Index(target, ":"); idx != -1 {
+			keyType = ParseColumnType(target[idx+1:])
+			target = target[:idx]
+		}
+		keyType.RefTable = formatTableName(target)
+		return keyType
+	}
+
+	// 커브 타입 처리: curve, curve<bezier>
+	if ct, ok := parseCurveType(typeStr); ok {
+		return ct
+	}
+	// 수식 타입 처리: formula, formula<base,level>
+	if ct, ok := parseFormulaType(typeStr); ok {
+		return ct
+	}
+
 	// 기본 타입 처리
 	switch typeStr {
 	case "int", "int32", "integer":
@@ -140,6 +198,8 @@ func ParseColumnType(typeStr string) ColumnType {
 		return DateTimeType
 	case "[]byte", "blob":
 		return BytesType
+	case "geo", "point":
+		return GeoType
 	case "string", "text", "varchar":
 		return StringType
 	default:
@@ -147,6 +207,52 @@ func ParseColumnType(typeStr string) ColumnType {
 	}
 }
 
+// splitArrayMaxLen은 "string,8" 형태의 배열 타입 인자를 원소 타입과 최대 원소 수로 나눕니다.
+// 최대 원소 수가 올바른 양의 정수가 아니면 ok는 false입니다.
+func splitArrayMaxLen(s string) (base string, maxLen int, ok bool) {
+	idx := strings.LastIndex(s, ",")
+	if idx == -1 {
+		return s, 0, true
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(s[idx+1:]))
+	if err != nil || n <= 0 {
+		return strings.TrimSpace(s[:idx]), 0, false
+	}
+	return strings.TrimSpace(s[:idx]), n, true
+}
+
+// KnownColumnType은 타입 문자열이 인식되는 타입인지 확인합니다.
+// ParseColumnType은 알 수 없는 타입을 string으로 처리하므로, 오타를 찾는 데 사용합니다.
+func KnownColumnType(typeStr string) bool {
+	typeStr = strings.TrimSpace(strings.ToLower(typeStr))
+
+	if strings.HasPrefix(typeStr, "array<") && strings.HasSuffix(typeStr, ">") {
+		baseTypeStr, _, ok := splitArrayMaxLen(strings.TrimSuffix(strings.TrimPrefix(typeStr, "array<"), ">"))
+		return ok && KnownColumnType(baseTypeStr)
+	}
+	if strings.HasPrefix(typeStr, "ref<") && strings.HasSuffix(typeStr, ">") {
+		target := strings.TrimSuffix(strings.TrimPrefix(typeStr, "ref<"), ">")
+		if idx := strings.Index(target, ":"); idx != -1 {
+			return target[:idx] != "" && KnownColumnType(target[idx+1:])
+		}
+		return target != ""
+	}
+	if _, ok := parseCurveType(typeStr); ok {
+		return true
+	}
+	if _, ok := parseFormulaType(typeStr); ok {
+		return true
+	}
+
+	switch typeStr {
+	case "", "int", "int32", "integer", "int64", "bigint", "float", "float64", "double",
+		"bool", "boolean", "time", "datetime", "timestamp", "date", "[]byte", "blob",
+		"geo", "point", "string", "text", "varchar":
+		return true
+	}
+	return false
+}
+
 // GoTypeString은 Go 코드 생성에 사용할 타입 문자열을 반환합니다
 func (ct ColumnType) GoTypeString() string {
 	if ct.IsArray {
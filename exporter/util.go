@@ -3,25 +3,43 @@ package exporter
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
-
-	"github.com/xuri/excelize/v2"
+	"unicode"
+	"unicode/utf8"
 )
 
 // ParseExcelFile은 Excel 파일을 파싱하여 테이블 정의를 반환합니다.
 func ParseExcelFile(filePath string) ([]Table, error) {
+	return ParseExcelFileSelected(filePath, nil)
+}
+
+// ParseExcelFileSelected는 selected에 포함된 테이블의 시트만 파싱합니다.
+// selected가 nil이면 모든 시트를 파싱합니다. 키는 테이블 이름(formatTableName 결과)입니다.
+func ParseExcelFileSelected(filePath string, selected map[string]bool) ([]Table, error) {
 	// ~$로 시작하는 임시 파일 무시
 	if strings.HasPrefix(filePath, "~$") {
 		return nil, nil
 	}
 
 	// Excel 파일 열기
-	f, err := excelize.OpenFile(filePath)
+	f, err := openWorkbook(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open Excel file: %v", err)
+		return nil, err
 	}
 	defer f.Close()
 
+	// 시트 배치(layout) 등 파싱 전에 필요한 설정이 있으므로 설정을 먼저 읽음
+	entries, err := parseConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+
+	optionalGroups, err := parseOptionalGroups(f)
+	if err != nil {
+		return nil, err
+	}
+
 	var tables []Table
 
 	// 각 시트 처리
@@ -31,25 +49,73 @@ func ParseExcelFile(filePath string) ([]Table, error) {
 			continue
 		}
 
+		// 선택되지 않은 시트는 읽지 않음
+		if selected != nil && !selected[formatTableName(sheetName)] {
+			continue
+		}
+
 		// 시트의 데이터 읽기
 		rows, err := f.GetRows(sheetName)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read sheet %s: %v", sheetName, err)
 		}
 
+		// 매트릭스 시트는 RowKey, ColKey, Value 테이블로 변환
+		if typeStr, ok := matrixValueType(rows); ok {
+			table, err := parseMatrixSheet(sheetName, rows, typeStr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse matrix sheet %s: %v", sheetName, err)
+			}
+			tables = append(tables, table)
+			continue
+		}
+
+		// 전치된 시트는 표준 배치로 변환
+		layout, rows, pos, err := sheetLayout(sheetName, rows, entries)
+		if err != nil {
+			return nil, fmt.Errorf("sheet %s: %v", sheetName, err)
+		}
+
 		if len(rows) < 4 { // 최소 4줄(컬럼명, 태그, 타입, 데이터) 필요
 			continue
 		}
 
+		// 행 길이를 헤더의 열 수에 맞춤 (헤더 범위 밖의 값은 경고)
+		rows, warnings := normalizeRows(sheetName, rows, pos)
+
+		rules, err := sheetHeaderRules(entries, sheetName, pos)
+		if err != nil {
+			return nil, fmt.Errorf("sheet %s: %v", sheetName, err)
+		}
+
 		// 시트에서 테이블 정의 파싱
-		table, err := parseSheet(sheetName, rows)
+		table, err := parseSheet(sheetName, rows, pos.record, rules)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse sheet %s: %v", sheetName, err)
 		}
+		table.Layout = layout
+		table.Source.pos = pos
+		table.Source.Warnings = warnings
+
+		// 시트에 없는 optional 그룹의 컬럼은 NULL로 채움
+		groups, err := tableOptionalGroups(optionalGroups, entries, sheetName)
+		if err == nil {
+			table, err = applyOptionalGroups(table, groups)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("sheet %s: %v", sheetName, err)
+		}
 
 		tables = append(tables, table)
 	}
 
+	// 키-값 설정 시트는 한 행짜리 테이블로 추가
+	settings, err := parseSettingsSheets(f, selected)
+	if err != nil {
+		return nil, err
+	}
+	tables = append(tables, settings...)
+
 	relations, err := parseRelations(f)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse relations: %v", err)
@@ -57,15 +123,102 @@ func ParseExcelFile(filePath string) ([]Table, error) {
 
 	tables = assignRelationsToTables(tables, relations)
 
+	tables, err = applyConfig(tables, entries, f.GetSheetList())
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply config: %v", err)
+	}
+
+	views, err := parseViews(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse views: %v", err)
+	}
+
+	tables, err = assignViews(tables, views, f.GetSheetList())
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign views: %v", err)
+	}
+
+	locks, _, err := parseLocks(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse locks: %v", err)
+	}
+
+	tables, err = assignLocks(tables, locks, f.GetSheetList())
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign locks: %v", err)
+	}
+
+	dict, err := parseDisplayDictionary(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse enums and locales: %v", err)
+	}
+
+	for i := range tables {
+		if tables[i].Source == nil {
+			tables[i].Source = &TableSource{Sheet: tables[i].SheetName}
+		}
+		tables[i].Source.Workbook = filePath
+		tables[i].Display = dict
+
+		// 셀이 참조하는 파일은 워크북 위치를 기준으로 읽음
+		if err := resolveFileColumns(&tables[i], filepath.Dir(filePath)); err != nil {
+			return nil, err
+		}
+		normalizeProbabilities(&tables[i])
+	}
+
 	return tables, nil
 }
 
 // parseSheet는 시트 데이터로부터 테이블 정의를 파싱합니다.
-func parseSheet(sheetName string, rows [][]string) (Table, error) {
+// label은 행 인덱스를 에러 메시지에 표시할 시트 위치로 바꿉니다.
+func parseSheet(sheetName string, rows [][]string, label func(int) string, rules headerRules) (Table, error) {
+	table, sources, err := parseHeader(sheetName, rows, rules)
+	if err != nil {
+		return table, err
+	}
+
+	parsers := make([]ValueParser, len(table.Columns))
+	bounds := make([]Bounds, len(table.Columns))
+	transforms := make([]*Transform, len(table.Columns))
+	for i, col := range table.Columns {
+		parsers[i] = CreateParser(col)
+		bounds[i], _ = ColumnBounds(col)
+		transforms[i], _ = ColumnTransform(col)
+	}
+	required, err := compileRequiredIf(table.Columns)
+	if err != nil {
+		return table, err
+	}
+
+	var records []int
+	for r := 3; r < len(rows); r++ {
+		row, err := parseRow(rows[r], table.Columns, sources, parsers, bounds, transforms)
+		if err == nil && row != nil {
+			err = checkRequiredIf(table.Columns, required, row)
+		}
+		if err != nil {
+			return table, fmt.Errorf("%s: %v", label(r), err)
+		}
+		if row != nil {
+			table.Rows = append(table.Rows, row)
+			records = append(records, r)
+		}
+	}
+
+	table.Source = newTableSource(table, sources, records)
+	return table, nil
+}
+
+// parseHeader는 시트의 헤더 3줄로부터 컬럼 정의를 파싱합니다.
+// 컬럼별로 데이터를 읽어올 시트의 열 인덱스 목록을 함께 반환합니다.
+// 배열이 아닌 컬럼 이름이 반복되면 rules의 중복 헤더 처리를 따릅니다.
+func parseHeader(sheetName string, rows [][]string, rules headerRules) (Table, [][]int, error) {
 
 	// 첫 번째 행: 컬럼명
 	// 두 번째 행: 태그
 	// 세 번째 행: 타입
+	// 네 번째 행부터: 데이터
 	columnNames := rows[0]
 	columnTags := rows[1]
 	columnTypes := rows[2]
@@ -75,7 +228,11 @@ func parseSheet(sheetName string, rows [][]string) (Table, error) {
 		SheetName: sheetName,
 	}
 
-	// TODO: 컬럼 타입이 배열이면,  TEXT 타입인 필드하나가 있고,  배열 원소의 수 만큼  원소의 해당 타입으로 FIELDNAME_0, FIELDNAME_1, ... 으로 추가 필드가 생성되어야 함
+	// 컬럼별로 데이터를 읽어올 시트의 열 인덱스 목록
+	// 같은 이름의 배열 컬럼이 반복되면 하나의 컬럼으로 합쳐집니다.
+	var sources [][]int
+	arrayColumns := make(map[string]int)
+	names := make(headerNames)
 
 	for i := 0; i < len(columnNames); i++ {
 		name := ParseColumnName(columnNames[i])
@@ -83,9 +240,9 @@ func parseSheet(sheetName string, rows [][]string) (Table, error) {
 			continue
 		}
 
-		tagValeus := ParseColumnTags(parseTags(columnTags[i]))
+		tagValeus := ParseColumnTags(parseTags(cellAt(columnTags, i)))
 
-		typeStr := strings.TrimSpace(columnTypes[i])
+		typeStr := cellAt(columnTypes, i)
 
 		// 디자인용 컬럼은 건너뛰기
 		if HasTag(tagValeus, TagDesign) {
@@ -94,6 +251,31 @@ func parseSheet(sheetName string, rows [][]string) (Table, error) {
 
 		columnType := ParseColumnType(typeStr)
 
+		// 반복된 배열 헤더는 기존 컬럼의 원소로 추가 (사이에 다른 컬럼이 있어도 됨)
+		// 원소는 시트의 열 순서대로 이어지며, 최대 원소 수보다 많은 열은 허용하지 않음
+		if columnType.IsArray {
+			if idx, ok := arrayColumns[name]; ok {
+				if ColumnTypeName(table.Columns[idx].Type) != ColumnTypeName(columnType) {
+					return table, nil, fmt.Errorf("column %s: repeated array header has type %s but the first one has %s",
+						name, typeStr, ColumnTypeName(table.Columns[idx].Type))
+				}
+				sources[idx] = append(sources[idx], i)
+				if max := columnType.MaxLen; max > 0 && len(sources[idx]) > max {
+					return table, nil, fmt.Errorf("column %s: %d repeated headers exceed the declared maximum of %d elements", name, len(sources[idx]), max)
+				}
+				continue
+			}
+		}
+
+		header := name
+		name, err := names.claim(name, i, rules)
+		if err != nil {
+			return table, nil, err
+		}
+		if columnType.IsArray {
+			arrayColumns[header] = len(table.Columns)
+		}
+
 		column := Column{
 			Name:     name,
 			Type:     columnType,
@@ -101,19 +283,102 @@ func parseSheet(sheetName string, rows [][]string) (Table, error) {
 			IsUnique: HasTag(tagValeus, TagUnique),
 		}
 
+		if _, err := ColumnBounds(column); err != nil {
+			return table, nil, fmt.Errorf("column %s: %v", name, err)
+		}
+		if _, err := ColumnTransform(column); err != nil {
+			return table, nil, fmt.Errorf("column %s: %v", name, err)
+		}
+		if _, err := columnDelimiter(column); err != nil {
+			return table, nil, fmt.Errorf("column %s: %v", name, err)
+		}
+
 		table.Columns = append(table.Columns, column)
+		sources = append(sources, []int{i})
 	}
 
-	return table, nil
+	return table, sources, nil
+}
+
+// parseRow는 시트의 한 행을 컬럼 타입에 맞게 변환합니다. 빈 행이면 nil을 반환합니다.
+// 빈 셀은 nil(NULL)로 저장됩니다.
+// transform 식은 타입 변환 전에 적용되며, min/max 범위를 벗어난 값은 에러입니다.
+func parseRow(cells []string, columns []Column, sources [][]int, parsers []ValueParser, bounds []Bounds, transforms []*Transform) ([]interface{}, error) {
+	row := make([]interface{}, len(columns))
+	empty := true
+
+	for i := range columns {
+		var parts []string
+		for _, idx := range sources[i] {
+			if cell := cellAt(cells, idx); cell != "" {
+				parts = append(parts, cell)
+			}
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		empty = false
+
+		text := parts[0]
+		if len(parts) > 1 {
+			text = strings.Join(parts, ArrayDelimiter(columns[i]))
+		}
+		if transforms[i] != nil {
+			var err error
+			if text, err = applyTransform(columns[i], transforms[i], text); err != nil {
+				return nil, fmt.Errorf("column %s: %v", columns[i].Name, err)
+			}
+		}
+
+		value, err := parsers[i].Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		row[i] = value.Interface()
+
+		if err := checkBounds(columns[i], bounds[i], row[i]); err != nil {
+			return nil, fmt.Errorf("column %s: %v", columns[i].Name, err)
+		}
+	}
+
+	if empty {
+		return nil, nil
+	}
+	return row, nil
+}
+
+// IndexColumn은 테이블의 인덱스(행 키) 컬럼 위치를 반환합니다.
+// "Index" 이름의 컬럼이 없으면 첫 번째 컬럼을 사용합니다.
+func IndexColumn(table Table) int {
+	for i, col := range table.Columns {
+		if strings.EqualFold(col.Name, "Index") {
+			return i
+		}
+	}
+	return 0
+}
+
+// RowKey는 행의 인덱스 값을 문자열로 반환합니다.
+// 매트릭스 테이블은 "행 키/열 키"를 반환합니다.
+func RowKey(table Table, row []interface{}) string {
+	if table.IsMatrix && len(row) >= 2 {
+		return fmt.Sprintf("%v/%v", row[0], row[1])
+	}
+
+	idx := IndexColumn(table)
+	if idx >= len(row) || row[idx] == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", row[idx])
 }
 
 func formatTableName(name string) string {
 	name = strings.TrimSpace(name)
 	parts := strings.Fields(name)
 	for i, part := range parts {
-		if len(part) > 0 {
-			parts[i] = strings.ToUpper(string(part[0])) + strings.ToLower(part[1:])
-		}
+		// 한글 등 여러 바이트 문자로 시작하는 이름이 깨지지 않도록 첫 바이트가 아닌 첫 문자를 바꿈
+		first, size := utf8.DecodeRuneInString(part)
+		parts[i] = string(unicode.ToUpper(first)) + strings.ToLower(part[size:])
 	}
 	return strings.Join(parts, "")
 }
@@ -127,24 +392,44 @@ func ParseColumnName(name string) string {
 	// 첫 글자를 대문자로 변환
 	parts := strings.Fields(name)
 	for i, part := range parts {
-		if len(part) > 0 {
-			// 첫 문자를 대문자로, 나머지는 그대로 유지
-			parts[i] = strings.ToUpper(string(part[0])) + part[1:]
-		}
+		// 첫 문자를 대문자로, 나머지는 그대로 유지
+		first, size := utf8.DecodeRuneInString(part)
+		parts[i] = string(unicode.ToUpper(first)) + part[size:]
 	}
 	return strings.Join(parts, "")
 }
 
 // parseTags는 태그 문자열을 태그 슬라이스로 파싱합니다.
+// 괄호나 따옴표 안의 쉼표는 구분자로 취급하지 않습니다. (예: transform:round(value, 2))
 func parseTags(tagStr string) []string {
-	tags := strings.Split(strings.TrimSpace(tagStr), ",")
 	var result []string
-	for _, tag := range tags {
-		tag = strings.TrimSpace(tag)
-		if tag != "" {
+	var quote rune
+	depth, start := 0, 0
+
+	add := func(tag string) {
+		if tag = strings.TrimSpace(tag); tag != "" {
 			result = append(result, tag)
 		}
 	}
+
+	for i, r := range tagStr {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case r == ',' && depth == 0:
+			add(tagStr[start:i])
+			start = i + 1
+		}
+	}
+	add(tagStr[start:])
 	return result
 }
 
@@ -170,10 +455,9 @@ func FormatColumnName(name string) string {
 	// 첫 글자를 대문자로 변환
 	parts := strings.Fields(name)
 	for i, part := range parts {
-		if len(part) > 0 {
-			// 첫 문자를 대문자로, 나머지는 그대로 유지
-			parts[i] = strings.ToUpper(string(part[0])) + part[1:]
-		}
+		// 첫 문자를 대문자로, 나머지는 그대로 유지
+		first, size := utf8.DecodeRuneInString(part)
+		parts[i] = string(unicode.ToUpper(first)) + part[size:]
 	}
 	return strings.Join(parts, "")
 }
@@ -0,0 +1,117 @@
+// exporter/validate.go
+package exporter
+
+import "fmt"
+
+// columnValidators는 컬럼 하나의 값을 모두 훑는 검증입니다. (cross-table 규칙은 validateCrossTable)
+// 컬럼의 정의와 값이 이전 실행과 같고 그때 통과했다면 결과도 같으므로 ValidateWithStats가 건너뜁니다.
+var columnValidators = []func(table Table, i int) []error{
+	validateDisplay,
+	validateNarrow,
+	validateCollation,
+}
+
+// Validate는 파싱된 테이블들에 대해 모든 검증 규칙을 실행하고 발견된 문제들을 반환합니다.
+func Validate(tables []Table) []error {
+	errs, _ := ValidateWithStats(tables, nil)
+	return errs
+}
+
+// ValidateWithStats는 Validate와 같은 검증을 실행하고 모든 컬럼의 통계를 함께 반환합니다.
+// previous에 해시가 같고 검증을 통과한 기록이 있는 컬럼은 컬럼 검증(columnValidators와 cross-table 규칙)을 건너뜁니다.
+func ValidateWithStats(tables []Table, previous ColumnStatsCache) ([]error, []ColumnStats) {
+	var errs []error
+	var stats []ColumnStats
+
+	for _, table := range tables {
+		if err := validateEffectiveDates(table); err != nil {
+			errs = append(errs, err)
+		}
+		if err := validateVariants(table); err != nil {
+			errs = append(errs, err)
+		}
+		if err := validateDeprecations(table); err != nil {
+			errs = append(errs, err)
+		}
+		if err := validateTree(table); err != nil {
+			errs = append(errs, err)
+		}
+		errs = append(errs, validateLocks(table)...)
+		errs = append(errs, validateBudget(table)...)
+		errs = append(errs, validateIntern(table)...)
+		errs = append(errs, validateAccess(table)...)
+		errs = append(errs, validateFileColumns(table)...)
+		errs = append(errs, validateCurves(table)...)
+		errs = append(errs, validateProbabilities(table)...)
+		errs = append(errs, validateGacha(table)...)
+		errs = append(errs, validateColumnEncoding(table)...)
+		if err := validateShard(table); err != nil {
+			errs = append(errs, err)
+		}
+		if err := validateStructTags(table); err != nil {
+			errs = append(errs, err)
+		}
+		if err := validateStorage(table); err != nil {
+			errs = append(errs, err)
+		}
+		for _, col := range table.Columns {
+			if _, err := ValidateRules(col); err != nil {
+				errs = append(errs, fmt.Errorf("table %s column %s: %v", table.Name, col.Name, err))
+			}
+		}
+	}
+
+	if _, err := GroupTables(tables); err != nil {
+		errs = append(errs, err)
+	}
+	errs = append(errs, validateAliases(tables)...)
+	errs = append(errs, validateViews(tables)...)
+	errs = append(errs, validateIndexes(tables)...)
+
+	// 컬럼 검증은 모든 컬럼의 통계를 구한 뒤 실행 (cross-table 규칙이 참조하는 컬럼이 바뀌었는지 확인)
+	current := make(ColumnStatsCache)
+	for _, table := range tables {
+		for i, col := range table.Columns {
+			current[columnStatsKey(table.Name, col.Name)] = computeColumnStats(table, i)
+		}
+	}
+	byName := make(map[string]Table, len(tables))
+	for _, table := range tables {
+		byName[table.Name] = table
+	}
+	for _, table := range tables {
+		for i, col := range table.Columns {
+			colStats := current[columnStatsKey(table.Name, col.Name)]
+			if previous.reusable(current, table.Name, col) {
+				colStats.Valid, colStats.Cached = true, true
+			} else {
+				var colErrs []error
+				for _, validate := range columnValidators {
+					colErrs = append(colErrs, validate(table, i)...)
+				}
+				colErrs = append(colErrs, validateCrossTable(byName, table, i)...)
+				colStats.Valid = len(colErrs) == 0
+				errs = append(errs, colErrs...)
+			}
+			stats = append(stats, colStats)
+		}
+	}
+	return errs, stats
+}
+
+// reusable은 이전 실행의 컬럼 검증 결과를 재사용할 수 있는지 반환합니다.
+// 이전에 통과했고, 컬럼과 cross-table 규칙이 참조하는 컬럼이 모두 바뀌지 않았어야 합니다.
+func (c ColumnStatsCache) reusable(current ColumnStatsCache, table string, col Column) bool {
+	prev, ok := c[columnStatsKey(table, col.Name)]
+	if !ok || !prev.Valid || prev.Hash != current[columnStatsKey(table, col.Name)].Hash {
+		return false
+	}
+	for _, ref := range crossTableRefs(col) {
+		key := columnStatsKey(ref.Table, ref.Column)
+		target, ok := current[key]
+		if !ok || c[key].Hash != target.Hash {
+			return false
+		}
+	}
+	return true
+}
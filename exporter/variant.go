@@ -0,0 +1,87 @@
+// exporter/variant.go
+package exporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// VariantColumn은 variant 태그가 붙은 컬럼의 위치를 반환합니다. 없으면 -1입니다.
+func VariantColumn(table Table) int {
+	for i, col := range table.Columns {
+		if HasTag(col.Tags, TagVariant) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Variants는 테이블 데이터에 등장하는 변형 이름을 정렬하여 반환합니다. 기본(빈) 변형은 제외됩니다.
+func Variants(table Table) []string {
+	idx := VariantColumn(table)
+	if idx == -1 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var variants []string
+	for _, row := range table.Rows {
+		variant := variantOf(row, idx)
+		if variant != "" && !seen[variant] {
+			seen[variant] = true
+			variants = append(variants, variant)
+		}
+	}
+	sort.Strings(variants)
+	return variants
+}
+
+func variantOf(row []interface{}, idx int) string {
+	if idx >= len(row) || row[idx] == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", row[idx])
+}
+
+// validateVariants는 같은 인덱스와 변형 조합이 중복되지 않는지 검사합니다.
+func validateVariants(table Table) error {
+	idx := VariantColumn(table)
+	if idx == -1 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for rowIdx, row := range table.Rows {
+		key := RowKey(table, row) + "\x00" + variantOf(row, idx)
+		if seen[key] {
+			return fmt.Errorf("table %s: duplicate index %s for variant %q at row %d",
+				table.Name, RowKey(table, row), variantOf(row, idx), rowIdx+1)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// buildVariantViewQueries는 변형별로 해당 변형의 행과, 변형이 없는 인덱스의 기본 행을 합친 뷰를 생성합니다.
+func buildVariantViewQueries(table Table, d SQLDialect) string {
+	idx := VariantColumn(table)
+	if idx == -1 {
+		return ""
+	}
+
+	quotedTable := d.QuoteIdentifier(table.Name)
+	quotedIndex := d.QuoteIdentifier(table.Columns[IndexColumn(table)].Name)
+	quotedVariant := d.QuoteIdentifier(table.Columns[idx].Name)
+
+	var b strings.Builder
+	for _, variant := range Variants(table) {
+		literal := "'" + strings.ReplaceAll(variant, "'", "''") + "'"
+		b.WriteString(fmt.Sprintf("CREATE VIEW IF NOT EXISTS %s AS\n", d.QuoteIdentifier(table.Name+"_variant_"+variant)))
+		b.WriteString(fmt.Sprintf("SELECT t.* FROM %s t\nWHERE t.%s = %s\n", quotedTable, quotedVariant, literal))
+		b.WriteString(fmt.Sprintf("   OR ((t.%s IS NULL OR t.%s = '') AND NOT EXISTS (\n", quotedVariant, quotedVariant))
+		b.WriteString(fmt.Sprintf("    SELECT 1 FROM %s o WHERE o.%s = t.%s AND o.%s = %s\n  ));\n",
+			quotedTable, quotedIndex, quotedIndex, quotedVariant, literal))
+	}
+	return b.String()
+}
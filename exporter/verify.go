@@ -0,0 +1,275 @@
+// exporter/verify.go
+package exporter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verifier는 export한 산출물을 다시 읽어 파싱된 시트 값과 셀 단위로 비교할 수 있는 exporter입니다.
+// verify 옵션이 켜져 있으면 Registry가 export 직후, 압축과 암호화 전에 호출합니다.
+type Verifier interface {
+	Verify(tables []Table, opts Options) ([]CellMismatch, error)
+}
+
+// CellMismatch는 산출물에 기록된 값이 파싱된 시트 값(선언된 타입으로 변환한 값)과 다른 셀입니다.
+type CellMismatch struct {
+	Output string // 산출물 파일 (출력 디렉토리 기준 경로)
+	Table  string
+	Row    string // 행 키 (키가 없으면 #순서), 비어 있으면 테이블 전체 (행 수)
+	Column string // 비어 있으면 행 전체 (산출물에 행이 없음)
+	Cell   string // 원본 셀 (C5 등), 알 수 없으면 빈 문자열
+	Want   string
+	Got    string
+}
+
+func (m CellMismatch) String() string {
+	where := m.Table
+	if m.Row != "" {
+		where += " row " + m.Row
+	}
+	if m.Column != "" {
+		where += " column " + m.Column
+	}
+	if m.Cell != "" {
+		where += " (" + m.Cell + ")"
+	}
+	return fmt.Sprintf("%s: %s: want %s, got %s", m.Output, where, m.Want, m.Got)
+}
+
+// maxLoggedMismatches는 로그에 남기는 다른 셀의 최대 개수입니다.
+const maxLoggedMismatches = 20
+
+// verifyExport는 exporter가 Verifier이면 산출물을 검증합니다. 다른 셀이 있으면 로그로 남기고 에러를 반환합니다.
+func verifyExport(exp Exporter, tables []Table, opts Options) error {
+	v, ok := exp.(Verifier)
+	if !ok {
+		return nil
+	}
+	mismatches, err := v.Verify(tables, opts)
+	if err != nil {
+		return fmt.Errorf("verify: %v", err)
+	}
+	for i, m := range mismatches {
+		if i == maxLoggedMismatches {
+			log.Printf("Verify: ... and %d more", len(mismatches)-i)
+			break
+		}
+		log.Printf("Verify: %s", m)
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("verify: %d cell(s) in the output differ from the sheets (first: %s)", len(mismatches), mismatches[0])
+	}
+	return nil
+}
+
+// missingValue는 산출물의 행에 컬럼이 없음을 나타냅니다. (JSON 객체에 키가 없음)
+type missingValue struct{}
+
+// verifyRows는 테이블의 모든 행을 산출물에서 읽은 행과 비교합니다.
+// 행 키가 모두 있고 유일하면 키 컬럼 값으로, 아니면 순서로 찾습니다. got의 행은 테이블 컬럼 순서의 값입니다.
+// storage는 산출물의 datetime 저장 형식입니다. (DateTimeEpoch이면 초 단위로 비교)
+func verifyRows(output string, table Table, got [][]interface{}, storage string) []CellMismatch {
+	var mismatches []CellMismatch
+	if len(got) != len(table.Rows) {
+		mismatches = append(mismatches, CellMismatch{
+			Output: output, Table: table.Name,
+			Want: fmt.Sprintf("%d rows", len(table.Rows)), Got: fmt.Sprintf("%d rows", len(got)),
+		})
+	}
+
+	byKey := map[string][]interface{}(nil)
+	if _, err := keyedRows(table); err == nil {
+		byKey = make(map[string][]interface{}, len(got))
+		for _, row := range got {
+			byKey[verifyKey(table, row, storage)] = row
+		}
+	}
+
+	for i, row := range table.Rows {
+		label := RowKey(table, row)
+		var actual []interface{}
+		found := false
+		if byKey != nil {
+			actual, found = byKey[verifyKey(table, row, storage)]
+		} else if i < len(got) {
+			actual, found = got[i], true
+		}
+		if label == "" || byKey == nil {
+			label = fmt.Sprintf("#%d", i+1)
+		}
+		if !found {
+			mismatches = append(mismatches, CellMismatch{Output: output, Table: table.Name, Row: label, Want: "a row", Got: "no row"})
+			continue
+		}
+
+		for j, col := range table.Columns {
+			var want, have interface{}
+			if j < len(row) {
+				want = row[j]
+			}
+			have = missingValue{}
+			if j < len(actual) {
+				have = actual[j]
+			}
+			w, g := canonicalCell(col, want, storage, true), canonicalCell(col, have, storage, false)
+			if w == g {
+				continue
+			}
+			cell := ""
+			if table.Source != nil {
+				cell = strings.Join(table.Source.Cells(RowKey(table, row), col.Name), ", ")
+			}
+			mismatches = append(mismatches, CellMismatch{
+				Output: output, Table: table.Name, Row: label, Column: col.Name, Cell: cell, Want: w, Got: g,
+			})
+		}
+	}
+	return mismatches
+}
+
+// verifyKey는 행의 키 컬럼 값으로 비교용 키를 만듭니다. 파싱된 행과 산출물에서 읽은 행이 같은 키를 갖도록 정규화한 값을 씁니다.
+func verifyKey(table Table, row []interface{}, storage string) string {
+	keys := []int{IndexColumn(table)}
+	if table.IsMatrix {
+		keys = []int{0, 1}
+	}
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		var value interface{}
+		if k < len(row) {
+			value = row[k]
+		}
+		parts = append(parts, canonicalCell(table.Columns[k], value, storage, false))
+	}
+	return strings.Join(parts, "/")
+}
+
+// canonicalCell은 셀 값을 비교할 수 있는 텍스트로 바꿉니다.
+// 파싱된 값(Go 타입)과 산출물에서 읽은 값(SQLite의 정수, 텍스트, JSON의 숫자, 문자열 등)이 같은 값이면 같은 텍스트가 됩니다.
+// parsed는 파싱된 값이며, epoch로 저장하는 datetime은 저장 정밀도(초)에 맞춥니다.
+func canonicalCell(col Column, value interface{}, storage string, parsed bool) string {
+	if value == nil {
+		return "null"
+	}
+	if _, ok := value.(missingValue); ok {
+		return "(missing)"
+	}
+
+	switch {
+	case col.Type.IsArray || col.Type.IsGeo() || col.Type.IsCurve():
+		return canonicalJSON(value)
+	case col.Type.Type == DateTimeType.Type:
+		t, ok := verifyTime(value)
+		if !ok {
+			return fmt.Sprintf("%v", value)
+		}
+		if parsed && storage == DateTimeEpoch {
+			t = time.Unix(t.Unix(), 0)
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	case col.Type.Type == BytesType.Type:
+		switch v := value.(type) {
+		case []byte:
+			return base64.StdEncoding.EncodeToString(v)
+		case string:
+			return v // JSON의 []byte는 base64 문자열
+		}
+	}
+
+	switch col.Type.Type.Kind() {
+	case reflect.Bool:
+		switch v := value.(type) {
+		case bool:
+			return strconv.FormatBool(v)
+		case int64:
+			return strconv.FormatBool(v != 0) // SQLite는 0 또는 1
+		}
+	case reflect.Int32, reflect.Int64:
+		if n, ok := verifyNumber(value); ok {
+			if i, err := strconv.ParseInt(n, 10, 64); err == nil {
+				return strconv.FormatInt(i, 10)
+			}
+			return n
+		}
+	case reflect.Float64:
+		if n, ok := verifyNumber(value); ok {
+			if f, err := strconv.ParseFloat(n, 64); err == nil {
+				return strconv.FormatFloat(f, 'g', -1, 64)
+			}
+			return n
+		}
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return strconv.Quote(string(v))
+	case string:
+		return strconv.Quote(v)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// verifyNumber는 정수, 실수, JSON 숫자를 숫자 텍스트로 바꿉니다.
+func verifyNumber(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case int:
+		return strconv.Itoa(v), true
+	case int32:
+		return strconv.FormatInt(int64(v), 10), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	case json.Number:
+		return v.String(), true
+	}
+	return "", false
+}
+
+// verifyTime은 파싱된 time.Time, 저장된 RFC 3339 텍스트, Unix 초를 시각으로 바꿉니다.
+func verifyTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case []byte:
+		return verifyTime(string(v))
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return t, err == nil
+	case int64:
+		return time.Unix(v, 0), true
+	case json.Number:
+		n, err := v.Int64()
+		return time.Unix(n, 0), err == nil
+	}
+	return time.Time{}, false
+}
+
+// canonicalJSON은 배열, 좌표, 커브 값을 키가 정렬된 JSON 텍스트로 바꿉니다. 파서가 만든 JSON 문자열과 저장된 텍스트는 JSON으로 해석합니다.
+func canonicalJSON(value interface{}) string {
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return fmt.Sprintf("%v", value)
+		}
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return strconv.Quote(string(data))
+	}
+	out, _ := json.Marshal(decoded)
+	return string(out)
+}
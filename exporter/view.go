@@ -0,0 +1,237 @@
+// exporter/view.go
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// View는 #View 시트에 선언된 SQL 뷰입니다.
+// SQL이 있으면 그대로 사용하고, 없으면 Base 테이블에서 Joins 테이블들을
+// #Relation 관계를 따라 LEFT JOIN하는 뷰를 생성합니다.
+type View struct {
+	Name  string   // 뷰 이름
+	Base  string   // 기준 테이블 (뷰는 이 테이블과 같은 출력 그룹에 생성됩니다)
+	Joins []string // 기준 테이블과 관계로 직접 연결된 테이블들
+	SQL   string   // 직접 작성한 SELECT 문
+	// Master이면 그룹 DB 대신 마스터 DB(sqlite --sqlite-master)에 팩 간 뷰로 선언됩니다. (Scope 컬럼이 master)
+	// 다른 팩의 테이블을 참조할 수 있으며, 실행 시 팩을 ATTACH한 연결에 TEMP VIEW로 생성됩니다.
+	Master bool
+}
+
+// ViewScopeMaster는 #View 시트 Scope 컬럼에서 팩 간 뷰를 나타내는 값입니다.
+const ViewScopeMaster = "master"
+
+// parseViews는 #View 시트(Name, Base, Join, SQL, Scope 헤더)에서 뷰 선언을 파싱합니다.
+// Join은 쉼표로 구분된 테이블 이름 목록입니다. Join, SQL, Scope 컬럼은 생략할 수 있습니다.
+func parseViews(f *excelize.File) ([]View, error) {
+	viewSheet := "#View"
+	if !contains(f.GetSheetList(), viewSheet) {
+		return nil, nil
+	}
+
+	rows, err := f.GetRows(viewSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read view sheet: %v", err)
+	}
+
+	if len(rows) < 2 {
+		return nil, nil
+	}
+
+	colIndexes := map[string]int{
+		"Name":  -1,
+		"Base":  -1,
+		"Join":  -1,
+		"SQL":   -1,
+		"Scope": -1,
+	}
+
+	for i, cell := range rows[0] {
+		colName := strings.TrimSpace(cell)
+		if _, ok := colIndexes[colName]; ok {
+			colIndexes[colName] = i
+		}
+	}
+
+	for _, col := range []string{"Name", "Base"} {
+		if colIndexes[col] == -1 {
+			return nil, fmt.Errorf("required column %s not found in view sheet", col)
+		}
+	}
+
+	var views []View
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		view := View{
+			Name: ParseColumnName(cellAt(row, colIndexes["Name"])),
+			Base: formatTableName(cellAt(row, colIndexes["Base"])),
+			SQL:  strings.TrimSuffix(cellAt(row, colIndexes["SQL"]), ";"),
+		}
+		if view.Name == "" {
+			continue
+		}
+		for _, join := range strings.Split(cellAt(row, colIndexes["Join"]), ",") {
+			if join = formatTableName(join); join != "" {
+				view.Joins = append(view.Joins, join)
+			}
+		}
+		if view.Base == "" {
+			return nil, fmt.Errorf("view %s has no base table", view.Name)
+		}
+		if view.SQL == "" && len(view.Joins) == 0 {
+			return nil, fmt.Errorf("view %s needs either Join tables or SQL", view.Name)
+		}
+		switch scope := strings.ToLower(strings.TrimSpace(cellAt(row, colIndexes["Scope"]))); scope {
+		case "":
+		case ViewScopeMaster:
+			view.Master = true
+		default:
+			return nil, fmt.Errorf("view %s has unknown scope %q (expected empty or %s)", view.Name, scope, ViewScopeMaster)
+		}
+		views = append(views, view)
+	}
+
+	return views, nil
+}
+
+// assignViews는 뷰를 기준 테이블에 연결합니다.
+// 워크북에 존재하지만 파싱되지 않은(선택되지 않은) 테이블을 기준으로 하는 뷰는 무시합니다.
+func assignViews(tables []Table, views []View, sheetNames []string) ([]Table, error) {
+	tableMap := make(map[string]int)
+	for i, table := range tables {
+		tableMap[table.Name] = i
+	}
+
+	knownSheets := make(map[string]bool)
+	for _, name := range sheetNames {
+		knownSheets[sheetTableName(name)] = true
+	}
+
+	for _, view := range views {
+		idx, ok := tableMap[view.Base]
+		if !ok && knownSheets[view.Base] {
+			continue
+		}
+		if !ok {
+			return nil, fmt.Errorf("view %s refers to unknown base table %s", view.Name, view.Base)
+		}
+		tables[idx].Views = append(tables[idx].Views, view)
+	}
+
+	return tables, nil
+}
+
+// buildViewQueries는 tables에 연결된 뷰의 생성 쿼리를 반환합니다.
+// master가 false이면 그룹 DB에 들어갈 뷰만, true이면 마스터 DB의 팩 간 뷰만 반환합니다.
+// 조인 대상 테이블은 tables에 포함되어 있어야 합니다.
+func buildViewQueries(tables []Table, master bool, d SQLDialect) ([]string, error) {
+	tableMap := make(map[string]Table)
+	for _, table := range tables {
+		tableMap[table.Name] = table
+	}
+
+	var queries []string
+	for _, table := range tables {
+		for _, view := range table.Views {
+			if view.Master != master {
+				continue
+			}
+			query, err := buildViewQuery(view, tableMap, d)
+			if err != nil {
+				return nil, err
+			}
+			queries = append(queries, query)
+		}
+	}
+	return queries, nil
+}
+
+// buildViewQuery는 뷰 하나의 생성 쿼리를 만듭니다.
+// 팩 간 뷰는 ATTACH된 DB의 테이블을 참조해야 하므로 TEMP VIEW로 만듭니다. (일반 뷰는 자기 DB의 테이블만 참조할 수 있습니다)
+func buildViewQuery(view View, tableMap map[string]Table, d SQLDialect) (string, error) {
+	create := "CREATE VIEW " + d.QuoteIdentifier(view.Name)
+	if view.Master {
+		create = "CREATE TEMP VIEW " + d.QuoteIdentifier(view.Name)
+	}
+	if view.SQL != "" {
+		return fmt.Sprintf("%s AS\n%s;\n", create, view.SQL), nil
+	}
+
+	base, ok := tableMap[view.Base]
+	if !ok {
+		return "", fmt.Errorf("view %s: base table %s is not part of this output", view.Name, view.Base)
+	}
+
+	selects := []string{"b.*"}
+	var joins []string
+
+	for i, name := range view.Joins {
+		target, ok := tableMap[name]
+		if !ok {
+			return "", fmt.Errorf("view %s: joined table %s is not part of this output", view.Name, name)
+		}
+
+		alias := fmt.Sprintf("j%d", i+1)
+		on, err := joinCondition(base, target, "b", alias, d)
+		if err != nil {
+			return "", fmt.Errorf("view %s: %v", view.Name, err)
+		}
+		joins = append(joins, fmt.Sprintf("LEFT JOIN %s %s ON %s", d.QuoteIdentifier(target.Name), alias, on))
+
+		// 조인된 테이블의 컬럼은 "<테이블>_<컬럼>" 이름으로 노출
+		for _, col := range target.Columns {
+			selects = append(selects, fmt.Sprintf("%s.%s AS %s",
+				alias, d.QuoteIdentifier(col.Name), d.QuoteIdentifier(target.Name+"_"+col.Name)))
+		}
+	}
+
+	return fmt.Sprintf("%s AS\nSELECT %s\nFROM %s b\n%s;\n",
+		create, strings.Join(selects, ",\n       "), d.QuoteIdentifier(base.Name), strings.Join(joins, "\n")), nil
+}
+
+// joinCondition은 두 테이블 사이의 #Relation 관계로부터 조인 조건을 만듭니다.
+// 관계는 어느 방향으로 선언되어 있어도 됩니다.
+func joinCondition(base, target Table, baseAlias, targetAlias string, d SQLDialect) (string, error) {
+	for _, rel := range base.Relations {
+		if rel.TargetTable == target.Name {
+			return fmt.Sprintf("%s.%s = %s.%s", baseAlias, d.QuoteIdentifier(rel.ForeignKey),
+				targetAlias, d.QuoteIdentifier(rel.ReferenceKey)), nil
+		}
+	}
+	for _, rel := range target.Relations {
+		if rel.TargetTable == base.Name {
+			return fmt.Sprintf("%s.%s = %s.%s", targetAlias, d.QuoteIdentifier(rel.ForeignKey),
+				baseAlias, d.QuoteIdentifier(rel.ReferenceKey)), nil
+		}
+	}
+	return "", fmt.Errorf("no relation between %s and %s", base.Name, target.Name)
+}
+
+// validateViews는 뷰 이름이 테이블이나 다른 뷰와 겹치지 않고 조인을 만들 수 있는지 확인합니다.
+func validateViews(tables []Table) []error {
+	names := make(map[string]bool)
+	for _, table := range tables {
+		names[strings.ToLower(table.Name)] = true
+	}
+
+	var errs []error
+	for _, table := range tables {
+		for _, view := range table.Views {
+			key := strings.ToLower(view.Name)
+			if names[key] {
+				errs = append(errs, fmt.Errorf("view %s conflicts with an existing table or view", view.Name))
+			}
+			names[key] = true
+		}
+	}
+
+	for _, master := range []bool{false, true} {
+		if _, err := buildViewQueries(tables, master, SQLiteDialect); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
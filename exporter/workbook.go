@@ -0,0 +1,77 @@
+// exporter/workbook.go
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// 워크북 읽기 재시도 설정 (Excel이 파일을 잠그고 있는 경우 대비)
+var (
+	workbookReadAttempts = 5
+	workbookRetryDelay   = 200 * time.Millisecond
+)
+
+// openWorkbook은 워크북을 메모리로 복사한 뒤 엽니다.
+// 파싱하는 동안 파일 핸들을 잡고 있지 않으므로 디자이너가 Excel에서 파일을 열어둔 상태에서도 안전하며,
+// 공유 위반(sharing violation)이 발생하면 잠시 기다렸다가 다시 시도합니다.
+func openWorkbook(path string) (*excelize.File, error) {
+	data, err := readFileWithRetry(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Excel file: %v", err)
+	}
+	f.Path = path
+	return f, nil
+}
+
+func readFileWithRetry(path string) ([]byte, error) {
+	osPath := longPath(path)
+	delay := workbookRetryDelay
+
+	var lastErr error
+	for attempt := 0; attempt < workbookReadAttempts; attempt++ {
+		data, err := os.ReadFile(osPath)
+		if err == nil {
+			return data, nil
+		}
+		lastErr = err
+
+		if !isSharingViolation(err) {
+			break
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+
+	if isSharingViolation(lastErr) || (!os.IsNotExist(lastErr) && excelLockFileExists(path)) {
+		return nil, fmt.Errorf("failed to read %s: the file is open in Excel or another program; close it and retry (%v)", path, lastErr)
+	}
+	return nil, fmt.Errorf("failed to read %s: %v", path, lastErr)
+}
+
+// excelLockFileExists는 Excel이 파일을 열 때 만드는 "~$" 잠금 파일이 있는지 확인합니다.
+func excelLockFileExists(path string) bool {
+	dir, base := filepath.Split(path)
+	candidates := []string{"~$" + base}
+	// Excel은 긴 파일 이름의 앞 두 글자를 잠금 파일 이름에서 생략합니다.
+	if runes := []rune(base); len(runes) > 2 {
+		candidates = append(candidates, "~$"+string(runes[2:]))
+	}
+
+	for _, name := range candidates {
+		if _, err := os.Stat(longPath(filepath.Join(dir, name))); err == nil {
+			return true
+		}
+	}
+	return false
+}
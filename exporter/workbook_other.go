@@ -0,0 +1,14 @@
+//go:build !windows
+
+// exporter/workbook_other.go
+package exporter
+
+// isSharingViolation은 Windows 이외의 환경에서는 항상 false입니다.
+func isSharingViolation(err error) bool {
+	return false
+}
+
+// longPath는 Windows 이외의 환경에서는 경로를 그대로 반환합니다.
+func longPath(path string) string {
+	return path
+}
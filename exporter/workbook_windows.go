@@ -0,0 +1,41 @@
+//go:build windows
+
+// exporter/workbook_windows.go
+package exporter
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// Windows 에러 코드
+const (
+	errorSharingViolation syscall.Errno = 32
+	errorLockViolation    syscall.Errno = 33
+)
+
+// maxPath는 긴 경로 접두사 없이 사용할 수 있는 최대 경로 길이입니다.
+const maxPath = 260
+
+// isSharingViolation은 다른 프로세스(주로 Excel)가 파일을 잠그고 있어서 실패했는지 확인합니다.
+func isSharingViolation(err error) bool {
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		return errno == errorSharingViolation || errno == errorLockViolation
+	}
+	return false
+}
+
+// longPath는 MAX_PATH를 넘는 경로에 \\?\ 접두사를 붙여 반환합니다.
+func longPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil || len(abs) < maxPath || strings.HasPrefix(abs, `\\?\`) {
+		return path
+	}
+	if strings.HasPrefix(abs, `\\`) {
+		return `\\?\UNC\` + abs[2:]
+	}
+	return `\\?\` + abs
+}
@@ -0,0 +1,160 @@
+// exporter/writeback.go
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ValidationSheet은 검증 결과를 기록한 워크북 사본의 요약 시트 이름입니다.
+// #으로 시작하므로 사본을 다시 파싱해도 데이터로 읽지 않습니다.
+const ValidationSheet = "#Validation"
+
+// validationAuthor는 검증 결과 셀 메모의 작성자 이름입니다.
+const validationAuthor = "excelite"
+
+// WriteValidationWorkbook은 src 워크북의 사본을 dst에 만들고 검증 결과를 기록합니다.
+// 셀 위치가 있는 결과는 해당 셀의 메모로, 모든 결과는 요약 시트(#Validation)에 셀로 가는 하이퍼링크와 함께 나열합니다.
+// 디자이너가 Excel에서 바로 문제를 확인할 수 있도록 하기 위함이며, 원본 워크북은 수정하지 않습니다.
+func WriteValidationWorkbook(src, dst string, issues []LintIssue) error {
+	if absPath(src) == absPath(dst) {
+		return fmt.Errorf("validation copy %s would overwrite the workbook", dst)
+	}
+
+	f, err := openWorkbook(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// 같은 셀의 결과는 메모 하나에 모음
+	type cellKey struct{ sheet, cell string }
+	var order []cellKey
+	notes := make(map[cellKey][]string)
+	sheets := f.GetSheetList()
+	for _, issue := range issues {
+		if issue.Cell == "" || !contains(sheets, issue.Sheet) {
+			continue
+		}
+		key := cellKey{issue.Sheet, issue.Cell}
+		if _, ok := notes[key]; !ok {
+			order = append(order, key)
+		}
+		notes[key] = append(notes[key], fmt.Sprintf("[%s] %s (%s)", issue.Severity, issue.Message, issue.Rule))
+	}
+	for _, key := range order {
+		if err := addValidationComment(f, key.sheet, key.cell, strings.Join(notes[key], "\n")); err != nil {
+			return fmt.Errorf("failed to comment %s!%s: %v", key.sheet, key.cell, err)
+		}
+	}
+
+	if err := writeValidationSheet(f, issues); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := f.SaveAs(dst); err != nil {
+		return fmt.Errorf("failed to write %s: %v", dst, err)
+	}
+	return nil
+}
+
+// addValidationComment는 셀에 메모를 추가합니다. 셀에 이미 메모가 있으면 기존 내용 뒤에 붙입니다.
+func addValidationComment(f *excelize.File, sheet, cell, text string) error {
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return err
+	}
+	for _, c := range comments {
+		if !strings.EqualFold(c.Cell, cell) {
+			continue
+		}
+		existing := c.Text
+		for _, run := range c.Paragraph {
+			existing += run.Text
+		}
+		if err := f.DeleteComment(sheet, c.Cell); err != nil {
+			return err
+		}
+		text = existing + "\n\n" + text
+		break
+	}
+	return f.AddComment(sheet, excelize.Comment{
+		Author: validationAuthor,
+		Cell:   cell,
+		Text:   text,
+		Width:  320,
+		Height: 120,
+	})
+}
+
+// writeValidationSheet는 요약 시트를 만들고 결과를 한 행씩 씁니다. 위치 셀은 해당 시트/셀로 가는 링크입니다.
+func writeValidationSheet(f *excelize.File, issues []LintIssue) error {
+	if contains(f.GetSheetList(), ValidationSheet) {
+		if err := f.DeleteSheet(ValidationSheet); err != nil {
+			return err
+		}
+	}
+	idx, err := f.NewSheet(ValidationSheet)
+	if err != nil {
+		return err
+	}
+	f.SetActiveSheet(idx)
+
+	header := []interface{}{"Severity", "Rule", "Location", "Message"}
+	if err := f.SetSheetRow(ValidationSheet, "A1", &header); err != nil {
+		return err
+	}
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	if err := f.SetCellStyle(ValidationSheet, "A1", "D1", bold); err != nil {
+		return err
+	}
+
+	sheets := f.GetSheetList()
+	for i, issue := range issues {
+		r := i + 2
+		location := issue.Sheet
+		if issue.Cell != "" {
+			location += "!" + issue.Cell
+		}
+		row := []interface{}{string(issue.Severity), issue.Rule, location, issue.Message}
+		if err := f.SetSheetRow(ValidationSheet, fmt.Sprintf("A%d", r), &row); err != nil {
+			return err
+		}
+		if issue.Sheet == "" || !contains(sheets, issue.Sheet) {
+			continue
+		}
+		target := issue.Cell
+		if target == "" {
+			target = "A1"
+		}
+		link := fmt.Sprintf("'%s'!%s", strings.ReplaceAll(issue.Sheet, "'", "''"), target)
+		if err := f.SetCellHyperLink(ValidationSheet, fmt.Sprintf("C%d", r), link, "Location"); err != nil {
+			return err
+		}
+	}
+
+	if err := f.SetColWidth(ValidationSheet, "A", "B", 14); err != nil {
+		return err
+	}
+	if err := f.SetColWidth(ValidationSheet, "C", "C", 20); err != nil {
+		return err
+	}
+	return f.SetColWidth(ValidationSheet, "D", "D", 100)
+}
+
+func absPath(path string) string {
+	if a, err := filepath.Abs(path); err == nil {
+		return a
+	}
+	return path
+}
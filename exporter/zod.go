@@ -0,0 +1,412 @@
+// exporter/zod.go
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ZodExporter는 테이블마다 TypeScript 런타임 검증 스키마(Zod 또는 TypeBox)를 생성합니다.
+// 타입, notnull, min/max, size, validate 태그로부터 만들어지므로 API 페이로드를 시트와 같은 규칙으로 검증할 수 있습니다.
+// readonly/writeonly 컬럼이 있는 테이블은 그 컬럼을 뺀 수정 페이로드(Update)와 출력(Output) 스키마를 함께 생성합니다.
+type ZodExporter struct {
+	BaseExporter
+	typebox bool
+}
+
+func NewZodExporter() Exporter {
+	return &ZodExporter{BaseExporter: NewBaseExporter("zod")}
+}
+
+func NewTypeBoxExporter() Exporter {
+	return &ZodExporter{BaseExporter: NewBaseExporter("typebox"), typebox: true}
+}
+
+func (e *ZodExporter) Describe() ExporterInfo {
+	library := "Zod"
+	if e.typebox {
+		library = "TypeBox"
+	}
+	return ExporterInfo{
+		Description: "TypeScript " + library + " runtime validation schemas from types, notnull, min/max, size and validate tags",
+		Outputs:     []string{"schemas.ts"},
+		Types: typeMappings(func(col Column) (string, error) {
+			return e.columnSchema(col, true)
+		}),
+	}
+}
+
+// validateRule은 validate 태그의 규칙 하나입니다. (예: email, regex('^[a-z]+$'), oneof(a|b), exists(Skill.Index))
+type validateRule struct {
+	Name string
+	Arg  string
+}
+
+// 지원하는 validate 규칙 (다른 테이블을 참조하는 규칙은 crossTableRuleNames)
+var validateRuleNames = []string{"email", "url", "uuid", "nonempty", "positive", "nonnegative", "regex", "oneof"}
+
+// ValidateRules는 컬럼의 validate 태그들을 규칙으로 파싱합니다.
+func ValidateRules(col Column) ([]validateRule, error) {
+	var rules []validateRule
+	for _, tag := range col.Tags {
+		if tag.Tag != TagValidate {
+			continue
+		}
+		value := strings.TrimSpace(tag.Value)
+		rule := validateRule{Name: strings.ToLower(value)}
+		if open := strings.Index(value, "("); open != -1 && strings.HasSuffix(value, ")") {
+			rule.Name = strings.ToLower(strings.TrimSpace(value[:open]))
+			rule.Arg = strings.Trim(strings.TrimSpace(value[open+1:len(value)-1]), `'"`)
+		}
+		if !containsString(validateRuleNames, rule.Name) && !rule.isCrossTableRule() {
+			return nil, fmt.Errorf("unknown validate rule %q (supported: %s)", value, strings.Join(validateRuleNames, ", ")+", "+strings.Join(crossTableRuleNames, ", "))
+		}
+		if rule.isCrossTableRule() {
+			if _, err := parseColumnRef(rule.Arg); err != nil {
+				return nil, fmt.Errorf("invalid validate rule %q: %v", value, err)
+			}
+		}
+		if rule.Name == "regex" {
+			if _, err := regexp.Compile(rule.Arg); err != nil {
+				return nil, fmt.Errorf("invalid validate regex %q: %v", rule.Arg, err)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// oneOfValues는 oneof 규칙의 값 목록입니다. 값은 |나 공백으로 구분합니다.
+func (r validateRule) oneOfValues() []string {
+	return strings.FieldsFunc(r.Arg, func(c rune) bool { return c == '|' || c == ' ' })
+}
+
+func (e *ZodExporter) Export(tables []Table, opts Options) error {
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by excelite. DO NOT EDIT.\n")
+	if e.typebox {
+		b.WriteString("import { Type, type Static } from \"@sinclair/typebox\";\n")
+	} else {
+		b.WriteString("import { z } from \"zod\";\n")
+	}
+
+	for _, table := range tables {
+		fmt.Fprintf(&b, "\n// %s is a row of the %s table.\n", table.Name, table.Name)
+		if e.typebox {
+			fmt.Fprintf(&b, "export const %sSchema = Type.Object({\n", table.Name)
+		} else {
+			fmt.Fprintf(&b, "export const %sSchema = z.object({\n", table.Name)
+		}
+		for i, col := range table.Columns {
+			schema, err := e.columnSchema(col, i == IndexColumn(table))
+			if err != nil {
+				return fmt.Errorf("table %s column %s: %v", table.Name, col.Name, err)
+			}
+			if msg, ok := DeprecationMessage(col); ok {
+				fmt.Fprintf(&b, "  /** @deprecated %s */\n", strings.ReplaceAll(msg, "*/", "* /"))
+			}
+			fmt.Fprintf(&b, "  %s: %s,\n", tsPropertyName(col.Name), schema)
+		}
+		b.WriteString("});\n")
+		e.writeType(&b, table.Name, table.Name+"Schema")
+
+		// 수정 페이로드는 readonly 컬럼을, 직렬화된 출력은 writeonly 컬럼을 뺀 스키마로 검증
+		var readOnly, writeOnly []string
+		for _, col := range table.Columns {
+			if IsReadOnly(col) {
+				readOnly = append(readOnly, col.Name)
+			}
+			if IsWriteOnly(col) {
+				writeOnly = append(writeOnly, col.Name)
+			}
+		}
+		if len(readOnly) > 0 {
+			fmt.Fprintf(&b, "\n// %sUpdate is an update payload of the %s table (readonly columns omitted).\n", table.Name, table.Name)
+			e.writeOmit(&b, table.Name, "Update", readOnly)
+		}
+		if len(writeOnly) > 0 {
+			fmt.Fprintf(&b, "\n// %sOutput is a serialized %s row (writeonly columns omitted).\n", table.Name, table.Name)
+			e.writeOmit(&b, table.Name, "Output", writeOnly)
+		}
+	}
+	if hasCurveColumn(tables) {
+		b.WriteString(tsCurveSource)
+	}
+	if hasFormulaColumn(tables) {
+		b.WriteString(tsFormulaSource)
+	}
+
+	return os.WriteFile(filepath.Join(opts.OutputDir, "schemas.ts"), []byte(b.String()), 0644)
+}
+
+func (e *ZodExporter) writeType(b *strings.Builder, name, schema string) {
+	if e.typebox {
+		fmt.Fprintf(b, "export type %s = Static<typeof %s>;\n", name, schema)
+	} else {
+		fmt.Fprintf(b, "export type %s = z.infer<typeof %s>;\n", name, schema)
+	}
+}
+
+// writeOmit은 테이블 스키마에서 columns를 뺀 <Table><suffix>Schema와 그 타입을 씁니다.
+func (e *ZodExporter) writeOmit(b *strings.Builder, table, suffix string, columns []string) {
+	keys := make([]string, len(columns))
+	for i, name := range columns {
+		if e.typebox {
+			keys[i] = jsString(name)
+		} else {
+			keys[i] = tsPropertyName(name) + ": true"
+		}
+	}
+	if e.typebox {
+		fmt.Fprintf(b, "export const %s%sSchema = Type.Omit(%sSchema, [%s]);\n", table, suffix, table, strings.Join(keys, ", "))
+	} else {
+		fmt.Fprintf(b, "export const %s%sSchema = %sSchema.omit({ %s });\n", table, suffix, table, strings.Join(keys, ", "))
+	}
+	e.writeType(b, table+suffix, table+suffix+"Schema")
+}
+
+// columnSchema는 컬럼 하나의 스키마 식을 만듭니다.
+// 배열 컬럼의 min/max와 validate 규칙은 원소에 적용됩니다.
+func (e *ZodExporter) columnSchema(col Column, isIndex bool) (string, error) {
+	rules, err := ValidateRules(col)
+	if err != nil {
+		return "", err
+	}
+	bounds, err := ColumnBounds(col)
+	if err != nil {
+		return "", err
+	}
+
+	elem := col.Type
+	if col.Type.IsArray {
+		elem = *col.Type.BaseType
+	}
+	kind := schemaKind(elem)
+	for _, rule := range rules {
+		switch rule.Name {
+		case "email", "url", "uuid", "regex", "nonempty":
+			if kind != "string" {
+				return "", fmt.Errorf("validate rule %s requires a string column", rule.Name)
+			}
+		case "positive", "nonnegative":
+			if kind != "integer" && kind != "number" {
+				return "", fmt.Errorf("validate rule %s requires a numeric column", rule.Name)
+			}
+		}
+	}
+	schema := e.scalarSchema(kind, rules, bounds, col)
+
+	if col.Type.IsArray {
+		if e.typebox {
+			schema = fmt.Sprintf("Type.Array(%s)", schema)
+		} else {
+			schema = fmt.Sprintf("z.array(%s)", schema)
+		}
+	}
+
+	if !isIndex && !HasTag(col.Tags, TagNotNull) {
+		if e.typebox {
+			schema = fmt.Sprintf("Type.Union([%s, Type.Null()])", schema)
+		} else {
+			schema += ".nullable()"
+		}
+	}
+	return schema, nil
+}
+
+// schemaKind는 배열이 아닌 타입을 스키마 종류로 분류합니다.
+func schemaKind(ct ColumnType) string {
+	kind := "string"
+	switch {
+	case ct.IsGeo():
+		kind = "geo"
+	case ct.IsCurve():
+		kind = "curve"
+	case ct.Type == DateTimeType.Type:
+		kind = "datetime"
+	case ct.Type == BytesType.Type:
+		kind = "bytes"
+	default:
+		switch ct.Type.Kind() {
+		case reflect.Int32, reflect.Int64:
+			kind = "integer"
+		case reflect.Float64:
+			kind = "number"
+		case reflect.Bool:
+			kind = "boolean"
+		}
+	}
+	return kind
+}
+
+// scalarSchema는 배열이 아닌 타입의 스키마와 제약을 만듭니다.
+func (e *ZodExporter) scalarSchema(kind string, rules []validateRule, bounds Bounds, col Column) string {
+	numeric := kind == "integer" || kind == "number"
+
+	// oneof는 리터럴 유니온으로 기본 타입을 대신함
+	for _, rule := range rules {
+		if rule.Name != "oneof" {
+			continue
+		}
+		var literals []string
+		for _, v := range rule.oneOfValues() {
+			lit := jsString(v)
+			if numeric {
+				if _, err := strconv.ParseFloat(v, 64); err == nil {
+					lit = v
+				}
+			}
+			if e.typebox {
+				literals = append(literals, fmt.Sprintf("Type.Literal(%s)", lit))
+			} else {
+				literals = append(literals, fmt.Sprintf("z.literal(%s)", lit))
+			}
+		}
+		if e.typebox {
+			return fmt.Sprintf("Type.Union([%s])", strings.Join(literals, ", "))
+		}
+		if len(literals) == 1 {
+			return literals[0]
+		}
+		return fmt.Sprintf("z.union([%s])", strings.Join(literals, ", "))
+	}
+
+	maxLen := -1
+	if size, ok := GetTagValue(col.Tags, TagSize); ok && kind == "string" {
+		if n, err := strconv.Atoi(strings.TrimSpace(size)); err == nil {
+			maxLen = n
+		}
+	}
+
+	if e.typebox {
+		var props []string
+		if bounds.HasMin {
+			props = append(props, typeboxBound(kind, "min", bounds.Min))
+		}
+		if bounds.HasMax {
+			props = append(props, typeboxBound(kind, "max", bounds.Max))
+		} else if maxLen >= 0 {
+			props = append(props, fmt.Sprintf("maxLength: %d", maxLen))
+		}
+		for _, rule := range rules {
+			switch rule.Name {
+			case "email", "uuid":
+				props = append(props, fmt.Sprintf("format: %q", rule.Name))
+			case "url":
+				props = append(props, `format: "uri"`)
+			case "regex":
+				props = append(props, "pattern: "+jsString(rule.Arg))
+			case "nonempty":
+				props = append(props, "minLength: 1")
+			case "positive":
+				props = append(props, "exclusiveMinimum: 0")
+			case "nonnegative":
+				props = append(props, "minimum: 0")
+			}
+		}
+		opts := ""
+		if len(props) > 0 {
+			opts = "{ " + strings.Join(props, ", ") + " }"
+		}
+		switch kind {
+		case "geo":
+			return "Type.Tuple([Type.Number(), Type.Number()])"
+		case "curve":
+			return `Type.Object({ mode: Type.Union([Type.Literal("linear"), Type.Literal("bezier")]), keys: Type.Array(Type.Array(Type.Number())) })`
+		case "datetime":
+			props = append([]string{`format: "date-time"`}, props...)
+			return "Type.String({ " + strings.Join(props, ", ") + " })"
+		case "bytes":
+			return `Type.String({ contentEncoding: "base64" })`
+		case "integer":
+			return "Type.Integer(" + opts + ")"
+		case "number":
+			return "Type.Number(" + opts + ")"
+		case "boolean":
+			return "Type.Boolean()"
+		}
+		return "Type.String(" + opts + ")"
+	}
+
+	var s string
+	switch kind {
+	case "geo":
+		return "z.tuple([z.number(), z.number()])"
+	case "curve":
+		return `z.object({ mode: z.enum(["linear", "bezier"]), keys: z.array(z.array(z.number())) })`
+	case "datetime":
+		return "z.coerce.date()"
+	case "bytes":
+		return "z.string().base64()"
+	case "integer":
+		s = "z.number().int()"
+	case "number":
+		s = "z.number()"
+	case "boolean":
+		return "z.boolean()"
+	default:
+		s = "z.string()"
+	}
+	if bounds.HasMin {
+		s += fmt.Sprintf(".min(%s)", schemaBound(kind, bounds.Min))
+	}
+	if bounds.HasMax {
+		s += fmt.Sprintf(".max(%s)", schemaBound(kind, bounds.Max))
+	} else if maxLen >= 0 {
+		s += fmt.Sprintf(".max(%d)", maxLen)
+	}
+	for _, rule := range rules {
+		switch rule.Name {
+		case "email", "url", "uuid":
+			s += "." + rule.Name + "()"
+		case "regex":
+			s += fmt.Sprintf(".regex(new RegExp(%s))", jsString(rule.Arg))
+		case "nonempty":
+			s += ".min(1)"
+		case "positive", "nonnegative":
+			s += "." + rule.Name + "()"
+		}
+	}
+	return s
+}
+
+// schemaBound는 범위 값을 스키마 리터럴로 씁니다. 문자열의 범위는 길이입니다.
+func schemaBound(kind string, v float64) string {
+	if kind == "string" || kind == "integer" {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func typeboxBound(kind, which string, v float64) string {
+	key := map[string]string{"min": "minimum", "max": "maximum"}[which]
+	if kind == "string" {
+		key = map[string]string{"min": "minLength", "max": "maxLength"}[which]
+	}
+	return key + ": " + schemaBound(kind, v)
+}
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsPropertyName은 식별자로 쓸 수 없는 컬럼 이름을 따옴표로 감쌉니다.
+func tsPropertyName(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return jsString(name)
+}
+
+func jsString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+// optionFlags는 ExtraOptions 키를 설정하는 generate 플래그입니다.
+var optionFlags = map[string]string{
+	exporter.OptSQLiteStrict:       "--sqlite-strict",
+	exporter.OptSQLiteWithoutRowID: "--sqlite-without-rowid",
+	exporter.OptSQLiteQueries:      "--sqlite-queries",
+	exporter.OptSQLiteMaster:       "--sqlite-master",
+	exporter.OptSQLiteBeforeSchema: "--sqlite-before-schema",
+	exporter.OptSQLiteAfterSchema:  "--sqlite-after-schema",
+	exporter.OptSQLiteAfterData:    "--sqlite-after-data",
+	exporter.OptSQLiteIncremental:  "--tables",
+	exporter.OptJSONKeyed:          "--json-keyed",
+	exporter.OptSQLXReload:         "--sqlx-reload",
+	exporter.OptSQLXColumnar:       "--sqlx-columnar",
+	exporter.OptNarrowInts:         "--narrow-ints",
+	exporter.OptDisplayLocale:      "--display-locale",
+	exporter.OptDisplayFormats:     "--display-format",
+	exporter.OptEncrypt:            "--encrypt",
+	exporter.OptEncryptKeyEnv:      "--encrypt-key-env",
+	exporter.OptCompress:           "--compress",
+	exporter.OptCompressLevel:      "--compress",
+}
+
+func newExportersCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "exporters [lang...]",
+		Short: "List the available exporters with their options and type mappings",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			registry := newCLIRegistry("models")
+
+			langs := args
+			if len(langs) == 0 {
+				langs = registry.Languages()
+				sort.Strings(langs)
+			}
+			infos := make([]exporter.ExporterInfo, 0, len(langs))
+			for _, lang := range langs {
+				info, err := registry.Describe(lang)
+				if err != nil {
+					return err
+				}
+				infos = append(infos, info)
+			}
+
+			w := cmd.OutOrStdout()
+			switch format {
+			case "text":
+				writeExporterInfos(w, infos)
+				return nil
+			case "json":
+				enc := json.NewEncoder(w)
+				enc.SetIndent("", "  ")
+				return enc.Encode(struct {
+					Exporters     []exporter.ExporterInfo `json:"exporters"`
+					CommonOptions []exporter.OptionInfo   `json:"commonOptions"`
+				}{infos, exporter.CommonOptions()})
+			}
+			return fmt.Errorf("unknown exporters format %q (expected text or json)", format)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+	return cmd
+}
+
+func writeExporterInfos(w io.Writer, infos []exporter.ExporterInfo) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	defer tw.Flush()
+
+	for _, info := range infos {
+		fmt.Fprintf(tw, "%s\t%s\n", info.Language, info.Description)
+		if len(info.Outputs) > 0 {
+			fmt.Fprintf(tw, "  outputs:\t%v\n", info.Outputs)
+		}
+		if len(info.Options) > 0 {
+			fmt.Fprintln(tw, "  options:")
+			writeOptionInfos(tw, info.Options)
+		}
+		if len(info.Types) > 0 {
+			fmt.Fprintln(tw, "  types:")
+			for _, t := range info.Types {
+				fmt.Fprintf(tw, "    %s\t%s\n", t.Type, t.Target)
+			}
+		}
+		fmt.Fprintln(tw)
+	}
+
+	fmt.Fprintln(tw, "options of every exporter:")
+	writeOptionInfos(tw, exporter.CommonOptions())
+}
+
+func writeOptionInfos(w io.Writer, options []exporter.OptionInfo) {
+	for _, opt := range options {
+		def := opt.Default
+		if def == "" {
+			def = `""`
+		}
+		fmt.Fprintf(w, "    %s\t%s (default %s)\t%s\t%s\n", opt.Key, opt.Type, def, optionFlags[opt.Key], opt.Description)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+func newFakeCommand(input *inputFlags) *cobra.Command {
+	var opts exporter.FakeOptions
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "fake",
+		Short: "Fill workbooks with seeded random rows generated from their header rows",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := input.resolve()
+			if err != nil {
+				return err
+			}
+
+			outputs, err := exporter.FakeWorkbooks(files, output, opts)
+			if err != nil {
+				return err
+			}
+			for _, out := range outputs {
+				log.Printf("Wrote %d fake row(s) per table to %s", opts.Rows, out)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.Rows, "rows", "n", 100, "Number of rows to generate per table")
+	cmd.Flags().Int64Var(&opts.Seed, "seed", 1, "Random seed (the same seed generates the same data)")
+	cmd.Flags().StringVarP(&output, "output", "o", "fake", "Directory for the generated workbooks")
+	cmd.MarkFlagDirname("output")
+	return cmd
+}
@@ -0,0 +1,971 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+// generateFlags는 generate 커맨드의 플래그 값입니다.
+type generateFlags struct {
+	outputDir     string
+	languages     string
+	packageName   string
+	overlayFiles  string
+	encrypt       bool
+	encryptKeyEnv string
+	onlyTables    string
+	writeManifest bool
+	sign          bool
+	signKeyEnv    string
+	compress      string
+	verify        bool
+	clean         bool
+	profile       string
+	profilesFile  string
+	idRangesFile  string
+	namesFile     string
+	sqliteStrict  bool
+	withoutRowID  bool
+	quoteAll      bool
+	queries       string
+	queriesPrune  string // Go 쿼리 레이어를 사용하는 코드베이스 디렉토리
+	sqliteMaster  bool
+	beforeSchema  string // 테이블 생성 전에 실행할 SQL 파일
+	afterSchema   string // 테이블 생성 후에 실행할 SQL 파일
+	afterData     string // 데이터 적재 후에 실행할 SQL 파일
+	jsonKeyed     bool
+	sqlxReload    bool
+	sqlxColumnar  bool
+	sqlxGeneric   bool
+	sqlxCache     int
+	sqlxHooks     bool
+	sqlxOTel      bool
+	sqlxInterface string
+	sqlxMarkers   bool
+	narrowInts    bool
+	summaryFile   string
+	snapshot      bool
+	snapshotTags  string
+	snapshotDir   string
+	snapshotKeep  int
+	fromSnapshot  string // snapshot export: 워크북 대신 사용할 스냅샷
+	displayLocale string
+	displayFormat string
+	tablesJSON    bool
+	sourceMap     bool
+	features      string
+	templateDir   string
+	until         string
+	force         bool
+}
+
+func newGenerateCommand(input *inputFlags) *cobra.Command {
+	var flags generateFlags
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate code and databases from Excel workbooks",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			printBanner()
+			_, err := runGenerate(cmd.Context(), input, &flags)
+			return err
+		},
+	}
+
+	addGenerateFlags(cmd, &flags)
+	return cmd
+}
+
+// addGenerateFlags는 generate와 같은 플래그를 사용하는 커맨드(watch, serve)에 플래그를 등록합니다.
+func addGenerateFlags(cmd *cobra.Command, flags *generateFlags) {
+	f := cmd.Flags()
+	f.StringVar(&flags.outputDir, "output", "generated", "Output directory for generated files")
+	f.StringVar(&flags.languages, "lang", "all", "Comma-separated list of target languages (go,cpp,nodejs,all)")
+	f.StringVar(&flags.packageName, "package", "models", "Package name for generated code")
+	f.StringVar(&flags.overlayFiles, "overlay", "", "Comma-separated list of overlay Excel files containing changed/added rows")
+	f.BoolVar(&flags.encrypt, "encrypt", false, "Encrypt generated data artifacts with AES-GCM")
+	f.StringVar(&flags.encryptKeyEnv, "encrypt-key-env", exporter.DefaultEncryptKeyEnv, "Environment variable holding the encryption key (hex or base64)")
+	f.StringVar(&flags.onlyTables, "tables", "", "Comma-separated list of tables to regenerate (their relation partners are included)")
+	f.BoolVar(&flags.writeManifest, "manifest", false, "Write a content-addressable manifest.json of all generated artifacts")
+	f.BoolVar(&flags.sign, "sign", false, "Sign manifest.json with an ed25519 key and emit Go/TypeScript verification code (implies --manifest)")
+	f.StringVar(&flags.signKeyEnv, "sign-key-env", exporter.DefaultSignKeyEnv, "Environment variable holding the ed25519 signing key (32-byte seed or 64-byte private key, hex or base64)")
+	f.BoolVar(&flags.tablesJSON, "tables-manifest", false, "Write tables.json describing every table's columns, types, tags, relations and source sheet")
+	f.BoolVar(&flags.sourceMap, "sourcemap", false, "Write sourcemap.json linking every generated row and field to its workbook cell (see where)")
+	f.StringVar(&flags.compress, "compress", "", "Compress data artifacts: algo[:level] for all exporters or lang=algo[:level],... (gzip, zstd)")
+	f.BoolVar(&flags.verify, "verify", false, "Re-read generated SQLite databases and JSON files and compare every cell with the parsed sheets")
+	f.StringVar(&flags.templateDir, "template-dir", "", "Directory overriding built-in templates by relative path (see emit-templates)")
+	f.StringVar(&flags.until, "until", "", "Stop after this pipeline stage (parse, transform, validate, export, package)")
+	f.BoolVar(&flags.snapshot, "snapshot", false, "Archive the parsed dataset under --snapshot-dir after a successful generate (see the snapshot command)")
+	f.StringVar(&flags.snapshotTags, "snapshot-tag", "", "Comma-separated tags of the archived snapshot, e.g. a release name (implies --snapshot)")
+	f.StringVar(&flags.snapshotDir, "snapshot-dir", exporter.DefaultSnapshotDir, "Directory of archived dataset snapshots")
+	f.IntVar(&flags.snapshotKeep, "snapshot-keep", 20, "Number of most recent untagged snapshots to keep (0 keeps all)")
+	f.StringVar(&flags.summaryFile, "summary-file", "", "Write the run summary (tables, rows, warnings, per-exporter status, duration and output size) as JSON to this file")
+	f.BoolVar(&flags.force, "force", false, "Run every pipeline stage even if its inputs are unchanged since the last run")
+	f.BoolVar(&flags.clean, "clean", false, "Replace the whole output directory (previous output is kept as <output>.bak)")
+	f.StringVar(&flags.profile, "profile", "", "Environment profile (e.g. dev, staging, prod) selecting output, DSN, tables and config overrides")
+	f.StringVar(&flags.profilesFile, "profiles-file", exporter.DefaultProfilesFile, "JSON file defining the profiles for --profile")
+	f.StringVar(&flags.idRangesFile, "id-ranges", exporter.DefaultIDRangesFile, "JSON file reserving index ranges per workbook/team; rows outside their workbook's range fail validation")
+	f.StringVar(&flags.namesFile, "names", exporter.DefaultIdentifierMapFile, "JSON file renaming Korean/Japanese sheet and column names to code identifiers (explicit mapping and/or romanization)")
+	f.BoolVar(&flags.sqliteStrict, "sqlite-strict", false, "Create SQLite STRICT tables that reject values of the wrong type (SQLite 3.37+)")
+	f.BoolVar(&flags.withoutRowID, "sqlite-without-rowid", false, "Create SQLite WITHOUT ROWID tables keyed by the index column")
+	f.BoolVar(&flags.quoteAll, "sqlite-quote-all", false, "Quote every SQLite identifier in the generated SQL (tables, indexes, views, inserts and query layers), not only keywords and special names")
+	f.BoolVar(&flags.sqliteMaster, "sqlite-master", false, "Also write a SQLite master.db that ATTACHes the group DBs (packs) and defines the cross-pack views of #View Scope master")
+	f.StringVar(&flags.beforeSchema, "sqlite-before-schema", "", "SQL file to run in every SQLite DB before its tables are created (e.g. pragmas); also written to schema.sql")
+	f.StringVar(&flags.afterSchema, "sqlite-after-schema", "", "SQL file to run after the SQLite tables are created and before rows are loaded (e.g. indexes, triggers); also written to schema.sql")
+	f.StringVar(&flags.afterData, "sqlite-after-data", "", "SQL file to run after the SQLite rows are loaded (e.g. extra views, ANALYZE); also written to schema.sql")
+	f.StringVar(&flags.queries, "sqlite-queries", "", "Comma-separated languages of typed prepared-query helpers to emit next to the SQLite DB (go,cpp,csharp)")
+	f.StringVar(&flags.queriesPrune, "sqlite-queries-prune", "", "Go code base directory using the Go query layer; only the tables and lookups it references are generated (smaller server binaries)")
+
+	f.StringVar(&flags.features, "features", "", "Comma-separated optional column groups to generate (overrides the profile; default: all groups)")
+	f.BoolVar(&flags.sqlxReload, "sqlx-reload", false, "Also generate an in-memory sqlx Dataset with Reload() and fsnotify auto-reload for long-running servers")
+	f.BoolVar(&flags.sqlxColumnar, "sqlx-columnar", false, "Also generate sqlx struct-of-arrays column types for systems iterating whole tables every tick")
+	f.BoolVar(&flags.sqlxGeneric, "sqlx-generic", false, "Also generate a generic sqlx Table[K, T] container with typed Get/All/Filter/Find and per-table loaders; the reload Dataset then holds Tables")
+	f.IntVar(&flags.sqlxCache, "sqlx-cache", 0, "Also generate a read-through LRU sqlx Cache over the primary/unique key loaders keeping this many rows per lookup by default (0: none)")
+	f.BoolVar(&flags.sqlxHooks, "sqlx-hooks", false, "Make the sqlx loaders report every query and unique key miss to a QueryHook (OnQuery, OnMiss)")
+	f.BoolVar(&flags.sqlxOTel, "sqlx-otel", false, "Also generate an OpenTelemetry QueryHook for the sqlx loaders recording spans and per-table metrics (implies --sqlx-hooks)")
+	f.StringVar(&flags.sqlxInterface, "sqlx-interface", "", "Name of a common interface (TableName, GetIndex) implemented by every generated sqlx row type, e.g. Indexed")
+	f.BoolVar(&flags.sqlxMarkers, "sqlx-markers", false, "Also generate a <Table>Record marker interface per table implemented only by its sqlx row type")
+	f.BoolVar(&flags.narrowInts, "narrow-ints", false, "Use the smallest integer types (uint8, int16, ...) that hold the data in sqlx structs and SQLite query layers; sheets keep their declared types")
+	f.BoolVar(&flags.jsonKeyed, "json-keyed", false, "Write JSON tables as objects keyed by the index column, plus <Table>.by<Column>.json maps for groupby columns")
+	f.StringVar(&flags.displayLocale, "display-locale", "", "Language of the display exporter's texts and number formats (default: first #Locale language)")
+	f.StringVar(&flags.displayFormat, "display-format", "", "Comma-separated display exporter outputs (csv,html; default both)")
+
+	cmd.MarkFlagDirname("output")
+	cmd.MarkFlagDirname("template-dir")
+	cmd.MarkFlagFilename("profiles-file", "json")
+	cmd.RegisterFlagCompletionFunc("lang", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return append(newCLIRegistry(flags.packageName).Languages(), "all"), cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.RegisterFlagCompletionFunc("compress", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"gzip", "zstd"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// newCLIRegistry는 CLI에서 사용할 exporter들을 등록한 레지스트리를 생성합니다.
+func newCLIRegistry(packageName string) *exporter.Registry {
+	registry := exporter.NewRegistry()
+
+	// Go(GORM) exporter 등록
+	registry.Register("go", exporter.NewGORMExporter, exporter.Options{
+		PackageName: packageName,
+		ExtraOptions: map[string]interface{}{
+			exporter.OptGoUseGorm:      true,
+			exporter.OptGoUseSQLite:    true,
+			exporter.OptGoGenerateRepo: true,
+		},
+	})
+
+	// sqlite exporter 등록
+	registry.Register("sqlite", exporter.NewSQLiteExporter, exporter.Options{
+		PackageName: packageName,
+	})
+
+	// ent exporter 등록
+	registry.Register("ent", exporter.NewEntExporter, exporter.Options{
+		PackageName: packageName,
+	})
+
+	// sqlx exporter 등록
+	registry.Register("sqlx", exporter.NewSQLXExporter, exporter.Options{
+		PackageName: packageName,
+	})
+
+	// 관리자 웹 UI exporter 등록 (sqlite exporter가 만든 DB를 읽음)
+	registry.Register("admin", exporter.NewAdminExporter, exporter.Options{
+		PackageName: packageName,
+	})
+
+	// JSON 데이터 exporter 등록
+	registry.Register("json", exporter.NewJSONExporter, exporter.Options{})
+
+	// 컬럼 단위 바이너리 exporter 등록 (compress 태그를 적용하고 Go, TypeScript 디코더를 생성)
+	registry.Register("binary", exporter.NewBinaryExporter, exporter.Options{
+		PackageName: packageName,
+	})
+
+	// 표시용 CSV/HTML exporter 등록 (enum 코드와 로컬라이제이션 키를 텍스트로 바꿈)
+	registry.Register("display", exporter.NewDisplayExporter, exporter.Options{})
+
+	// 가챠 확률 공개 보고서 exporter 등록 (probability, pity, cost 태그)
+	registry.Register("gacha", exporter.NewGachaExporter, exporter.Options{})
+
+	// TypeScript 검증 스키마 exporter 등록
+	registry.Register("zod", exporter.NewZodExporter, exporter.Options{})
+	registry.Register("typebox", exporter.NewTypeBoxExporter, exporter.Options{})
+
+	// Node.js(TypeORM) exporter 등록
+	registry.Register("nodejs", exporter.NewNodeJSExporter, exporter.Options{
+		PackageName: packageName,
+		ExtraOptions: map[string]interface{}{
+			exporter.OptNodeUseTypeORM: true,
+			exporter.OptNodeSeed:       true,
+		},
+	})
+
+	return registry
+}
+
+// runGenerate는 워크북을 파싱하고 요청된 모든 exporter를 실행합니다.
+// parse → transform → validate → export → package 단계의 파이프라인으로 실행하며, 입력이 바뀌지 않은 단계는 건너뜁니다.
+// export 단계가 실행되었으면 생성한 테이블들을, 산출물이 최신이어서 건너뛰었으면 nil을 반환합니다.
+// runGenerate는 생성 파이프라인을 실행하고 마지막에 실행 결과를 표로 출력합니다. (--summary-file이면 JSON으로도 저장)
+func runGenerate(ctx context.Context, input *inputFlags, flags *generateFlags) ([]exporter.Table, error) {
+	summary := newRunSummary()
+	tables, err := generate(ctx, input, flags, summary)
+	summary.finish(err)
+	summary.print(log.Writer())
+	if flags.summaryFile != "" {
+		if werr := summary.write(flags.summaryFile); werr != nil {
+			log.Printf("Failed to write summary file %s: %v", flags.summaryFile, werr)
+		}
+	}
+	return tables, err
+}
+
+func generate(ctx context.Context, input *inputFlags, flags *generateFlags, summary *runSummary) ([]exporter.Table, error) {
+	// 스냅샷에서 다시 생성하는 경우 워크북 대신 스냅샷의 테이블을 사용
+	var excelFiles []string
+	var snapshotTables []exporter.Table
+	var snapshot exporter.DatasetSnapshot
+	var err error
+	if flags.fromSnapshot != "" {
+		store := exporter.SnapshotStore{Dir: flags.snapshotDir}
+		if snapshotTables, snapshot, err = store.Load(flags.fromSnapshot); err != nil {
+			return nil, err
+		}
+		log.Printf("Generating from snapshot %s (%s, %d tables)", snapshot.ID, snapshot.Time.Local().Format(time.DateTime), snapshot.Tables)
+	} else if excelFiles, err = input.resolve(); err != nil {
+		return nil, err
+	}
+	expandSelection := func(names []string) (map[string]bool, error) {
+		if flags.fromSnapshot != "" {
+			return exporter.ExpandTableSelectionFromTables(snapshotTables, names), nil
+		}
+		return exporter.ExpandTableSelection(excelFiles, names)
+	}
+
+	profile, err := flags.loadProfile()
+	if err != nil {
+		return nil, err
+	}
+	idRanges, err := loadIDRanges(flags.idRangesFile, false)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := newIdentifierCheck(flags.namesFile, flags.requestedLanguages(newCLIRegistry(flags.packageName)), flags.queries)
+	if err != nil {
+		return nil, err
+	}
+
+	// 프로필에 포함된 테이블만 생성 (관계로 연결된 테이블까지 포함)
+	var selected map[string]bool
+	if len(profile.Tables) > 0 {
+		selected, err = expandSelection(profile.Tables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve tables of profile %s: %v", profile.Name, err)
+		}
+	}
+
+	// 선택된 테이블만 다시 생성하는 경우, 관계로 연결된 테이블까지 포함
+	incremental := flags.onlyTables != ""
+	if incremental {
+		only, err := expandSelection(strings.Split(flags.onlyTables, ","))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve table selection: %v", err)
+		}
+		for name := range only {
+			if selected != nil && !selected[name] {
+				delete(only, name)
+			}
+		}
+		selected = only
+		log.Printf("Regenerating selected tables: %v", sortedKeys(selected))
+	}
+
+	// 스테이징 디렉토리에 생성한 뒤 출력 디렉토리로 옮김
+	// 선택된 테이블만 다시 생성하는 경우는 기존 산출물을 갱신해야 하므로 바로 출력 디렉토리에 씀
+	finalDir := flags.outputFor(profile)
+	summary.Output, summary.Workbooks = finalDir, len(excelFiles)
+	if incremental && flags.clean {
+		return nil, fmt.Errorf("--clean cannot be combined with --tables")
+	}
+
+	// 파싱한 데이터셋은 생성이 성공한 뒤에 스냅샷으로 보관
+	// 일부 테이블만 다시 생성하면 전체 데이터셋이 아니므로 보관하지 않음
+	takeSnapshot := (flags.snapshot || flags.snapshotTags != "") && flags.fromSnapshot == ""
+	if takeSnapshot && incremental {
+		log.Printf("Snapshots are not taken with --tables; run a full generate to archive the dataset")
+		takeSnapshot = false
+	}
+	var pending *exporter.PendingSnapshot
+
+	// 컬럼 통계는 검증 단계가 실행된 경우 파이프라인이 끝난 뒤(출력 디렉토리가 교체된 뒤) 기록
+	statsPath := filepath.Join(finalDir, exporter.ColumnStatsFile)
+	var columnStats []exporter.ColumnStats
+
+	var allTables []exporter.Table
+	pipeline := &exporter.Pipeline{
+		StatePath: filepath.Join(finalDir, exporter.PipelineStateFile),
+		Force:     flags.force || incremental || flags.clean,
+		Until:     flags.until,
+	}
+	stages := []exporter.PipelineStage{
+		{
+			// Excel 파일들을 파싱하여 테이블 정의 수집
+			Name: exporter.StageParse,
+			Key: func() (string, error) {
+				if flags.fromSnapshot != "" {
+					return fmt.Sprintf("snapshot %s %v", snapshot.Hash, sortedKeys(selected)), nil
+				}
+				hash, err := exporter.HashFiles(excelFiles)
+				// 셀이 참조하는 파일(file, asset 컬럼)이 바뀌어도 다시 파싱
+				if refs := exporter.HashFileRefs(finalDir); refs != "" {
+					hash += " " + refs
+				}
+				return fmt.Sprintf("%s %v", hash, sortedKeys(selected)), err
+			},
+			Run: func(ctx context.Context) error {
+				parseCtx, stage := exporter.StartStage(ctx, exporter.StageParse)
+				// 파싱하지 못한 워크북은 경고로 남기고 계속하지만, span과 단계 에러 메트릭에는 에러로 기록
+				var parseErr error
+				if flags.fromSnapshot != "" {
+					allTables = nil
+					for _, table := range snapshotTables {
+						if selected == nil || selected[table.Name] {
+							allTables = append(allTables, table)
+						}
+					}
+				} else {
+					var warnings []string
+					allTables, warnings = parseWorkbooksWarnings(excelFiles, selected)
+					summary.Warnings = append(summary.Warnings, warnings...)
+					if len(warnings) > 0 {
+						parseErr = fmt.Errorf("failed to parse %d of %d workbook(s)", len(warnings), len(excelFiles))
+					}
+				}
+				summary.setTables(allTables)
+				var err error
+				if takeSnapshot {
+					if pending, err = exporter.PrepareSnapshot(allTables); err != nil {
+						err = fmt.Errorf("failed to prepare snapshot: %v", err)
+						parseErr = err
+					}
+				}
+				stage.End(parseCtx, exporter.CountRows(allTables), parseErr)
+				return err
+			},
+		},
+		{
+			// 프로필의 설정 오버레이, 행 필터, optional 그룹, 테이블 변환 반영
+			Name:  exporter.StageTransform,
+			Needs: []string{exporter.StageParse},
+			Key: func() (string, error) {
+				data, err := json.Marshal(struct {
+					Profile exporter.Profile
+					Names   exporter.IdentifierMap
+				}{profile, ids.names})
+				return profile.Name + string(data), err
+			},
+			Run: func(ctx context.Context) error {
+				transformCtx, stage := exporter.StartStage(ctx, exporter.StageTransform)
+				var err error
+				allTables, err = profile.Apply(allTables)
+				if err == nil {
+					allTables, err = ids.rename(allTables)
+				}
+				stage.End(transformCtx, exporter.CountRows(allTables), err)
+				if err != nil {
+					return err
+				}
+				summary.setTables(allTables)
+				for _, warning := range exporter.DeprecationWarnings(allTables) {
+					log.Printf("Warning: %s", warning)
+					summary.Warnings = append(summary.Warnings, warning)
+				}
+				for _, warning := range exporter.CellWarnings(allTables) {
+					log.Printf("Warning: %s", warning)
+					summary.Warnings = append(summary.Warnings, warning.String())
+				}
+				return nil
+			},
+		},
+		{
+			Name:  exporter.StageValidate,
+			Needs: []string{exporter.StageTransform},
+			Key: func() (string, error) {
+				// 식별자 규칙과 intern 컬럼 지원은 생성하는 언어에 따라 다름
+				key := exporter.StageValidate + " " + strings.Join(ids.langs, ",") + " " + strings.Join(ids.exporters, ",")
+				if len(idRanges.Ranges) == 0 {
+					return key, nil
+				}
+				hash, err := exporter.HashFiles([]string{flags.idRangesFile})
+				return key + " " + hash, err
+			},
+			Run: func(ctx context.Context) error {
+				validateCtx, stage := exporter.StartStage(ctx, exporter.StageValidate)
+				// 이전 실행과 같고 통과했던 컬럼은 컬럼 검증을 건너뜀
+				var previous exporter.ColumnStatsCache
+				if !flags.force && !flags.clean {
+					var err error
+					if previous, err = exporter.LoadColumnStats(statsPath); err != nil {
+						log.Printf("Ignoring column stats: %v", err)
+					}
+				}
+				errs, stats := exporter.ValidateWithStats(allTables, previous)
+				if n := exporter.CachedColumns(stats); n > 0 {
+					log.Printf("Skipped validation of %d unchanged column(s)", n)
+				}
+				summary.Columns = stats
+				if incremental {
+					stats = previous.Merge(stats)
+				}
+				columnStats = stats
+
+				errs = append(errs, idRanges.Validate(allTables)...)
+				errs = append(errs, ids.validate(allTables)...)
+				var err error
+				if len(errs) > 0 {
+					err = fmt.Errorf("validation failed with %d error(s)", len(errs))
+				}
+				stage.End(validateCtx, exporter.CountRows(allTables), err)
+				for _, e := range errs {
+					log.Printf("Validation error: %v", e)
+					summary.ValidationErrors = append(summary.ValidationErrors, e.Error())
+				}
+				return err
+			},
+		},
+		{
+			Name:    exporter.StageExport,
+			Needs:   []string{exporter.StageValidate},
+			Key:     flags.exportKey,
+			Outputs: []string{filepath.Join(finalDir, exporter.OutputMarkerFile)},
+			Run: func(ctx context.Context) error {
+				if incremental {
+					return exportAll(ctx, allTables, incremental, flags, profile, finalDir, summary)
+				}
+				staged, err := exporter.PrepareOutputDir(finalDir)
+				if err != nil {
+					return err
+				}
+				// 실패한 exporter가 하나라도 있으면 (나머지 exporter는 끝까지 실행됨) 이전 출력을 그대로 두고 스테이징을 버림
+				if err := exportAll(ctx, allTables, incremental, flags, profile, staged.Staging, summary); err != nil {
+					staged.Abort()
+					return err
+				}
+				if err := staged.Commit(flags.clean); err != nil {
+					return fmt.Errorf("failed to update output directory: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			// 산출물 전체를 기술하는 매니페스트 작성 (--sign이면 서명과 검증 코드 포함)
+			Name:  exporter.StagePackage,
+			Needs: []string{exporter.StageExport},
+			Key:   flags.packageKey,
+			Run: func(ctx context.Context) error {
+				return packageOutput(flags, finalDir)
+			},
+		},
+	}
+	for _, stage := range stages {
+		if err := pipeline.Add(stage); err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := pipeline.Run(ctx)
+	summary.addStages(results)
+	if columnStats != nil {
+		if werr := exporter.WriteColumnStats(statsPath, columnStats); werr != nil {
+			log.Printf("Failed to write column stats: %v", werr)
+		}
+	}
+	exported := false
+	for _, result := range results {
+		if result.Cached {
+			log.Printf("Stage %s is up to date", result.Name)
+		} else if result.Name == exporter.StageExport {
+			exported = true
+		}
+	}
+	if err != nil || !exported {
+		return nil, err
+	}
+	if pending != nil {
+		if err := saveSnapshot(flags, pending, excelFiles); err != nil {
+			log.Printf("Failed to archive snapshot: %v", err)
+		}
+	}
+	return allTables, nil
+}
+
+// exportKey는 export 단계의 입력 중 워크북 외의 것(생성 옵션, 템플릿, 실행 파일)을 나타냅니다.
+// 암호화 키는 값 대신 해시만 포함합니다.
+func (flags *generateFlags) exportKey() (string, error) {
+	key := struct {
+		Languages, Package, Overlay, Compress, Queries, Features string
+		SQLHooks, Prune                                          string
+		DisplayLocale, DisplayFormat                             string
+		Encrypt, Strict, WithoutRowID, JSONKeyed, TablesJSON     bool
+		SourceMap, SQLXReload, SQLXColumnar, NarrowInts          bool
+		SQLiteMaster, SQLXMarkers, SQLXGeneric                   bool
+		SQLXHooks, SQLXOTel, QuoteAll, Verify                    bool
+		SQLXInterface                                            string
+		SQLXCache                                                int
+		EncryptKey, Templates, Executable                        string
+	}{
+		Languages: flags.languages, Package: flags.packageName, Overlay: flags.overlayFiles,
+		Compress: flags.compress, Queries: flags.queries, Features: flags.features,
+		Encrypt: flags.encrypt, Strict: flags.sqliteStrict, WithoutRowID: flags.withoutRowID,
+		JSONKeyed: flags.jsonKeyed, TablesJSON: flags.tablesJSON, SourceMap: flags.sourceMap,
+		SQLXReload: flags.sqlxReload, SQLXColumnar: flags.sqlxColumnar, NarrowInts: flags.narrowInts,
+		SQLXMarkers: flags.sqlxMarkers, SQLXGeneric: flags.sqlxGeneric, SQLXCache: flags.sqlxCache,
+		SQLXHooks: flags.sqlxHooks, SQLXOTel: flags.sqlxOTel, QuoteAll: flags.quoteAll, Verify: flags.verify, SQLXInterface: flags.sqlxInterface,
+		DisplayLocale: flags.displayLocale, DisplayFormat: flags.displayFormat, SQLiteMaster: flags.sqliteMaster,
+	}
+
+	if flags.overlayFiles != "" {
+		hash, err := exporter.HashFiles(strings.Split(flags.overlayFiles, ","))
+		if err != nil {
+			return "", err
+		}
+		key.Overlay += " " + hash
+	}
+	for _, path := range []string{flags.beforeSchema, flags.afterSchema, flags.afterData} {
+		if path == "" {
+			continue
+		}
+		hash, err := exporter.HashFiles([]string{path})
+		if err != nil {
+			return "", err
+		}
+		key.SQLHooks += " " + hash
+	}
+	// 정리(prune)한 쿼리 레이어는 코드베이스가 사용하는 식별자가 바뀌면 다시 생성
+	if flags.queriesPrune != "" {
+		hash, err := exporter.HashGoReferences(flags.queriesPrune)
+		if err != nil {
+			return "", err
+		}
+		key.Prune = flags.queriesPrune + " " + hash
+	}
+	if flags.encrypt {
+		sum := sha256.Sum256([]byte(os.Getenv(flags.encryptKeyEnv)))
+		key.EncryptKey = hex.EncodeToString(sum[:])
+	}
+	if flags.templateDir != "" {
+		manifest, err := exporter.BuildManifest(flags.templateDir)
+		if err != nil {
+			return "", err
+		}
+		key.Templates = manifest.RootHash
+	}
+	// excelite를 다시 빌드하면 생성 결과가 달라질 수 있으므로 실행 파일이 바뀌면 다시 생성
+	if exe, err := os.Executable(); err == nil {
+		if info, err := os.Stat(exe); err == nil {
+			key.Executable = fmt.Sprintf("%s %d %d", exe, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+
+	data, err := json.Marshal(key)
+	return string(data), err
+}
+
+// packageKey는 package 단계의 입력(매니페스트와 서명 설정)을 나타냅니다. 서명 키는 공개 키만 포함합니다.
+func (flags *generateFlags) packageKey() (string, error) {
+	key := fmt.Sprint(flags.writeManifest, flags.sign, flags.packageName)
+	if flags.sign {
+		priv, err := exporter.LoadSigningKey(flags.signKeyEnv)
+		if err != nil {
+			return "", err
+		}
+		key += " " + hex.EncodeToString(priv.Public().(ed25519.PublicKey))
+	}
+	return key, nil
+}
+
+// packageOutput은 매니페스트를 쓰고, --sign이면 검증 코드를 생성한 뒤 매니페스트를 서명합니다.
+// 검증 코드도 매니페스트에 포함되도록 매니페스트보다 먼저 생성합니다.
+func packageOutput(flags *generateFlags, dir string) error {
+	if !flags.writeManifest && !flags.sign {
+		return nil
+	}
+
+	var key ed25519.PrivateKey
+	if flags.sign {
+		var err error
+		if key, err = exporter.LoadSigningKey(flags.signKeyEnv); err != nil {
+			return err
+		}
+		if err := exporter.WriteVerifyLoaders(dir, flags.packageName, key.Public().(ed25519.PublicKey)); err != nil {
+			return fmt.Errorf("failed to generate verification loaders: %v", err)
+		}
+	}
+
+	manifest, err := exporter.WriteManifest(dir)
+	if err != nil {
+		return fmt.Errorf("failed to write manifest: %v", err)
+	}
+	log.Printf("Wrote manifest with %d artifacts (root %s)", len(manifest.Artifacts), manifest.RootHash)
+
+	if flags.sign {
+		if err := exporter.SignManifest(dir, key); err != nil {
+			return fmt.Errorf("failed to sign manifest: %v", err)
+		}
+		log.Printf("Signed manifest with ed25519 key %s", hex.EncodeToString(key.Public().(ed25519.PublicKey)))
+	}
+	return nil
+}
+
+// loadProfile은 --profile로 선택한 프로필을 읽습니다. 프로필을 지정하지 않으면 빈 프로필을 반환합니다.
+// --features는 프로필의 features보다 우선합니다.
+func (flags *generateFlags) loadProfile() (exporter.Profile, error) {
+	var profile exporter.Profile
+	if flags.profile != "" {
+		var err error
+		if profile, err = exporter.LoadProfile(flags.profilesFile, flags.profile); err != nil {
+			return profile, err
+		}
+	}
+	if flags.features != "" {
+		profile.Features = strings.Split(flags.features, ",")
+	}
+	return profile, nil
+}
+
+// requestedLanguages는 --lang으로 요청된 exporter 언어들입니다. all이면 등록된 모든 언어입니다.
+func (flags *generateFlags) requestedLanguages(registry *exporter.Registry) []string {
+	return splitLanguages(flags.languages, registry)
+}
+
+// splitLanguages는 쉼표로 구분된 --lang 값을 언어 목록으로 나눕니다.
+func splitLanguages(languages string, registry *exporter.Registry) []string {
+	if languages == "all" {
+		return registry.Languages()
+	}
+	return strings.Split(languages, ",")
+}
+
+// loadIdentifierMap은 이름 매핑 파일을 읽습니다. 기본 경로의 파일은 없어도 됩니다. (이름을 바꾸지 않음)
+func loadIdentifierMap(path string) (exporter.IdentifierMap, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) && path == exporter.DefaultIdentifierMapFile {
+		return exporter.IdentifierMap{}, nil
+	}
+	return exporter.LoadIdentifierMap(path)
+}
+
+// identifierCheck는 시트/컬럼 이름을 이름 매핑 파일(--names)대로 바꾸고, 생성하는 코드의 언어에서 식별자로 쓸 수 있는지와
+// 생성하는 exporter가 컬럼을 읽을 수 있는지(intern) 검증합니다.
+// generate와 validate 명령이 같은 규칙으로 이름을 바꾸고 검증하도록 함께 사용합니다.
+type identifierCheck struct {
+	names     exporter.IdentifierMap
+	langs     []string // 생성하는 코드의 언어
+	exporters []string // 생성하는 exporter
+}
+
+// newIdentifierCheck는 이름 매핑 파일을 읽고, exporter 언어들(--lang)과 쿼리 레이어 언어(--sqlite-queries)로 검증할 언어를 정합니다.
+func newIdentifierCheck(namesFile string, languages []string, queries string) (identifierCheck, error) {
+	names, err := loadIdentifierMap(namesFile)
+	if err != nil {
+		return identifierCheck{}, err
+	}
+	return identifierCheck{names: names, langs: exporter.IdentifierLanguages(languages, queries), exporters: languages}, nil
+}
+
+// rename은 생성 코드의 식별자로 쓸 수 없는 시트/컬럼 이름을 이름 매핑 파일대로 바꿉니다.
+func (c identifierCheck) rename(tables []exporter.Table) ([]exporter.Table, error) {
+	return c.names.Apply(tables)
+}
+
+// validate는 바뀐 테이블과 컬럼 이름이 모든 언어에서 식별자로 쓸 수 있고, intern 컬럼을 읽지 못하는 exporter가 없는지 확인합니다.
+func (c identifierCheck) validate(tables []exporter.Table) []error {
+	errs := exporter.ValidateIdentifiers(tables, c.langs)
+	return append(errs, exporter.ValidateInternExporters(tables, c.exporters)...)
+}
+
+// outputFor는 프로필의 출력 디렉토리를 반환합니다.
+// 프로필이 출력 디렉토리를 지정하지 않으면 환경끼리 섞이지 않도록 <output>/<프로필 이름>을 사용합니다.
+func (flags *generateFlags) outputFor(profile exporter.Profile) string {
+	switch {
+	case profile.Output != "":
+		return profile.Output
+	case profile.Name != "":
+		return filepath.Join(flags.outputDir, profile.Name)
+	}
+	return flags.outputDir
+}
+
+// exportAll은 요청된 모든 exporter를 outputDir 아래에 실행합니다.
+func exportAll(ctx context.Context, allTables []exporter.Table, incremental bool, flags *generateFlags, profile exporter.Profile, outputDir string, summary *runSummary) error {
+	// 오버레이가 주어지면 기본 데이터 대비 패치 파일 생성
+	if flags.overlayFiles != "" {
+		if err := generatePatch(allTables, strings.Split(flags.overlayFiles, ","), outputDir); err != nil {
+			return fmt.Errorf("failed to generate overlay patch: %v", err)
+		}
+	}
+
+	// 데이터 모델 매니페스트는 전체 테이블을 기술해야 하므로 일부 테이블만 다시 생성할 때는 갱신하지 않음
+	if flags.tablesJSON {
+		if incremental {
+			log.Printf("%s is not regenerated with --tables; run a full generate to update it", exporter.TablesManifestFileName)
+		} else if err := exporter.WriteTablesManifest(outputDir, allTables); err != nil {
+			return fmt.Errorf("failed to write %s: %v", exporter.TablesManifestFileName, err)
+		}
+	}
+	// asset 컬럼이 참조하는 파일은 해시 이름이므로 일부 테이블만 다시 생성할 때도 그대로 추가
+	assets, err := exporter.WriteAssets(outputDir, allTables)
+	if err != nil {
+		return fmt.Errorf("failed to copy assets: %v", err)
+	}
+	if assets > 0 {
+		log.Printf("Copied %d asset(s) to %s", assets, filepath.Join(outputDir, exporter.AssetsDir))
+	}
+	if !incremental {
+		if err := exporter.WriteFileRefs(outputDir, allTables); err != nil {
+			return fmt.Errorf("failed to write %s: %v", exporter.FileRefsFile, err)
+		}
+	}
+	if flags.sourceMap {
+		if incremental {
+			log.Printf("%s is not regenerated with --tables; run a full generate to update it", exporter.SourceMapFileName)
+		} else if err := exporter.WriteSourceMap(outputDir, allTables, flags.jsonKeyed); err != nil {
+			return fmt.Errorf("failed to write %s: %v", exporter.SourceMapFileName, err)
+		}
+	}
+
+	// Registry에 exporter들 등록
+	registry := newCLIRegistry(flags.packageName)
+
+	// 요청된 언어들로 export
+	requestedLangs := flags.requestedLanguages(registry)
+
+	compressions, err := parseCompressFlag(flags.compress)
+	if err != nil {
+		return fmt.Errorf("invalid --compress value: %v", err)
+	}
+
+	// 각 언어별 exporter를 동시에 실행
+	results := registry.ExportAll(ctx, requestedLangs, allTables, func(lang string) exporter.Options {
+		opts := exporter.Options{
+			OutputDir:    filepath.Join(outputDir, lang),
+			PackageName:  flags.packageName,
+			TemplateDir:  flags.templateDir,
+			DBDriver:     "sqlite",
+			DBName:       profile.DSN,
+			ExtraOptions: map[string]interface{}{},
+		}
+		if profile.DBDriver != "" {
+			opts.DBDriver = profile.DBDriver
+		}
+
+		// exporter별 옵션은 해당 exporter에만 전달 (알 수 없는 옵션은 에러)
+		switch lang {
+		case "sqlite":
+			if incremental {
+				opts.ExtraOptions[exporter.OptSQLiteIncremental] = true
+			}
+			if flags.sqliteStrict {
+				opts.ExtraOptions[exporter.OptSQLiteStrict] = true
+			}
+			if flags.withoutRowID {
+				opts.ExtraOptions[exporter.OptSQLiteWithoutRowID] = true
+			}
+			if flags.quoteAll {
+				opts.ExtraOptions[exporter.OptSQLiteQuoteAll] = true
+			}
+			if flags.queries != "" {
+				opts.ExtraOptions[exporter.OptSQLiteQueries] = flags.queries
+			}
+			if flags.queriesPrune != "" {
+				opts.ExtraOptions[exporter.OptSQLitePrune] = flags.queriesPrune
+			}
+			if flags.sqliteMaster {
+				opts.ExtraOptions[exporter.OptSQLiteMaster] = true
+			}
+			if flags.beforeSchema != "" {
+				opts.ExtraOptions[exporter.OptSQLiteBeforeSchema] = flags.beforeSchema
+			}
+			if flags.afterSchema != "" {
+				opts.ExtraOptions[exporter.OptSQLiteAfterSchema] = flags.afterSchema
+			}
+			if flags.afterData != "" {
+				opts.ExtraOptions[exporter.OptSQLiteAfterData] = flags.afterData
+			}
+			if flags.narrowInts {
+				opts.ExtraOptions[exporter.OptNarrowInts] = true
+			}
+		case "sqlx":
+			if flags.sqlxReload {
+				opts.ExtraOptions[exporter.OptSQLXReload] = true
+			}
+			if flags.sqlxColumnar {
+				opts.ExtraOptions[exporter.OptSQLXColumnar] = true
+			}
+			if flags.sqlxGeneric {
+				opts.ExtraOptions[exporter.OptSQLXGeneric] = true
+			}
+			if flags.sqlxCache > 0 {
+				opts.ExtraOptions[exporter.OptSQLXCache] = flags.sqlxCache
+			}
+			if flags.sqlxHooks {
+				opts.ExtraOptions[exporter.OptSQLXHooks] = true
+			}
+			if flags.sqlxOTel {
+				opts.ExtraOptions[exporter.OptSQLXOTel] = true
+			}
+			if flags.sqlxInterface != "" {
+				opts.ExtraOptions[exporter.OptSQLXInterface] = flags.sqlxInterface
+			}
+			if flags.sqlxMarkers {
+				opts.ExtraOptions[exporter.OptSQLXMarkers] = true
+			}
+			if flags.narrowInts {
+				opts.ExtraOptions[exporter.OptNarrowInts] = true
+			}
+		case "json":
+			if flags.jsonKeyed {
+				opts.ExtraOptions[exporter.OptJSONKeyed] = true
+			}
+		case "display":
+			if flags.displayLocale != "" {
+				opts.ExtraOptions[exporter.OptDisplayLocale] = flags.displayLocale
+			}
+			if flags.displayFormat != "" {
+				opts.ExtraOptions[exporter.OptDisplayFormats] = flags.displayFormat
+			}
+		}
+
+		// 명시된 옵션만 전달하여 exporter별 기본 옵션을 덮어쓰지 않도록 함
+		if flags.encrypt {
+			opts.ExtraOptions[exporter.OptEncrypt] = true
+			opts.ExtraOptions[exporter.OptEncryptKeyEnv] = flags.encryptKeyEnv
+		}
+		if flags.verify {
+			opts.ExtraOptions[exporter.OptVerify] = true
+		}
+		if c, ok := compressions[lang]; ok {
+			opts.ExtraOptions[exporter.OptCompress] = c.algo
+			opts.ExtraOptions[exporter.OptCompressLevel] = c.level
+		} else if c, ok := compressions[""]; ok {
+			opts.ExtraOptions[exporter.OptCompress] = c.algo
+			opts.ExtraOptions[exporter.OptCompressLevel] = c.level
+		}
+		return opts
+	})
+
+	// exporter별 결과는 실행이 끝날 때 요약 표로 출력
+	summary.addExports(results, outputDir)
+
+	// 실패한 exporter가 있으면 export 단계를 완료로 기록하지 않도록 에러를 반환 (다음 실행에서 다시 시도)
+	var failed []string
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.Lang)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d exporter(s) failed: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// generatePatch는 오버레이 워크북과 기본 데이터를 비교하여 patch.json을 생성합니다.
+func generatePatch(base []exporter.Table, files []string, outputDir string) error {
+	overlay, err := exporter.ParseOverlayFiles(files)
+	if err != nil {
+		return err
+	}
+
+	ops, err := exporter.ComputePatch(base, overlay)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+
+	patchPath := filepath.Join(outputDir, "patch.json")
+	if err := exporter.WritePatchFile(patchPath, ops); err != nil {
+		return err
+	}
+	log.Printf("Generated overlay patch with %d operations: %s", len(ops), patchPath)
+	return nil
+}
+
+type compression struct {
+	algo  string
+	level int
+}
+
+// parseCompressFlag는 --compress 값을 언어별 압축 설정으로 파싱합니다.
+// "zstd:19" 처럼 언어를 생략하면 빈 문자열 키에 담겨 모든 exporter에 적용됩니다.
+func parseCompressFlag(value string) (map[string]compression, error) {
+	result := make(map[string]compression)
+	if value == "" {
+		return result, nil
+	}
+
+	for _, spec := range strings.Split(value, ",") {
+		lang := ""
+		if idx := strings.Index(spec, "="); idx != -1 {
+			lang, spec = strings.TrimSpace(spec[:idx]), spec[idx+1:]
+		}
+
+		parts := strings.SplitN(strings.TrimSpace(spec), ":", 2)
+		c := compression{algo: parts[0]}
+		if len(parts) == 2 {
+			level, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid compression level %q", parts[1])
+			}
+			c.level = level
+		}
+		result[lang] = c
+	}
+
+	return result, nil
+}
+
+// saveSnapshot은 생성에 사용한 데이터셋을 스냅샷으로 보관하고 오래된 스냅샷을 정리합니다.
+func saveSnapshot(flags *generateFlags, pending *exporter.PendingSnapshot, workbooks []string) error {
+	var tags []string
+	for _, tag := range strings.Split(flags.snapshotTags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	store := exporter.SnapshotStore{Dir: flags.snapshotDir}
+	snapshot, created, err := store.Save(pending, workbooks, tags)
+	if err != nil {
+		return err
+	}
+	if created {
+		log.Printf("Archived snapshot %s (%s)", snapshot.ID, exporter.FormatByteSize(snapshot.Size))
+	} else {
+		log.Printf("Dataset is unchanged since snapshot %s", snapshot.ID)
+	}
+
+	removed, err := store.Prune(flags.snapshotKeep)
+	if len(removed) > 0 {
+		log.Printf("Removed %d old snapshots", len(removed))
+	}
+	return err
+}
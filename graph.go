@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+func newGraphCommand(input *inputFlags) *cobra.Command {
+	var format string
+	var output string
+	var findOrphans bool
+	var roots string
+
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Export the dependency graph of rows referenced by data cells",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := input.resolve()
+			if err != nil {
+				return err
+			}
+
+			graph := exporter.BuildRefGraph(parseWorkbooks(files, nil))
+			for _, edge := range graph.Dangling {
+				log.Printf("Warning: %s.%s refers to missing row %s", edge.From, edge.Column, edge.To)
+			}
+
+			var w io.Writer = cmd.OutOrStdout()
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+
+			// 아무도 참조하지 않는 행만 출력
+			if findOrphans {
+				rootTables := make(map[string]bool)
+				for _, name := range strings.Split(roots, ",") {
+					if name = strings.TrimSpace(name); name != "" {
+						rootTables[name] = true
+					}
+				}
+				orphans := graph.Orphans(rootTables)
+				for _, node := range orphans {
+					fmt.Fprintln(w, node)
+				}
+				log.Printf("Found %d orphan row(s) among %d rows", len(orphans), len(graph.Nodes))
+				return nil
+			}
+
+			switch format {
+			case "dot":
+				return graph.WriteDOT(w)
+			case "json":
+				enc := json.NewEncoder(w)
+				enc.SetIndent("", "  ")
+				return enc.Encode(graph)
+			}
+			return fmt.Errorf("unknown graph format %q (expected dot or json)", format)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "dot", "Output format: dot or json")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (defaults to stdout)")
+	cmd.Flags().BoolVar(&findOrphans, "find-orphans", false, "List rows of referenced tables that nothing references")
+	cmd.Flags().StringVar(&roots, "roots", "", "Comma-separated tables whose rows are entry points and never orphans")
+	return cmd
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+// idReport는 ids 명령의 결과입니다. --report로 저장합니다.
+type idReport struct {
+	Collisions []exporter.IDCollision `json:"collisions"`
+	Remaps     []exporter.IDRemap     `json:"remaps"`
+	Errors     []string               `json:"errors"`
+	Applied    bool                   `json:"applied"`
+}
+
+func newIDsCommand(input *inputFlags) *cobra.Command {
+	var policyFile, reportFile string
+	var renumber bool
+
+	cmd := &cobra.Command{
+		Use:   "ids",
+		Short: "Find index values used more than once across the workbooks, and renumber them into each workbook's ID range with --renumber",
+		Long: `Tables of the same name in several workbooks are merged, so an index used in two workbooks (e.g. base data and a DLC) collides.
+The ID ranges file assigns index ranges to workbooks ({"ranges": [{"workbook": "dlc_*.xlsx", "start": 10000, "end": 19999}]}).
+For each collision the row whose index is inside its workbook's range keeps it; the others get the lowest free index of their workbook's range.
+With --renumber the new indexes are written to the workbooks, together with the ref<>, belongsTo and parent cells of the same workbook that point to the moved rows.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := input.resolve()
+			if err != nil {
+				return err
+			}
+			var tables []exporter.Table
+			for _, file := range files {
+				parsed, err := exporter.ParseExcelFile(file)
+				if err != nil {
+					return fmt.Errorf("%s: %v", file, err)
+				}
+				tables = append(tables, parsed...)
+			}
+
+			policy, err := loadIDRanges(policyFile, renumber)
+			if err != nil {
+				return err
+			}
+
+			report := idReport{Collisions: exporter.FindIDCollisions(tables), Remaps: []exporter.IDRemap{}, Errors: []string{}}
+			if report.Collisions == nil {
+				report.Collisions = []exporter.IDCollision{}
+			}
+			// 범위 설정이 없으면 충돌만 보고
+			var remaps []exporter.IDRemap
+			var errs []error
+			if renumber || len(policy.Ranges) > 0 {
+				remaps, errs = exporter.PlanRenumber(tables, report.Collisions, policy)
+			}
+			if remaps != nil {
+				report.Remaps = remaps
+			}
+			for _, err := range errs {
+				report.Errors = append(report.Errors, err.Error())
+			}
+
+			if renumber && len(errs) == 0 && len(remaps) > 0 {
+				if err := exporter.ApplyRenumber(tables, report.Remaps); err != nil {
+					return err
+				}
+				report.Applied = true
+			}
+
+			writeIDReport(cmd.OutOrStdout(), report)
+			if reportFile != "" {
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(reportFile, append(data, '\n'), 0644); err != nil {
+					return err
+				}
+				log.Printf("Remap report written to %s", reportFile)
+			}
+
+			switch {
+			case len(errs) > 0:
+				return fmt.Errorf("%d collision(s) cannot be renumbered:\n%v", len(errs), errors.Join(errs...))
+			case report.Applied:
+				log.Printf("Renumbered %d row(s)", len(report.Remaps))
+			case len(report.Collisions) > 0:
+				return fmt.Errorf("%d index collision(s) (run with --renumber to fix)", len(report.Collisions))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&policyFile, "id-ranges", exporter.DefaultIDRangesFile, "JSON file assigning index ranges to workbooks")
+	cmd.Flags().BoolVar(&renumber, "renumber", false, "Write the new indexes and updated references into the workbooks")
+	cmd.Flags().StringVar(&reportFile, "report", "", "Write the collisions and the old -> new index remap as JSON to this file")
+	return cmd
+}
+
+// loadIDRanges는 인덱스 범위 설정 파일을 읽습니다.
+// 기본 경로의 파일은 없어도 되며(범위 없음), 다른 경로를 지정했거나 required이면 파일이 있어야 합니다.
+func loadIDRanges(path string, required bool) (exporter.IDRangePolicy, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) && path == exporter.DefaultIDRangesFile && !required {
+		return exporter.IDRangePolicy{}, nil
+	}
+	return exporter.LoadIDRangePolicy(path)
+}
+
+func writeIDReport(w io.Writer, report idReport) {
+	if len(report.Collisions) == 0 {
+		fmt.Fprintln(w, "no index collisions")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TABLE\tINDEX\tROWS")
+	for _, c := range report.Collisions {
+		var rows []string
+		for _, occ := range c.Occurrences {
+			rows = append(rows, fmt.Sprintf("%s %s %s", occ.Workbook, occ.Sheet, occ.Location))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", c.Table, c.Index, strings.Join(rows, "; "))
+	}
+
+	if len(report.Remaps) > 0 {
+		fmt.Fprintln(tw, "\nTABLE\tOLD -> NEW\tROW\tREFS")
+		for _, r := range report.Remaps {
+			refs := "-"
+			if report.Applied {
+				refs = fmt.Sprint(len(r.Refs))
+			}
+			fmt.Fprintf(tw, "%s\t%d -> %d\t%s %s %s\t%s\n", r.Table, r.Old, r.New, r.Workbook, r.Sheet, r.Location, refs)
+		}
+	}
+	tw.Flush()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+func newJSONLCommand(input *inputFlags) *cobra.Command {
+	var output string
+	var onlyTables string
+
+	cmd := &cobra.Command{
+		Use:   "jsonl",
+		Short: "Stream every table to <Table>.jsonl (one row object per line) without loading whole tables",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := input.resolve()
+			if err != nil {
+				return err
+			}
+
+			var selected map[string]bool
+			if onlyTables != "" {
+				selected = make(map[string]bool)
+				for _, name := range strings.Split(onlyTables, ",") {
+					if name = strings.TrimSpace(name); name != "" {
+						selected[name] = true
+					}
+				}
+			}
+
+			for _, file := range files {
+				results, err := exporter.StreamJSONL(file, output, selected)
+				if err != nil {
+					return fmt.Errorf("failed to stream %s: %v", file, err)
+				}
+				for _, result := range results {
+					for _, warning := range result.Warnings {
+						log.Printf("Warning: %s", warning)
+					}
+					log.Printf("Wrote %d row(s) of %s to %s", result.Rows, result.Table, result.Path)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "jsonl", "Directory for the generated .jsonl files")
+	cmd.Flags().StringVar(&onlyTables, "tables", "", "Comma-separated list of tables to stream (defaults to all)")
+	cmd.MarkFlagDirname("output")
+	return cmd
+}
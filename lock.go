@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+func newLockCommand(input *inputFlags) *cobra.Command {
+	var update bool
+
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Show the content hashes of tables and columns listed in #Lock sheets, or record them with --update",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := input.resolve()
+			if err != nil {
+				return err
+			}
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			changed := 0
+			for _, file := range files {
+				statuses, err := exporter.WorkbookLocks(file, update)
+				if err != nil {
+					return fmt.Errorf("%s: %v", file, err)
+				}
+				for _, s := range statuses {
+					target := s.Table
+					if s.Column != "" {
+						target += "." + s.Column
+					}
+
+					status := "locked"
+					switch {
+					case s.Changed() && update:
+						status = "updated"
+					case s.Hash == "":
+						status = "no hash"
+						changed++
+					case s.Changed():
+						status = "CHANGED"
+						changed++
+					}
+					fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", file, target, status, s.Actual, s.Reason)
+				}
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+
+			if changed > 0 {
+				return fmt.Errorf("%d locked table(s)/column(s) changed or have no recorded hash (run with --update to accept)", changed)
+			}
+			if update {
+				log.Printf("Recorded lock hashes")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&update, "update", false, "Write the current content hashes into the #Lock sheets (locks the milestone or accepts an intended change)")
+	return cmd
+}
@@ -1,105 +1,215 @@
 package main
 
 import (
-	"flag"
+	"context"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
 
 	"excelite/exporter"
 )
 
-// go run main.go -inputdir=./data -output=./generated -lang="go,nodejs" -package=models
-// go run main.go -inputfiles=game_data.xlsx -output=./generated -lang="all" -package=models
+// excelite generate --inputdir=./data --output=./generated --lang="go,nodejs" --package=models
+// excelite generate --inputfiles=game_data.xlsx --output=./generated --lang="all" --package=models
+// excelite generate --inputdir=./data --output=./generated --profile=prod --profiles-file=excelite.profiles.json
+// excelite generate --inputfiles=game_data.xlsx --output=./generated --sqlite-strict --sqlite-without-rowid
+// excelite generate --inputfiles=game_data.xlsx --output=./generated --sqlite-queries=go,cpp,csharp
+// excelite generate --inputfiles=game_data.xlsx --output=./generated --lang=ent && (cd generated/ent && go generate ./...)
+// excelite generate --inputfiles=game_data.xlsx --output=./generated --lang=sqlite,sqlx
+// excelite generate --inputfiles=game_data.xlsx --output=./generated --lang=zod   # or --lang=typebox
+// excelite generate --inputfiles=game_data.xlsx --output=./generated --lang=json --json-keyed
+// excelite jsonl --inputdir=./data -o ./ingest --tables=DropLog
+// excelite generate --inputdir=./data --output=./generated --tables-manifest --manifest
+// EXCELITE_SIGN_KEY=<ed25519 seed> excelite generate --inputdir=./data --output=./generated --sign   # loader/verify.go, verify.ts
+// excelite generate --inputdir=./data --output=./generated --sourcemap && excelite where Item 42 Price
+// excelite generate --inputdir=./data --output=./generated --until=validate   # or --force to ignore cached stages
+// excelite generate --inputfiles=game_data.xlsx --output=./generated --lang=sqlite,admin && (cd generated/admin && go run .)
+// excelite validate --inputfiles=game_data.xlsx
+// excelite validate --staged --diff-base HEAD
+// excelite validate --inputdir=./data --output-format=github
+// excelite validate --inputdir=./data --writeback-dir=./validation
+// excelite lock --inputdir=./data --update   # record hashes of tables/columns listed in #Lock sheets
+// excelite diff old.xlsx new.xlsx
+// excelite diff --base-rev origin/main ./data --html data-diff.html
+// excelite watch --inputdir=./data --output=./generated
+// excelite serve --inputdir=./data --output=./generated --addr=:8080
+// curl -N http://localhost:8080/_events   # reload notifications (Server-Sent Events) listing the changed tables
+// excelite check --inputdir=./data --baseline=schema-snapshot.json
+// excelite publish --inputdir=./data --registry-url=http://localhost:8081 --format=avro
+// excelite graph --inputdir=./data --format=dot -o refs.dot
+// excelite graph --inputdir=./data --find-orphans --roots=Quest
+// excelite fake --inputdir=./schema --rows=10000 --seed=42 -o ./fake
+// excelite bench --rows=1000,100000 --count=10 -o new.txt && benchstat old.txt new.txt
+// excelite completion bash
+// excelite exporters sqlite json --format=json
+// excelite emit-templates -o ./templates && excelite generate --inputdir=./data --template-dir=./templates
+// excelite generate --inputdir=./data --otlp-endpoint=http://localhost:4318
 func main() {
-	// CLI 플래그 정의
-	inputDir := flag.String("inputdir", "", "Directory containing Excel files")
-	inputFiles := flag.String("inputfiles", "", "Comma-separated list of Excel files")
-	outputDir := flag.String("output", "generated", "Output directory for generated files")
-	languages := flag.String("lang", "all", "Comma-separated list of target languages (go,cpp,nodejs,all)")
-	packageName := flag.String("package", "models", "Package name for generated code")
-	flag.Parse()
-
-	if *inputDir == "" && *inputFiles == "" {
-		log.Fatal("Either -inputdir or -inputfiles must be provided")
+	root := newRootCommand()
+	root.SetArgs(legacyArgs(os.Args[1:]))
+
+	err := root.Execute()
+
+	// 남은 span과 메트릭 전송
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if shutdownErr := shutdownTelemetry(ctx); shutdownErr != nil {
+		log.Printf("Warning: Failed to flush telemetry: %v", shutdownErr)
+	}
+
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// shutdownTelemetry는 setupTelemetry가 설정한 provider를 종료합니다.
+var shutdownTelemetry = func(context.Context) error { return nil }
+
+// inputFlags는 여러 서브커맨드가 공유하는 입력 워크북 플래그입니다.
+type inputFlags struct {
+	inputDir   string
+	inputFiles string
+}
+
+func newRootCommand() *cobra.Command {
+	var input inputFlags
+	var otlpEndpoint string
+
+	root := &cobra.Command{
+		Use:          "excelite",
+		Short:        "Excel to Code & DB Generator",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			shutdown, err := setupTelemetry(cmd.Context(), otlpEndpoint)
+			if err != nil {
+				return fmt.Errorf("failed to set up telemetry: %v", err)
+			}
+			shutdownTelemetry = shutdown
+			return nil
+		},
+	}
+
+	root.PersistentFlags().StringVar(&input.inputDir, "inputdir", "", "Directory containing Excel files")
+	root.PersistentFlags().StringVar(&input.inputFiles, "inputfiles", "", "Comma-separated list of Excel files")
+	root.PersistentFlags().StringVar(&otlpEndpoint, "otlp-endpoint", "", "OTLP/HTTP endpoint URL for traces and metrics (defaults to OTEL_EXPORTER_OTLP_ENDPOINT)")
+	root.MarkPersistentFlagDirname("inputdir")
+	root.MarkPersistentFlagFilename("inputfiles", "xlsx", "xls")
+
+	root.AddCommand(
+		newGenerateCommand(&input),
+		newValidateCommand(&input),
+		newDiffCommand(),
+		newWatchCommand(&input),
+		newServeCommand(&input),
+		newPublishCommand(&input),
+		newCheckCommand(&input),
+		newGraphCommand(&input),
+		newFakeCommand(&input),
+		newJSONLCommand(&input),
+		newBenchCommand(),
+		newEmitTemplatesCommand(),
+		newExportersCommand(),
+		newWhereCommand(),
+		newLockCommand(&input),
+		newCompareRunsCommand(),
+		newSnapshotCommand(&input),
+		newIDsCommand(&input),
+	)
+
+	return root
+}
+
+// legacyArgs는 서브커맨드 없이 "-inputfiles=..." 형태로 호출하던 이전 방식을
+// "generate --inputfiles=..." 로 변환합니다.
+func legacyArgs(args []string) []string {
+	if len(args) == 0 || !strings.HasPrefix(args[0], "-") || args[0] == "-h" || args[0] == "--help" {
+		return args
+	}
+
+	converted := []string{"generate"}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") && len(arg) > 2 {
+			arg = "-" + arg
+		}
+		converted = append(converted, arg)
 	}
+	return converted
+}
 
-	printBanner()
+// resolve는 입력 플래그로부터 Excel 파일 목록을 수집합니다.
+func (in *inputFlags) resolve() ([]string, error) {
+	if in.inputDir == "" && in.inputFiles == "" {
+		return nil, fmt.Errorf("either --inputdir or --inputfiles must be provided")
+	}
 
-	// Excel 파일 목록 수집
-	var excelFiles []string
-	if *inputDir != "" {
-		files, err := collectExcelFiles(*inputDir)
+	if in.inputDir != "" {
+		files, err := collectExcelFiles(in.inputDir)
 		if err != nil {
-			log.Fatalf("Failed to collect Excel files: %v", err)
+			return nil, fmt.Errorf("failed to collect Excel files: %v", err)
 		}
-		excelFiles = files
-	} else {
-		excelFiles = strings.Split(*inputFiles, ",")
+		return files, nil
 	}
+	return strings.Split(in.inputFiles, ","), nil
+}
+
+// parseWorkbooks는 Excel 파일들을 파싱하여 테이블 정의를 수집합니다.
+// 파싱에 실패한 파일은 경고만 남기고 건너뜁니다.
+func parseWorkbooks(files []string, selected map[string]bool) []exporter.Table {
+	allTables, _ := parseWorkbooksWarnings(files, selected)
+	return allTables
+}
 
-	// Excel 파일들을 파싱하여 테이블 정의 수집
+// parseWorkbooksWarnings는 parseWorkbooks와 같으며, 파싱하지 못해 건너뛴 파일의 경고도 반환합니다.
+func parseWorkbooksWarnings(files []string, selected map[string]bool) ([]exporter.Table, []string) {
 	var allTables []exporter.Table
-	for _, file := range excelFiles {
-		tables, err := exporter.ParseExcelFile(file)
+	var warnings []string
+	for _, file := range files {
+		tables, err := exporter.ParseExcelFileSelected(file, selected)
 		if err != nil {
-			log.Printf("Warning: Failed to parse %s: %v", file, err)
+			warning := fmt.Sprintf("Failed to parse %s: %v", file, err)
+			log.Printf("Warning: %s", warning)
+			warnings = append(warnings, warning)
 			continue
 		}
 		allTables = append(allTables, tables...)
 	}
+	return allTables, warnings
+}
 
-	// Registry에 exporter들 등록
-	registry := exporter.NewRegistry()
-
-	// Go exporter 등록
-	// registry.Register("go", exporter.NewGORMExporter, exporter.Options{
-	// 	PackageName: *packageName,
-	// 	ExtraOptions: map[string]interface{}{
-	// 		"useGorm":      true,
-	// 		"useSQLite":    true,
-	// 		"generateRepo": true,
-	// 	},
-	// })
-
-	// sqlite exporter 등록
-	registry.Register("sqlite", exporter.NewSQLiteExporter, exporter.Options{
-		PackageName: *packageName,
-	})
-
-	// // Node.js exporter 등록
-	// registry.Register("nodejs", exporter.NewNodeJSExporter, exporter.Options{
-	// 	PackageName: *packageName,
-	// 	ExtraOptions: map[string]interface{}{
-	// 		"useTypeScript": true,
-	// 		"useTypeORM":    true,
-	// 	},
-	// })
-
-	// 요청된 언어들로 export
-	requestedLangs := []string{}
-	if *languages == "all" {
-		requestedLangs = registry.Languages()
-	} else {
-		requestedLangs = strings.Split(*languages, ",")
-	}
-
-	// 각 언어별로 Export 실행
-	for _, lang := range requestedLangs {
-		opts := exporter.Options{
-			OutputDir:   filepath.Join(*outputDir, lang),
-			PackageName: *packageName,
-			DBDriver:    "sqlite",
-			DBName:      "app.db",
+// expandPaths는 파일 또는 디렉토리 경로 목록을 Excel 파일 목록으로 펼칩니다.
+func expandPaths(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
 		}
-
-		if err := registry.Export(lang, allTables, opts); err != nil {
-			log.Printf("Failed to export %s code: %v", lang, err)
+		if !info.IsDir() {
+			files = append(files, path)
 			continue
 		}
-		log.Printf("Successfully exported %s code", lang)
+		dirFiles, err := collectExcelFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, dirFiles...)
+	}
+	return files, nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
+	return keys
 }
 
 // Excel 파일 수집 함수
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+func newPublishCommand(input *inputFlags) *cobra.Command {
+	var (
+		registryURL   string
+		format        string
+		namespace     string
+		subjectPrefix string
+		userEnv       string
+		passwordEnv   string
+		checkOnly     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "publish",
+		Short: "Publish table schemas to a Confluent-compatible schema registry",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := input.resolve()
+			if err != nil {
+				return err
+			}
+
+			tables := parseWorkbooks(files, nil)
+			client := exporter.NewSchemaRegistryClient(registryURL, os.Getenv(userEnv), os.Getenv(passwordEnv))
+
+			results, err := exporter.PublishSchemas(client, tables, format, namespace, subjectPrefix, checkOnly)
+			var incompatible *exporter.IncompatibleSchemaError
+			if errors.As(err, &incompatible) {
+				for subject, messages := range incompatible.Subjects {
+					log.Printf("Incompatible schema: %s", subject)
+					for _, message := range messages {
+						log.Printf("  %s", message)
+					}
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			for _, result := range results {
+				if checkOnly {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s: compatible\n", result.Subject)
+				} else {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s: schema id %d\n", result.Subject, result.SchemaID)
+				}
+			}
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&registryURL, "registry-url", "", "Schema registry base URL (for Apicurio use its ccompat API, e.g. http://host/apis/ccompat/v7)")
+	f.StringVar(&format, "format", "json", "Schema format to publish (json, avro)")
+	f.StringVar(&namespace, "namespace", "models", "Avro namespace for record schemas")
+	f.StringVar(&subjectPrefix, "subject-prefix", "", "Prefix for registry subjects (<prefix><Table>-value)")
+	f.StringVar(&userEnv, "registry-user-env", "EXCELITE_REGISTRY_USER", "Environment variable holding the registry username")
+	f.StringVar(&passwordEnv, "registry-password-env", "EXCELITE_REGISTRY_PASSWORD", "Environment variable holding the registry password")
+	f.BoolVar(&checkOnly, "check-only", false, "Only check compatibility without registering schemas")
+	cmd.MarkFlagRequired("registry-url")
+	cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json", "avro"}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
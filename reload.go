@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"excelite/exporter"
+)
+
+// reloadEventsPath는 serve 모드에서 재생성 알림을 보내는 Server-Sent Events 엔드포인트입니다.
+const reloadEventsPath = "/_events"
+
+// reloadKeepAlive는 프록시가 유휴 연결을 끊지 않도록 주석 줄을 보내는 간격입니다.
+const reloadKeepAlive = 15 * time.Second
+
+// reloadEvent는 재생성 한 번의 결과입니다. 게임 에디터 등 내부 도구는 Changed 테이블만 다시 읽으면 됩니다.
+type reloadEvent struct {
+	Generation int       `json:"generation"`
+	Time       time.Time `json:"time"`
+	Changed    []string  `json:"changed,omitempty"` // 추가되었거나 내용이 바뀐 테이블
+	Removed    []string  `json:"removed,omitempty"` // 더 이상 생성되지 않는 테이블
+	Error      string    `json:"error,omitempty"`   // 생성 실패 (이전 산출물이 유지됨)
+}
+
+// reloadHub는 연결된 클라이언트들에게 재생성 알림을 보냅니다.
+// 마지막으로 생성한 테이블들의 내용 해시를 기억하여 바뀐 테이블만 알립니다.
+type reloadHub struct {
+	mu          sync.Mutex
+	clients     map[chan reloadEvent]struct{}
+	hashes      map[string]string
+	generation  int
+	incremental bool // --tables로 일부 테이블만 생성하면 생성되지 않은 테이블을 삭제로 보지 않음
+}
+
+func newReloadHub(incremental bool) *reloadHub {
+	return &reloadHub{
+		clients:     make(map[chan reloadEvent]struct{}),
+		hashes:      make(map[string]string),
+		incremental: incremental,
+	}
+}
+
+// generated는 생성 결과를 알립니다. 바뀐 테이블이 없으면 알리지 않습니다.
+// tables가 nil이면 산출물이 최신이어서 export 단계를 건너뛴 것입니다.
+func (h *reloadHub) generated(tables []exporter.Table, genErr error) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	event := reloadEvent{Time: time.Now()}
+	if genErr != nil {
+		event.Error = genErr.Error()
+	} else {
+		if tables == nil {
+			return
+		}
+		hashes := make(map[string]string, len(tables))
+		for _, table := range tables {
+			hash, err := exporter.LockHash(table, "")
+			if err != nil {
+				log.Printf("Failed to hash table %s: %v", table.Name, err)
+				continue
+			}
+			hashes[table.Name] = hash
+			if h.hashes[table.Name] != hash {
+				event.Changed = append(event.Changed, table.Name)
+			}
+		}
+		for name := range h.hashes {
+			if _, ok := hashes[name]; ok {
+				continue
+			}
+			if h.incremental {
+				hashes[name] = h.hashes[name]
+				continue
+			}
+			event.Removed = append(event.Removed, name)
+		}
+		h.hashes = hashes
+		if len(event.Changed) == 0 && len(event.Removed) == 0 {
+			return
+		}
+		sort.Strings(event.Changed)
+		sort.Strings(event.Removed)
+	}
+
+	h.generation++
+	event.Generation = h.generation
+	for ch := range h.clients {
+		select {
+		case ch <- event:
+		default:
+			log.Printf("Dropping reload notification for a slow client")
+		}
+	}
+}
+
+func (h *reloadHub) subscribe() chan reloadEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ch := make(chan reloadEvent, 16)
+	h.clients[ch] = struct{}{}
+	return ch
+}
+
+func (h *reloadHub) unsubscribe(ch chan reloadEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.clients, ch)
+}
+
+// ServeHTTP는 연결을 Server-Sent Events 스트림으로 유지하며 재생성마다 "reload" 이벤트를, 실패하면 "error" 이벤트를 보냅니다.
+// 브라우저는 EventSource로, 다른 도구는 text/event-stream을 읽는 HTTP 클라이언트로 구독합니다.
+func (h *reloadHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := h.subscribe()
+	defer h.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	fmt.Fprintf(w, "retry: %d\n\n", time.Second.Milliseconds())
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(reloadKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case event := <-ch:
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Printf("Failed to encode reload notification: %v", err)
+				continue
+			}
+			name := "reload"
+			if event.Error != "" {
+				name = "error"
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Generation, name, data)
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"excelite/exporter"
+)
+
+// 출력 형식
+const (
+	formatText   = "text"   // 사람이 읽는 "경로:위치: 심각도: 메시지" 형식
+	formatGitHub = "github" // GitHub Actions 워크플로 명령 (::error file=...)
+	formatSARIF  = "sarif"  // SARIF 2.1.0 (GitHub code scanning 등)
+	formatGitLab = "gitlab" // GitLab Code Quality 리포트
+)
+
+var outputFormats = []string{formatText, formatGitHub, formatSARIF, formatGitLab}
+
+func checkOutputFormat(format string) error {
+	for _, f := range outputFormats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format %q (expected %s)", format, strings.Join(outputFormats, ", "))
+}
+
+// 린트 규칙이 아닌 검증 결과의 규칙 이름
+const (
+	ruleParse       = "parse"
+	ruleValidate    = "validate"
+	ruleDeprecated  = "deprecated"
+	ruleIgnoredCell = "ignored-cell"
+	ruleBreaking    = "breaking-change"
+	ruleRowChanges  = "row-changes"
+)
+
+// finding은 검증 결과 하나입니다. File/Location이 비어있으면 특정 위치가 없는 결과입니다.
+type finding struct {
+	Rule     string
+	Severity exporter.LintSeverity
+	File     string
+	Location string // 시트 또는 "시트!셀"
+	Message  string
+}
+
+func lintFinding(issue exporter.LintIssue) finding {
+	loc := issue.Sheet
+	if issue.Cell != "" {
+		loc += "!" + issue.Cell
+	}
+	return finding{Rule: issue.Rule, Severity: issue.Severity, File: issue.File, Location: loc, Message: issue.Message}
+}
+
+// findingReport는 검증 결과를 모아 지정한 형식으로 출력합니다.
+type findingReport struct {
+	findings []finding
+}
+
+func (r *findingReport) add(f finding) {
+	r.findings = append(r.findings, f)
+}
+
+func (r *findingReport) errorf(rule, file, format string, args ...interface{}) {
+	r.add(finding{Rule: rule, Severity: exporter.LintError, File: file, Message: fmt.Sprintf(format, args...)})
+}
+
+// errors는 error 심각도 결과 수를 반환합니다.
+func (r *findingReport) errors() int {
+	n := 0
+	for _, f := range r.findings {
+		if f.Severity == exporter.LintError {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *findingReport) write(out io.Writer, format string) error {
+	switch format {
+	case formatText:
+		for _, f := range r.findings {
+			fmt.Fprintln(out, formatTextFinding(f))
+		}
+		return nil
+	case formatGitHub:
+		for _, f := range r.findings {
+			fmt.Fprintln(out, formatGitHubFinding(f))
+		}
+		return nil
+	case formatSARIF:
+		return writeSARIF(out, r.findings)
+	case formatGitLab:
+		return writeGitLabCodeQuality(out, r.findings)
+	}
+	return checkOutputFormat(format)
+}
+
+func formatTextFinding(f finding) string {
+	var prefix string
+	switch {
+	case f.File != "" && f.Location != "":
+		prefix = f.File + ":" + f.Location + ": "
+	case f.File != "":
+		prefix = f.File + ": "
+	}
+	return fmt.Sprintf("%s%s: %s (%s)", prefix, f.Severity, f.Message, f.Rule)
+}
+
+// formatGitHubFinding은 결과를 GitHub Actions 워크플로 명령으로 변환합니다.
+// 엑셀 파일에는 줄 번호가 없으므로 셀 위치는 메시지 앞에 붙입니다.
+func formatGitHubFinding(f finding) string {
+	command := "notice"
+	switch f.Severity {
+	case exporter.LintError:
+		command = "error"
+	case exporter.LintWarning:
+		command = "warning"
+	}
+
+	var props []string
+	if f.File != "" {
+		props = append(props, "file="+escapeGitHubProperty(f.File))
+	}
+	props = append(props, "title="+escapeGitHubProperty("excelite "+f.Rule))
+
+	message := f.Message
+	if f.Location != "" {
+		message = f.Location + ": " + message
+	}
+	return fmt.Sprintf("::%s %s::%s", command, strings.Join(props, ","), escapeGitHubData(message))
+}
+
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// SARIF 2.1.0 문서 구조 (사용하는 필드만)
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// writeSARIF는 결과를 SARIF 2.1.0 문서로 씁니다. 셀 위치는 논리적 위치(시트!셀)로 기록합니다.
+func writeSARIF(out io.Writer, findings []finding) error {
+	descriptions := map[string]string{
+		ruleParse:       "Workbook could not be parsed",
+		ruleValidate:    "Table data failed validation",
+		ruleDeprecated:  "Deprecated column is still in use",
+		ruleIgnoredCell: "Cell value outside the header columns is ignored",
+		ruleBreaking:    "Breaking schema change against the base revision",
+		ruleRowChanges:  "Row changes against the base revision",
+	}
+	for _, rule := range exporter.LintRules {
+		descriptions[rule.Name] = rule.Description
+	}
+
+	used := make(map[string]bool)
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: "excelite"}}, Results: []sarifResult{}}
+
+	for _, f := range findings {
+		used[f.Rule] = true
+
+		result := sarifResult{RuleID: f.Rule, Level: sarifLevel(f.Severity), Message: sarifMessage{Text: f.Message}}
+		if f.File != "" || f.Location != "" {
+			var loc sarifLocation
+			if f.File != "" {
+				// 엑셀 파일에는 줄이 없으므로 첫 줄을 가리킴 (코드 스캐닝 도구는 region을 요구)
+				loc.PhysicalLocation = &sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: f.File},
+					Region:           sarifRegion{StartLine: 1},
+				}
+			}
+			if f.Location != "" {
+				loc.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: f.Location, Kind: "element"}}
+			}
+			result.Locations = []sarifLocation{loc}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	rules := make([]string, 0, len(used))
+	for rule := range used {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	for _, rule := range rules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: rule, ShortDescription: sarifMessage{Text: descriptions[rule]}})
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+func sarifLevel(severity exporter.LintSeverity) string {
+	switch severity {
+	case exporter.LintError:
+		return "error"
+	case exporter.LintWarning:
+		return "warning"
+	}
+	return "note"
+}
+
+// gitLabIssue는 GitLab Code Quality 리포트의 항목입니다.
+type gitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitLabLocation `json:"location"`
+}
+
+type gitLabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitLabLines `json:"lines"`
+}
+
+type gitLabLines struct {
+	Begin int `json:"begin"`
+}
+
+// writeGitLabCodeQuality는 결과를 GitLab Code Quality 리포트(JSON 배열)로 씁니다.
+func writeGitLabCodeQuality(out io.Writer, findings []finding) error {
+	issues := make([]gitLabIssue, 0, len(findings))
+	for _, f := range findings {
+		description := f.Message
+		if f.Location != "" {
+			description = f.Location + ": " + description
+		}
+
+		severity := "info"
+		switch f.Severity {
+		case exporter.LintError:
+			severity = "major"
+		case exporter.LintWarning:
+			severity = "minor"
+		}
+
+		sum := sha1.Sum([]byte(f.Rule + "\x00" + f.File + "\x00" + f.Location + "\x00" + f.Message))
+		issues = append(issues, gitLabIssue{
+			Description: description,
+			CheckName:   f.Rule,
+			Fingerprint: hex.EncodeToString(sum[:]),
+			Severity:    severity,
+			Location:    gitLabLocation{Path: f.File, Lines: gitLabLines{Begin: 1}},
+		})
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(issues)
+}
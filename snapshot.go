@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+func newSnapshotCommand(input *inputFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "List, tag, diff and re-export archived dataset snapshots (taken by generate --snapshot)",
+		Long: `Snapshots are the parsed datasets of earlier generate runs, archived with --snapshot or --snapshot-tag.
+A snapshot is referred to by its ID, a unique ID prefix, one of its tags, or "latest".
+When the current workbooks are broken, "snapshot export <ref>" regenerates the output from a snapshot.`,
+	}
+	cmd.AddCommand(
+		newSnapshotListCommand(),
+		newSnapshotTagCommand(),
+		newSnapshotDiffCommand(input),
+		newSnapshotExportCommand(input),
+		newSnapshotPruneCommand(),
+	)
+	return cmd
+}
+
+func newSnapshotListCommand() *cobra.Command {
+	var dir, format string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List archived snapshots, oldest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snapshots, err := exporter.SnapshotStore{Dir: dir}.List()
+			if err != nil {
+				return err
+			}
+
+			switch format {
+			case "text":
+				tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+				fmt.Fprintln(tw, "ID\tTIME\tTABLES\tROWS\tSIZE\tTAGS")
+				for _, s := range snapshots {
+					fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\t%s\n", s.ID, s.Time.Local().Format(time.DateTime),
+						s.Tables, s.Rows, exporter.FormatByteSize(s.Size), strings.Join(s.Tags, ","))
+				}
+				return tw.Flush()
+			case "json":
+				if snapshots == nil {
+					snapshots = []exporter.DatasetSnapshot{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(snapshots)
+			}
+			return fmt.Errorf("unknown snapshot list format %q (expected text or json)", format)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "snapshot-dir", exporter.DefaultSnapshotDir, "Directory of archived dataset snapshots")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+	return cmd
+}
+
+func newSnapshotTagCommand() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "tag <snapshot> <tag>...",
+		Short: "Tag a snapshot (a tag on another snapshot is moved); tagged snapshots are never pruned",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snapshot, err := exporter.SnapshotStore{Dir: dir}.Tag(args[0], args[1:])
+			if err != nil {
+				return err
+			}
+			log.Printf("Snapshot %s is tagged %s", snapshot.ID, strings.Join(snapshot.Tags, ", "))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "snapshot-dir", exporter.DefaultSnapshotDir, "Directory of archived dataset snapshots")
+	return cmd
+}
+
+func newSnapshotDiffCommand(input *inputFlags) *cobra.Command {
+	var dir, htmlFile string
+
+	cmd := &cobra.Command{
+		Use:   "diff <base-snapshot> [target-snapshot]",
+		Short: "Show row changes between two snapshots, or between a snapshot and the current workbooks",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store := exporter.SnapshotStore{Dir: dir}
+			baseTables, base, err := store.Load(args[0])
+			if err != nil {
+				return err
+			}
+
+			var targetTables []exporter.Table
+			targetName := "current workbooks"
+			if len(args) == 2 {
+				var target exporter.DatasetSnapshot
+				if targetTables, target, err = store.Load(args[1]); err != nil {
+					return err
+				}
+				targetName = target.ID
+			} else {
+				files, err := input.resolve()
+				if err != nil {
+					return err
+				}
+				targetTables = parseWorkbooks(files, nil)
+			}
+
+			ops := exporter.DiffTables(baseTables, targetTables)
+			for _, op := range ops {
+				fmt.Fprintf(cmd.OutOrStdout(), "%-6s %s[%s]\n", op.Op, op.Table, op.Index)
+			}
+
+			if htmlFile != "" {
+				f, err := os.Create(htmlFile)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+
+				title := fmt.Sprintf("Data diff: %s → %s", base.ID, targetName)
+				if err := exporter.WriteHTMLDiff(f, title, exporter.BuildTableDiffs(baseTables, targetTables)); err != nil {
+					return err
+				}
+				log.Printf("HTML diff written to %s", htmlFile)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "snapshot-dir", exporter.DefaultSnapshotDir, "Directory of archived dataset snapshots")
+	cmd.Flags().StringVar(&htmlFile, "html", "", "Write an HTML report of changed rows with old and new values")
+	return cmd
+}
+
+func newSnapshotExportCommand(input *inputFlags) *cobra.Command {
+	var flags generateFlags
+
+	cmd := &cobra.Command{
+		Use:   "export <snapshot>",
+		Short: "Generate output from a snapshot instead of the workbooks (takes the generate flags)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			printBanner()
+			flags.fromSnapshot = args[0]
+			_, err := runGenerate(cmd.Context(), input, &flags)
+			return err
+		},
+	}
+
+	addGenerateFlags(cmd, &flags)
+	return cmd
+}
+
+func newSnapshotPruneCommand() *cobra.Command {
+	var dir string
+	var keep int
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete old untagged snapshots, keeping the most recent ones",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if keep <= 0 {
+				return fmt.Errorf("--keep must be positive")
+			}
+			removed, err := exporter.SnapshotStore{Dir: dir}.Prune(keep)
+			for _, s := range removed {
+				fmt.Fprintf(cmd.OutOrStdout(), "removed %s\n", s.ID)
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "snapshot-dir", exporter.DefaultSnapshotDir, "Directory of archived dataset snapshots")
+	cmd.Flags().IntVar(&keep, "keep", 20, "Number of most recent untagged snapshots to keep")
+	return cmd
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// stagedWorkbook은 git 인덱스에 스테이징된 워크북입니다.
+// 작업 트리가 아닌 스테이징된 내용을 검증하기 위해 임시 파일로 꺼내 둡니다.
+type stagedWorkbook struct {
+	Path string // 저장소 루트 기준 경로 (출력용)
+	File string // 스테이징된 내용을 담은 임시 파일
+}
+
+// gitRepository는 git 명령을 실행할 저장소입니다.
+type gitRepository struct {
+	root string
+}
+
+func openGitRepository() (*gitRepository, error) {
+	out, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return nil, fmt.Errorf("not inside a git repository: %v", err)
+	}
+	return &gitRepository{root: strings.TrimSpace(string(out))}, nil
+}
+
+func (r *gitRepository) run(args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.root
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// stagedWorkbookPaths는 추가/수정/이름 변경으로 스테이징된 Excel 파일 경로를 반환합니다.
+// dir이 주어지면 그 디렉토리 아래의 파일만 반환합니다.
+func (r *gitRepository) stagedWorkbookPaths(dir string) ([]string, error) {
+	out, err := r.run("diff", "--cached", "--name-only", "--diff-filter=ACMR", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := ""
+	if dir != "" {
+		rel, err := r.relPath(dir)
+		if err != nil {
+			return nil, err
+		}
+		if rel != "." {
+			prefix = rel + "/"
+		}
+	}
+
+	var paths []string
+	for _, path := range strings.Split(string(out), "\x00") {
+		if path != "" && strings.HasPrefix(path, prefix) && isWorkbookPath(path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// workbookPathsAt은 rev에 있는 Excel 파일 경로를 반환합니다. path는 파일 또는 디렉토리입니다.
+func (r *gitRepository) workbookPathsAt(rev, path string) ([]string, error) {
+	rel, err := r.relPath(path)
+	if err != nil {
+		return nil, err
+	}
+	out, err := r.run("ls-tree", "-r", "--name-only", "-z", rev, "--", rel)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, p := range strings.Split(string(out), "\x00") {
+		if p != "" && isWorkbookPath(p) {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// relPath는 path를 저장소 루트 기준의 슬래시 경로로 변환합니다.
+func (r *gitRepository) relPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(r.root, abs)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func isWorkbookPath(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return (ext == ".xlsx" || ext == ".xls") && !strings.HasPrefix(filepath.Base(path), "~$")
+}
+
+// extract는 rev의 path 내용을 tmpDir 아래 임시 파일로 꺼냅니다. rev가 빈 문자열이면 인덱스에서 꺼냅니다.
+// rev에 파일이 없으면 빈 문자열을 반환합니다.
+func (r *gitRepository) extract(rev, path, tmpDir string) (string, error) {
+	if rev != "" {
+		if _, err := r.run("cat-file", "-e", rev+":"+path); err != nil {
+			return "", nil
+		}
+	}
+
+	data, err := r.run("show", rev+":"+path)
+	if err != nil {
+		return "", err
+	}
+
+	// 같은 이름의 파일이 여러 디렉토리에 있을 수 있으므로 저장소 경로 구조를 유지
+	name := rev
+	if name == "" {
+		name = "index"
+	}
+	file := filepath.Join(tmpDir, strings.NewReplacer("/", "_", "~", "_", "^", "_").Replace(name), filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return "", err
+	}
+	return file, nil
+}
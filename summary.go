@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"excelite/exporter"
+)
+
+// 실행 결과 상태
+const (
+	summaryOK       = "ok"
+	summaryFailed   = "failed"
+	summaryUpToDate = "up-to-date" // export 단계의 입력이 바뀌지 않아 산출물을 다시 만들지 않음
+)
+
+// runSummary는 generate 한 번의 결과입니다.
+// 실행이 끝나면 표로 출력하고, --summary-file이 주어지면 빌드 대시보드가 읽을 수 있도록 JSON으로 저장합니다.
+type runSummary struct {
+	Status           string            `json:"status"`
+	Error            string            `json:"error,omitempty"`
+	StartedAt        time.Time         `json:"startedAt"`
+	DurationMs       int64             `json:"durationMs"`
+	Output           string            `json:"output"`
+	Workbooks        int               `json:"workbooks"`
+	Tables           int               `json:"tables"`
+	Rows             int               `json:"rows"`
+	Warnings         []string          `json:"warnings"`
+	ValidationErrors []string          `json:"validationErrors"`
+	Stages           []stageSummary    `json:"stages"`
+	Exporters        []exporterSummary `json:"exporters"`
+
+	// Columns는 컬럼별 통계(행 수, 빈 값, 서로 다른 값, 최솟값/최댓값)이며, 검증 단계가 실행된 경우에만 채워집니다.
+	Columns []exporter.ColumnStats `json:"columns,omitempty"`
+}
+
+// stageSummary는 파이프라인 단계 하나의 결과입니다.
+type stageSummary struct {
+	Name       string `json:"name"`
+	Cached     bool   `json:"cached,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+// exporterSummary는 exporter 하나의 결과와 산출물 크기입니다.
+type exporterSummary struct {
+	Lang       string `json:"lang"`
+	Status     string `json:"status"`
+	DurationMs int64  `json:"durationMs"`
+	Files      int    `json:"files"`
+	Bytes      int64  `json:"bytes"`
+	Error      string `json:"error,omitempty"`
+}
+
+func newRunSummary() *runSummary {
+	return &runSummary{StartedAt: time.Now(), Warnings: []string{}, ValidationErrors: []string{}}
+}
+
+// setTables는 변환까지 적용된 최종 테이블 수와 행 수를 기록합니다.
+func (s *runSummary) setTables(tables []exporter.Table) {
+	s.Tables = len(tables)
+	s.Rows = exporter.CountRows(tables)
+}
+
+func (s *runSummary) addStages(results []exporter.StageResult) {
+	for _, result := range results {
+		stage := stageSummary{Name: result.Name, Cached: result.Cached, DurationMs: result.Duration.Milliseconds()}
+		if result.Err != nil {
+			stage.Error = result.Err.Error()
+		}
+		s.Stages = append(s.Stages, stage)
+	}
+}
+
+// addExports는 exporter별 결과와 dir/<lang> 아래 산출물의 파일 수와 크기를 기록합니다.
+func (s *runSummary) addExports(results []exporter.ExportResult, dir string) {
+	for _, result := range results {
+		e := exporterSummary{Lang: result.Lang, Status: summaryOK, DurationMs: result.Duration.Milliseconds()}
+		if result.Err != nil {
+			e.Status, e.Error = summaryFailed, result.Err.Error()
+		}
+		filepath.WalkDir(filepath.Join(dir, result.Lang), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				e.Files++
+				e.Bytes += info.Size()
+			}
+			return nil
+		})
+		s.Exporters = append(s.Exporters, e)
+	}
+}
+
+// finish는 실행 결과 상태를 정합니다. 실패한 exporter가 있으면 다른 exporter가 성공해도 실패입니다.
+func (s *runSummary) finish(err error) {
+	s.DurationMs = time.Since(s.StartedAt).Milliseconds()
+	s.Status = summaryOK
+	for _, stage := range s.Stages {
+		if stage.Name == exporter.StageExport && stage.Cached {
+			s.Status = summaryUpToDate
+		}
+	}
+	for _, e := range s.Exporters {
+		if e.Status == summaryFailed {
+			s.Status = summaryFailed
+		}
+	}
+	if err != nil {
+		s.Status, s.Error = summaryFailed, err.Error()
+	}
+}
+
+// print는 실행 결과를 표로 출력합니다.
+func (s *runSummary) print(w io.Writer) {
+	fmt.Fprintf(w, "\n%s in %v: %d workbooks, %d tables, %d rows, %d warnings, %d validation errors\n",
+		s.Status, time.Duration(s.DurationMs)*time.Millisecond, s.Workbooks, s.Tables, s.Rows, len(s.Warnings), len(s.ValidationErrors))
+	if s.Error != "" {
+		fmt.Fprintf(w, "error: %s\n", s.Error)
+	}
+	if len(s.Exporters) == 0 {
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	defer tw.Flush()
+	fmt.Fprintln(tw, "EXPORTER\tSTATUS\tDURATION\tFILES\tSIZE\t")
+	for _, e := range s.Exporters {
+		fmt.Fprintf(tw, "%s\t%s\t%v\t%d\t%s\t%s\n", e.Lang, e.Status, time.Duration(e.DurationMs)*time.Millisecond,
+			e.Files, exporter.FormatByteSize(e.Bytes), e.Error)
+	}
+}
+
+// write는 실행 결과를 JSON 파일로 저장합니다.
+func (s *runSummary) write(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+func newEmitTemplatesCommand() *cobra.Command {
+	var output string
+	var force bool
+	var list bool
+
+	cmd := &cobra.Command{
+		Use:   "emit-templates",
+		Short: "Write the built-in exporter templates to a directory for customization with generate --template-dir",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if list {
+				for _, name := range exporter.TemplateNames() {
+					fmt.Println(name)
+				}
+				return nil
+			}
+
+			written, err := exporter.EmitTemplates(output, force)
+			if err != nil {
+				return fmt.Errorf("failed to emit templates: %v", err)
+			}
+			for _, name := range written {
+				log.Printf("Wrote %s", name)
+			}
+			if skipped := len(exporter.TemplateNames()) - len(written); skipped > 0 {
+				log.Printf("Kept %d existing template(s) in %s (use --force to overwrite)", skipped, output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "templates", "Directory to write the templates to")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite templates that already exist in the directory")
+	cmd.Flags().BoolVar(&list, "list", false, "Only list the built-in template names")
+	cmd.MarkFlagDirname("output")
+	return cmd
+}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+func newValidateCommand(input *inputFlags) *cobra.Command {
+	var lint bool
+	var staged bool
+	var diffBase string
+	var outputFormat string
+	var writebackDir string
+	var idRangesFile string
+	var statsFile string
+	var namesFile string
+	var languages string
+	var queries string
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Parse and validate Excel workbooks without generating output",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkOutputFormat(outputFormat); err != nil {
+				return err
+			}
+			if diffBase != "" && !staged {
+				return fmt.Errorf("--diff-base requires --staged")
+			}
+
+			idRanges, err := loadIDRanges(idRangesFile, false)
+			if err != nil {
+				return err
+			}
+			// generate와 같은 이름 매핑과 식별자 규칙
+			ids, err := newIdentifierCheck(namesFile, splitLanguages(languages, newCLIRegistry("")), queries)
+			if err != nil {
+				return err
+			}
+
+			var report findingReport
+			var workbooks []stagedWorkbook
+			if staged {
+				repo, err := openGitRepository()
+				if err != nil {
+					return err
+				}
+				tmpDir, err := os.MkdirTemp("", "excelite-staged-")
+				if err != nil {
+					return err
+				}
+				defer os.RemoveAll(tmpDir)
+
+				if workbooks, err = stagedWorkbooks(repo, input.inputDir, tmpDir); err != nil {
+					return err
+				}
+				if len(workbooks) == 0 {
+					log.Printf("No staged workbooks to validate")
+					return nil
+				}
+				validateWorkbooks(&report, workbooks, lint, idRanges, ids, statsFile)
+
+				if diffBase != "" {
+					for _, wb := range workbooks {
+						compareWithBase(&report, repo, wb, diffBase, tmpDir)
+					}
+				}
+			} else {
+				files, err := input.resolve()
+				if err != nil {
+					return err
+				}
+				for _, file := range files {
+					workbooks = append(workbooks, stagedWorkbook{Path: file, File: file})
+				}
+				validateWorkbooks(&report, workbooks, lint, idRanges, ids, statsFile)
+			}
+
+			if err := report.write(cmd.OutOrStdout(), outputFormat); err != nil {
+				return err
+			}
+			if writebackDir != "" {
+				if err := writeBackFindings(&report, workbooks, writebackDir); err != nil {
+					return err
+				}
+			}
+			if errs := report.errors(); errs > 0 {
+				return fmt.Errorf("validation of %d workbook(s) failed with %d error(s)", len(workbooks), errs)
+			}
+
+			log.Printf("Validated %d workbook(s)", len(workbooks))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&lint, "lint", true, "Run spreadsheet lint rules (configured by the #Lint sheet)")
+	cmd.Flags().BoolVar(&staged, "staged", false, "Validate only workbooks staged in git, as a pre-commit hook")
+	cmd.Flags().StringVar(&diffBase, "diff-base", "", "With --staged, report breaking schema changes and row changes against this revision (e.g. HEAD or main)")
+	cmd.Flags().StringVar(&outputFormat, "output-format", formatText, "Findings format: "+strings.Join(outputFormats, ", "))
+	cmd.Flags().StringVar(&writebackDir, "writeback-dir", "", "Write a copy of every workbook with findings into this directory, with cell comments and a #Validation summary sheet")
+	cmd.MarkFlagDirname("writeback-dir")
+	cmd.Flags().StringVar(&statsFile, "stats-file", "", "Reuse column statistics from this file to skip re-validating unchanged columns, and update it with this run's statistics")
+	cmd.Flags().StringVar(&idRangesFile, "id-ranges", exporter.DefaultIDRangesFile, "JSON file reserving index ranges per workbook/team; rows outside their workbook's range are errors")
+	cmd.Flags().StringVar(&namesFile, "names", exporter.DefaultIdentifierMapFile, "JSON file renaming Korean/Japanese sheet and column names to code identifiers, applied as by generate before validation")
+	cmd.Flags().StringVar(&languages, "lang", "all", "Comma-separated list of target languages whose identifier rules and supported tags (intern) the workbooks must follow (go,cpp,nodejs,all)")
+	cmd.Flags().StringVar(&queries, "sqlite-queries", "", "Comma-separated languages of the SQLite query layers whose identifier rules names must follow (go,cpp,csharp)")
+	return cmd
+}
+
+// writeBackFindings는 결과가 있는 워크북마다 결과를 기록한 사본을 dir에 씁니다.
+// 사본은 워크북들의 공통 상위 디렉토리 기준 상대 경로에 쓰므로 다른 디렉토리의 같은 이름 워크북이 서로 덮어쓰지 않습니다.
+// 특정 워크북에 속하지 않는 결과(테이블 간 검증 등)는 모든 사본의 요약 시트에 들어갑니다.
+func writeBackFindings(report *findingReport, workbooks []stagedWorkbook, dir string) error {
+	paths := make([]string, len(workbooks))
+	for i, wb := range workbooks {
+		paths[i] = wb.Path
+	}
+	dsts, err := writebackPaths(paths, dir)
+	if err != nil {
+		return err
+	}
+
+	var general []exporter.LintIssue
+	for _, f := range report.findings {
+		if f.File == "" {
+			general = append(general, findingIssue(f))
+		}
+	}
+
+	for i, wb := range workbooks {
+		var issues []exporter.LintIssue
+		for _, f := range report.findings {
+			if f.File == wb.Path {
+				issues = append(issues, findingIssue(f))
+			}
+		}
+		if len(issues) == 0 && len(general) == 0 {
+			continue
+		}
+
+		dst := dsts[i]
+		if err := exporter.WriteValidationWorkbook(wb.File, dst, append(issues, general...)); err != nil {
+			return fmt.Errorf("failed to write back findings of %s: %v", wb.Path, err)
+		}
+		log.Printf("Wrote %d finding(s) of %s to %s", len(issues)+len(general), wb.Path, dst)
+	}
+	return nil
+}
+
+// writebackPaths는 워크북 경로들을 공통 상위 디렉토리 기준 상대 경로로 바꿔 dir 아래의 경로를 만듭니다.
+// 워크북이 모두 한 디렉토리에 있으면 파일 이름만 남습니다.
+func writebackPaths(paths []string, dir string) ([]string, error) {
+	abs := make([]string, len(paths))
+	common := ""
+	for i, path := range paths {
+		p, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		abs[i] = p
+		if i == 0 {
+			common = filepath.Dir(p)
+			continue
+		}
+		for !strings.HasPrefix(p, common+string(filepath.Separator)) && common != filepath.Dir(common) {
+			common = filepath.Dir(common)
+		}
+	}
+
+	dsts := make([]string, len(abs))
+	for i, p := range abs {
+		rel, err := filepath.Rel(common, p)
+		if err != nil {
+			return nil, err
+		}
+		dsts[i] = filepath.Join(dir, rel)
+	}
+	return dsts, nil
+}
+
+// findingIssue는 결과의 위치("시트" 또는 "시트!셀")를 시트와 셀로 나눕니다.
+func findingIssue(f finding) exporter.LintIssue {
+	issue := exporter.LintIssue{Rule: f.Rule, Severity: f.Severity, File: f.File, Message: f.Message, Sheet: f.Location}
+	if idx := strings.LastIndex(f.Location, "!"); idx != -1 {
+		issue.Sheet, issue.Cell = f.Location[:idx], f.Location[idx+1:]
+	}
+	return issue
+}
+
+// validateWorkbooks는 워크북을 파싱, 검증, 린트하고 결과를 report에 모읍니다.
+// 인덱스가 워크북에 배정된 범위(--id-ranges)를 벗어난 행도 검증 에러입니다.
+// 시트/컬럼 이름은 generate처럼 ids로 바꾼 뒤 검증하며, 생성하는 코드의 식별자로 쓸 수 없는 이름도 검증 에러입니다.
+// statsFile이 있으면 그 파일의 컬럼 통계로 바뀌지 않은 컬럼의 검증을 건너뛰고, 이번 통계를 기록합니다.
+func validateWorkbooks(report *findingReport, workbooks []stagedWorkbook, lint bool, idRanges exporter.IDRangePolicy, ids identifierCheck, statsFile string) {
+	var tables []exporter.Table
+	for _, wb := range workbooks {
+		parsed, err := exporter.ParseExcelFile(wb.File)
+		if err != nil {
+			report.errorf(ruleParse, wb.Path, "%v", err)
+			continue
+		}
+		tables = append(tables, parsed...)
+	}
+
+	for _, warning := range exporter.DeprecationWarnings(tables) {
+		report.add(finding{Rule: ruleDeprecated, Severity: exporter.LintWarning, Message: warning})
+	}
+	for _, warning := range exporter.CellWarnings(tables) {
+		report.add(finding{Rule: ruleIgnoredCell, Severity: exporter.LintWarning, File: warning.Workbook,
+			Location: warning.Sheet + "!" + warning.Cell, Message: warning.Message})
+	}
+	if renamed, err := ids.rename(tables); err != nil {
+		report.errorf(ruleValidate, "", "%v", err)
+	} else {
+		tables = renamed
+	}
+	for _, err := range ids.validate(tables) {
+		report.errorf(ruleValidate, "", "%v", err)
+	}
+	var previous exporter.ColumnStatsCache
+	if statsFile != "" {
+		var err error
+		if previous, err = exporter.LoadColumnStats(statsFile); err != nil {
+			log.Printf("Ignoring column stats: %v", err)
+		}
+	}
+	errs, stats := exporter.ValidateWithStats(tables, previous)
+	for _, err := range errs {
+		report.errorf(ruleValidate, "", "%v", err)
+	}
+	if statsFile != "" {
+		if n := exporter.CachedColumns(stats); n > 0 {
+			log.Printf("Skipped validation of %d unchanged column(s)", n)
+		}
+		// --staged는 일부 워크북만 검증하므로 나머지 테이블의 기록은 유지
+		if err := exporter.WriteColumnStats(statsFile, previous.Merge(stats)); err != nil {
+			log.Printf("Failed to write column stats: %v", err)
+		}
+	}
+	for _, err := range idRanges.Validate(tables) {
+		report.errorf(ruleValidate, "", "%v", err)
+	}
+
+	// 린트 규칙 위반은 셀 위치와 함께 보고
+	if !lint {
+		return
+	}
+	for _, wb := range workbooks {
+		issues, err := exporter.LintWorkbook(wb.File)
+		if err != nil {
+			report.errorf(ruleParse, wb.Path, "lint failed: %v", err)
+			continue
+		}
+		for _, issue := range issues {
+			issue.File = wb.Path
+			report.add(lintFinding(issue))
+		}
+	}
+}
+
+// stagedWorkbooks는 git에 스테이징된 워크북의 내용을 tmpDir에 꺼냅니다. pre-commit 훅에서 사용합니다.
+// 작업 트리가 아닌 스테이징된 내용을 검증하기 위함입니다.
+func stagedWorkbooks(repo *gitRepository, dir, tmpDir string) ([]stagedWorkbook, error) {
+	paths, err := repo.stagedWorkbookPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	workbooks := make([]stagedWorkbook, 0, len(paths))
+	for _, path := range paths {
+		file, err := repo.extract("", path, tmpDir)
+		if err != nil {
+			return nil, err
+		}
+		workbooks = append(workbooks, stagedWorkbook{Path: path, File: file})
+	}
+	return workbooks, nil
+}
+
+// compareWithBase는 스테이징된 워크북을 base 리비전의 같은 파일과 비교합니다.
+// 하위 호환성을 깨는 스키마 변경은 에러로, 행 변경 요약은 정보로 보고합니다.
+func compareWithBase(report *findingReport, repo *gitRepository, wb stagedWorkbook, base, tmpDir string) {
+	tables, err := exporter.ParseExcelFile(wb.File)
+	if err != nil {
+		return // 파싱 실패는 validateWorkbooks에서 보고됨
+	}
+
+	baseFile, err := repo.extract(base, wb.Path, tmpDir)
+	if err != nil {
+		report.errorf(ruleBreaking, wb.Path, "%v", err)
+		return
+	}
+	if baseFile == "" {
+		report.add(finding{Rule: ruleRowChanges, Severity: exporter.LintInfo, File: wb.Path,
+			Message: fmt.Sprintf("new workbook (not in %s)", base)})
+		return
+	}
+
+	baseTables, err := exporter.ParseExcelFile(baseFile)
+	if err != nil {
+		report.add(finding{Rule: ruleBreaking, Severity: exporter.LintWarning, File: wb.Path,
+			Message: fmt.Sprintf("cannot parse %s version: %v", base, err)})
+		return
+	}
+
+	for _, change := range exporter.CompareSnapshots(exporter.TakeSnapshot(baseTables), exporter.TakeSnapshot(tables)) {
+		report.add(finding{Rule: ruleBreaking, Severity: exporter.LintError, File: wb.Path, Location: change.Table,
+			Message: fmt.Sprintf("breaking change against %s: %s", base, change)})
+	}
+
+	counts := make(map[string]int)
+	for _, op := range exporter.DiffTables(baseTables, tables) {
+		counts[op.Op]++
+	}
+	if len(counts) > 0 {
+		report.add(finding{Rule: ruleRowChanges, Severity: exporter.LintInfo, File: wb.Path,
+			Message: fmt.Sprintf("%d inserted, %d updated, %d deleted row(s) against %s",
+				counts[exporter.PatchInsert], counts[exporter.PatchUpdate], counts[exporter.PatchDelete], base)})
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newWatchCommand(input *inputFlags) *cobra.Command {
+	var flags generateFlags
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Regenerate output whenever input workbooks change",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			printBanner()
+			return watchAndGenerate(cmd.Context(), input, &flags, interval, nil, nil)
+		},
+	}
+
+	addGenerateFlags(cmd, &flags)
+	cmd.Flags().DurationVar(&interval, "interval", time.Second, "Polling interval for workbook changes")
+	return cmd
+}
+
+func newServeCommand(input *inputFlags) *cobra.Command {
+	var flags generateFlags
+	var interval time.Duration
+	var addr string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve generated output over HTTP, regenerate on workbook changes and notify clients of changed tables",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			printBanner()
+
+			profile, err := flags.loadProfile()
+			if err != nil {
+				return err
+			}
+			outputDir := flags.outputFor(profile)
+			if err := os.MkdirAll(outputDir, 0755); err != nil {
+				return err
+			}
+
+			// 재생성 알림은 Server-Sent Events로 보내고, 나머지 경로는 산출물 파일
+			hub := newReloadHub(flags.onlyTables != "")
+			mux := http.NewServeMux()
+			mux.Handle(reloadEventsPath, hub)
+			mux.Handle("/", http.FileServer(http.Dir(outputDir)))
+
+			server := &http.Server{Addr: addr, Handler: mux}
+			errCh := make(chan error, 1)
+			go func() {
+				log.Printf("Serving %s on %s (reload notifications on %s)", outputDir, addr, reloadEventsPath)
+				errCh <- server.ListenAndServe()
+			}()
+
+			return watchAndGenerate(cmd.Context(), input, &flags, interval, errCh, hub)
+		},
+	}
+
+	addGenerateFlags(cmd, &flags)
+	cmd.Flags().DurationVar(&interval, "interval", time.Second, "Polling interval for workbook changes")
+	cmd.Flags().StringVar(&addr, "addr", ":8080", "HTTP listen address")
+	return cmd
+}
+
+// watchAndGenerate는 입력 워크북의 변경을 주기적으로 확인하고 변경되면 다시 생성합니다.
+// stop 채널로 에러가 전달되면 종료합니다. hub가 있으면 생성할 때마다 바뀐 테이블을 알립니다.
+func watchAndGenerate(ctx context.Context, input *inputFlags, flags *generateFlags, interval time.Duration, stop <-chan error, hub *reloadHub) error {
+	var lastState map[string]time.Time
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		state, err := snapshotInputs(input)
+		if err != nil {
+			log.Printf("Failed to read inputs: %v", err)
+		} else if !sameState(state, lastState) {
+			lastState = state
+			tables, err := runGenerate(ctx, input, flags)
+			if err != nil {
+				log.Printf("Generation failed: %v", err)
+			}
+			hub.generated(tables, err)
+			log.Printf("Watching %d workbook(s) for changes...", len(state))
+		}
+
+		select {
+		case err := <-stop:
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+// snapshotInputs는 입력 워크북들의 수정 시각을 수집합니다.
+func snapshotInputs(input *inputFlags) (map[string]time.Time, error) {
+	files, err := input.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	state := make(map[string]time.Time, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		state[file] = info.ModTime()
+	}
+	return state, nil
+}
+
+func sameState(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for file, modTime := range a {
+		if other, ok := b[file]; !ok || !other.Equal(modTime) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"excelite/exporter"
+)
+
+func newWhereCommand() *cobra.Command {
+	var outputDir string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "where <table> <key> [column]",
+		Short: "Find the workbook cell a generated row or field came from (needs generate --sourcemap)",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sm, err := exporter.ReadSourceMap(outputDir)
+			if err != nil {
+				return err
+			}
+			table, row, err := sm.Lookup(args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			// 컬럼을 지정하면 그 컬럼의 셀만 출력
+			cells := row.Cells
+			if len(args) == 3 {
+				column, ok := findCellColumn(row.Cells, args[2])
+				if !ok {
+					return fmt.Errorf("column %s of %s has no source cell (unknown column, or derived by a transform)", args[2], table.Table)
+				}
+				cells = map[string][]string{column: row.Cells[column]}
+			}
+
+			w := cmd.OutOrStdout()
+			switch format {
+			case "text":
+				fmt.Fprintf(w, "%s %s: %s, sheet %s, %s\n", table.Table, row.Key, table.Workbook, table.Sheet, row.Location)
+				fmt.Fprintf(w, "  sqlite rowid %d, json %s.json%s\n", row.RowID, table.Table, row.JSONPath)
+
+				columns := make([]string, 0, len(cells))
+				for column := range cells {
+					columns = append(columns, column)
+				}
+				sort.Strings(columns)
+				tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+				for _, column := range columns {
+					fmt.Fprintf(tw, "  %s\t%s!%s\n", column, table.Sheet, strings.Join(cells[column], ", "))
+				}
+				return tw.Flush()
+			case "json":
+				row.Cells = cells
+				enc := json.NewEncoder(w)
+				enc.SetIndent("", "  ")
+				return enc.Encode(struct {
+					Table    string `json:"table"`
+					Workbook string `json:"workbook,omitempty"`
+					Sheet    string `json:"sheet"`
+					exporter.RowSourceMap
+				}{table.Table, table.Workbook, table.Sheet, row})
+			}
+			return fmt.Errorf("unknown where format %q (expected text or json)", format)
+		},
+	}
+
+	cmd.Flags().StringVar(&outputDir, "output", "generated", "Generated output directory containing sourcemap.json")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+	cmd.MarkFlagDirname("output")
+	return cmd
+}
+
+// findCellColumn은 대소문자를 구분하지 않고 컬럼 이름을 찾습니다.
+func findCellColumn(cells map[string][]string, name string) (string, bool) {
+	for column := range cells {
+		if strings.EqualFold(column, name) {
+			return column, true
+		}
+	}
+	return "", false
+}